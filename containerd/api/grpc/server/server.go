@@ -370,6 +370,7 @@ func convertToPb(st *runtime.Stat) *types.StatsResponse {
 			ThrottledTime:    st.CPU.Throttling.ThrottledTime,
 		},
 		SystemUsage: systemUsage,
+		Psi:         convertPSIToPb(st.CPU.PSI),
 	}
 	pbSt.CgroupStats.MemoryStats = &types.MemoryStats{
 		Cache: st.Memory.Cache,
@@ -392,6 +393,16 @@ func convertToPb(st *runtime.Stat) *types.StatsResponse {
 			Limit:    st.Memory.Kernel.Limit,
 		},
 		Stats: st.Memory.Raw,
+		Psi:   convertPSIToPb(st.Memory.PSI),
+	}
+	if e := st.Memory.Events; e != nil {
+		pbSt.CgroupStats.MemoryStats.Events = &types.MemoryEvents{
+			Low:     e.Low,
+			High:    e.High,
+			Max:     e.Max,
+			Oom:     e.Oom,
+			OomKill: e.OomKill,
+		}
 	}
 	pbSt.CgroupStats.BlkioStats = &types.BlkioStats{
 		IoServiceBytesRecursive: convertBlkioEntryToPb(st.Blkio.IoServiceBytesRecursive),
@@ -402,6 +413,7 @@ func convertToPb(st *runtime.Stat) *types.StatsResponse {
 		IoMergedRecursive:       convertBlkioEntryToPb(st.Blkio.IoMergedRecursive),
 		IoTimeRecursive:         convertBlkioEntryToPb(st.Blkio.IoTimeRecursive),
 		SectorsRecursive:        convertBlkioEntryToPb(st.Blkio.SectorsRecursive),
+		Psi:                     convertPSIToPb(st.Blkio.PSI),
 	}
 	pbSt.CgroupStats.HugetlbStats = make(map[string]*types.HugetlbStats)
 	for k, st := range st.Hugetlb {
@@ -431,6 +443,26 @@ func convertBlkioEntryToPb(b []runtime.BlkioEntry) []*types.BlkioStatsEntry {
 	return pbEs
 }
 
+func convertPSIToPb(p *runtime.PSI) *types.PSIStats {
+	if p == nil {
+		return nil
+	}
+	return &types.PSIStats{
+		Some: &types.PSIData{
+			Avg10:  p.Some.Avg10,
+			Avg60:  p.Some.Avg60,
+			Avg300: p.Some.Avg300,
+			Total:  p.Some.Total,
+		},
+		Full: &types.PSIData{
+			Avg10:  p.Full.Avg10,
+			Avg60:  p.Full.Avg60,
+			Avg300: p.Full.Avg300,
+			Total:  p.Full.Total,
+		},
+	}
+}
+
 const nanoSecondsPerSecond = 1e9
 
 // getSystemCPUUsage returns the host system's cpu usage in
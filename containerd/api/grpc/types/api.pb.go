@@ -55,6 +55,9 @@ It has these top-level messages:
 	CgroupStats
 	StatsResponse
 	StatsRequest
+	PSIData
+	PSIStats
+	MemoryEvents
 */
 package types
 
@@ -1503,6 +1506,7 @@ type CpuStats struct {
 	CpuUsage       *CpuUsage       `protobuf:"bytes,1,opt,name=cpu_usage,json=cpuUsage" json:"cpu_usage,omitempty"`
 	ThrottlingData *ThrottlingData `protobuf:"bytes,2,opt,name=throttling_data,json=throttlingData" json:"throttling_data,omitempty"`
 	SystemUsage    uint64          `protobuf:"varint,3,opt,name=system_usage,json=systemUsage" json:"system_usage,omitempty"`
+	Psi            *PSIStats       `protobuf:"bytes,4,opt,name=psi" json:"psi,omitempty"`
 }
 
 func (m *CpuStats) Reset()                    { *m = CpuStats{} }
@@ -1531,6 +1535,13 @@ func (m *CpuStats) GetSystemUsage() uint64 {
 	return 0
 }
 
+func (m *CpuStats) GetPsi() *PSIStats {
+	if m != nil {
+		return m.Psi
+	}
+	return nil
+}
+
 type PidsStats struct {
 	Current uint64 `protobuf:"varint,1,opt,name=current" json:"current,omitempty"`
 	Limit   uint64 `protobuf:"varint,2,opt,name=limit" json:"limit,omitempty"`
@@ -1601,6 +1612,8 @@ type MemoryStats struct {
 	SwapUsage   *MemoryData       `protobuf:"bytes,3,opt,name=swap_usage,json=swapUsage" json:"swap_usage,omitempty"`
 	KernelUsage *MemoryData       `protobuf:"bytes,4,opt,name=kernel_usage,json=kernelUsage" json:"kernel_usage,omitempty"`
 	Stats       map[string]uint64 `protobuf:"bytes,5,rep,name=stats" json:"stats,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	Events      *MemoryEvents     `protobuf:"bytes,6,opt,name=events" json:"events,omitempty"`
+	Psi         *PSIStats         `protobuf:"bytes,7,opt,name=psi" json:"psi,omitempty"`
 }
 
 func (m *MemoryStats) Reset()                    { *m = MemoryStats{} }
@@ -1643,6 +1656,20 @@ func (m *MemoryStats) GetStats() map[string]uint64 {
 	return nil
 }
 
+func (m *MemoryStats) GetEvents() *MemoryEvents {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *MemoryStats) GetPsi() *PSIStats {
+	if m != nil {
+		return m.Psi
+	}
+	return nil
+}
+
 type BlkioStatsEntry struct {
 	Major uint64 `protobuf:"varint,1,opt,name=major" json:"major,omitempty"`
 	Minor uint64 `protobuf:"varint,2,opt,name=minor" json:"minor,omitempty"`
@@ -1692,6 +1719,7 @@ type BlkioStats struct {
 	IoMergedRecursive       []*BlkioStatsEntry `protobuf:"bytes,6,rep,name=io_merged_recursive,json=ioMergedRecursive" json:"io_merged_recursive,omitempty"`
 	IoTimeRecursive         []*BlkioStatsEntry `protobuf:"bytes,7,rep,name=io_time_recursive,json=ioTimeRecursive" json:"io_time_recursive,omitempty"`
 	SectorsRecursive        []*BlkioStatsEntry `protobuf:"bytes,8,rep,name=sectors_recursive,json=sectorsRecursive" json:"sectors_recursive,omitempty"`
+	Psi                     *PSIStats          `protobuf:"bytes,9,opt,name=psi" json:"psi,omitempty"`
 }
 
 func (m *BlkioStats) Reset()                    { *m = BlkioStats{} }
@@ -1755,6 +1783,13 @@ func (m *BlkioStats) GetSectorsRecursive() []*BlkioStatsEntry {
 	return nil
 }
 
+func (m *BlkioStats) GetPsi() *PSIStats {
+	if m != nil {
+		return m.Psi
+	}
+	return nil
+}
+
 type HugetlbStats struct {
 	Usage    uint64 `protobuf:"varint,1,opt,name=usage" json:"usage,omitempty"`
 	MaxUsage uint64 `protobuf:"varint,2,opt,name=max_usage,json=maxUsage" json:"max_usage,omitempty"`
@@ -1892,6 +1927,118 @@ func (m *StatsRequest) GetId() string {
 	return ""
 }
 
+type PSIData struct {
+	Avg10  float64 `protobuf:"fixed64,1,opt,name=avg10" json:"avg10,omitempty"`
+	Avg60  float64 `protobuf:"fixed64,2,opt,name=avg60" json:"avg60,omitempty"`
+	Avg300 float64 `protobuf:"fixed64,3,opt,name=avg300" json:"avg300,omitempty"`
+	Total  uint64  `protobuf:"varint,4,opt,name=total" json:"total,omitempty"`
+}
+
+func (m *PSIData) Reset()                    { *m = PSIData{} }
+func (m *PSIData) String() string            { return proto.CompactTextString(m) }
+func (*PSIData) ProtoMessage()               {}
+func (*PSIData) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
+
+func (m *PSIData) GetAvg10() float64 {
+	if m != nil {
+		return m.Avg10
+	}
+	return 0
+}
+
+func (m *PSIData) GetAvg60() float64 {
+	if m != nil {
+		return m.Avg60
+	}
+	return 0
+}
+
+func (m *PSIData) GetAvg300() float64 {
+	if m != nil {
+		return m.Avg300
+	}
+	return 0
+}
+
+func (m *PSIData) GetTotal() uint64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+type PSIStats struct {
+	Some *PSIData `protobuf:"bytes,1,opt,name=some" json:"some,omitempty"`
+	Full *PSIData `protobuf:"bytes,2,opt,name=full" json:"full,omitempty"`
+}
+
+func (m *PSIStats) Reset()                    { *m = PSIStats{} }
+func (m *PSIStats) String() string            { return proto.CompactTextString(m) }
+func (*PSIStats) ProtoMessage()               {}
+func (*PSIStats) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
+
+func (m *PSIStats) GetSome() *PSIData {
+	if m != nil {
+		return m.Some
+	}
+	return nil
+}
+
+func (m *PSIStats) GetFull() *PSIData {
+	if m != nil {
+		return m.Full
+	}
+	return nil
+}
+
+type MemoryEvents struct {
+	Low     uint64 `protobuf:"varint,1,opt,name=low" json:"low,omitempty"`
+	High    uint64 `protobuf:"varint,2,opt,name=high" json:"high,omitempty"`
+	Max     uint64 `protobuf:"varint,3,opt,name=max" json:"max,omitempty"`
+	Oom     uint64 `protobuf:"varint,4,opt,name=oom" json:"oom,omitempty"`
+	OomKill uint64 `protobuf:"varint,5,opt,name=oom_kill,json=oomKill" json:"oom_kill,omitempty"`
+}
+
+func (m *MemoryEvents) Reset()                    { *m = MemoryEvents{} }
+func (m *MemoryEvents) String() string            { return proto.CompactTextString(m) }
+func (*MemoryEvents) ProtoMessage()               {}
+func (*MemoryEvents) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
+
+func (m *MemoryEvents) GetLow() uint64 {
+	if m != nil {
+		return m.Low
+	}
+	return 0
+}
+
+func (m *MemoryEvents) GetHigh() uint64 {
+	if m != nil {
+		return m.High
+	}
+	return 0
+}
+
+func (m *MemoryEvents) GetMax() uint64 {
+	if m != nil {
+		return m.Max
+	}
+	return 0
+}
+
+func (m *MemoryEvents) GetOom() uint64 {
+	if m != nil {
+		return m.Oom
+	}
+	return 0
+}
+
+func (m *MemoryEvents) GetOomKill() uint64 {
+	if m != nil {
+		return m.OomKill
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*GetServerVersionRequest)(nil), "types.GetServerVersionRequest")
 	proto.RegisterType((*GetServerVersionResponse)(nil), "types.GetServerVersionResponse")
@@ -1939,6 +2086,9 @@ func init() {
 	proto.RegisterType((*CgroupStats)(nil), "types.CgroupStats")
 	proto.RegisterType((*StatsResponse)(nil), "types.StatsResponse")
 	proto.RegisterType((*StatsRequest)(nil), "types.StatsRequest")
+	proto.RegisterType((*PSIData)(nil), "types.PSIData")
+	proto.RegisterType((*PSIStats)(nil), "types.PSIStats")
+	proto.RegisterType((*MemoryEvents)(nil), "types.MemoryEvents")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -2408,169 +2558,179 @@ var _API_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("api.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 2616 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xec, 0x19, 0x4d, 0x6f, 0x1c, 0x49,
-	0x35, 0x33, 0xd3, 0x9e, 0xf1, 0xbc, 0xf9, 0xb0, 0xa7, 0xe2, 0xd8, 0x9d, 0xd9, 0xdd, 0xc4, 0xdb,
-	0x5a, 0x58, 0x03, 0x2b, 0x27, 0x38, 0x1b, 0x88, 0x58, 0x09, 0x29, 0xb1, 0xc3, 0x62, 0x36, 0x4e,
-	0x26, 0x6d, 0x9b, 0x08, 0x09, 0x69, 0xd4, 0xee, 0xae, 0xcc, 0x14, 0xee, 0xe9, 0xea, 0x54, 0x57,
-	0xdb, 0xe3, 0xcb, 0x1e, 0x38, 0xc0, 0x0d, 0xae, 0x48, 0x1c, 0xb9, 0x71, 0xe7, 0x00, 0x7f, 0x00,
-	0x89, 0x1f, 0xc2, 0x6d, 0xef, 0x1c, 0x51, 0x7d, 0x74, 0x77, 0xf5, 0x7c, 0x38, 0x09, 0x12, 0xe2,
-	0xc2, 0xa5, 0x55, 0xef, 0xd5, 0xfb, 0xaa, 0x57, 0xef, 0xbd, 0x7a, 0x55, 0x0d, 0x4d, 0x2f, 0x26,
-	0xbb, 0x31, 0xa3, 0x9c, 0xa2, 0x15, 0x7e, 0x15, 0xe3, 0xa4, 0x7f, 0x77, 0x44, 0xe9, 0x28, 0xc4,
-	0xf7, 0x24, 0xf2, 0x2c, 0x7d, 0x7d, 0x8f, 0x93, 0x09, 0x4e, 0xb8, 0x37, 0x89, 0x15, 0x9d, 0x73,
-	0x1b, 0xb6, 0xbe, 0xc4, 0xfc, 0x18, 0xb3, 0x0b, 0xcc, 0x7e, 0x8e, 0x59, 0x42, 0x68, 0xe4, 0xe2,
-	0x37, 0x29, 0x4e, 0xb8, 0x33, 0x05, 0x7b, 0x7e, 0x2a, 0x89, 0x69, 0x94, 0x60, 0xb4, 0x01, 0x2b,
-	0x13, 0xef, 0x57, 0x94, 0xd9, 0x95, 0xed, 0xca, 0x4e, 0xc7, 0x55, 0x80, 0xc4, 0x92, 0x88, 0x32,
-	0xbb, 0xaa, 0xb1, 0x02, 0x10, 0xd8, 0xd8, 0xe3, 0xfe, 0xd8, 0xae, 0x29, 0xac, 0x04, 0x50, 0x1f,
-	0x56, 0x19, 0xbe, 0x20, 0x42, 0xaa, 0x6d, 0x6d, 0x57, 0x76, 0x9a, 0x6e, 0x0e, 0x3b, 0xbf, 0xa9,
-	0xc0, 0xc6, 0x69, 0x1c, 0x78, 0x1c, 0x0f, 0x18, 0xf5, 0x71, 0x92, 0x68, 0x93, 0x50, 0x17, 0xaa,
-	0x24, 0x90, 0x3a, 0x9b, 0x6e, 0x95, 0x04, 0x68, 0x1d, 0x6a, 0x31, 0x09, 0xa4, 0xba, 0xa6, 0x2b,
-	0x86, 0xe8, 0x0e, 0x80, 0x1f, 0xd2, 0x04, 0x1f, 0xf3, 0x80, 0x44, 0x52, 0xe3, 0xaa, 0x6b, 0x60,
-	0x84, 0x31, 0x97, 0x24, 0xe0, 0x63, 0xa9, 0xb3, 0xe3, 0x2a, 0x00, 0x6d, 0x42, 0x7d, 0x8c, 0xc9,
-	0x68, 0xcc, 0xed, 0x15, 0x89, 0xd6, 0x90, 0xb3, 0x05, 0xb7, 0x66, 0xec, 0x50, 0xeb, 0x77, 0xfe,
-	0x51, 0x85, 0xcd, 0x7d, 0x86, 0x3d, 0x8e, 0xf7, 0x69, 0xc4, 0x3d, 0x12, 0x61, 0xb6, 0xcc, 0xc6,
-	0x3b, 0x00, 0x67, 0x69, 0x14, 0x84, 0x78, 0xe0, 0xf1, 0xb1, 0x36, 0xd5, 0xc0, 0x48, 0x8b, 0xc7,
-	0xd8, 0x3f, 0x8f, 0x29, 0x89, 0xb8, 0xb4, 0xb8, 0xe9, 0x1a, 0x18, 0x61, 0x71, 0x22, 0x17, 0xa3,
-	0xbc, 0xa4, 0x00, 0x61, 0x71, 0xc2, 0x03, 0x9a, 0x2a, 0x8b, 0x9b, 0xae, 0x86, 0x34, 0x1e, 0x33,
-	0x66, 0xd7, 0x73, 0x3c, 0x66, 0x4c, 0xe0, 0x43, 0xef, 0x0c, 0x87, 0x89, 0xdd, 0xd8, 0xae, 0x09,
-	0xbc, 0x82, 0xd0, 0x36, 0xb4, 0x22, 0x3a, 0x20, 0x17, 0x94, 0xbb, 0x94, 0x72, 0x7b, 0x55, 0x3a,
-	0xcc, 0x44, 0x21, 0x1b, 0x1a, 0x2c, 0x8d, 0x44, 0xdc, 0xd8, 0x4d, 0x29, 0x32, 0x03, 0x05, 0xaf,
-	0x1e, 0x3e, 0x66, 0xa3, 0xc4, 0x06, 0x29, 0xd8, 0x44, 0xa1, 0x4f, 0xa0, 0x53, 0xac, 0xe4, 0x80,
-	0x30, 0xbb, 0x25, 0x25, 0x94, 0x91, 0xce, 0x21, 0x6c, 0xcd, 0xf9, 0x52, 0xc7, 0xd9, 0x2e, 0x34,
-	0xfd, 0x0c, 0x29, 0x7d, 0xda, 0xda, 0x5b, 0xdf, 0x95, 0xa1, 0xbd, 0x5b, 0x10, 0x17, 0x24, 0xce,
-	0x21, 0x74, 0x8e, 0xc9, 0x28, 0xf2, 0xc2, 0x77, 0x8f, 0x18, 0xe1, 0x31, 0xc9, 0xa2, 0xe3, 0x53,
-	0x43, 0xce, 0x3a, 0x74, 0x33, 0x51, 0x7a, 0xd3, 0xff, 0x52, 0x83, 0xde, 0xe3, 0x20, 0x78, 0x4b,
-	0x4c, 0xf6, 0x61, 0x95, 0x63, 0x36, 0x21, 0x42, 0x62, 0x55, 0xba, 0x33, 0x87, 0xd1, 0x5d, 0xb0,
-	0xd2, 0x04, 0x33, 0xa9, 0xa9, 0xb5, 0xd7, 0xd2, 0x2b, 0x39, 0x4d, 0x30, 0x73, 0xe5, 0x04, 0x42,
-	0x60, 0x79, 0xc2, 0x97, 0x96, 0xf4, 0xa5, 0x1c, 0x0b, 0x93, 0x71, 0x74, 0x61, 0xaf, 0x48, 0x94,
-	0x18, 0x0a, 0x8c, 0x7f, 0x19, 0xe8, 0x1d, 0x16, 0xc3, 0x6c, 0x59, 0x8d, 0x62, 0x59, 0x79, 0xd8,
-	0xac, 0x2e, 0x0e, 0x9b, 0xe6, 0x92, 0xb0, 0x81, 0x52, 0xd8, 0x38, 0xd0, 0xf6, 0xbd, 0xd8, 0x3b,
-	0x23, 0x21, 0xe1, 0x04, 0x27, 0x76, 0x4b, 0x1a, 0x51, 0xc2, 0xa1, 0x1d, 0x58, 0xf3, 0xe2, 0xd8,
-	0x63, 0x13, 0xca, 0x06, 0x8c, 0xbe, 0x26, 0x21, 0xb6, 0xdb, 0x52, 0xc8, 0x2c, 0x5a, 0x48, 0x4b,
-	0x70, 0x48, 0xa2, 0x74, 0xfa, 0x4c, 0x44, 0x9f, 0xdd, 0x91, 0x64, 0x25, 0x9c, 0x90, 0x16, 0xd1,
-	0xe7, 0xf8, 0x72, 0xc0, 0xc8, 0x05, 0x09, 0xf1, 0x08, 0x27, 0x76, 0x57, 0x7a, 0x71, 0x16, 0x8d,
-	0x3e, 0x85, 0x06, 0x0b, 0xc9, 0x84, 0xf0, 0xc4, 0x5e, 0xdb, 0xae, 0xed, 0xb4, 0xf6, 0x3a, 0xda,
-	0x9f, 0xae, 0xc4, 0xba, 0xd9, 0xac, 0x73, 0x00, 0x75, 0x85, 0x12, 0xee, 0x15, 0x24, 0x7a, 0xb7,
-	0xe4, 0x58, 0xe0, 0x12, 0xfa, 0x9a, 0xcb, 0xbd, 0xb2, 0x5c, 0x39, 0x16, 0xb8, 0xb1, 0xc7, 0x02,
-	0xb9, 0x4f, 0x96, 0x2b, 0xc7, 0x8e, 0x0b, 0x96, 0xd8, 0x28, 0xe1, 0xea, 0x54, 0x6f, 0x78, 0xc7,
-	0x15, 0x43, 0x81, 0x19, 0xe9, 0x98, 0xea, 0xb8, 0x62, 0x88, 0xbe, 0x0d, 0x5d, 0x2f, 0x08, 0x08,
-	0x27, 0x34, 0xf2, 0xc2, 0x2f, 0x49, 0x90, 0xd8, 0xb5, 0xed, 0xda, 0x4e, 0xc7, 0x9d, 0xc1, 0x3a,
-	0x7b, 0x80, 0xcc, 0x80, 0xd2, 0x41, 0xff, 0x21, 0x34, 0x93, 0xab, 0x84, 0xe3, 0xc9, 0x20, 0xd7,
-	0x53, 0x20, 0x9c, 0x5f, 0x57, 0xf2, 0x74, 0xc9, 0xb3, 0x68, 0x59, 0x2c, 0x7e, 0xbf, 0x54, 0x5b,
-	0xaa, 0x32, 0xea, 0x7a, 0x59, 0xfe, 0x14, 0xdc, 0x66, 0xb9, 0x99, 0x4b, 0xd9, 0xda, 0xa2, 0x94,
-	0xed, 0x83, 0x3d, 0x6f, 0x83, 0x4e, 0x13, 0x1f, 0xb6, 0x0e, 0x70, 0x88, 0xdf, 0xc5, 0x3e, 0x04,
-	0x56, 0xe4, 0x4d, 0xb0, 0x4e, 0x47, 0x39, 0x7e, 0x77, 0x03, 0xe6, 0x95, 0x68, 0x03, 0x8e, 0xe0,
-	0xd6, 0x33, 0x92, 0xf0, 0xb7, 0xab, 0x9f, 0x53, 0x55, 0x5d, 0xa4, 0xea, 0x0f, 0x15, 0x80, 0x42,
-	0x56, 0x6e, 0x73, 0xc5, 0xb0, 0x19, 0x81, 0x85, 0xa7, 0x84, 0xeb, 0x7c, 0x97, 0x63, 0x11, 0x15,
-	0xdc, 0x8f, 0xf5, 0x11, 0x24, 0x86, 0xa2, 0x5e, 0xa6, 0x11, 0x99, 0x1e, 0x53, 0xff, 0x1c, 0xf3,
-	0x44, 0xd6, 0xf3, 0x55, 0xd7, 0x44, 0xc9, 0xa4, 0x1d, 0xe3, 0x30, 0x94, 0x45, 0x7d, 0xd5, 0x55,
-	0x80, 0xa8, 0xc0, 0x78, 0x12, 0xf3, 0xab, 0xe7, 0xc7, 0x76, 0x5d, 0xe6, 0x5f, 0x06, 0x3a, 0x47,
-	0xb0, 0x39, 0xbb, 0x52, 0x1d, 0x43, 0x0f, 0xa0, 0x55, 0xac, 0x22, 0xb1, 0x2b, 0x32, 0x41, 0x16,
-	0x6c, 0xbd, 0x49, 0xe5, 0xdc, 0x81, 0xf6, 0x31, 0xf7, 0x38, 0x5e, 0xe2, 0x2f, 0x67, 0x07, 0xba,
-	0x79, 0xd5, 0x95, 0x84, 0xaa, 0x6e, 0x78, 0x3c, 0x4d, 0x34, 0x95, 0x86, 0x9c, 0xbf, 0xd6, 0xa0,
-	0xa1, 0xc3, 0x3a, 0xab, 0x4d, 0x95, 0xa2, 0x36, 0xfd, 0x4f, 0x4a, 0x64, 0x29, 0xab, 0x1a, 0x33,
-	0x59, 0xf5, 0xff, 0x72, 0x59, 0x94, 0xcb, 0xbf, 0x57, 0xa0, 0x99, 0x6f, 0xf3, 0x7b, 0xb7, 0x33,
-	0x9f, 0x41, 0x33, 0x56, 0x1b, 0x8f, 0x55, 0xd5, 0x6b, 0xed, 0x75, 0xb5, 0xa2, 0xac, 0xce, 0x15,
-	0x04, 0x46, 0xfc, 0x58, 0x66, 0xfc, 0x18, 0xed, 0xca, 0x4a, 0xa9, 0x5d, 0x41, 0x60, 0xc5, 0xa2,
-	0x9c, 0xd6, 0x65, 0x39, 0x95, 0x63, 0xb3, 0x41, 0x69, 0x94, 0x1a, 0x14, 0xe7, 0x21, 0x34, 0x8e,
-	0x3c, 0x7f, 0x4c, 0x22, 0x99, 0xa1, 0x7e, 0xac, 0xc3, 0xb4, 0xe3, 0xca, 0xb1, 0x50, 0x32, 0xc1,
-	0x13, 0xca, 0xae, 0x74, 0xed, 0xd7, 0x90, 0x73, 0x0e, 0x1d, 0x9d, 0x06, 0x3a, 0x99, 0xee, 0x03,
-	0xe4, 0x2d, 0x46, 0x96, 0x4b, 0xf3, 0x6d, 0x88, 0x41, 0x83, 0x76, 0xa0, 0x31, 0x51, 0x9a, 0x75,
-	0xd5, 0xcd, 0x7c, 0xa0, 0xed, 0x71, 0xb3, 0x69, 0xe7, 0xb7, 0x15, 0xd8, 0x54, 0x3d, 0xe6, 0x5b,
-	0x3b, 0xc9, 0xc5, 0xbd, 0x8b, 0x72, 0x5f, 0xad, 0xe4, 0xbe, 0x07, 0xd0, 0x64, 0x38, 0xa1, 0x29,
-	0xf3, 0xb1, 0xf2, 0x6c, 0x6b, 0xef, 0x56, 0x96, 0x49, 0x52, 0x97, 0xab, 0x67, 0xdd, 0x82, 0xce,
-	0xf9, 0xa6, 0x0e, 0xdd, 0xf2, 0xac, 0xa8, 0x58, 0x67, 0xe1, 0x39, 0xa1, 0xaf, 0x54, 0x73, 0x5c,
-	0x91, 0x6e, 0x32, 0x51, 0x22, 0xab, 0xfc, 0x38, 0x3d, 0x1e, 0x7b, 0x0c, 0x27, 0xda, 0x8d, 0x05,
-	0x42, 0xcf, 0x0e, 0x30, 0x23, 0x34, 0x3b, 0x4c, 0x0b, 0x84, 0x28, 0x03, 0x7e, 0x9c, 0xbe, 0x4c,
-	0x29, 0xf7, 0xa4, 0x91, 0x96, 0x9b, 0xc3, 0xb2, 0x2b, 0x8e, 0xd3, 0x04, 0xf3, 0x7d, 0xb1, 0x6b,
-	0x2b, 0xba, 0x2b, 0xce, 0x31, 0xc5, 0xfc, 0x11, 0x9e, 0x24, 0x3a, 0xcd, 0x0d, 0x8c, 0xb0, 0x5c,
-	0xed, 0xe6, 0x33, 0x11, 0xd4, 0x32, 0x30, 0x2c, 0xd7, 0x44, 0x09, 0x09, 0x0a, 0x3c, 0xbe, 0xf4,
-	0x62, 0x99, 0xf6, 0x96, 0x6b, 0x60, 0xd0, 0x67, 0xd0, 0x53, 0x90, 0x8b, 0x13, 0xcc, 0x2e, 0x3c,
-	0x71, 0x6c, 0xcb, 0x32, 0x60, 0xb9, 0xf3, 0x13, 0x82, 0xfa, 0x1c, 0xb3, 0x08, 0x87, 0x47, 0x86,
-	0x56, 0x50, 0xd4, 0x73, 0x13, 0x68, 0x0f, 0x36, 0x14, 0xf2, 0x64, 0x7f, 0x60, 0x32, 0xb4, 0x24,
-	0xc3, 0xc2, 0x39, 0x91, 0xe9, 0xd2, 0xf1, 0xcf, 0xb0, 0xf7, 0x5a, 0xef, 0x47, 0x5b, 0x92, 0xcf,
-	0xa2, 0xd1, 0x63, 0xe8, 0x19, 0x5b, 0x74, 0x80, 0x2f, 0x88, 0x8f, 0xed, 0x8e, 0x8c, 0xda, 0x9b,
-	0x3a, 0x0a, 0xcc, 0x29, 0x77, 0x9e, 0x1a, 0x9d, 0x42, 0x5f, 0x22, 0x4f, 0xc6, 0x8c, 0x72, 0x1e,
-	0x62, 0x17, 0x7b, 0xc1, 0x93, 0x38, 0xd1, 0xb2, 0xba, 0x52, 0x56, 0x16, 0x51, 0x19, 0x8d, 0x96,
-	0x76, 0x0d, 0x23, 0x7a, 0x05, 0x1f, 0x94, 0x66, 0x5f, 0x31, 0xc2, 0x71, 0x21, 0x77, 0xed, 0x3a,
-	0xb9, 0xd7, 0x71, 0xce, 0x09, 0x16, 0x6a, 0x0f, 0x69, 0x2e, 0x78, 0xfd, 0xdd, 0x05, 0x97, 0x39,
-	0xd1, 0x2f, 0xe0, 0xc3, 0x79, 0xbd, 0x86, 0xe4, 0xde, 0x75, 0x92, 0xaf, 0x65, 0x75, 0xbe, 0x80,
-	0xce, 0x93, 0x90, 0xfa, 0xe7, 0x87, 0x2f, 0xb4, 0xae, 0xd2, 0xa5, 0xba, 0xb6, 0xf0, 0x52, 0x5d,
-	0xd3, 0x97, 0x6a, 0xe7, 0x6b, 0x68, 0x97, 0x36, 0xec, 0x07, 0x32, 0x53, 0x33, 0x51, 0xfa, 0xaa,
-	0xb4, 0xa1, 0xcd, 0x2a, 0xa9, 0x71, 0x4d, 0x42, 0x51, 0x41, 0x2e, 0x55, 0x30, 0xa9, 0xf6, 0x55,
-	0x43, 0x22, 0x3b, 0xc2, 0x22, 0xd0, 0xd4, 0xcd, 0xc8, 0xc0, 0x38, 0xbf, 0x84, 0x6e, 0x79, 0xb1,
-	0xff, 0xb1, 0x05, 0x08, 0x2c, 0xe6, 0x71, 0x9c, 0xf5, 0xdf, 0x62, 0xec, 0xdc, 0x86, 0xad, 0xb9,
-	0x9a, 0xa8, 0x9b, 0xbb, 0x2b, 0xe8, 0x3c, 0xbd, 0xc0, 0x11, 0xcf, 0xef, 0x5f, 0x8f, 0xa0, 0x99,
-	0x3f, 0x6a, 0xe8, 0x62, 0xdb, 0xdf, 0x55, 0xcf, 0x1e, 0xbb, 0xd9, 0xb3, 0xc7, 0xee, 0x49, 0x46,
-	0xe1, 0x16, 0xc4, 0x62, 0x8d, 0x09, 0xa7, 0x0c, 0x07, 0x2f, 0xa2, 0xf0, 0x2a, 0x7b, 0x2b, 0x28,
-	0x30, 0xba, 0xfe, 0x5a, 0x79, 0xfb, 0xf3, 0xfb, 0x0a, 0xac, 0x48, 0xdd, 0x0b, 0xef, 0x11, 0x8a,
-	0xba, 0x9a, 0x57, 0xeb, 0x72, 0x6d, 0xee, 0xe4, 0xb5, 0x59, 0x57, 0x71, 0xab, 0xa8, 0xe2, 0xa5,
-	0x15, 0xd4, 0xdf, 0x63, 0x05, 0xce, 0xef, 0xaa, 0xd0, 0x7e, 0x8e, 0xf9, 0x25, 0x65, 0xe7, 0xe2,
-	0xc4, 0x4a, 0x16, 0x36, 0xa7, 0xb7, 0x61, 0x95, 0x4d, 0x87, 0x67, 0x57, 0x3c, 0xaf, 0xd0, 0x0d,
-	0x36, 0x7d, 0x22, 0x40, 0xf4, 0x11, 0x00, 0x9b, 0x0e, 0x07, 0x9e, 0x6a, 0x48, 0x75, 0x81, 0x66,
-	0x53, 0x8d, 0x40, 0x1f, 0x40, 0xd3, 0x9d, 0x0e, 0x31, 0x63, 0x94, 0x25, 0x59, 0x85, 0x66, 0xd3,
-	0xa7, 0x12, 0x16, 0xbc, 0xee, 0x74, 0x18, 0x30, 0x1a, 0xc7, 0x38, 0x90, 0x15, 0x5a, 0xf2, 0x1e,
-	0x28, 0x84, 0xd0, 0x7a, 0x92, 0x69, 0xad, 0x2b, 0xad, 0xbc, 0xd0, 0x7a, 0x32, 0x1d, 0xc6, 0x5a,
-	0xab, 0x2a, 0xcd, 0x4d, 0x6e, 0x6a, 0x3d, 0xc9, 0xb5, 0xaa, 0xba, 0xbc, 0xca, 0x0d, 0xad, 0x27,
-	0x85, 0xd6, 0x66, 0xc6, 0xab, 0xb5, 0x3a, 0x7f, 0xae, 0xc0, 0xea, 0x7e, 0x9c, 0x9e, 0x26, 0xde,
-	0x08, 0xa3, 0xbb, 0xd0, 0xe2, 0x94, 0x7b, 0xe1, 0x30, 0x15, 0xa0, 0x3e, 0xbd, 0x40, 0xa2, 0x14,
-	0xc1, 0xc7, 0xd0, 0x8e, 0x31, 0xf3, 0xe3, 0x54, 0x53, 0x54, 0xb7, 0x6b, 0xe2, 0x94, 0x50, 0x38,
-	0x45, 0xb2, 0x0b, 0x37, 0xe5, 0xdc, 0x90, 0x44, 0x43, 0x55, 0x96, 0x27, 0x34, 0xc0, 0xda, 0x55,
-	0x3d, 0x39, 0x75, 0x18, 0x7d, 0x95, 0x4f, 0xa0, 0xef, 0x42, 0x2f, 0xa7, 0x17, 0xed, 0xaa, 0xa4,
-	0x56, 0xae, 0x5b, 0xd3, 0xd4, 0xa7, 0x1a, 0xed, 0x7c, 0x9d, 0xe7, 0x10, 0x89, 0x46, 0x07, 0x1e,
-	0xf7, 0x44, 0x2b, 0x13, 0xcb, 0xb3, 0x31, 0xd1, 0xd6, 0x66, 0x20, 0xfa, 0x1e, 0xf4, 0xb8, 0xce,
-	0xb7, 0x60, 0x98, 0xd1, 0xa8, 0xdd, 0x5c, 0xcf, 0x27, 0x06, 0x9a, 0xf8, 0x5b, 0xd0, 0x2d, 0x88,
-	0x65, 0x63, 0xa4, 0xec, 0xed, 0xe4, 0x58, 0x11, 0x4d, 0xce, 0x1f, 0x95, 0xb3, 0x54, 0xe4, 0x7c,
-	0x26, 0x8f, 0x6a, 0xc3, 0x55, 0xad, 0xbd, 0xb5, 0xac, 0xc5, 0xd1, 0xce, 0x90, 0xc7, 0xb3, 0x72,
-	0xcb, 0x8f, 0x61, 0x8d, 0xe7, 0xa6, 0x0f, 0x03, 0x8f, 0x7b, 0x3a, 0xf5, 0x66, 0x2a, 0xa1, 0x5e,
-	0x98, 0xdb, 0xe5, 0xe5, 0x85, 0x7e, 0x0c, 0x6d, 0xd5, 0x7b, 0x6b, 0x85, 0xca, 0xbe, 0x96, 0xc2,
-	0x49, 0x15, 0xce, 0x17, 0xd0, 0x1c, 0x90, 0x20, 0x51, 0xd6, 0xd9, 0xd0, 0xf0, 0x53, 0xc6, 0x70,
-	0x94, 0x35, 0x21, 0x19, 0x28, 0xca, 0xa3, 0xec, 0x5b, 0xb5, 0x33, 0x14, 0xe0, 0x50, 0x00, 0x75,
-	0x76, 0x4a, 0x6d, 0x1b, 0xb0, 0x62, 0x86, 0x80, 0x02, 0x44, 0x9c, 0x4d, 0xbc, 0x69, 0xbe, 0xf5,
-	0x32, 0xce, 0x26, 0xde, 0x54, 0x2d, 0xd0, 0x86, 0xc6, 0x6b, 0x8f, 0x84, 0xbe, 0x7e, 0x92, 0xb3,
-	0xdc, 0x0c, 0x2c, 0x14, 0x5a, 0xa6, 0xc2, 0x3f, 0x55, 0xa1, 0xa5, 0x34, 0x2a, 0x83, 0x37, 0x60,
-	0xc5, 0xf7, 0xfc, 0x71, 0xae, 0x52, 0x02, 0xe8, 0xd3, 0xcc, 0x90, 0xf2, 0x55, 0xbc, 0x30, 0x35,
-	0xb3, 0xed, 0x3e, 0x40, 0x72, 0xe9, 0xc5, 0x86, 0x77, 0x16, 0x52, 0x37, 0x05, 0x91, 0x32, 0xf8,
-	0x73, 0x68, 0xab, 0xf8, 0xd4, 0x3c, 0xd6, 0x32, 0x9e, 0x96, 0x22, 0x53, 0x5c, 0x0f, 0xc4, 0xb5,
-	0xc7, 0xe3, 0xaa, 0xcd, 0x6e, 0xed, 0x7d, 0x54, 0x22, 0x97, 0x2b, 0xd9, 0x95, 0xdf, 0xa7, 0x11,
-	0x67, 0x57, 0xae, 0xa2, 0xed, 0x3f, 0x02, 0x28, 0x90, 0xa2, 0x9e, 0x9d, 0xe3, 0xab, 0xec, 0x7a,
-	0x77, 0x8e, 0xaf, 0xc4, 0xda, 0x2f, 0xbc, 0x30, 0xcd, 0x9c, 0xaa, 0x80, 0x1f, 0x55, 0x1f, 0x55,
-	0x1c, 0x1f, 0xd6, 0x9e, 0x88, 0x23, 0xd1, 0x60, 0x2f, 0x1d, 0x7a, 0xd6, 0xc2, 0x43, 0xcf, 0xca,
-	0x5e, 0x92, 0xbb, 0x50, 0xa5, 0xb1, 0x6e, 0x75, 0xab, 0x34, 0x2e, 0x14, 0x59, 0x86, 0x22, 0xe7,
-	0x9f, 0x16, 0x40, 0xa1, 0x05, 0x1d, 0x43, 0x9f, 0xd0, 0xa1, 0xe8, 0xd4, 0x88, 0x8f, 0x55, 0x41,
-	0x1a, 0x32, 0xec, 0xa7, 0x2c, 0x21, 0x17, 0x58, 0x37, 0xf3, 0x9b, 0xf9, 0x31, 0x55, 0x32, 0xce,
-	0xdd, 0x22, 0xf4, 0x58, 0x31, 0xca, 0xca, 0xe5, 0x66, 0x6c, 0xe8, 0x67, 0x70, 0xab, 0x10, 0x1a,
-	0x18, 0xf2, 0xaa, 0xd7, 0xca, 0xbb, 0x99, 0xcb, 0x0b, 0x0a, 0x59, 0x3f, 0x81, 0x9b, 0x84, 0x0e,
-	0xdf, 0xa4, 0x38, 0x2d, 0x49, 0xaa, 0x5d, 0x2b, 0xa9, 0x47, 0xe8, 0x4b, 0xc9, 0x51, 0xc8, 0x79,
-	0x09, 0xb7, 0x8d, 0x85, 0x8a, 0xb4, 0x37, 0xa4, 0x59, 0xd7, 0x4a, 0xdb, 0xcc, 0xed, 0x12, 0x85,
-	0xa1, 0x10, 0xf9, 0x15, 0x6c, 0x12, 0x3a, 0xbc, 0xf4, 0x08, 0x9f, 0x95, 0xb7, 0xf2, 0xb6, 0x75,
-	0xbe, 0xf2, 0x08, 0x2f, 0x0b, 0x53, 0xeb, 0x9c, 0x60, 0x36, 0x2a, 0xad, 0xb3, 0xfe, 0xb6, 0x75,
-	0x1e, 0x49, 0x8e, 0x42, 0xce, 0x13, 0xe8, 0x11, 0x3a, 0x6b, 0x4f, 0xe3, 0x5a, 0x29, 0x6b, 0x84,
-	0x96, 0x6d, 0xd9, 0x87, 0x5e, 0x82, 0x7d, 0x4e, 0x99, 0x19, 0x0b, 0xab, 0xd7, 0xca, 0x58, 0xd7,
-	0x0c, 0xb9, 0x10, 0xe7, 0x0d, 0xb4, 0x7f, 0x9a, 0x8e, 0x30, 0x0f, 0xcf, 0xf2, 0x9c, 0xff, 0x6f,
-	0x97, 0x99, 0x7f, 0x55, 0xa1, 0xb5, 0x3f, 0x62, 0x34, 0x8d, 0x4b, 0x55, 0x5b, 0xe5, 0xf0, 0x5c,
-	0xd5, 0x96, 0x34, 0xb2, 0x6a, 0x2b, 0xea, 0x87, 0xd0, 0x56, 0x37, 0x17, 0xcd, 0xa0, 0xaa, 0x10,
-	0x9a, 0x4f, 0xfa, 0xec, 0xa6, 0xa4, 0xd8, 0xf6, 0xf4, 0x2d, 0x50, 0x73, 0x95, 0xab, 0x51, 0xe1,
-	0x26, 0x17, 0xce, 0x8a, 0xac, 0x3b, 0x84, 0xce, 0x58, 0xf9, 0x46, 0x73, 0xa9, 0x00, 0xfc, 0x24,
-	0x33, 0xae, 0x58, 0xc3, 0xae, 0xe9, 0x43, 0xe5, 0xea, 0xf6, 0xd8, 0x74, 0xeb, 0x3d, 0x00, 0x71,
-	0xcf, 0x1f, 0x66, 0x85, 0xca, 0xfc, 0x09, 0x90, 0x9f, 0x10, 0x6e, 0x33, 0xce, 0x86, 0xfd, 0x13,
-	0xe8, 0xcd, 0xc9, 0x5c, 0x50, 0xa6, 0xbe, 0x63, 0x96, 0xa9, 0xe2, 0x6a, 0x64, 0xb2, 0x9a, 0xb5,
-	0xeb, 0x6f, 0x15, 0xf5, 0x2c, 0x50, 0xbc, 0xd3, 0x3e, 0x82, 0x4e, 0xa4, 0x9a, 0xaf, 0x7c, 0x03,
-	0xcc, 0x3b, 0x96, 0xd9, 0x98, 0xb9, 0xed, 0xc8, 0x6c, 0xd3, 0x1e, 0x42, 0xdb, 0x97, 0x1e, 0x58,
-	0xb8, 0x11, 0x86, 0x73, 0xdc, 0x96, 0x6f, 0xec, 0x76, 0xa9, 0x51, 0xb4, 0xde, 0xa7, 0x51, 0xd4,
-	0x2f, 0x7b, 0xcb, 0x7e, 0x5a, 0xec, 0x7d, 0x53, 0x87, 0xda, 0xe3, 0xc1, 0x21, 0x3a, 0x85, 0xf5,
-	0xd9, 0x7f, 0x7e, 0xe8, 0x8e, 0x36, 0x6b, 0xc9, 0x7f, 0xc2, 0xfe, 0xdd, 0xa5, 0xf3, 0xba, 0x65,
-	0xbf, 0x81, 0x5c, 0x58, 0x9b, 0xf9, 0xc3, 0x83, 0xb2, 0xa3, 0x66, 0xf1, 0x5f, 0xb4, 0xfe, 0x9d,
-	0x65, 0xd3, 0xa6, 0xcc, 0x99, 0x3b, 0x42, 0x2e, 0x73, 0xf1, 0x7b, 0x4a, 0x2e, 0x73, 0xd9, 0xd5,
-	0xe2, 0x06, 0xfa, 0x21, 0xd4, 0xd5, 0x3f, 0x1f, 0x94, 0x5d, 0x5c, 0x4a, 0x7f, 0x93, 0xfa, 0xb7,
-	0x66, 0xb0, 0x39, 0xe3, 0x33, 0xe8, 0x94, 0x7e, 0x14, 0xa2, 0x0f, 0x4a, 0xba, 0xca, 0xbf, 0x8c,
-	0xfa, 0x1f, 0x2e, 0x9e, 0xcc, 0xa5, 0xed, 0x03, 0x14, 0xbf, 0x05, 0x90, 0xad, 0xa9, 0xe7, 0x7e,
-	0x3d, 0xf5, 0x6f, 0x2f, 0x98, 0xc9, 0x85, 0x9c, 0xc2, 0xfa, 0xec, 0x13, 0x3d, 0x9a, 0xf1, 0xea,
-	0xec, 0x03, 0x79, 0xbe, 0x95, 0x4b, 0xdf, 0xf6, 0xa5, 0xd8, 0xd9, 0x87, 0xf7, 0x5c, 0xec, 0x92,
-	0x67, 0xff, 0x5c, 0xec, 0xd2, 0x17, 0xfb, 0x1b, 0xe8, 0x05, 0x74, 0xcb, 0x2f, 0xd9, 0x28, 0x73,
-	0xd2, 0xc2, 0xa7, 0xfc, 0xfe, 0x47, 0x4b, 0x66, 0x73, 0x81, 0x9f, 0xc3, 0x8a, 0x7a, 0xa2, 0xce,
-	0xd2, 0xd1, 0x7c, 0xd9, 0xee, 0x6f, 0x94, 0x91, 0x39, 0xd7, 0x7d, 0xa8, 0xab, 0xdb, 0x65, 0x1e,
-	0x00, 0xa5, 0xcb, 0x66, 0xbf, 0x6d, 0x62, 0x9d, 0x1b, 0xf7, 0x2b, 0x99, 0x9e, 0xa4, 0xa4, 0x27,
-	0x59, 0xa4, 0xc7, 0xd8, 0x9c, 0xb3, 0xba, 0x4c, 0xd7, 0x07, 0xff, 0x0e, 0x00, 0x00, 0xff, 0xff,
-	0x11, 0x58, 0x45, 0xd9, 0xb2, 0x1f, 0x00, 0x00,
+	// 2771 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xed, 0x19, 0x4d, 0x6f, 0x1c, 0x4b,
+	0x31, 0xfb, 0xe1, 0x5d, 0x6f, 0xed, 0x87, 0xed, 0x49, 0xe2, 0x6c, 0x36, 0x9f, 0x8c, 0x1e, 0x10,
+	0xe0, 0xc9, 0xf1, 0x73, 0xde, 0x0b, 0x11, 0x48, 0x48, 0x89, 0x13, 0x1e, 0x26, 0x5f, 0xce, 0xd8,
+	0x21, 0x42, 0x42, 0x5a, 0x8d, 0x77, 0xdb, 0xeb, 0xc1, 0xb3, 0x33, 0xf3, 0x66, 0x66, 0xfd, 0x71,
+	0xe1, 0xc0, 0x01, 0xc4, 0x05, 0xae, 0xfc, 0x06, 0xee, 0x1c, 0xe0, 0xc0, 0x15, 0x89, 0xff, 0xf2,
+	0x4e, 0x5c, 0x38, 0x52, 0xd5, 0x5d, 0x3d, 0xd3, 0xb3, 0x1f, 0x4e, 0x82, 0x84, 0xb8, 0x70, 0xd9,
+	0xed, 0xaa, 0xae, 0xae, 0xaa, 0xae, 0xaf, 0xae, 0x9e, 0x86, 0x86, 0x1b, 0x79, 0x1b, 0x51, 0x1c,
+	0xa6, 0xa1, 0xb5, 0x94, 0x9e, 0x47, 0x22, 0xe9, 0xdd, 0x19, 0x85, 0xe1, 0xc8, 0x17, 0xf7, 0x25,
+	0xf2, 0x60, 0x72, 0x78, 0x3f, 0xf5, 0xc6, 0x22, 0x49, 0xdd, 0x71, 0xa4, 0xe8, 0xec, 0xeb, 0x70,
+	0xed, 0x4b, 0x91, 0xee, 0x89, 0xf8, 0x44, 0xc4, 0x3f, 0x13, 0x71, 0xe2, 0x85, 0x81, 0x23, 0xbe,
+	0x9a, 0x20, 0x8d, 0x7d, 0x06, 0xdd, 0xd9, 0xa9, 0x24, 0x0a, 0x83, 0x44, 0x58, 0x57, 0x60, 0x69,
+	0xec, 0xfe, 0x32, 0x8c, 0xbb, 0xa5, 0xbb, 0xa5, 0x7b, 0x6d, 0x47, 0x01, 0x12, 0xeb, 0x05, 0x88,
+	0x2d, 0x33, 0x96, 0x00, 0xc2, 0x46, 0x6e, 0x3a, 0x38, 0xea, 0x56, 0x14, 0x56, 0x02, 0x56, 0x0f,
+	0x96, 0x63, 0x71, 0xe2, 0x11, 0xd7, 0x6e, 0x15, 0x27, 0x1a, 0x4e, 0x06, 0xdb, 0xbf, 0x29, 0xc1,
+	0x95, 0xb7, 0xd1, 0xd0, 0x4d, 0xc5, 0x6e, 0x1c, 0x0e, 0x44, 0x92, 0xb0, 0x4a, 0x56, 0x07, 0xca,
+	0xde, 0x50, 0xca, 0x6c, 0x38, 0x38, 0xb2, 0x56, 0xa1, 0x12, 0x21, 0xa2, 0x2c, 0x11, 0x34, 0xb4,
+	0x6e, 0x03, 0x0c, 0xfc, 0x30, 0x11, 0x7b, 0xe9, 0xd0, 0x0b, 0xa4, 0xc4, 0x65, 0xc7, 0xc0, 0x90,
+	0x32, 0xa7, 0xde, 0x30, 0x3d, 0x92, 0x32, 0x51, 0x19, 0x09, 0x58, 0xeb, 0x50, 0x3b, 0x12, 0xde,
+	0xe8, 0x28, 0xed, 0x2e, 0x49, 0x34, 0x43, 0xf6, 0x35, 0xb8, 0x3a, 0xa5, 0x87, 0xda, 0xbf, 0xfd,
+	0x8f, 0x32, 0xac, 0x6f, 0xc7, 0x02, 0x67, 0xb6, 0xc3, 0x20, 0x75, 0xbd, 0x40, 0xc4, 0x8b, 0x74,
+	0x44, 0x8d, 0x0e, 0x26, 0xc1, 0xd0, 0x17, 0xbb, 0x2e, 0x8a, 0x55, 0xaa, 0x1a, 0x18, 0xa9, 0xf1,
+	0x91, 0x18, 0x1c, 0x47, 0xa1, 0x17, 0xa4, 0x52, 0x63, 0x9c, 0xcf, 0x31, 0xa4, 0x71, 0x22, 0x37,
+	0xa3, 0xac, 0xa4, 0x00, 0xd2, 0x18, 0x07, 0xe1, 0x44, 0x69, 0xdc, 0x70, 0x18, 0x62, 0xbc, 0x88,
+	0xe3, 0x6e, 0x2d, 0xc3, 0x23, 0x44, 0x78, 0xdf, 0x3d, 0x10, 0x7e, 0xd2, 0xad, 0xdf, 0xad, 0x10,
+	0x5e, 0x41, 0xd6, 0x5d, 0x68, 0x06, 0xe1, 0xae, 0x77, 0x12, 0xa6, 0x4e, 0x18, 0xa6, 0xdd, 0x65,
+	0x69, 0x30, 0x13, 0x65, 0x75, 0xa1, 0x1e, 0x4f, 0x02, 0x8a, 0x9b, 0x6e, 0x43, 0xb2, 0xd4, 0x20,
+	0xad, 0xe5, 0xe1, 0xe3, 0x78, 0x94, 0x74, 0x41, 0x32, 0x36, 0x51, 0xd6, 0x27, 0xd0, 0xce, 0x77,
+	0xf2, 0xd4, 0x8b, 0xbb, 0x4d, 0xc9, 0xa1, 0x88, 0xb4, 0x77, 0xe0, 0xda, 0x8c, 0x2d, 0x39, 0xce,
+	0x36, 0xa0, 0x31, 0xd0, 0x48, 0x69, 0xd3, 0xe6, 0xd6, 0xea, 0x86, 0x0c, 0xed, 0x8d, 0x9c, 0x38,
+	0x27, 0x41, 0x56, 0xed, 0x3d, 0x6f, 0x14, 0xb8, 0xfe, 0x87, 0x47, 0x0c, 0x59, 0x4c, 0x2e, 0xe1,
+	0xf8, 0x64, 0xc8, 0x5e, 0x85, 0x8e, 0x66, 0xc5, 0x4e, 0xff, 0x73, 0x05, 0xd6, 0x1e, 0x0f, 0x87,
+	0xef, 0x89, 0x49, 0x0c, 0xec, 0x54, 0xc4, 0x18, 0xfa, 0xc8, 0xb1, 0x2c, 0xcd, 0x99, 0xc1, 0xd6,
+	0x1d, 0xa8, 0x4e, 0x12, 0xdc, 0x49, 0x45, 0xee, 0xa4, 0xc9, 0x3b, 0x79, 0x8b, 0x28, 0x47, 0x4e,
+	0x58, 0x16, 0x54, 0x5d, 0xb2, 0x65, 0x55, 0xda, 0x52, 0x8e, 0x49, 0x65, 0x11, 0x9c, 0xa0, 0x9f,
+	0x09, 0x45, 0x43, 0xc2, 0x0c, 0x4e, 0x87, 0xec, 0x61, 0x1a, 0xea, 0x6d, 0xd5, 0xf3, 0x6d, 0x65,
+	0x61, 0xb3, 0x3c, 0x3f, 0x6c, 0x1a, 0x0b, 0xc2, 0x06, 0x0a, 0x61, 0x63, 0x43, 0x6b, 0xe0, 0x46,
+	0xee, 0x81, 0xe7, 0x7b, 0xa9, 0x27, 0x12, 0xf4, 0x1f, 0x29, 0x51, 0xc0, 0x59, 0xf7, 0x60, 0xc5,
+	0x8d, 0x22, 0x37, 0x1e, 0x87, 0x31, 0x9a, 0xe6, 0xd0, 0xf3, 0x45, 0xb7, 0x25, 0x99, 0x4c, 0xa3,
+	0x89, 0x5b, 0x22, 0x7c, 0x2f, 0x98, 0x9c, 0xbd, 0xa0, 0xe8, 0xeb, 0xb6, 0x25, 0x59, 0x01, 0x47,
+	0xdc, 0x82, 0xf0, 0x95, 0x38, 0xdd, 0x8d, 0xbd, 0x13, 0x5c, 0x33, 0x42, 0xa1, 0x1d, 0x69, 0xc5,
+	0x69, 0xb4, 0xf5, 0x6d, 0x0c, 0x4c, 0xdf, 0x1b, 0x7b, 0x69, 0xd2, 0x5d, 0x41, 0xb5, 0x9a, 0x5b,
+	0x6d, 0xb6, 0xa7, 0x23, 0xb1, 0x8e, 0x9e, 0xb5, 0x9f, 0x42, 0x4d, 0xa1, 0xc8, 0xbc, 0x44, 0xc2,
+	0xde, 0x92, 0x63, 0xc2, 0x25, 0xe1, 0x61, 0x2a, 0x7d, 0x55, 0x75, 0xe4, 0x98, 0x70, 0x47, 0x6e,
+	0x3c, 0x94, 0x7e, 0x42, 0x1c, 0x8d, 0x6d, 0x07, 0xaa, 0xe4, 0x28, 0x32, 0xf5, 0x84, 0x1d, 0xde,
+	0x76, 0x68, 0x48, 0x98, 0x11, 0xc7, 0x14, 0x62, 0x70, 0x68, 0x7d, 0x0b, 0x3a, 0xee, 0x70, 0x88,
+	0xe6, 0x09, 0xd1, 0xeb, 0x5f, 0x7a, 0xc3, 0x04, 0x39, 0x55, 0x70, 0x72, 0x0a, 0x6b, 0x6f, 0x81,
+	0x65, 0x06, 0x14, 0x07, 0xfd, 0x4d, 0x68, 0x24, 0xe7, 0x49, 0x2a, 0xc6, 0xbb, 0x99, 0x9c, 0x1c,
+	0x61, 0xff, 0xba, 0x94, 0xa5, 0x4b, 0x96, 0x45, 0x8b, 0x62, 0xf1, 0xb3, 0x42, 0x6d, 0x29, 0xcb,
+	0xa8, 0x5b, 0xd3, 0xf9, 0x93, 0xaf, 0x36, 0xcb, 0xcd, 0x4c, 0xca, 0x56, 0xe6, 0xa5, 0x6c, 0x0f,
+	0xba, 0xb3, 0x3a, 0x70, 0x9a, 0x0c, 0xe0, 0xda, 0x53, 0xe1, 0x8b, 0x0f, 0xd1, 0x0f, 0xed, 0x1c,
+	0xb8, 0x58, 0x58, 0x54, 0x3a, 0xca, 0xf1, 0x87, 0x2b, 0x30, 0x2b, 0x84, 0x15, 0x78, 0x09, 0x57,
+	0x5f, 0x78, 0x49, 0xfa, 0x7e, 0xf1, 0x33, 0xa2, 0xca, 0xf3, 0x44, 0xfd, 0xb1, 0x04, 0x90, 0xf3,
+	0xca, 0x74, 0x2e, 0x19, 0x3a, 0x23, 0x4e, 0x9c, 0x79, 0x29, 0xe7, 0xbb, 0x1c, 0x53, 0x54, 0xa4,
+	0x83, 0x88, 0x8f, 0x20, 0x1a, 0x52, 0xbd, 0x9c, 0x04, 0xde, 0xd9, 0x5e, 0x38, 0x38, 0x16, 0x69,
+	0x22, 0xeb, 0x39, 0xd6, 0x5a, 0x03, 0x25, 0x93, 0xf6, 0x48, 0xf8, 0xbe, 0x2c, 0xea, 0xcb, 0x8e,
+	0x02, 0xa8, 0x02, 0x8b, 0x71, 0x94, 0x9e, 0xbf, 0xda, 0xc3, 0x94, 0xa7, 0xfc, 0xd3, 0x20, 0xee,
+	0x74, 0x7d, 0x7a, 0xa7, 0x1c, 0x43, 0x0f, 0xa0, 0x99, 0xef, 0x22, 0x41, 0x65, 0x2b, 0xf3, 0x5d,
+	0x6f, 0x52, 0xd9, 0xb7, 0xa1, 0xb5, 0x97, 0xa2, 0x53, 0x17, 0xd8, 0xcb, 0xbe, 0x07, 0x9d, 0xac,
+	0xea, 0x4a, 0x42, 0x55, 0x37, 0xdc, 0x74, 0x92, 0x30, 0x15, 0x43, 0xf6, 0x5f, 0x2a, 0x50, 0xe7,
+	0xb0, 0xd6, 0xb5, 0xa9, 0x94, 0xd7, 0xa6, 0xff, 0x49, 0x89, 0x2c, 0x64, 0x55, 0x7d, 0x2a, 0xab,
+	0xfe, 0x5f, 0x2e, 0xf3, 0x72, 0xf9, 0xf7, 0x12, 0x34, 0x32, 0x37, 0x7f, 0x74, 0x3b, 0xf3, 0x29,
+	0x34, 0x22, 0xe5, 0x78, 0xa1, 0xaa, 0x5e, 0x73, 0xab, 0xc3, 0x82, 0x74, 0x9d, 0xcb, 0x09, 0x8c,
+	0xf8, 0xa9, 0x9a, 0xf1, 0x63, 0xb4, 0x2b, 0x4b, 0x85, 0x76, 0x05, 0x9d, 0x1f, 0x51, 0x39, 0xad,
+	0xc9, 0x72, 0x2a, 0xc7, 0x66, 0x83, 0x52, 0x2f, 0x34, 0x28, 0xf6, 0x17, 0x50, 0x7f, 0xe9, 0x0e,
+	0x8e, 0x70, 0x1f, 0xb4, 0x70, 0x10, 0x71, 0x98, 0xe2, 0x42, 0x1a, 0x93, 0x90, 0xb1, 0x40, 0x7b,
+	0x9f, 0x73, 0xed, 0x67, 0xc8, 0x3e, 0xc6, 0x26, 0x42, 0xa5, 0x01, 0x27, 0xd3, 0x26, 0x96, 0x51,
+	0x6d, 0x10, 0x9d, 0x4b, 0xb3, 0x6d, 0x88, 0x41, 0x83, 0x6e, 0xa9, 0x8f, 0x95, 0x64, 0xae, 0xba,
+	0xda, 0x06, 0xac, 0x8f, 0xa3, 0xa7, 0xed, 0xdf, 0x96, 0x60, 0x5d, 0xf5, 0x98, 0xef, 0xed, 0x24,
+	0xe7, 0xf7, 0x2e, 0xca, 0x7c, 0x95, 0x82, 0xf9, 0x1e, 0x40, 0x23, 0x16, 0x49, 0x38, 0x89, 0xd1,
+	0xcc, 0xd2, 0xb2, 0xcd, 0xad, 0xab, 0x3a, 0x93, 0xa4, 0x2c, 0x87, 0x67, 0x9d, 0x9c, 0xce, 0xfe,
+	0xba, 0x06, 0x9d, 0xe2, 0x2c, 0x55, 0xac, 0x03, 0xff, 0xd8, 0x0b, 0xdf, 0xa9, 0xe6, 0xb8, 0x24,
+	0xcd, 0x64, 0xa2, 0x28, 0xab, 0xd0, 0x96, 0x7b, 0x78, 0x42, 0xa2, 0x24, 0x65, 0xc6, 0x1c, 0xc1,
+	0xb3, 0xbb, 0x22, 0xf6, 0x42, 0x7d, 0x98, 0xe6, 0x08, 0x2a, 0x03, 0x08, 0xbc, 0x99, 0x84, 0xa9,
+	0x2b, 0x95, 0xac, 0x3a, 0x19, 0x2c, 0xbb, 0x62, 0xf4, 0x91, 0x48, 0xb7, 0xc9, 0x6b, 0x4b, 0xdc,
+	0x15, 0x67, 0x98, 0x7c, 0xfe, 0xa5, 0x18, 0x27, 0x9c, 0xe6, 0x06, 0x86, 0x34, 0x57, 0xde, 0x7c,
+	0x41, 0x41, 0x2d, 0x03, 0x03, 0x35, 0x37, 0x50, 0xc4, 0x41, 0x81, 0x7b, 0xa7, 0x6e, 0x24, 0xd3,
+	0xbe, 0xea, 0x18, 0x18, 0x0c, 0xe4, 0x35, 0x05, 0xa1, 0x35, 0xf0, 0x0e, 0xe4, 0xd2, 0xb1, 0x2d,
+	0xcb, 0x40, 0xd5, 0x99, 0x9d, 0x20, 0xea, 0x63, 0x11, 0x07, 0xc2, 0x7f, 0x69, 0x48, 0x05, 0x45,
+	0x3d, 0x33, 0x61, 0x6d, 0xc1, 0x15, 0x85, 0xdc, 0xdf, 0xde, 0x35, 0x17, 0x34, 0xe5, 0x82, 0xb9,
+	0x73, 0x94, 0xe9, 0xd2, 0xf0, 0x2f, 0x84, 0x7b, 0xc8, 0xfe, 0x68, 0x49, 0xf2, 0x69, 0xb4, 0xf5,
+	0x18, 0xd6, 0x0c, 0x17, 0x3d, 0xc5, 0x5b, 0xd5, 0x40, 0x60, 0xf1, 0xa0, 0xa8, 0xbd, 0xcc, 0x51,
+	0x60, 0x4e, 0x39, 0xb3, 0xd4, 0xd6, 0x5b, 0xe8, 0x49, 0xe4, 0xfe, 0x11, 0xde, 0x12, 0x53, 0x1f,
+	0x23, 0xc2, 0x1d, 0x3e, 0x89, 0x12, 0xe6, 0xd5, 0x91, 0xbc, 0x74, 0x44, 0x69, 0x1a, 0xe6, 0x76,
+	0xc1, 0x42, 0xeb, 0x1d, 0xdc, 0x28, 0xcc, 0xbe, 0x8b, 0xbd, 0x54, 0xe4, 0x7c, 0x57, 0x2e, 0xe2,
+	0x7b, 0xd1, 0xca, 0x19, 0xc6, 0x24, 0x76, 0x27, 0xcc, 0x18, 0xaf, 0x7e, 0x38, 0xe3, 0xe2, 0x4a,
+	0xeb, 0xe7, 0x70, 0x73, 0x56, 0xae, 0xc1, 0x79, 0xed, 0x22, 0xce, 0x17, 0x2e, 0xb5, 0x7f, 0x08,
+	0xed, 0x27, 0x3e, 0x1e, 0xfc, 0x3b, 0xaf, 0x59, 0x56, 0xe1, 0x52, 0x5d, 0x99, 0x7b, 0xa9, 0xae,
+	0xf0, 0xa5, 0xda, 0xfe, 0x15, 0xb4, 0x0a, 0x0e, 0x7b, 0x28, 0x33, 0x55, 0xb3, 0xe2, 0xab, 0xd2,
+	0x15, 0x56, 0xab, 0x20, 0xc6, 0x31, 0x09, 0xa9, 0x82, 0x9c, 0xaa, 0x60, 0x52, 0xed, 0x2b, 0x43,
+	0x94, 0x1d, 0x7e, 0x1e, 0x68, 0xea, 0x66, 0x64, 0x60, 0xec, 0x5f, 0x40, 0xa7, 0xb8, 0xd9, 0xff,
+	0x58, 0x03, 0xac, 0xcc, 0x31, 0xd6, 0x1c, 0xdd, 0x7f, 0xd3, 0x98, 0xbe, 0x4a, 0xcc, 0xd4, 0x44,
+	0x6e, 0xee, 0xce, 0xa1, 0xfd, 0xec, 0x44, 0x60, 0xb7, 0xa2, 0xab, 0xe4, 0x23, 0x68, 0x64, 0x1f,
+	0x35, 0xb8, 0xd8, 0xf6, 0x36, 0xd4, 0x67, 0x8f, 0x0d, 0xfd, 0xd9, 0x63, 0x63, 0x5f, 0x53, 0x38,
+	0x39, 0x31, 0xed, 0x31, 0x49, 0xc3, 0x58, 0x0c, 0x5f, 0x07, 0xfe, 0xb9, 0xfe, 0x56, 0x90, 0x63,
+	0xb8, 0xfe, 0x56, 0xb3, 0xf6, 0xe7, 0x0f, 0x25, 0x58, 0x92, 0xb2, 0xe7, 0xde, 0x23, 0x14, 0x75,
+	0x39, 0xab, 0xd6, 0xc5, 0xda, 0xdc, 0xce, 0x6a, 0x33, 0x57, 0xf1, 0x6a, 0x5e, 0xc5, 0x0b, 0x3b,
+	0xa8, 0x7d, 0xc4, 0x0e, 0xec, 0xdf, 0x97, 0xa1, 0xf5, 0x4a, 0xa4, 0xa7, 0x61, 0x7c, 0x4c, 0x27,
+	0x56, 0x32, 0xb7, 0x39, 0xbd, 0x0e, 0xcb, 0xf1, 0x59, 0xff, 0xe0, 0x3c, 0xcd, 0x2a, 0x74, 0x3d,
+	0x3e, 0x7b, 0x42, 0xa0, 0x75, 0x0b, 0x00, 0xa7, 0x76, 0x5d, 0xd5, 0x90, 0x72, 0x81, 0x8e, 0xcf,
+	0x18, 0x61, 0xdd, 0x80, 0x86, 0x73, 0xd6, 0xc7, 0xc6, 0x26, 0x8c, 0x13, 0x5d, 0xa1, 0xe3, 0xb3,
+	0x67, 0x12, 0xa6, 0xb5, 0x38, 0x39, 0x8c, 0xc3, 0x28, 0x12, 0x43, 0x59, 0xa1, 0xe5, 0xda, 0xa7,
+	0x0a, 0x41, 0x52, 0xf7, 0xb5, 0xd4, 0x9a, 0x92, 0x9a, 0xe6, 0x52, 0x71, 0x2a, 0x62, 0xa9, 0xaa,
+	0x34, 0x37, 0x52, 0x53, 0xea, 0x7e, 0x26, 0x55, 0xd5, 0xe5, 0xe5, 0xd4, 0x90, 0xba, 0x9f, 0x4b,
+	0x6d, 0xe8, 0xb5, 0x2c, 0xd5, 0xfe, 0x53, 0x09, 0x96, 0xf1, 0x7c, 0x78, 0x9b, 0xb8, 0x23, 0x81,
+	0xad, 0x64, 0x33, 0xc5, 0xb3, 0xc4, 0xef, 0x4f, 0x08, 0xe4, 0xd3, 0x0b, 0x24, 0x4a, 0x11, 0x7c,
+	0x03, 0x5a, 0x91, 0x88, 0xf1, 0xd4, 0x60, 0x8a, 0x32, 0x26, 0x33, 0x9e, 0x12, 0x0a, 0xa7, 0x48,
+	0x36, 0xe0, 0xb2, 0x9c, 0xeb, 0x7b, 0x41, 0x5f, 0x95, 0xe5, 0x71, 0x38, 0x14, 0x6c, 0xaa, 0x35,
+	0x39, 0xb5, 0x13, 0x3c, 0xcf, 0x26, 0xac, 0xef, 0xc2, 0x5a, 0x46, 0x4f, 0xed, 0xaa, 0xa4, 0x56,
+	0xa6, 0x5b, 0x61, 0xea, 0xb7, 0x8c, 0xc6, 0x1c, 0xd6, 0x39, 0xe4, 0x05, 0xa3, 0xa7, 0x2e, 0x9e,
+	0x7a, 0xd8, 0xca, 0x44, 0xf2, 0x6c, 0x4c, 0x58, 0x5b, 0x0d, 0x5a, 0xdf, 0x83, 0xb5, 0x94, 0xf3,
+	0x6d, 0xd8, 0xd7, 0x34, 0xca, 0x9b, 0xab, 0xd9, 0xc4, 0x2e, 0x13, 0x7f, 0x13, 0x3a, 0x39, 0xb1,
+	0x6c, 0x8c, 0x94, 0xbe, 0xed, 0x0c, 0x4b, 0xd1, 0x64, 0xff, 0x4d, 0x19, 0x4b, 0x45, 0xce, 0xa7,
+	0xf2, 0xa8, 0x36, 0x4c, 0xd5, 0xdc, 0x5a, 0xd1, 0x2d, 0x0e, 0x1b, 0x43, 0x1e, 0xcf, 0xca, 0x2c,
+	0x3f, 0x82, 0x95, 0x34, 0x53, 0xbd, 0x8f, 0x99, 0xea, 0x72, 0xea, 0x4d, 0x55, 0x42, 0xde, 0x98,
+	0xd3, 0x49, 0x8b, 0x1b, 0x45, 0xcb, 0xab, 0xde, 0x9b, 0x05, 0x2a, 0xfd, 0x9a, 0x0a, 0xa7, 0x9d,
+	0x53, 0x89, 0x12, 0x8f, 0xbb, 0x17, 0xad, 0xca, 0xee, 0xde, 0x8e, 0x54, 0xd7, 0xa1, 0x39, 0xac,
+	0xa0, 0x0d, 0xec, 0xdd, 0x13, 0xb5, 0x01, 0xb4, 0xdd, 0x60, 0x12, 0xc7, 0x98, 0x9e, 0xda, 0x76,
+	0x0c, 0x52, 0x05, 0x95, 0xad, 0x2d, 0xdb, 0x4b, 0x01, 0x76, 0x08, 0xa0, 0x8e, 0x57, 0xa9, 0x10,
+	0xd2, 0x98, 0x51, 0xa2, 0x00, 0x0a, 0xc5, 0xb1, 0x7b, 0x96, 0x45, 0x87, 0x0c, 0x45, 0x44, 0x28,
+	0x05, 0x51, 0xe0, 0xa1, 0xeb, 0xf9, 0x03, 0xfe, 0x6a, 0x87, 0x02, 0x19, 0xcc, 0x05, 0x56, 0x4d,
+	0x81, 0xff, 0x2c, 0x43, 0x53, 0x49, 0x54, 0x0a, 0x23, 0xd5, 0x00, 0x9b, 0xc0, 0x4c, 0xa4, 0x04,
+	0xb0, 0x4d, 0x5f, 0xca, 0xc5, 0xe5, 0x57, 0xb6, 0x5c, 0x55, 0xad, 0x1b, 0x36, 0xa5, 0x09, 0xf6,
+	0x29, 0x86, 0x01, 0xe7, 0x52, 0x37, 0x88, 0x48, 0x29, 0xfc, 0x39, 0xb4, 0x54, 0x08, 0xf3, 0x9a,
+	0xea, 0xa2, 0x35, 0x4d, 0x45, 0xa6, 0x56, 0x3d, 0xa0, 0x9b, 0x11, 0xea, 0x2b, 0x3b, 0xf1, 0xe6,
+	0xd6, 0xad, 0x02, 0xb9, 0xdc, 0xc9, 0x86, 0xfc, 0x7d, 0x16, 0xa4, 0xd8, 0x12, 0x29, 0x5a, 0x0c,
+	0xd7, 0x9a, 0x90, 0x55, 0x9a, 0xeb, 0xd9, 0xe5, 0xc2, 0x2a, 0x2e, 0xe0, 0x4c, 0xa2, 0x3d, 0x5d,
+	0x5f, 0xec, 0xe9, 0xde, 0x23, 0x80, 0x5c, 0x08, 0x95, 0xd0, 0x63, 0x71, 0xae, 0x6f, 0x94, 0x38,
+	0x24, 0x5b, 0x9e, 0xb8, 0xfe, 0x44, 0x3b, 0x49, 0x01, 0x3f, 0x28, 0x3f, 0x2a, 0xd9, 0x03, 0x58,
+	0x79, 0x42, 0xa7, 0xb0, 0xb1, 0xbc, 0x70, 0xce, 0x56, 0xe7, 0x9e, 0xb3, 0x55, 0xfd, 0xf1, 0x1a,
+	0xab, 0x7a, 0x18, 0x71, 0x77, 0x8d, 0xa3, 0x5c, 0x50, 0xd5, 0x10, 0x64, 0xff, 0x6e, 0x09, 0x20,
+	0x97, 0x62, 0xed, 0x41, 0xcf, 0x0b, 0xfb, 0xd4, 0x1c, 0xe2, 0x01, 0xa7, 0x6a, 0x60, 0x3f, 0x16,
+	0x18, 0x8e, 0x89, 0x77, 0x22, 0xf8, 0xfe, 0xb0, 0x9e, 0x9d, 0x8c, 0x05, 0xe5, 0x9c, 0x6b, 0x08,
+	0xa9, 0x85, 0xb2, 0x58, 0x3a, 0x7a, 0x99, 0xf5, 0x53, 0xb8, 0x9a, 0x33, 0x1d, 0x1a, 0xfc, 0xca,
+	0x17, 0xf2, 0xbb, 0x9c, 0xf1, 0x1b, 0xe6, 0xbc, 0x7e, 0x0c, 0x88, 0xee, 0xe3, 0xf9, 0x39, 0x29,
+	0x70, 0xaa, 0x5c, 0xc8, 0x69, 0xcd, 0x0b, 0xdf, 0xc8, 0x15, 0x39, 0x9f, 0x37, 0x70, 0xdd, 0xd8,
+	0x28, 0x55, 0x1a, 0x83, 0x5b, 0xf5, 0x42, 0x6e, 0xeb, 0x99, 0x5e, 0x54, 0x8b, 0x72, 0x96, 0xcf,
+	0x01, 0x67, 0xfa, 0xa7, 0xae, 0x97, 0x4e, 0xf3, 0x5b, 0x7a, 0xdf, 0x3e, 0xdf, 0xe1, 0xa2, 0x22,
+	0x33, 0xb5, 0xcf, 0xb1, 0x88, 0x47, 0x85, 0x7d, 0xd6, 0xde, 0xb7, 0xcf, 0x97, 0x72, 0x45, 0xce,
+	0xe7, 0x09, 0x20, 0x72, 0x5a, 0x9f, 0xfa, 0x85, 0x5c, 0x56, 0xb0, 0xf1, 0x2b, 0xe8, 0xb2, 0x0d,
+	0x6b, 0x89, 0x18, 0x60, 0x77, 0x61, 0xc6, 0xc2, 0xf2, 0x85, 0x3c, 0x56, 0x79, 0x41, 0xce, 0x84,
+	0x53, 0xa5, 0x71, 0x41, 0x51, 0xfc, 0x0a, 0x5a, 0x3f, 0x99, 0x8c, 0x44, 0xea, 0x1f, 0x64, 0x65,
+	0xe6, 0xbf, 0x5d, 0xd9, 0xfe, 0x85, 0x95, 0x6d, 0x7b, 0x14, 0x87, 0x93, 0xa8, 0x70, 0x96, 0xa8,
+	0xb2, 0x31, 0x73, 0x96, 0x28, 0x5d, 0xe9, 0x2c, 0x51, 0xd4, 0x5f, 0x40, 0x4b, 0xdd, 0xa7, 0x78,
+	0x81, 0x2a, 0x7c, 0xd6, 0x6c, 0x9d, 0xd1, 0xf7, 0x37, 0xb5, 0x6c, 0x8b, 0xef, 0xa6, 0xbc, 0xaa,
+	0x58, 0x00, 0x73, 0x4b, 0x3a, 0x70, 0x90, 0x27, 0xe6, 0x0e, 0xb4, 0x8f, 0x94, 0x6d, 0x78, 0x95,
+	0x8a, 0xd1, 0x4f, 0xb4, 0x72, 0xf9, 0x1e, 0x36, 0x4c, 0x1b, 0x2a, 0x6f, 0xb4, 0x8e, 0x4c, 0xb3,
+	0xde, 0x07, 0xa0, 0xaf, 0x0f, 0x7d, 0x5d, 0x1b, 0xcd, 0xa7, 0x89, 0xec, 0x50, 0x72, 0x1a, 0x91,
+	0x1e, 0xf6, 0xf6, 0x61, 0x6d, 0x86, 0xe7, 0x9c, 0x4a, 0xf6, 0x1d, 0xb3, 0x92, 0xe5, 0x85, 0xd3,
+	0x5c, 0x6a, 0x96, 0xb7, 0xbf, 0x96, 0xd4, 0xc7, 0x8a, 0xfc, 0xeb, 0xf1, 0x23, 0x68, 0x07, 0xaa,
+	0x25, 0xcc, 0x1c, 0x60, 0xde, 0xfc, 0xcc, 0x76, 0xd1, 0x69, 0x05, 0x66, 0xf3, 0x88, 0x8e, 0x18,
+	0x48, 0x0b, 0xcc, 0x75, 0x84, 0x61, 0x1c, 0xa7, 0x39, 0x30, 0xbc, 0x5d, 0x68, 0x5f, 0xab, 0x1f,
+	0xd3, 0xbe, 0xf2, 0xf7, 0xc6, 0x45, 0x4f, 0x29, 0x58, 0xbb, 0xeb, 0x18, 0xdb, 0xfa, 0x7c, 0x76,
+	0x4f, 0x46, 0x9f, 0x6d, 0xca, 0xd9, 0x92, 0xa3, 0x00, 0xc6, 0x3e, 0xdc, 0x94, 0xaa, 0x2a, 0xec,
+	0xc3, 0x4d, 0xea, 0xbc, 0x71, 0xf0, 0x60, 0x73, 0x53, 0x06, 0x45, 0xc9, 0x61, 0x88, 0xa8, 0x65,
+	0xf3, 0xa7, 0x83, 0x57, 0x02, 0xb6, 0x03, 0xcb, 0x3a, 0x81, 0x2c, 0x9b, 0xde, 0x02, 0xc6, 0xba,
+	0xff, 0xe9, 0xe4, 0xf9, 0x25, 0x8f, 0x45, 0x39, 0x47, 0x34, 0x87, 0x13, 0xdf, 0x9f, 0xfa, 0xae,
+	0x93, 0xd1, 0xd0, 0x9c, 0x9d, 0x40, 0xcb, 0x3c, 0xe9, 0xc8, 0xcd, 0x7e, 0x78, 0xca, 0x19, 0x48,
+	0x43, 0xf9, 0xc2, 0x80, 0x17, 0x29, 0x7d, 0xeb, 0xa1, 0x31, 0x51, 0x61, 0x0a, 0x72, 0xca, 0xd1,
+	0x90, 0x30, 0x61, 0x38, 0x66, 0x7d, 0x69, 0x48, 0x6d, 0x35, 0xfe, 0xf5, 0x8f, 0x3d, 0xfe, 0x48,
+	0x8c, 0xb9, 0x89, 0xf0, 0x73, 0x04, 0xb7, 0xbe, 0xae, 0x41, 0xe5, 0xf1, 0xee, 0x0e, 0xde, 0xdd,
+	0x57, 0xa7, 0xdf, 0x6d, 0xad, 0xdb, 0xac, 0xe6, 0x82, 0xb7, 0xde, 0xde, 0x9d, 0x85, 0xf3, 0x7c,
+	0xed, 0xba, 0x64, 0x39, 0xb0, 0x32, 0xf5, 0x4a, 0x67, 0xe9, 0x5e, 0x60, 0xfe, 0x4b, 0x68, 0xef,
+	0xf6, 0xa2, 0x69, 0x93, 0xe7, 0xd4, 0x3d, 0x2f, 0xe3, 0x39, 0xff, 0x9b, 0x58, 0xc6, 0x73, 0xd1,
+	0xf5, 0xf0, 0x92, 0xf5, 0x7d, 0xa8, 0xa9, 0x77, 0x3b, 0x4b, 0x5f, 0x3e, 0x0b, 0x2f, 0x82, 0xbd,
+	0xab, 0x53, 0xd8, 0x6c, 0xe1, 0x0b, 0x68, 0x17, 0x1e, 0x7b, 0xad, 0x1b, 0x05, 0x59, 0xc5, 0x67,
+	0xbf, 0xde, 0xcd, 0xf9, 0x93, 0x19, 0xb7, 0x6d, 0x80, 0xfc, 0x69, 0xc7, 0xea, 0x32, 0xf5, 0xcc,
+	0xf3, 0x61, 0xef, 0xfa, 0x9c, 0x99, 0x8c, 0x09, 0xba, 0x72, 0xfa, 0x99, 0xc5, 0x9a, 0xb2, 0xea,
+	0xf4, 0x23, 0x47, 0xe6, 0xca, 0x85, 0xef, 0x33, 0x92, 0xed, 0xf4, 0xe3, 0x49, 0xc6, 0x76, 0xc1,
+	0xd3, 0x4d, 0xc6, 0x76, 0xe1, 0xab, 0xcb, 0x25, 0xeb, 0x35, 0x74, 0x8a, 0xaf, 0x11, 0x96, 0x36,
+	0xd2, 0xdc, 0xe7, 0x98, 0xde, 0xad, 0x05, 0xb3, 0x19, 0xc3, 0xcf, 0x61, 0x49, 0x3d, 0x33, 0xe8,
+	0xe2, 0x65, 0xbe, 0x4e, 0xf4, 0xae, 0x14, 0x91, 0xd9, 0xaa, 0x4d, 0xa8, 0x71, 0xda, 0x69, 0x8a,
+	0xc2, 0x07, 0x83, 0x5e, 0xcb, 0xc4, 0xda, 0x97, 0x36, 0x4b, 0x5a, 0x4e, 0x52, 0x90, 0x93, 0xcc,
+	0x93, 0x63, 0x38, 0xe7, 0xa0, 0x26, 0x8b, 0xdb, 0x83, 0x7f, 0x03, 0x13, 0xdf, 0x9c, 0x1f, 0x76,
+	0x21, 0x00, 0x00,
 }
@@ -38,6 +38,7 @@ type Blkio struct {
 	IoMergedRecursive       []BlkioEntry `json:"ioMergedRecursive,omitempty"`
 	IoTimeRecursive         []BlkioEntry `json:"ioTimeRecursive,omitempty"`
 	SectorsRecursive        []BlkioEntry `json:"sectorsRecursive,omitempty"`
+	PSI                     *PSI         `json:"psi,omitempty"`
 }
 
 // Pids holds the stat of the pid usage of the machine
@@ -66,6 +67,24 @@ type CPUUsage struct {
 type CPU struct {
 	Usage      CPUUsage   `json:"usage,omitempty"`
 	Throttling Throttling `json:"throttling,omitempty"`
+	PSI        *PSI       `json:"psi,omitempty"`
+}
+
+// PSIData holds the pressure stall information of a resource for some or all
+// the tasks of a container
+type PSIData struct {
+	Avg10  float64 `json:"avg10"`
+	Avg60  float64 `json:"avg60"`
+	Avg300 float64 `json:"avg300"`
+	// Units: microseconds.
+	Total uint64 `json:"total"`
+}
+
+// PSI holds the pressure stall information of a resource, where the kernel
+// provides it
+type PSI struct {
+	Some PSIData `json:"some,omitempty"`
+	Full PSIData `json:"full,omitempty"`
 }
 
 // MemoryEntry regroups statistic about a given type of memory
@@ -83,5 +102,17 @@ type Memory struct {
 	Swap      MemoryEntry       `json:"swap,omitempty"`
 	Kernel    MemoryEntry       `json:"kernel,omitempty"`
 	KernelTCP MemoryEntry       `json:"kernelTCP,omitempty"`
+	Events    *MemoryEvents     `json:"events,omitempty"`
+	PSI       *PSI              `json:"psi,omitempty"`
 	Raw       map[string]uint64 `json:"raw,omitempty"`
 }
+
+// MemoryEvents holds the number of times the memory events of a container
+// happened
+type MemoryEvents struct {
+	Low     uint64 `json:"low"`
+	High    uint64 `json:"high"`
+	Max     uint64 `json:"max"`
+	Oom     uint64 `json:"oom"`
+	OomKill uint64 `json:"oomKill"`
+}
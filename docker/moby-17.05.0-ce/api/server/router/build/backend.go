@@ -5,6 +5,7 @@ import (
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/backend"
+	"github.com/docker/docker/api/types/filters"
 	"golang.org/x/net/context"
 )
 
@@ -17,4 +18,7 @@ type Backend interface {
 	//
 	// TODO: make this return a reference instead of string
 	BuildFromContext(ctx context.Context, src io.ReadCloser, remote string, buildOptions *types.ImageBuildOptions, pg backend.ProgressWriter) (string, error)
+
+	// PruneCache removes the unused caches of RUN cache mounts.
+	PruneCache(pruneFilters filters.Args) (*types.BuildCachePruneReport, error)
 }
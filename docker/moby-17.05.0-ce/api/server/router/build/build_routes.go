@@ -17,6 +17,7 @@ import (
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/backend"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/versions"
 	"github.com/docker/docker/pkg/ioutils"
 	"github.com/docker/docker/pkg/progress"
@@ -57,6 +58,28 @@ func newImageBuildOptions(ctx context.Context, r *http.Request) (*types.ImageBui
 	options.SecurityOpt = r.Form["securityopt"]
 	options.Squash = httputils.BoolValue(r, "squash")
 	options.Target = r.FormValue("target")
+	options.SessionID = r.FormValue("session")
+	options.ExportPath = r.FormValue("exportpath")
+	options.CacheTo = r.FormValue("cacheto")
+
+	if r.Form.Get("sourcedateepoch") != "" {
+		epoch, err := strconv.ParseInt(r.Form.Get("sourcedateepoch"), 10, 64)
+		if err != nil || epoch < 0 {
+			return nil, fmt.Errorf("invalid value for sourcedateepoch: %s", r.Form.Get("sourcedateepoch"))
+		}
+		options.SourceDateEpoch = &epoch
+	}
+
+	if r.Form.Get("parallel") != "" {
+		parallel, err := strconv.Atoi(r.Form.Get("parallel"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for parallel: %v", err)
+		}
+		if parallel < 1 {
+			return nil, fmt.Errorf("invalid value for parallel: %d, must be at least 1", parallel)
+		}
+		options.Parallel = parallel
+	}
 
 	if r.Form.Get("shmsize") != "" {
 		shmSize, err := strconv.ParseInt(r.Form.Get("shmsize"), 10, 64)
@@ -204,10 +227,18 @@ func (br *buildRouter) postBuild(ctx context.Context, w http.ResponseWriter, r *
 	stdout := &streamformatter.StdoutFormatter{Writer: out, StreamFormatter: sf}
 	stderr := &streamformatter.StderrFormatter{Writer: out, StreamFormatter: sf}
 
+	// The exported build result is sent even if the output is suppressed.
+	auxOut := out
+	if buildOptions.SuppressOutput {
+		auxOut = &syncWriter{w: output}
+	}
+	aux := &streamformatter.AuxFormatter{Writer: auxOut, StreamFormatter: sf}
+
 	pg := backend.ProgressWriter{
 		Output:             out,
 		StdoutFormatter:    stdout,
 		StderrFormatter:    stderr,
+		AuxFormatter:       aux,
 		ProgressReaderFunc: createProgressReader,
 	}
 
@@ -225,3 +256,20 @@ func (br *buildRouter) postBuild(ctx context.Context, w http.ResponseWriter, r *
 
 	return nil
 }
+
+func (br *buildRouter) postPrune(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	pruneFilters, err := filters.FromParam(r.Form.Get("filters"))
+	if err != nil {
+		return err
+	}
+
+	pruneReport, err := br.backend.PruneCache(pruneFilters)
+	if err != nil {
+		return err
+	}
+	return httputils.WriteJSON(w, http.StatusOK, pruneReport)
+}
@@ -8,5 +8,6 @@ import (
 // system specific functionality.
 type Backend interface {
 	LxcfsInfo() (*types.LxcfsInfo, error)
+	ContainerLxcfs(name string) (*types.ContainerLxcfs, error)
 }
 
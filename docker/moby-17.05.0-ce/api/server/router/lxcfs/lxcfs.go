@@ -19,6 +19,7 @@ func NewRouter(b Backend) router.Router {
 
 	r.routes = []router.Route{
 		router.NewGetRoute("/lxcfs/info", r.getLxcfsInfo),
+		router.NewGetRoute("/containers/{name:.*}/lxcfs", r.getContainerLxcfs),
 	}
 
 	return r
@@ -17,3 +17,12 @@ func (s *lxcfsRouter) getLxcfsInfo(ctx context.Context, w http.ResponseWriter, r
 	fmt.Printf("yang test ... info:%v\n", info)
 	return httputils.WriteJSON(w, http.StatusOK, info)
 }
+
+func (s *lxcfsRouter) getContainerLxcfs(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	lxcfs, err := s.backend.ContainerLxcfs(vars["name"])
+	if err != nil {
+		return err
+	}
+
+	return httputils.WriteJSON(w, http.StatusOK, lxcfs)
+}
@@ -0,0 +1,15 @@
+package session
+
+import (
+	"net/http"
+
+	"golang.org/x/net/context"
+)
+
+// Backend abstracts a session manager, which keeps the sessions that
+// clients open to serve the build context of their builds.
+type Backend interface {
+	// HandleHTTPRequest hijacks the connection of a /session request and
+	// serves it as a session until the client closes it.
+	HandleHTTPRequest(ctx context.Context, w http.ResponseWriter, r *http.Request) error
+}
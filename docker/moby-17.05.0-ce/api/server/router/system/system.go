@@ -27,6 +27,7 @@ func NewRouter(b Backend, c *cluster.Cluster) router.Router {
 		router.NewGetRoute("/info", r.getInfo),
 		router.NewGetRoute("/version", r.getVersion),
 		router.NewGetRoute("/system/df", r.getDiskUsage),
+		router.NewPostRoute("/system/check", r.postSystemCheck),
 		router.NewPostRoute("/auth", r.postAuth),
 	}
 
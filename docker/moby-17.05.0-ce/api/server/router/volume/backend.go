@@ -1,6 +1,8 @@
 package volume
 
 import (
+	"io"
+
 	// TODO return types need to be refactored into pkg
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/filters"
@@ -14,4 +16,8 @@ type Backend interface {
 	VolumeCreate(name, driverName string, opts, labels map[string]string) (*types.Volume, error)
 	VolumeRm(name string, force bool) error
 	VolumesPrune(pruneFilters filters.Args) (*types.VolumesPruneReport, error)
+	VolumeClone(src, name string, opts, labels map[string]string) (*types.Volume, error)
+	VolumeSnapshot(src, name string, labels map[string]string) (*types.Volume, error)
+	VolumeExport(name string, out io.Writer) error
+	VolumeImport(name string, in io.Reader) error
 }
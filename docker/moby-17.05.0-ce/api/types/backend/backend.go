@@ -98,6 +98,11 @@ type ExecProcessConfig struct {  //容器中要运行的进程信息
 type ContainerCommitConfig struct {
 	types.ContainerCommitConfig
 	Changes []string
+	// SourceDateEpoch, if not zero, makes the image reproducible. The
+	// modification times of the files of the layer are clamped to it, the
+	// entries of the layer are sorted, and it is the creation time of the
+	// image.
+	SourceDateEpoch time.Time
 }
 
 // ProgressWriter is an interface
@@ -106,5 +111,6 @@ type ProgressWriter struct {
 	Output             io.Writer
 	StdoutFormatter    *streamformatter.StdoutFormatter
 	StderrFormatter    *streamformatter.StderrFormatter
+	AuxFormatter       *streamformatter.AuxFormatter
 	ProgressReaderFunc func(io.ReadCloser) io.ReadCloser
 }
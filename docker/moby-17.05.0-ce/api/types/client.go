@@ -177,6 +177,24 @@ type ImageBuildOptions struct {
 	SecurityOpt []string
 	ExtraHosts  []string // List of extra hosts
 	Target      string
+	// Parallel is the maximum number of build stages that are built at the
+	// same time. Stages are built one after another if it is less than 2.
+	Parallel int
+	// SessionID is the ID of the session that the client serves the build
+	// context on, when RemoteContext is "client-session", and the secrets
+	// and the SSH agents that RUN instructions mount.
+	SessionID string
+	// ExportPath is the path in the result of the build to send back as a
+	// tar archive, instead of tagging the image.
+	ExportPath string
+	// CacheTo is the registry://<repository>:<tag> that the build cache is
+	// pushed to after the build. CacheFrom entries of this form are pulled
+	// from the registry before the build.
+	CacheTo string
+	// SourceDateEpoch, if set, is the time, in seconds since the Unix epoch,
+	// that the modification times of the files of the layers are clamped to,
+	// and that the images are created at, for the build to be reproducible.
+	SourceDateEpoch *int64
 }
 
 // ImageBuildResponse holds information
@@ -26,6 +26,12 @@ type HealthConfig struct {
 	// Retries is the number of consecutive failures needed to consider a container as unhealthy.
 	// Zero means inherit.
 	Retries int `json:",omitempty"`
+
+	// MemoryPressure is the share of the time, in percent, that some processes of the container
+	// may be stalled on memory over the last 10 seconds. A check fails when the memory pressure
+	// stays above it for MemoryPressureDuration. Zero means inherit.
+	MemoryPressure         float64       `json:",omitempty"`
+	MemoryPressureDuration time.Duration `json:",omitempty"`
 }
 
 // Config contains the configuration data about a container.
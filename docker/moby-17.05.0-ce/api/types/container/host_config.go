@@ -20,6 +20,36 @@ func (i Isolation) IsDefault() bool {
 	return strings.ToLower(string(i)) == "default" || string(i) == ""
 }
 
+// LxcfsMode represents whether the lxcfs virtualized /proc and /sys files
+// are bind-mounted into the container.
+type LxcfsMode string
+
+// IsDefault indicates that the daemon's default lxcfs mode is used.
+func (m LxcfsMode) IsDefault() bool {
+	return m == ""
+}
+
+// IsOff indicates that no lxcfs files are mounted into the container.
+func (m LxcfsMode) IsOff() bool {
+	return m == "off"
+}
+
+// IsAuto indicates that the lxcfs files are mounted when lxcfs is available.
+func (m LxcfsMode) IsAuto() bool {
+	return m == "auto"
+}
+
+// IsStrict indicates that the lxcfs files must be mounted, and the container
+// fails to start when lxcfs is not healthy.
+func (m LxcfsMode) IsStrict() bool {
+	return m == "strict"
+}
+
+// Valid indicates whether the lxcfs mode is valid.
+func (m LxcfsMode) Valid() bool {
+	return m.IsDefault() || m.IsOff() || m.IsAuto() || m.IsStrict()
+}
+
 // IpcMode represents the container ipc stack.
 type IpcMode string
 
@@ -381,6 +411,7 @@ type HostConfig struct {//生效使用见 postContainersStart->ContainerStart
 	ShmSize         int64             // Total shm memory usage
 	Sysctls         map[string]string `json:",omitempty"` // List of Namespaced sysctls used for the container
 	Runtime         string            `json:",omitempty"` // Runtime to use with this container
+	Lxcfs           LxcfsMode         `json:",omitempty"` // Whether to mount the lxcfs /proc files (off, auto or strict)
 
 	// Applicable to Windows
 	ConsoleSize [2]uint   // Initial console size (height,width)
@@ -32,6 +32,8 @@ const ( //docker events 可以监控所有的这些event
 	PluginEventType = "plugin"  //LogPluginEventWithAttributes 中记录
 	// VolumeEventType is the event type that volumes generate
 	VolumeEventType = "volume"  //LogVolumeEvent记录
+	// LxcfsEventType is the event type that lxcfs generates
+	LxcfsEventType = "lxcfs" //LogLxcfsEvent 记录
 )
 
 // Actor describes something that generates events,
@@ -52,6 +52,49 @@ type CPUStats struct {
 
 	// Throttling Data. Linux only.
 	ThrottlingData ThrottlingData `json:"throttling_data,omitempty"`
+
+	// Pressure stall information. Linux only, on kernels providing it
+	// in the unified cgroup hierarchy.
+	PSI *PSIStats `json:"psi,omitempty"`
+}
+
+// PSIData stores the pressure stall information of a resource for some or
+// all the tasks of one running container.
+// Not used on Windows.
+type PSIData struct {
+	// Share of the time the tasks were stalled, in percent, over the last
+	// 10, 60 and 300 seconds.
+	Avg10  float64 `json:"avg10"`
+	Avg60  float64 `json:"avg60"`
+	Avg300 float64 `json:"avg300"`
+	// Total time the tasks were stalled.
+	// Units: microseconds.
+	Total uint64 `json:"total"`
+}
+
+// PSIStats stores the pressure stall information of a resource.
+// Not used on Windows.
+type PSIStats struct {
+	// time some tasks were stalled on the resource
+	Some PSIData `json:"some"`
+	// time all the tasks were stalled on the resource at the same time
+	Full PSIData `json:"full"`
+}
+
+// MemoryEvents stores the number of times the memory events of one running
+// container happened.
+// Linux only, in the unified cgroup hierarchy.
+type MemoryEvents struct {
+	// usage was reclaimed below the low boundary
+	Low uint64 `json:"low"`
+	// usage went over the high boundary and was throttled
+	High uint64 `json:"high"`
+	// usage was about to go over the limit
+	Max uint64 `json:"max"`
+	// the OOM killer was invoked
+	Oom uint64 `json:"oom"`
+	// a process was killed by the OOM killer
+	OomKill uint64 `json:"oom_kill"`
 }
 
 // MemoryStats aggregates all memory stats since container inception on Linux.
@@ -69,6 +112,10 @@ type MemoryStats struct {
 	// number of times memory usage hits limits.
 	Failcnt uint64 `json:"failcnt,omitempty"`
 	Limit   uint64 `json:"limit,omitempty"`
+	// counts of the memory events, in the unified cgroup hierarchy.
+	Events *MemoryEvents `json:"events,omitempty"`
+	// pressure stall information, on kernels providing it.
+	PSI *PSIStats `json:"psi,omitempty"`
 
 	// Windows Memory Stats
 	// See https://technet.microsoft.com/en-us/magazine/ff382715.aspx
@@ -104,6 +151,8 @@ type BlkioStats struct {
 	IoMergedRecursive       []BlkioStatEntry `json:"io_merged_recursive"`
 	IoTimeRecursive         []BlkioStatEntry `json:"io_time_recursive"`
 	SectorsRecursive        []BlkioStatEntry `json:"sectors_recursive"`
+	// pressure stall information, on kernels providing it.
+	PSI *PSIStats `json:"psi,omitempty"`
 }
 
 // StorageStats is the disk I/O stats for read/write on Windows.
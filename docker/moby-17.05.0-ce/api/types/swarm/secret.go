@@ -13,6 +13,9 @@ type Secret struct {
 type SecretSpec struct {
 	Annotations
 	Data []byte `json:",omitempty"`
+	// Driver is the secret provider plugin that supplies the value of
+	// the secret when a task starts. Data must be empty when it is set.
+	Driver *Driver `json:",omitempty"`
 }
 
 // SecretReferenceFileTarget is a file target in a secret reference
@@ -153,6 +153,7 @@ type Info struct {
 	SystemTime         string
 	LoggingDriver      string
 	CgroupDriver       string
+	CgroupVersion      string `json:",omitempty"`
 	NEventsListener    int
 	KernelVersion      string
 	OperatingSystem    string
@@ -185,6 +186,7 @@ type Info struct {
 	RuncCommit         Commit
 	InitCommit         Commit
 	SecurityOptions    []string
+	Lxcfs              *LxcfsStatus `json:",omitempty"`
 }
 
 // KeyValue holds a key/value pair
@@ -481,6 +483,25 @@ type DiskUsage struct {
 	Volumes    []*Volume
 }
 
+// SystemCheckProblem is a problem found by the integrity check of the
+// daemon storage.
+type SystemCheckProblem struct {
+	// Type is the type of the object with the problem: "layer", "mount",
+	// "cache" or "reference".
+	Type string
+	// ID identifies the object with the problem.
+	ID          string
+	Description string
+	// Repair describes how the problem was repaired, if it was.
+	Repair string `json:",omitempty"`
+}
+
+// SystemCheckReport contains the response of Engine API:
+// POST "/system/check"
+type SystemCheckReport struct {
+	Problems []SystemCheckProblem
+}
+
 // ContainersPruneReport contains the response for Engine API:
 // POST "/containers/prune"
 type ContainersPruneReport struct {
@@ -502,6 +523,20 @@ type ImagesPruneReport struct {
 	SpaceReclaimed uint64
 }
 
+// BuildCachePruneReport contains the response for Engine API:
+// POST "/build/prune"
+type BuildCachePruneReport struct {
+	CachesDeleted  []string
+	SpaceReclaimed uint64
+}
+
+// BuildExportData is a chunk of the tar archive of the exported build
+// result, sent in the aux field of the messages of Engine API:
+// POST "/build"
+type BuildExportData struct {
+	Data []byte
+}
+
 // NetworksPruneReport contains the response for Engine API:
 // POST "/networks/prune"
 type NetworksPruneReport struct {
@@ -541,4 +576,29 @@ type LxcfsInfo struct {
 	LxcfsCat      []LxcfsCatItem
 }
 
+// LxcfsStatus contains the status of the lxcfs instance supervised by the
+// daemon. It is part of Info when lxcfs is enabled.
+type LxcfsStatus struct {
+	Pid                int
+	Uptime             time.Duration
+	RestartCount       int
+	MountPath          string
+	Healthy            bool
+	HealthCheckLatency time.Duration
+}
+
+// ContainerLxcfs contains the lxcfs virtualized /proc files as they are
+// seen from inside a running container.
+// GET "/containers/{name:.*}/lxcfs"
+type ContainerLxcfs struct {
+	ID        string
+	Name      string
+	Pid       int
+	Meminfo   string
+	Cpuinfo   string
+	Stat      string
+	Uptime    string
+	Diskstats string
+}
+
 
@@ -55,4 +55,8 @@ type VolumeUsageData struct {
 	// The disk space used by the volume (local driver only)
 	// Required: true
 	Size int64 `json:"Size"`
+
+	// The last time a container started or stopped referencing the volume,
+	// or the time the volume was created if it was never referenced.
+	LastUsed string `json:"LastUsed,omitempty"`
 }
@@ -0,0 +1,21 @@
+package volume
+
+// ----------------------------------------------------------------------------
+// DO NOT EDIT THIS FILE
+// This file was generated by `swagger generate operation`
+//
+// See hack/generate-swagger-api.sh
+// ----------------------------------------------------------------------------
+
+// VolumeSnapshotBody volume snapshot body
+// swagger:model VolumeSnapshotBody
+type VolumeSnapshotBody struct {
+
+	// User-defined key/value metadata.
+	// Required: true
+	Labels map[string]string `json:"Labels"`
+
+	// The snapshot's name. If not specified, Docker names it after the volume and the current time.
+	// Required: true
+	Name string `json:"Name"`
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/backend"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/image"
 	"golang.org/x/net/context"
 )
@@ -20,6 +21,10 @@ import (
 const (
 	// DefaultDockerfileName is the Default filename with Docker commands, read by docker build
 	DefaultDockerfileName string = "Dockerfile"
+
+	// CacheMountLabel is the label of the volumes that back the cache mounts
+	// of RUN instructions. Its value is the id of the cache.
+	CacheMountLabel = "com.docker.build.cache-mount"
 )
 
 // Context represents a file system tree.
@@ -50,6 +55,15 @@ type ModifiableContext interface {
 	Remove(path string) error
 }
 
+// SyncContext represents a Context whose files are only available at their
+// Path once they are synced.
+type SyncContext interface {
+	Context
+	// Sync makes the file, or the directory and the files in it, available
+	// at the Path of fi. FileInfos that are not from the context are ignored.
+	Sync(fi FileInfo) error
+}
+
 // FileInfo extends os.FileInfo to allow retrieving an absolute path to the file.
 // TODO: remove this interface once pkg/archive exposes a walk function that Context can use.
 type FileInfo interface {
@@ -149,6 +163,14 @@ type Backend interface {
 
 	// MountImage returns mounted path with rootfs of an image.
 	MountImage(name string) (string, func() error, error)
+
+	// ContainerMountStubs records which of the paths, and of their parent
+	// directories, don't exist in the container yet, and returns a function
+	// which removes them once the container has run, if they are empty.
+	ContainerMountStubs(containerID string, paths []string) (func() error, error)
+
+	// CacheMountsPrune removes the unused volumes of RUN cache mounts.
+	CacheMountsPrune(pruneFilters filters.Args) (*types.BuildCachePruneReport, error)
 }
 
 // Image represents a Docker image used by the builder.
@@ -163,6 +185,16 @@ type ImageCacheBuilder interface {
 	MakeImageCache(cacheFrom []string) ImageCache
 }
 
+// BuildCacheRegistry represents a backend that exports build caches to, and
+// imports them from, a registry.
+type BuildCacheRegistry interface {
+	// ImportBuildCache pulls the build cache `name`, and returns the IDs of
+	// its images.
+	ImportBuildCache(ctx context.Context, name string, authConfigs map[string]types.AuthConfig, output io.Writer) ([]string, error)
+	// ExportBuildCache pushes the images with the IDs as the build cache `name`.
+	ExportBuildCache(ctx context.Context, name string, imageIDs []string, authConfigs map[string]types.AuthConfig, output io.Writer) error
+}
+
 // ImageCache abstracts an image cache.
 // (parent image, child runconfig) -> child image
 type ImageCache interface {
@@ -185,3 +185,42 @@ func TestBuilderFlags(t *testing.T) {
 		t.Fatalf("Test %s, bool1 should be true", bf.Args)
 	}
 }
+
+func TestBuilderFlagsStrings(t *testing.T) {
+	bf := NewBFlags()
+	flStrs := bf.AddStrings("strs")
+	bf.Args = []string{}
+
+	if err := bf.Parse(); err != nil {
+		t.Fatalf("Test1 of %q was supposed to work: %s", bf.Args, err)
+	}
+	if len(flStrs.StringValues) != 0 {
+		t.Fatalf("Test1 of %q, strs should be empty: %q", bf.Args, flStrs.StringValues)
+	}
+
+	// ---
+
+	bf = NewBFlags()
+	flStrs = bf.AddStrings("strs")
+	bf.Args = []string{"--strs=a", "--strs=b,c"}
+
+	if err := bf.Parse(); err != nil {
+		t.Fatalf("Test %q was supposed to work: %s", bf.Args, err)
+	}
+	if len(flStrs.StringValues) != 2 || flStrs.StringValues[0] != "a" || flStrs.StringValues[1] != "b,c" {
+		t.Fatalf("Test %q, unexpected strs: %q", bf.Args, flStrs.StringValues)
+	}
+	if !flStrs.IsUsed() {
+		t.Fatalf("Test %q, strs should be used", bf.Args)
+	}
+
+	// ---
+
+	bf = NewBFlags()
+	flStrs = bf.AddStrings("strs")
+	bf.Args = []string{"--strs"}
+
+	if err := bf.Parse(); err == nil {
+		t.Fatalf("Test %q was supposed to fail", bf.Args)
+	}
+}
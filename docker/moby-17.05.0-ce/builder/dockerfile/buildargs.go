@@ -35,6 +35,26 @@ func newBuildArgs(argsFromOptions map[string]*string) *buildArgs {
 	}
 }
 
+// Clone returns a copy of the args for a build stage. The args of the stage
+// start out empty, as after a FROM.
+func (b *buildArgs) Clone() *buildArgs {
+	result := newBuildArgs(b.argsFromOptions)
+	for k, v := range b.allowedMetaArgs {
+		result.allowedMetaArgs[k] = v
+	}
+	for k := range b.referencedArgs {
+		result.referencedArgs[k] = struct{}{}
+	}
+	return result
+}
+
+// MergeReferencedArgs adds the args referenced by a build stage.
+func (b *buildArgs) MergeReferencedArgs(other *buildArgs) {
+	for k := range other.referencedArgs {
+		b.referencedArgs[k] = struct{}{}
+	}
+}
+
 // UnreferencedOptionArgs returns the list of args that were set from options but
 // were never referenced from the Dockerfile
 func (b *buildArgs) UnreferencedOptionArgs() []string {
@@ -15,10 +15,12 @@ import (
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/backend"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/builder"
-	"github.com/docker/docker/builder/dockerfile/command"
 	"github.com/docker/docker/builder/dockerfile/parser"
+	"github.com/docker/docker/builder/session"
 	"github.com/docker/docker/image"
+	"github.com/docker/docker/pkg/streamformatter"
 	"github.com/docker/docker/pkg/stringid"
 	perrors "github.com/pkg/errors"
 	"golang.org/x/net/context"
@@ -47,17 +49,21 @@ type Builder struct {
 	Stdout io.Writer
 	Stderr io.Writer
 	Output io.Writer
+	aux    *streamformatter.AuxFormatter // writes the exported build result
 
 	docker    builder.Backend
 	context   builder.Context
+	session   runMountSession // serves the secrets and the SSH agents of the build
 	clientCtx context.Context
 	cancel    context.CancelFunc
 
 	runConfig     *container.Config // runconfig for cmd, run, entrypoint etc.
 	flags         *BFlags
+	heredocs      []parser.Heredoc // here-documents of the instruction being dispatched
 	tmpContainers map[string]struct{}
 	image         string         // imageID
 	imageContexts *imageContexts // helper for storing contexts from builds
+	stage         *imageMount    // build stage being dispatched
 	noBaseImage   bool           // A flag to track the use of `scratch` as the base image
 	maintainer    string
 	cmdSet        bool
@@ -76,12 +82,14 @@ type Builder struct {
 // BuildManager implements builder.Backend and is shared across all Builder objects.
 type BuildManager struct {
 	backend   builder.Backend
+	sessions  *session.Manager
 	pathCache *pathCache // TODO: make this persistent
 }
 
-// NewBuildManager creates a BuildManager.
-func NewBuildManager(b builder.Backend) (bm *BuildManager) {
-	return &BuildManager{backend: b, pathCache: &pathCache{}}
+// NewBuildManager creates a BuildManager. Build contexts are transferred
+// over the sessions of sm.
+func NewBuildManager(b builder.Backend, sm *session.Manager) (bm *BuildManager) {
+	return &BuildManager{backend: b, sessions: sm, pathCache: &pathCache{}}
 }
 
 // BuildFromContext builds a new image from a given context.
@@ -89,7 +97,36 @@ func (bm *BuildManager) BuildFromContext(ctx context.Context, src io.ReadCloser,
 	if buildOptions.Squash && !bm.backend.HasExperimental() {
 		return "", apierrors.NewBadRequestError(errors.New("squash is only supported with experimental mode"))
 	}
-	buildContext, dockerfileName, err := builder.DetectContextFromRemoteURL(src, remote, pg.ProgressReaderFunc)
+	if buildOptions.ExportPath != "" && len(buildOptions.Tags) > 0 {
+		return "", apierrors.NewBadRequestError(errors.New("an exported build result can't be tagged"))
+	}
+	if err := validateCacheTo(buildOptions.CacheTo); err != nil {
+		return "", apierrors.NewBadRequestError(err)
+	}
+	var (
+		buildContext   builder.ModifiableContext
+		dockerfileName string
+		buildSession   *session.Session
+		err            error
+	)
+	if buildOptions.SessionID != "" {
+		if bm.sessions == nil {
+			return "", apierrors.NewBadRequestError(errors.New("build sessions are not supported"))
+		}
+		// The secrets and the SSH agents of the build are served on its
+		// session.
+		if buildSession, err = bm.sessions.Get(ctx, buildOptions.SessionID); err != nil {
+			return "", err
+		}
+	}
+	if remote == session.ClientSessionRemote {
+		if bm.sessions == nil {
+			return "", apierrors.NewBadRequestError(errors.New("build context sessions are not supported"))
+		}
+		buildContext, err = bm.sessions.NewContext(ctx, buildOptions.SessionID)
+	} else {
+		buildContext, dockerfileName, err = builder.DetectContextFromRemoteURL(src, remote, pg.ProgressReaderFunc)
+	}
 	if err != nil {
 		return "", err
 	}
@@ -107,9 +144,28 @@ func (bm *BuildManager) BuildFromContext(ctx context.Context, src io.ReadCloser,
 		return "", err
 	}
 	b.imageContexts.cache = bm.pathCache
+	if buildSession != nil {
+		b.session = buildSession
+	}
+	b.aux = pg.AuxFormatter
 	return b.build(pg.StdoutFormatter, pg.StderrFormatter, pg.Output)
 }
 
+// PruneCache removes the unused volumes of RUN cache mounts. Without filters,
+// it also removes the files that builds received over sessions.
+func (bm *BuildManager) PruneCache(pruneFilters filters.Args) (*types.BuildCachePruneReport, error) {
+	report, err := bm.backend.CacheMountsPrune(pruneFilters)
+	if err != nil || bm.sessions == nil || pruneFilters.Len() > 0 {
+		return report, err
+	}
+	reclaimed, err := bm.sessions.Prune()
+	if err != nil {
+		return nil, err
+	}
+	report.SpaceReclaimed += reclaimed
+	return report, nil
+}
+
 // NewBuilder creates a new Dockerfile builder from an optional dockerfile and a Config.
 // If dockerfile is nil, the Dockerfile specified by Config.DockerfileName,
 // will be read from the Context passed to Build().
@@ -144,6 +200,9 @@ func NewBuilder(clientCtx context.Context, config *types.ImageBuildOptions, back
 func (b *Builder) resetImageCache() {
 	if icb, ok := b.docker.(builder.ImageCacheBuilder); ok {
 		b.imageCache = icb.MakeImageCache(b.options.CacheFrom)
+		if epoch := b.sourceDateEpoch(); !epoch.IsZero() {
+			b.imageCache = &reproducibleImageCache{ImageCache: b.imageCache, backend: b.docker, epoch: epoch}
+		}
 	}
 	b.noBaseImage = false
 	b.cacheBusted = false
@@ -216,7 +275,8 @@ func (b *Builder) build(stdout io.Writer, stderr io.Writer, out io.Writer) (stri
 
 	addNodesForLabelOption(dockerfile, b.options.Labels)
 
-	var shortImgID string
+	b.importCache()
+
 	total := len(dockerfile.Children)
 	for _, n := range dockerfile.Children {
 		if err := b.checkDispatch(n, false); err != nil {
@@ -224,36 +284,36 @@ func (b *Builder) build(stdout io.Writer, stderr io.Writer, out io.Writer) (stri
 		}
 	}
 
-	for i, n := range dockerfile.Children {
-		select {
-		case <-b.clientCtx.Done():
-			logrus.Debug("Builder: build cancelled!")
-			fmt.Fprint(b.Stdout, "Build cancelled")
-			return "", errors.New("Build cancelled")
-		default:
-			// Not cancelled yet, keep going...
+	meta, stages := splitStages(dockerfile)
+	for _, s := range stages {
+		if _, err := b.imageContexts.new(s.name); err != nil {
+			return "", err
 		}
+	}
 
-		if command.From == n.Value && b.imageContexts.isCurrentTarget(b.options.Target) {
-			break
-		}
+	if err := b.dispatchNodes(meta, 0, total); err != nil {
+		return "", err
+	}
 
-		if err := b.dispatch(i, total, n); err != nil {
-			if b.options.ForceRemove {
-				b.clearTmp()
-			}
+	var images []string
+	if len(stages) > 0 || b.options.Target != "" {
+		required, target, err := requiredStages(stages, b.options.Target)
+		if err != nil {
 			return "", err
 		}
 
-		shortImgID = stringid.TruncateID(b.image)
-		fmt.Fprintf(b.Stdout, " ---> %s\n", shortImgID)
-		if b.options.Remove {
-			b.clearTmp()
+		builders, err := b.dispatchStages(required, total)
+		if err != nil {
+			return "", err
+		}
+		for _, sb := range builders {
+			b.buildArgs.MergeReferencedArgs(sb.buildArgs)
 		}
-	}
 
-	if b.options.Target != "" && !b.imageContexts.isCurrentTarget(b.options.Target) {
-		return "", perrors.Errorf("failed to reach build target %s in Dockerfile", b.options.Target)
+		final := builders[target.index]
+		b.image = final.image
+		b.from = final.from
+		images = stageImages(builders)
 	}
 
 	b.warnOnUnusedBuildArgs()
@@ -261,6 +321,10 @@ func (b *Builder) build(stdout io.Writer, stderr io.Writer, out io.Writer) (stri
 	if b.image == "" {
 		return "", errors.New("No image was generated. Is your Dockerfile empty?")
 	}
+	shortImgID := stringid.TruncateID(b.image)
+	if len(images) == 0 {
+		images = []string{b.image}
+	}
 
 	if b.options.Squash {
 		var fromID string
@@ -275,6 +339,21 @@ func (b *Builder) build(stdout io.Writer, stderr io.Writer, out io.Writer) (stri
 
 	fmt.Fprintf(b.Stdout, "Successfully built %s\n", shortImgID)
 
+	if b.options.CacheTo != "" {
+		// The cache has the images before they are squashed, as the
+		// instructions of the build map to their layers.
+		if err := b.exportCache(images); err != nil {
+			return "", perrors.Wrap(err, "error exporting build cache")
+		}
+	}
+
+	if b.options.ExportPath != "" {
+		if err := b.export(b.image); err != nil {
+			return "", perrors.Wrap(err, "error exporting build result")
+		}
+		fmt.Fprintf(b.Stdout, "Successfully exported %s from %s\n", b.options.ExportPath, shortImgID)
+	}
+
 	imageID := image.ID(b.image)
 	for _, rt := range repoAndTags {
 		if err := b.docker.TagImageWithReference(imageID, rt); err != nil {
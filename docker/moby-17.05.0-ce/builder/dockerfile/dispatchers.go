@@ -174,7 +174,8 @@ func add(b *Builder, args []string, attributes map[string]bool, original string)
 
 // COPY foo /path
 //
-// Same as 'ADD' but without the tar and remote url handling.
+// Same as 'ADD' but without the tar and remote url handling. A source can be
+// a here-document, such as COPY <<EOF /path, which is copied like a file.
 //
 func dispatchCopy(b *Builder, args []string, attributes map[string]bool, original string) error {
 	if len(args) < 2 {
@@ -189,8 +190,11 @@ func dispatchCopy(b *Builder, args []string, attributes map[string]bool, origina
 
 	var im *imageMount
 	if flFrom.IsUsed() {
+		if b.stage == nil {
+			return errors.New("Please provide a source image with `from` prior to copy")
+		}
 		var err error
-		im, err = b.imageContexts.get(flFrom.Value)
+		im, err = b.imageContexts.get(b, flFrom.Value, b.stage.index)
 		if err != nil {
 			return err
 		}
@@ -231,11 +235,17 @@ func from(b *Builder, args []string, attributes map[string]bool, original string
 	var image builder.Image
 
 	b.resetImageCache()
-	if _, err := b.imageContexts.new(ctxName, true); err != nil {
-		return err
+	// The stages of a planned build are added to the image contexts before
+	// they are dispatched.
+	if b.stage == nil {
+		im, err := b.imageContexts.new(ctxName)
+		if err != nil {
+			return err
+		}
+		b.stage = im
 	}
 
-	if im, ok := b.imageContexts.byName[name]; ok {
+	if im, ok := b.imageContexts.byStageName(name, b.stage.index); ok {
 		if len(im.ImageID()) > 0 {
 			image = im
 		}
@@ -256,7 +266,7 @@ func from(b *Builder, args []string, attributes map[string]bool, original string
 		}
 	}
 	if image != nil {
-		b.imageContexts.update(image.ImageID(), image.RunConfig())
+		b.stage.update(image.ImageID(), image.RunConfig())
 	}
 	b.from = image
 
@@ -366,17 +376,40 @@ func workdir(b *Builder, args []string, attributes map[string]bool, original str
 // RUN echo hi          # cmd /S /C echo hi   (Windows)
 // RUN [ "echo", "hi" ] # echo hi
 //
+// Here-documents follow the command, or are the script that is run:
+//
+// RUN <<EOF            # sh -c 'echo hi\n'
+// echo hi
+// EOF
+//
 func run(b *Builder, args []string, attributes map[string]bool, original string) error {
 	if !b.hasFromImage() {
 		return errors.New("Please provide a source image with `from` prior to run")
 	}
 
+	flMounts := b.flags.AddStrings("mount")
+
 	if err := b.flags.Parse(); err != nil {
 		return err
 	}
 
+	requestedMounts, err := parseRunMounts(flMounts.StringValues)
+	if err != nil {
+		return err
+	}
+	if len(requestedMounts) > 0 && runtime.GOOS == "windows" {
+		return errors.New("RUN --mount is not supported on Windows")
+	}
+
 	args = handleJSONArgs(args, attributes)
 
+	if len(b.heredocs) > 0 {
+		if runtime.GOOS == "windows" {
+			return errors.New("RUN with a here-document is not supported on Windows")
+		}
+		args = []string{heredocScript(args[0], b.heredocs)}
+	}
+
 	if !attributes["json"] {
 		args = append(getShell(b.runConfig), args...)
 	}
@@ -420,23 +453,42 @@ func run(b *Builder, args []string, attributes map[string]bool, original string)
 		return nil
 	}
 
+	mounts, err := b.setupRunMounts(requestedMounts)
+	if err != nil {
+		return err
+	}
+	defer mounts.Release()
+
 	// set Cmd manually, this is special case only for Dockerfiles
 	b.runConfig.Cmd = config.Cmd
 	// set build-time environment for 'run'.
 	b.runConfig.Env = append(b.runConfig.Env, cmdBuildEnv...)
+	b.runConfig.Env = append(b.runConfig.Env, mounts.env...)
 	// set config as already being escaped, this prevents double escaping on windows
 	b.runConfig.ArgsEscaped = true
 
 	logrus.Debugf("[BUILDER] Command to be executed: %v", b.runConfig.Cmd)
 
-	cID, err := b.create()
+	cID, err := b.create(mounts.mounts)
 	if err != nil {
 		return err
 	}
 
+	// The mountpoints which don't exist in the image are created in the
+	// container, they are removed for them not to be committed.
+	removeStubs := func() error { return nil }
+	if len(mounts.mounts) > 0 {
+		if removeStubs, err = b.docker.ContainerMountStubs(cID, mounts.targets()); err != nil {
+			return err
+		}
+	}
+
 	if err := b.run(cID); err != nil {
 		return err
 	}
+	if err := removeStubs(); err != nil {
+		return err
+	}
 
 	// revert to original config environment and set the command string to
 	// have the build-time env vars in it (if any) so that future cache look-ups
@@ -853,12 +905,7 @@ func mountByRef(b *Builder, name string) (*imageMount, error) {
 	if err != nil {
 		return nil, err
 	}
-	im, err := b.imageContexts.new("", false)
-	if err != nil {
-		return nil, err
-	}
-	im.id = image.ImageID()
-	return im, nil
+	return b.imageContexts.newRef(image.ImageID()), nil
 }
 
 func pullOrGetImage(b *Builder, name string) (builder.Image, error) {
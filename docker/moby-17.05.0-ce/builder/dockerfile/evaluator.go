@@ -108,6 +108,7 @@ func (b *Builder) dispatch(stepN int, stepTotal int, ast *parser.Node) error {
 	attrs := ast.Attributes
 	original := ast.Original
 	flags := ast.Flags
+	heredocs := ast.Heredocs
 	strList := []string{}
 	msg := fmt.Sprintf("Step %d/%d : %s", stepN+1, stepTotal, upperCasedCmd)
 
@@ -151,6 +152,7 @@ func (b *Builder) dispatch(stepN int, stepTotal int, ast *parser.Node) error {
 	if f, ok := evaluateTable[cmd]; ok {
 		b.flags = NewBFlags()
 		b.flags.Args = flags
+		b.heredocs = heredocs
 		return f(b, strList, attrs, original)
 	}
 
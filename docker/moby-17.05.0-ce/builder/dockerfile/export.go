@@ -0,0 +1,74 @@
+package dockerfile
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/pkg/streamformatter"
+	"github.com/docker/docker/pkg/symlink"
+	"github.com/pkg/errors"
+)
+
+// exportChunkSize is the maximum size of the chunks of the tar archive of an
+// exported build result.
+const exportChunkSize = 512 * 1024
+
+// export sends the files at the export path in the root filesystem of the
+// image back to the client, as a tar archive. The content of a directory is
+// at the root of the archive, and a file is the only file in it.
+func (b *Builder) export(imageID string) error {
+	if b.aux == nil {
+		return errors.New("the build output does not support exporting the build result")
+	}
+
+	root, release, err := b.docker.MountImage(imageID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to mount %s", imageID)
+	}
+	defer release()
+
+	src, err := symlink.FollowSymlinkInScope(filepath.Join(root, filepath.FromSlash(b.options.ExportPath)), root)
+	if err != nil {
+		return err
+	}
+	fi, err := os.Stat(src)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return errors.Errorf("%s does not exist in the build result", b.options.ExportPath)
+		}
+		return err
+	}
+
+	options := &archive.TarOptions{}
+	if !fi.IsDir() {
+		options.IncludeFiles = []string{filepath.Base(src)}
+		src = filepath.Dir(src)
+	}
+	rc, err := archive.TarWithOptions(src, options)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	w := bufio.NewWriterSize(&exportWriter{aux: b.aux}, exportChunkSize)
+	if _, err := io.Copy(w, rc); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// exportWriter sends the data written to it as BuildExportData messages.
+type exportWriter struct {
+	aux *streamformatter.AuxFormatter
+}
+
+func (w *exportWriter) Write(p []byte) (int, error) {
+	if err := w.aux.Emit(types.BuildExportData{Data: p}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
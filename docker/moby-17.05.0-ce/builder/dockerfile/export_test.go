@@ -0,0 +1,108 @@
+package dockerfile
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/docker/docker/pkg/streamformatter"
+	"github.com/docker/docker/pkg/testutil/assert"
+)
+
+// newExportTestBuilder returns a builder whose images have the root
+// filesystem in root, and the buffer that the exported data is written to.
+func newExportTestBuilder(root, exportPath string) (*Builder, *bytes.Buffer) {
+	b := newBuilderWithMockBackend()
+	b.docker = &MockBackend{
+		mountImageFunc: func(string) (string, func() error, error) {
+			return root, func() error { return nil }, nil
+		},
+	}
+	b.options.ExportPath = exportPath
+	buf := bytes.NewBuffer(nil)
+	b.aux = &streamformatter.AuxFormatter{Writer: buf, StreamFormatter: streamformatter.NewJSONStreamFormatter()}
+	return b, buf
+}
+
+// exportedFiles returns the names of the files in the tar archive that was
+// exported to buf.
+func exportedFiles(t *testing.T, buf *bytes.Buffer) map[string]string {
+	var archive bytes.Buffer
+	dec := json.NewDecoder(buf)
+	for {
+		var msg jsonmessage.JSONMessage
+		if err := dec.Decode(&msg); err == io.EOF {
+			break
+		} else {
+			assert.NilError(t, err)
+		}
+		var data types.BuildExportData
+		assert.NilError(t, json.Unmarshal(*msg.Aux, &data))
+		archive.Write(data.Data)
+	}
+
+	files := make(map[string]string)
+	tr := tar.NewReader(&archive)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		assert.NilError(t, err)
+		content, err := ioutil.ReadAll(tr)
+		assert.NilError(t, err)
+		files[hdr.Name] = string(content)
+	}
+	return files
+}
+
+func newExportTestRoot(t *testing.T) string {
+	root, err := ioutil.TempDir("", "builder-export-test")
+	assert.NilError(t, err)
+	assert.NilError(t, os.MkdirAll(filepath.Join(root, "out", "bin"), 0755))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(root, "out", "bin", "app"), []byte("app"), 0755))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(root, "out", "README"), []byte("readme"), 0644))
+	return root
+}
+
+func TestExportDirectory(t *testing.T) {
+	root := newExportTestRoot(t)
+	defer os.RemoveAll(root)
+
+	b, buf := newExportTestBuilder(root, "/out")
+	assert.NilError(t, b.export("image"))
+
+	files := exportedFiles(t, buf)
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	assert.DeepEqual(t, names, []string{"README", "bin/", "bin/app"})
+	assert.Equal(t, files["bin/app"], "app")
+}
+
+func TestExportFile(t *testing.T) {
+	root := newExportTestRoot(t)
+	defer os.RemoveAll(root)
+
+	b, buf := newExportTestBuilder(root, "/out/bin/app")
+	assert.NilError(t, b.export("image"))
+	assert.DeepEqual(t, exportedFiles(t, buf), map[string]string{"app": "app"})
+}
+
+func TestExportMissingPath(t *testing.T) {
+	root := newExportTestRoot(t)
+	defer os.RemoveAll(root)
+
+	b, _ := newExportTestBuilder(root, "/missing")
+	assert.Error(t, b.export("image"), "/missing does not exist in the build result")
+}
@@ -0,0 +1,92 @@
+package dockerfile
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/builder"
+	"github.com/docker/docker/builder/dockerfile/parser"
+	"github.com/docker/docker/pkg/ioutils"
+	"github.com/docker/docker/pkg/system"
+	digest "github.com/opencontainers/go-digest"
+)
+
+// heredocScript returns the shell command of a RUN instruction with
+// here-documents. If the command is only a here-document, the content of the
+// here-document is the script that is run. Otherwise the here-documents
+// follow the command, so that the shell handles them like in a shell script:
+//
+//	RUN python3 <<EOF   # sh -c 'python3 <<EOF\n...\nEOF\n'
+func heredocScript(cmdLine string, heredocs []parser.Heredoc) string {
+	if len(heredocs) == 1 {
+		if h := parser.ParseHeredocMarker(strings.TrimSpace(cmdLine)); h != nil && h.Name == heredocs[0].Name {
+			return heredocs[0].Content
+		}
+	}
+
+	script := cmdLine + "\n"
+	for _, h := range heredocs {
+		script += h.Content + h.Name + "\n"
+	}
+	return script
+}
+
+// heredocFile writes the content of a here-document of the instruction to a
+// file in a temporary directory, so that the here-document can be copied
+// like a file of the build context. Variables in the content are expanded
+// unless the delimiter of the here-document is quoted.
+func (b *Builder) heredocFile(name string) (builder.FileInfo, error) {
+	var heredoc *parser.Heredoc
+	for i := range b.heredocs {
+		if b.heredocs[i].Name == name {
+			heredoc = &b.heredocs[i]
+			break
+		}
+	}
+	if heredoc == nil {
+		return nil, fmt.Errorf("here-document %s not found", name)
+	}
+
+	content := heredoc.Content
+	if heredoc.Expand {
+		envs := append(append([]string{}, b.runConfig.Env...), b.buildArgsWithoutConfigEnv()...)
+		var err error
+		if content, err = ProcessHeredoc(content, envs, b.directive.EscapeToken); err != nil {
+			return nil, err
+		}
+	}
+
+	tmpDir, err := ioutils.TempDir("", "docker-heredoc")
+	if err != nil {
+		return nil, err
+	}
+	fi, err := writeHeredocFile(filepath.Join(tmpDir, name), content)
+	if err != nil {
+		os.RemoveAll(tmpDir)
+		return nil, err
+	}
+	return fi, nil
+}
+
+func writeHeredocFile(path, content string) (builder.FileInfo, error) {
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		return nil, err
+	}
+	// Like for a downloaded file without a Last-Modified header, the times
+	// of the file don't depend on when it was written.
+	if err := system.Chtimes(path, time.Time{}, time.Time{}); err != nil {
+		return nil, err
+	}
+	st, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	// The mode and times of the file are always the same, so the build
+	// cache only needs to compare the name and the content.
+	hash := "heredoc:" + digest.FromString(filepath.Base(path)+"\n"+content).String()
+	return &builder.HashedFileInfo{FileInfo: builder.PathFileInfo{FileInfo: st, FilePath: path}, FileHash: hash}, nil
+}
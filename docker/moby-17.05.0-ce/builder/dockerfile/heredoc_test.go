@@ -0,0 +1,66 @@
+package dockerfile
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/docker/builder"
+	"github.com/docker/docker/builder/dockerfile/parser"
+	"github.com/docker/docker/pkg/testutil/assert"
+)
+
+func TestHeredocScript(t *testing.T) {
+	script := []parser.Heredoc{{Name: "EOF", Content: "echo hello\necho world\n", Expand: true}}
+	assert.Equal(t, heredocScript("<<EOF", script), "echo hello\necho world\n")
+	assert.Equal(t, heredocScript(" <<'EOF' ", script), "echo hello\necho world\n")
+	assert.Equal(t, heredocScript("sh <<EOF", script), "sh <<EOF\necho hello\necho world\nEOF\n")
+
+	heredocs := []parser.Heredoc{
+		{Name: "A", Content: "a\n", Expand: true},
+		{Name: "B", Content: "b\n", Chomp: true},
+	}
+	assert.Equal(t, heredocScript("cat <<A <<-B", heredocs), "cat <<A <<-B\na\nA\nb\nB\n")
+}
+
+func TestHeredocFile(t *testing.T) {
+	b := newBuilderWithMockBackend()
+	b.directive.EscapeToken = '\\'
+	b.runConfig.Env = []string{"NAME=app"}
+	b.heredocs = []parser.Heredoc{
+		{Name: "EOF", Content: "name=$NAME\n", Expand: true},
+		{Name: "RAW", Content: "name=$NAME\n"},
+	}
+
+	for name, expected := range map[string]string{"EOF": "name=app\n", "RAW": "name=$NAME\n"} {
+		fi, err := b.heredocFile(name)
+		assert.NilError(t, err)
+		defer os.RemoveAll(filepath.Dir(fi.Path()))
+
+		assert.Equal(t, fi.Name(), name)
+		content, err := ioutil.ReadFile(fi.Path())
+		assert.NilError(t, err)
+		assert.Equal(t, string(content), expected)
+		assert.Equal(t, fi.(builder.Hashed).Hash() != "", true)
+	}
+
+	_, err := b.heredocFile("MISSING")
+	assert.Error(t, err, "here-document MISSING not found")
+}
+
+func TestHeredocFileHashDependsOnContent(t *testing.T) {
+	b := newBuilderWithMockBackend()
+	b.directive.EscapeToken = '\\'
+
+	var hashes []string
+	for _, content := range []string{"a\n", "a\n", "b\n"} {
+		b.heredocs = []parser.Heredoc{{Name: "EOF", Content: content}}
+		fi, err := b.heredocFile("EOF")
+		assert.NilError(t, err)
+		defer os.RemoveAll(filepath.Dir(fi.Path()))
+		hashes = append(hashes, fi.(builder.Hashed).Hash())
+	}
+	assert.Equal(t, hashes[0], hashes[1])
+	assert.Equal(t, hashes[0] != hashes[2], true)
+}
@@ -9,21 +9,28 @@ import (
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/builder"
 	"github.com/docker/docker/builder/remotecontext"
+	"github.com/docker/go-connections/nat"
 	"github.com/pkg/errors"
 )
 
 // imageContexts is a helper for stacking up built image rootfs and reusing
-// them as contexts
+// them as contexts. It is shared by the builders of all the stages of a
+// build, which may run concurrently.
 type imageContexts struct {
-	b           *Builder
-	list        []*imageMount
-	byName      map[string]*imageMount
-	cache       *pathCache
-	currentName string
+	b      *Builder
+	mu     sync.Mutex
+	list   []*imageMount // one per build stage, in Dockerfile order
+	byName map[string]*imageMount
+	refs   []*imageMount // images referenced with COPY --from=<image>
+	cache  *pathCache
 }
 
-func (ic *imageContexts) new(name string, increment bool) (*imageMount, error) {
-	im := &imageMount{ic: ic}
+// new adds a build stage named name.
+func (ic *imageContexts) new(name string) (*imageMount, error) {
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+
+	im := &imageMount{ic: ic, index: len(ic.list)}
 	if len(name) > 0 {
 		if ic.byName == nil {
 			ic.byName = make(map[string]*imageMount)
@@ -33,22 +40,35 @@ func (ic *imageContexts) new(name string, increment bool) (*imageMount, error) {
 		}
 		ic.byName[name] = im
 	}
-	if increment {
-		ic.list = append(ic.list, im)
-	}
-	ic.currentName = name
+	ic.list = append(ic.list, im)
 	return im, nil
 }
 
-func (ic *imageContexts) update(imageID string, runConfig *container.Config) {
-	ic.list[len(ic.list)-1].id = imageID
-	ic.list[len(ic.list)-1].runConfig = runConfig
+// stage returns the build stage with the given index.
+func (ic *imageContexts) stage(index int) (*imageMount, error) {
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+
+	if index < 0 || index >= len(ic.list) {
+		return nil, errors.Errorf("invalid build stage %d", index)
+	}
+	return ic.list[index], nil
+}
+
+// newRef adds an image that is not built by a stage of the build.
+func (ic *imageContexts) newRef(imageID string) *imageMount {
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+
+	im := &imageMount{ic: ic, index: -1, id: imageID}
+	ic.refs = append(ic.refs, im)
+	return im
 }
 
-func (ic *imageContexts) validate(i int) error {
-	if i < 0 || i >= len(ic.list)-1 {
+func (ic *imageContexts) validate(i, current int) error {
+	if i < 0 || i >= current {
 		var extraMsg string
-		if i == len(ic.list)-1 {
+		if i == current {
 			extraMsg = " refers current build block"
 		}
 		return errors.Errorf("invalid from flag value %d%s", i, extraMsg)
@@ -56,18 +76,36 @@ func (ic *imageContexts) validate(i int) error {
 	return nil
 }
 
-func (ic *imageContexts) get(indexOrName string) (*imageMount, error) {
+// byStageName returns the stage named name if it comes before the stage
+// with index current.
+func (ic *imageContexts) byStageName(name string, current int) (*imageMount, bool) {
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+
+	im, ok := ic.byName[name]
+	if !ok || im.index >= current {
+		return nil, false
+	}
+	return im, true
+}
+
+// get returns the image that a COPY --from flag of the stage with index
+// current refers to. The images which are not build stages are pulled with
+// the output of b, the builder of that stage.
+func (ic *imageContexts) get(b *Builder, indexOrName string, current int) (*imageMount, error) {
 	index, err := strconv.Atoi(indexOrName)
 	if err == nil {
-		if err := ic.validate(index); err != nil {
+		if err := ic.validate(index, current); err != nil {
 			return nil, err
 		}
+		ic.mu.Lock()
+		defer ic.mu.Unlock()
 		return ic.list[index], nil
 	}
-	if im, ok := ic.byName[strings.ToLower(indexOrName)]; ok {
+	if im, ok := ic.byStageName(strings.ToLower(indexOrName), current+1); ok {
 		return im, nil
 	}
-	im, err := mountByRef(ic.b, indexOrName)
+	im, err := mountByRef(b, indexOrName)
 	if err != nil {
 		return nil, errors.Wrapf(err, "invalid from flag value %s", indexOrName)
 	}
@@ -75,13 +113,10 @@ func (ic *imageContexts) get(indexOrName string) (*imageMount, error) {
 }
 
 func (ic *imageContexts) unmount() (retErr error) {
-	for _, im := range ic.list {
-		if err := im.unmount(); err != nil {
-			logrus.Error(err)
-			retErr = err
-		}
-	}
-	for _, im := range ic.byName {
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+
+	for _, im := range append(ic.list, ic.refs...) {
 		if err := im.unmount(); err != nil {
 			logrus.Error(err)
 			retErr = err
@@ -90,13 +125,6 @@ func (ic *imageContexts) unmount() (retErr error) {
 	return
 }
 
-func (ic *imageContexts) isCurrentTarget(target string) bool {
-	if target == "" {
-		return false
-	}
-	return strings.EqualFold(ic.currentName, target)
-}
-
 func (ic *imageContexts) getCache(id, path string) (interface{}, bool) {
 	if ic.cache != nil {
 		if id == "" {
@@ -116,14 +144,27 @@ func (ic *imageContexts) setCache(id, path string, v interface{}) {
 // imageMount is a reference for getting access to a buildcontext that is backed
 // by an existing image
 type imageMount struct {
+	mu        sync.Mutex
 	id        string
+	index     int // index of the build stage, -1 for other images
 	ctx       builder.Context
 	release   func() error
 	ic        *imageContexts
 	runConfig *container.Config
 }
 
+// update records the result of the latest instruction of a build stage.
+func (im *imageMount) update(imageID string, runConfig *container.Config) {
+	im.mu.Lock()
+	im.id = imageID
+	im.runConfig = runConfig
+	im.mu.Unlock()
+}
+
 func (im *imageMount) context() (builder.Context, error) {
+	im.mu.Lock()
+	defer im.mu.Unlock()
+
 	if im.ctx == nil {
 		if im.id == "" {
 			return nil, errors.Errorf("could not copy from empty context")
@@ -143,6 +184,9 @@ func (im *imageMount) context() (builder.Context, error) {
 }
 
 func (im *imageMount) unmount() error {
+	im.mu.Lock()
+	defer im.mu.Unlock()
+
 	if im.release != nil {
 		if err := im.release(); err != nil {
 			return errors.Wrapf(err, "failed to unmount previous build image %s", im.id)
@@ -153,10 +197,61 @@ func (im *imageMount) unmount() error {
 }
 
 func (im *imageMount) ImageID() string {
+	im.mu.Lock()
+	defer im.mu.Unlock()
 	return im.id
 }
+
+// RunConfig returns a copy of the config of the image, as the stages that
+// build on it modify the config in place.
 func (im *imageMount) RunConfig() *container.Config {
-	return im.runConfig
+	im.mu.Lock()
+	defer im.mu.Unlock()
+	return copyRunConfig(im.runConfig)
+}
+
+func copyRunConfig(c *container.Config) *container.Config {
+	if c == nil {
+		return nil
+	}
+	cp := *c
+	cp.Cmd = copyStrings(c.Cmd)
+	cp.Entrypoint = copyStrings(c.Entrypoint)
+	cp.Shell = copyStrings(c.Shell)
+	cp.Env = copyStrings(c.Env)
+	cp.OnBuild = copyStrings(c.OnBuild)
+	if c.ExposedPorts != nil {
+		cp.ExposedPorts = make(nat.PortSet, len(c.ExposedPorts))
+		for k, v := range c.ExposedPorts {
+			cp.ExposedPorts[k] = v
+		}
+	}
+	if c.Volumes != nil {
+		cp.Volumes = make(map[string]struct{}, len(c.Volumes))
+		for k, v := range c.Volumes {
+			cp.Volumes[k] = v
+		}
+	}
+	if c.Labels != nil {
+		cp.Labels = make(map[string]string, len(c.Labels))
+		for k, v := range c.Labels {
+			cp.Labels[k] = v
+		}
+	}
+	if c.Healthcheck != nil {
+		healthcheck := *c.Healthcheck
+		healthcheck.Test = copyStrings(c.Healthcheck.Test)
+		cp.Healthcheck = &healthcheck
+	}
+	return &cp
+}
+
+// copyStrings copies s, keeping the difference between nil and empty.
+func copyStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+	return append(make([]string, 0, len(s)), s...)
 }
 
 type pathCache struct {
@@ -22,6 +22,7 @@ import (
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/backend"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/api/types/strslice"
 	"github.com/docker/docker/builder"
 	"github.com/docker/docker/builder/dockerfile/parser"
@@ -59,7 +60,7 @@ func (b *Builder) commit(id string, autoCmd strslice.StrSlice, comment string) e
 		} else if hit {
 			return nil
 		}
-		id, err = b.create()
+		id, err = b.create(nil)
 		if err != nil {
 			return err
 		}
@@ -75,6 +76,7 @@ func (b *Builder) commit(id string, autoCmd strslice.StrSlice, comment string) e
 			Pause:  true,
 			Config: &autoConfig,
 		},
+		SourceDateEpoch: b.sourceDateEpoch(),
 	}
 
 	// Commit the container
@@ -84,7 +86,7 @@ func (b *Builder) commit(id string, autoCmd strslice.StrSlice, comment string) e
 	}
 
 	b.image = imageID
-	b.imageContexts.update(imageID, &autoConfig)
+	b.stage.update(imageID, &autoConfig)
 	return nil
 }
 
@@ -111,6 +113,18 @@ func (b *Builder) runContextCommand(args []string, allowRemote bool, allowLocalD
 	var err error
 	for _, orig := range args[0 : len(args)-1] {
 		var fi builder.FileInfo
+		if h := parser.ParseHeredocMarker(orig); h != nil && len(b.heredocs) > 0 {
+			fi, err = b.heredocFile(h.Name)
+			if err != nil {
+				return err
+			}
+			defer os.RemoveAll(filepath.Dir(fi.Path()))
+			infos = append(infos, copyInfo{
+				FileInfo:   fi,
+				decompress: false,
+			})
+			continue
+		}
 		if urlutil.IsURL(orig) {
 			if !allowRemote {
 				return fmt.Errorf("Source can't be a URL for %s", cmdName)
@@ -197,6 +211,12 @@ func (b *Builder) runContextCommand(args []string, allowRemote bool, allowLocalD
 		return err
 	}
 
+	if imageSource == nil {
+		if err := b.syncContext(infos); err != nil {
+			return err
+		}
+	}
+
 	for _, info := range infos {
 		if err := b.docker.CopyOnBuild(container.ID, dest, info.FileInfo, info.decompress); err != nil {
 			return err
@@ -206,6 +226,25 @@ func (b *Builder) runContextCommand(args []string, allowRemote bool, allowLocalD
 	return b.commit(container.ID, cmd, comment)
 }
 
+// syncContext makes the files to copy from the build context available
+// locally, for contexts that only have the files once they are synced.
+func (b *Builder) syncContext(infos []copyInfo) error {
+	ctx := b.context
+	if dockerIgnore, ok := ctx.(builder.DockerIgnoreContext); ok {
+		ctx = dockerIgnore.ModifiableContext
+	}
+	syncContext, ok := ctx.(builder.SyncContext)
+	if !ok {
+		return nil
+	}
+	for _, info := range infos {
+		if err := syncContext.Sync(info.FileInfo); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (b *Builder) download(srcURL string) (fi builder.FileInfo, err error) {
 	// get filename from URL
 	u, err := url.Parse(srcURL)
@@ -510,12 +549,12 @@ func (b *Builder) probeCache() (bool, error) {
 	fmt.Fprint(b.Stdout, " ---> Using cache\n")
 	logrus.Debugf("[BUILDER] Use cached version: %s", b.runConfig.Cmd)
 	b.image = string(cache)
-	b.imageContexts.update(b.image, b.runConfig)
+	b.stage.update(b.image, b.runConfig)
 
 	return true, nil
 }
 
-func (b *Builder) create() (string, error) {
+func (b *Builder) create(mounts []mount.Mount) (string, error) {
 	if !b.hasFromImage() {
 		return "", errors.New("Please provide a source image with `from` prior to run")
 	}
@@ -543,6 +582,7 @@ func (b *Builder) create() (string, error) {
 		// Set a log config to override any default value set on the daemon
 		LogConfig:  defaultLogConfig,
 		ExtraHosts: b.options.ExtraHosts,
+		Mounts:     mounts,
 	}
 
 	config := *b.runConfig
@@ -8,6 +8,7 @@ import (
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/backend"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/builder"
 	"github.com/docker/docker/image"
 	"golang.org/x/net/context"
@@ -16,6 +17,9 @@ import (
 // MockBackend implements the builder.Backend interface for unit testing
 type MockBackend struct {
 	getImageOnBuildFunc func(string) (builder.Image, error)
+	pullOnBuildFunc     func(string, io.Writer) (builder.Image, error)
+	commitFunc          func(string, *backend.ContainerCommitConfig) (string, error)
+	mountImageFunc      func(string) (string, func() error, error)
 }
 
 func (m *MockBackend) GetImageOnBuild(name string) (builder.Image, error) {
@@ -30,6 +34,9 @@ func (m *MockBackend) TagImageWithReference(image.ID, reference.Named) error {
 }
 
 func (m *MockBackend) PullOnBuild(ctx context.Context, name string, authConfigs map[string]types.AuthConfig, output io.Writer) (builder.Image, error) {
+	if m.pullOnBuildFunc != nil {
+		return m.pullOnBuildFunc(name, output)
+	}
 	return nil, nil
 }
 
@@ -45,7 +52,10 @@ func (m *MockBackend) ContainerRm(name string, config *types.ContainerRmConfig)
 	return nil
 }
 
-func (m *MockBackend) Commit(string, *backend.ContainerCommitConfig) (string, error) {
+func (m *MockBackend) Commit(cID string, cfg *backend.ContainerCommitConfig) (string, error) {
+	if m.commitFunc != nil {
+		return m.commitFunc(cID, cfg)
+	}
 	return "", nil
 }
 
@@ -82,9 +92,20 @@ func (m *MockBackend) SquashImage(from string, to string) (string, error) {
 }
 
 func (m *MockBackend) MountImage(name string) (string, func() error, error) {
+	if m.mountImageFunc != nil {
+		return m.mountImageFunc(name)
+	}
 	return "", func() error { return nil }, nil
 }
 
+func (m *MockBackend) ContainerMountStubs(containerID string, paths []string) (func() error, error) {
+	return func() error { return nil }, nil
+}
+
+func (m *MockBackend) CacheMountsPrune(pruneFilters filters.Args) (*types.BuildCachePruneReport, error) {
+	return &types.BuildCachePruneReport{}, nil
+}
+
 type mockImage struct {
 	id     string
 	config *container.Config
@@ -0,0 +1,174 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/docker/docker/builder/dockerfile/command"
+)
+
+// Heredoc is a here-document of an instruction, such as
+//
+//	RUN <<EOF
+//	echo hello
+//	EOF
+type Heredoc struct {
+	Name    string // the delimiter of the here-document
+	Content string // the lines of the here-document, without the delimiter
+	Expand  bool   // whether variables are expanded in the content
+	Chomp   bool   // whether leading tabs are stripped from the lines (<<-)
+}
+
+// heredocMarker matches the markers that start a here-document, such as
+// <<EOF, <<-EOF, <<"EOF" and <<'EOF'. A marker preceded by another '<' is a
+// here-string (<<<), which is not a here-document.
+var heredocMarker = regexp.MustCompile(`(?:^|[^<])<<(-?)(["']?)([A-Za-z_][A-Za-z0-9_]*)(["']?)`)
+
+// heredocCommands are the instructions that support here-documents.
+var heredocCommands = map[string]bool{
+	command.Copy: true,
+	command.Run:  true,
+}
+
+// ParseHeredocMarker returns the here-document that word starts, without its
+// content, or nil if word is not a here-document marker.
+func ParseHeredocMarker(word string) *Heredoc {
+	match := heredocMarker.FindStringSubmatch(word)
+	if match == nil || len(match[0]) != len(word) {
+		return nil
+	}
+	return newHeredoc(match)
+}
+
+func newHeredoc(match []string) *Heredoc {
+	if match[2] != match[4] {
+		return nil
+	}
+	return &Heredoc{
+		Name:   match[3],
+		Expand: match[2] == "",
+		Chomp:  match[1] == "-",
+	}
+}
+
+// heredocsFromNode returns the here-documents that the instruction of the
+// node starts, in the order in which their content follows the instruction.
+func heredocsFromNode(node *Node) []Heredoc {
+	if !heredocCommands[node.Value] || node.Attributes["json"] {
+		return nil
+	}
+
+	var heredocs []Heredoc
+	for n := node.Next; n != nil; n = n.Next {
+		if node.Value == command.Copy {
+			// The sources of COPY are either a file or a here-document
+			// as a whole.
+			if h := ParseHeredocMarker(n.Value); h != nil {
+				heredocs = append(heredocs, *h)
+			}
+			continue
+		}
+		for _, match := range shellHeredocMarkers(n.Value) {
+			if h := newHeredoc(match); h != nil {
+				heredocs = append(heredocs, *h)
+			}
+		}
+	}
+	return heredocs
+}
+
+// shellHeredocMarkers returns the submatches of heredocMarker for the markers
+// of the shell command cmd. Only the markers that are a word of their own,
+// outside of quotes and of arithmetic expressions such as $((1<<BITS)), start
+// a here-document.
+func shellHeredocMarkers(cmd string) [][]string {
+	var (
+		matches [][]string
+		quote   byte
+		arith   int // depth of the parentheses of an arithmetic expression
+	)
+	for i := 0; i < len(cmd); i++ {
+		c := cmd[i]
+		switch {
+		case quote == '\'':
+			if c == '\'' {
+				quote = 0
+			}
+		case c == '\\':
+			i++
+		case quote == '"':
+			if c == '"' {
+				quote = 0
+			}
+		case arith > 0:
+			if c == '(' {
+				arith++
+			} else if c == ')' {
+				arith--
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case strings.HasPrefix(cmd[i:], "$(("):
+			arith = 2
+			i += 2
+		case strings.HasPrefix(cmd[i:], "((") && (i == 0 || isShellSeparator(cmd[i-1])):
+			arith = 2
+			i++
+		case strings.HasPrefix(cmd[i:], "<<") && (i == 0 || isShellSeparator(cmd[i-1])):
+			match := heredocMarker.FindStringSubmatch(cmd[i:])
+			if match == nil || !strings.HasPrefix(cmd[i:], match[0]) {
+				// A here-string (<<<), or an invalid delimiter.
+				i++
+				continue
+			}
+			end := i + len(match[0])
+			if end < len(cmd) && !isShellSeparator(cmd[end]) {
+				i++
+				continue
+			}
+			matches = append(matches, match)
+			i = end - 1
+		}
+	}
+	return matches
+}
+
+// isShellSeparator returns whether c separates the words of a shell command.
+func isShellSeparator(c byte) bool {
+	return strings.IndexByte(" \t\n;&|()<>", c) >= 0
+}
+
+// readHeredocs reads the content of the here-documents from the lines that
+// follow an instruction, and returns the number of lines that it read.
+func readHeredocs(scanner *bufio.Scanner, heredocs []Heredoc) (int, error) {
+	lines := 0
+	for i := range heredocs {
+		h := &heredocs[i]
+		var content bytes.Buffer
+		terminated := false
+		for scanner.Scan() {
+			lines++
+			line := scanner.Text()
+			if h.Chomp {
+				line = strings.TrimLeft(line, "\t")
+			}
+			if line == h.Name {
+				terminated = true
+				break
+			}
+			content.WriteString(line)
+			content.WriteString("\n")
+		}
+		if !terminated {
+			if err := scanner.Err(); err != nil {
+				return lines, err
+			}
+			return lines, fmt.Errorf("unterminated here-document %s", h.Name)
+		}
+		h.Content = content.String()
+	}
+	return lines, nil
+}
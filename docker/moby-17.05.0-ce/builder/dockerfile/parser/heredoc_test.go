@@ -0,0 +1,115 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/pkg/testutil/assert"
+)
+
+func TestParseHeredocMarker(t *testing.T) {
+	testCases := []struct {
+		word     string
+		expected *Heredoc
+	}{
+		{word: "<<EOF", expected: &Heredoc{Name: "EOF", Expand: true}},
+		{word: "<<-EOF", expected: &Heredoc{Name: "EOF", Expand: true, Chomp: true}},
+		{word: `<<"EOF"`, expected: &Heredoc{Name: "EOF"}},
+		{word: "<<-'EOF'", expected: &Heredoc{Name: "EOF", Chomp: true}},
+		{word: `<<"EOF'`},
+		{word: "<<<EOF"},
+		{word: "<<1"},
+		{word: "file<<EOF"},
+		{word: "EOF"},
+	}
+
+	for _, testCase := range testCases {
+		assert.DeepEqual(t, ParseHeredocMarker(testCase.word), testCase.expected)
+	}
+}
+
+func TestParseHeredocs(t *testing.T) {
+	dockerfile := strings.Join([]string{
+		"FROM busybox",
+		"RUN python3 <<EOF && cat <<-'CONF'",
+		"print('$HOME')",
+		"EOF",
+		"\tkey=$value",
+		"\tCONF",
+		"COPY <<EOF file <<\"B\" /dest/",
+		"a",
+		"EOF",
+		"",
+		"B",
+		`RUN ["sh", "-c", "cat <<EOF"]`,
+		"RUN echo $((1<<2))",
+	}, "\n")
+
+	d := Directive{LookingForDirectives: true}
+	SetEscapeToken(DefaultEscapeToken, &d)
+	ast, err := Parse(strings.NewReader(dockerfile), &d)
+	assert.NilError(t, err)
+	assert.Equal(t, len(ast.Children), 5)
+
+	run := ast.Children[1]
+	assert.DeepEqual(t, run.Heredocs, []Heredoc{
+		{Name: "EOF", Content: "print('$HOME')\n", Expand: true},
+		{Name: "CONF", Content: "key=$value\n", Chomp: true},
+	})
+	assert.Equal(t, run.StartLine, 2)
+	assert.Equal(t, run.EndLine, 6)
+
+	copyNode := ast.Children[2]
+	assert.DeepEqual(t, copyNode.Heredocs, []Heredoc{
+		{Name: "EOF", Content: "a\n", Expand: true},
+		{Name: "B", Content: "\n"},
+	})
+	assert.Equal(t, copyNode.StartLine, 7)
+	assert.Equal(t, copyNode.EndLine, 11)
+
+	// Here-documents are not supported in the JSON form.
+	assert.Equal(t, len(ast.Children[3].Heredocs), 0)
+	assert.Equal(t, len(ast.Children[4].Heredocs), 0)
+}
+
+func TestParseHeredocUnterminated(t *testing.T) {
+	d := Directive{LookingForDirectives: true}
+	SetEscapeToken(DefaultEscapeToken, &d)
+	_, err := Parse(strings.NewReader("FROM busybox\nCOPY <<EOF /file\ncontent\n"), &d)
+	assert.Error(t, err, "unterminated here-document EOF")
+}
+
+func TestParseHeredocShellMarkers(t *testing.T) {
+	testCases := []struct {
+		cmd      string
+		expected []string
+	}{
+		{cmd: "cat <<EOF", expected: []string{"EOF"}},
+		{cmd: "<<EOF python3 && cat <<-'CONF' >file", expected: []string{"EOF", "CONF"}},
+		{cmd: "cat <<EOF|grep a;cat <<B", expected: []string{"EOF", "B"}},
+		{cmd: "echo $((1<<BITS))"},
+		{cmd: "echo $(( (1 << BITS) - 1 )) && cat <<EOF", expected: []string{"EOF"}},
+		{cmd: "((x = 1<<BITS)) && echo $x"},
+		{cmd: `echo "<<EOF" '<<EOF' \<<EOF`},
+		{cmd: "cat <<<EOF"},
+		{cmd: "cat file<<EOF"},
+		{cmd: "cat <<EOF-1"},
+	}
+
+	for _, testCase := range testCases {
+		var names []string
+		for _, match := range shellHeredocMarkers(testCase.cmd) {
+			names = append(names, match[3])
+		}
+		assert.DeepEqual(t, names, testCase.expected)
+	}
+}
+
+func TestParseHeredocArithmeticShift(t *testing.T) {
+	d := Directive{LookingForDirectives: true}
+	SetEscapeToken(DefaultEscapeToken, &d)
+	ast, err := Parse(strings.NewReader("FROM busybox\nRUN echo $((1<<BITS))\nRUN echo done\n"), &d)
+	assert.NilError(t, err)
+	assert.Equal(t, len(ast.Children), 3)
+	assert.Equal(t, len(ast.Children[1].Heredocs), 0)
+}
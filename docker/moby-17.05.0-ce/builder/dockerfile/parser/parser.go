@@ -35,6 +35,7 @@ type Node struct {
 	Flags      []string        // only top Node should have this set
 	StartLine  int             // the line in the original dockerfile where the node begins
 	EndLine    int             // the line in the original dockerfile where the node ends
+	Heredocs   []Heredoc       // only top Node should have this set
 }
 
 // Dump dumps the AST defined by `node` as a list of sexps.
@@ -250,6 +251,14 @@ func Parse(rwc io.Reader, d *Directive) (*Node, error) {
 		}
 
 		if child != nil {
+			if child.Heredocs = heredocsFromNode(child); len(child.Heredocs) > 0 {
+				n, err := readHeredocs(scanner, child.Heredocs)
+				currentLine += n
+				if err != nil {
+					return nil, err
+				}
+			}
+
 			// Update the line information for the current child.
 			child.StartLine = startLine
 			child.EndLine = currentLine
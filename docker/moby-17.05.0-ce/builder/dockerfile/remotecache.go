@@ -0,0 +1,85 @@
+package dockerfile
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/builder"
+	"github.com/pkg/errors"
+)
+
+// cacheRegistryPrefix is the prefix of the --cache-from and --cache-to
+// values that refer to a build cache in a registry.
+const cacheRegistryPrefix = "registry://"
+
+// validateCacheTo checks that the build cache can be exported to cacheTo.
+func validateCacheTo(cacheTo string) error {
+	if cacheTo == "" {
+		return nil
+	}
+	if !strings.HasPrefix(cacheTo, cacheRegistryPrefix) || len(cacheTo) == len(cacheRegistryPrefix) {
+		return errors.Errorf("invalid cache-to %q: must be %s<repository>:<tag>", cacheTo, cacheRegistryPrefix)
+	}
+	return nil
+}
+
+// importCache pulls the build caches in the cache sources of the build from
+// their registry, and replaces them with the images of the caches. A build
+// cache that can't be pulled, such as one that wasn't exported yet, only
+// makes the build miss the cache.
+func (b *Builder) importCache() {
+	var cacheFrom []string
+	for _, source := range b.options.CacheFrom {
+		if !strings.HasPrefix(source, cacheRegistryPrefix) {
+			cacheFrom = append(cacheFrom, source)
+			continue
+		}
+		name := strings.TrimPrefix(source, cacheRegistryPrefix)
+		r, ok := b.docker.(builder.BuildCacheRegistry)
+		if !ok {
+			fmt.Fprintf(b.Stderr, "[Warning] Importing build cache %s is not supported, skipping\n", name)
+			continue
+		}
+		fmt.Fprintf(b.Stdout, "Importing build cache %s\n", name)
+		ids, err := r.ImportBuildCache(b.clientCtx, name, b.options.AuthConfigs, b.Output)
+		if err != nil {
+			fmt.Fprintf(b.Stderr, "[Warning] Failed to import build cache %s: %v\n", name, err)
+			continue
+		}
+		cacheFrom = append(cacheFrom, ids...)
+	}
+	b.options.CacheFrom = cacheFrom
+}
+
+// exportCache pushes the images of the build to the registry of the build
+// cache in the options of the build.
+func (b *Builder) exportCache(imageIDs []string) error {
+	name := strings.TrimPrefix(b.options.CacheTo, cacheRegistryPrefix)
+	r, ok := b.docker.(builder.BuildCacheRegistry)
+	if !ok {
+		return errors.New("exporting the build cache is not supported")
+	}
+	fmt.Fprintf(b.Stdout, "Exporting build cache %s\n", name)
+	return r.ExportBuildCache(b.clientCtx, name, imageIDs, b.options.AuthConfigs, b.Output)
+}
+
+// stageImages returns the images of the build stages, in Dockerfile order,
+// including those of the stages that are not the result of the build.
+func stageImages(builders map[int]*Builder) []string {
+	var indexes []int
+	for i := range builders {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+
+	var images []string
+	seen := make(map[string]bool)
+	for _, i := range indexes {
+		if img := builders[i].image; img != "" && !seen[img] {
+			seen[img] = true
+			images = append(images, img)
+		}
+	}
+	return images
+}
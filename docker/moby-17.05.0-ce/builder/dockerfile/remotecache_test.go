@@ -0,0 +1,76 @@
+package dockerfile
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/testutil/assert"
+	"golang.org/x/net/context"
+)
+
+// mockCacheRegistry is a backend with a registry of build caches.
+type mockCacheRegistry struct {
+	MockBackend
+	caches map[string][]string
+}
+
+func (m *mockCacheRegistry) ImportBuildCache(ctx context.Context, name string, authConfigs map[string]types.AuthConfig, output io.Writer) ([]string, error) {
+	ids, ok := m.caches[name]
+	if !ok {
+		return nil, errors.New("manifest unknown")
+	}
+	return ids, nil
+}
+
+func (m *mockCacheRegistry) ExportBuildCache(ctx context.Context, name string, imageIDs []string, authConfigs map[string]types.AuthConfig, output io.Writer) error {
+	m.caches[name] = imageIDs
+	return nil
+}
+
+func TestValidateCacheTo(t *testing.T) {
+	assert.NilError(t, validateCacheTo(""))
+	assert.NilError(t, validateCacheTo("registry://localhost:5000/app:cache"))
+	assert.Error(t, validateCacheTo("localhost:5000/app:cache"), "invalid cache-to")
+	assert.Error(t, validateCacheTo("registry://"), "invalid cache-to")
+}
+
+func TestImportCache(t *testing.T) {
+	b := newBuilderWithMockBackend()
+	b.docker = &mockCacheRegistry{caches: map[string][]string{
+		"localhost:5000/app:cache": {"sha256:stage0", "sha256:stage1"},
+	}}
+	stderr := &bytes.Buffer{}
+	b.Stdout = &bytes.Buffer{}
+	b.Stderr = stderr
+	b.options.CacheFrom = []string{"app:latest", "registry://localhost:5000/app:cache", "registry://localhost:5000/app:missing"}
+
+	b.importCache()
+	assert.DeepEqual(t, b.options.CacheFrom, []string{"app:latest", "sha256:stage0", "sha256:stage1"})
+	assert.Equal(t, stderr.String(), "[Warning] Failed to import build cache localhost:5000/app:missing: manifest unknown\n")
+}
+
+func TestExportCache(t *testing.T) {
+	r := &mockCacheRegistry{caches: make(map[string][]string)}
+	b := newBuilderWithMockBackend()
+	b.docker = r
+	b.Stdout = &bytes.Buffer{}
+	b.options.CacheTo = "registry://localhost:5000/app:cache"
+
+	builders := map[int]*Builder{
+		2: {image: "sha256:final"},
+		0: {image: "sha256:builder"},
+		1: {image: "sha256:builder"},
+	}
+	assert.NilError(t, b.exportCache(stageImages(builders)))
+	assert.DeepEqual(t, r.caches["localhost:5000/app:cache"], []string{"sha256:builder", "sha256:final"})
+}
+
+func TestExportCacheNotSupported(t *testing.T) {
+	b := newBuilderWithMockBackend()
+	b.Stdout = &bytes.Buffer{}
+	b.options.CacheTo = "registry://localhost:5000/app:cache"
+	assert.Error(t, b.exportCache([]string{"sha256:final"}), "not supported")
+}
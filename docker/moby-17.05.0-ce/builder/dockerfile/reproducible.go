@@ -0,0 +1,42 @@
+package dockerfile
+
+import (
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/builder"
+	"github.com/docker/docker/image"
+)
+
+// sourceDateEpoch returns the time that the images of a reproducible build
+// are created at, or the zero time if the build is not reproducible.
+func (b *Builder) sourceDateEpoch() time.Time {
+	if b.options.SourceDateEpoch == nil {
+		return time.Time{}
+	}
+	return time.Unix(*b.options.SourceDateEpoch, 0).UTC()
+}
+
+// reproducibleImageCache is the image cache of a reproducible build. It only
+// returns the images that were created at the source date epoch of the
+// build, as other images have layers that don't depend only on the build.
+type reproducibleImageCache struct {
+	builder.ImageCache
+	backend builder.Backend
+	epoch   time.Time
+}
+
+func (c *reproducibleImageCache) GetCache(parentID string, cfg *container.Config) (string, error) {
+	id, err := c.ImageCache.GetCache(parentID, cfg)
+	if err != nil || id == "" {
+		return id, err
+	}
+	img, err := c.backend.GetImageOnBuild(id)
+	if err != nil {
+		return "", err
+	}
+	if created, ok := img.(*image.Image); !ok || !created.Created.Equal(c.epoch) {
+		return "", nil
+	}
+	return id, nil
+}
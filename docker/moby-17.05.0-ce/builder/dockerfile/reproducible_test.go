@@ -0,0 +1,53 @@
+package dockerfile
+
+import (
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/builder"
+	"github.com/docker/docker/image"
+	"github.com/docker/docker/pkg/testutil/assert"
+)
+
+type mockImageCache map[string]string
+
+func (c mockImageCache) GetCache(parentID string, cfg *container.Config) (string, error) {
+	return c[parentID], nil
+}
+
+func TestSourceDateEpoch(t *testing.T) {
+	b := newBuilderWithMockBackend()
+	assert.Equal(t, b.sourceDateEpoch().IsZero(), true)
+
+	epoch := int64(0)
+	b.options.SourceDateEpoch = &epoch
+	assert.Equal(t, b.sourceDateEpoch(), time.Unix(0, 0).UTC())
+}
+
+func TestReproducibleImageCache(t *testing.T) {
+	epoch := time.Unix(1500000000, 0).UTC()
+	created := map[string]time.Time{
+		"reproducible": epoch,
+		"other":        epoch.Add(time.Second),
+	}
+	c := &reproducibleImageCache{
+		ImageCache: mockImageCache{"base": "reproducible", "old": "other"},
+		backend: &MockBackend{getImageOnBuildFunc: func(name string) (builder.Image, error) {
+			return &image.Image{V1Image: image.V1Image{Created: created[name]}}, nil
+		}},
+		epoch: epoch,
+	}
+
+	id, err := c.GetCache("base", &container.Config{})
+	assert.NilError(t, err)
+	assert.Equal(t, id, "reproducible")
+
+	id, err = c.GetCache("old", &container.Config{})
+	assert.NilError(t, err)
+	assert.Equal(t, id, "")
+
+	id, err = c.GetCache("missing", &container.Config{})
+	assert.NilError(t, err)
+	assert.Equal(t, id, "")
+}
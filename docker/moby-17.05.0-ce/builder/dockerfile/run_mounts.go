@@ -0,0 +1,349 @@
+package dockerfile
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/builder"
+	digest "github.com/opencontainers/go-digest"
+)
+
+const (
+	runMountTypeSecret = "secret"
+	runMountTypeSSH    = "ssh"
+	runMountTypeCache  = "cache"
+
+	defaultSecretMountDir  = "/run/secrets"
+	defaultSSHMountPrefix  = "/run/buildkit/ssh_agent."
+	defaultSSHID           = "default"
+	defaultSecretMountMode = 0400
+	defaultSSHMountMode    = 0600
+
+	cacheMountVolumePrefix = "buildcache-"
+)
+
+// runMount is a mount requested by a RUN instruction with --mount.
+type runMount struct {
+	Type     string
+	ID       string
+	Target   string
+	Required bool
+	ReadOnly bool
+	Mode     os.FileMode
+	UID      int
+	GID      int
+}
+
+// parseRunMount parses the value of a RUN --mount flag, which is a list of
+// comma separated key=value pairs like the --mount flag of docker run.
+func parseRunMount(value string) (*runMount, error) {
+	csvReader := csv.NewReader(strings.NewReader(value))
+	fields, err := csvReader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("invalid mount %q: %v", value, err)
+	}
+
+	m := &runMount{Mode: defaultSecretMountMode}
+	modeSet := false
+	for _, field := range fields {
+		parts := strings.SplitN(field, "=", 2)
+		key := strings.ToLower(parts[0])
+
+		if len(parts) == 1 {
+			switch key {
+			case "required":
+				m.Required = true
+				continue
+			case "readonly", "ro":
+				m.ReadOnly = true
+				continue
+			}
+			return nil, fmt.Errorf("invalid field '%s' must be a key=value pair", field)
+		}
+
+		value := parts[1]
+		switch key {
+		case "type":
+			m.Type = strings.ToLower(value)
+		case "id":
+			m.ID = value
+		case "target", "dst", "destination":
+			m.Target = value
+		case "required":
+			m.Required, err = strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for %s: %s", key, value)
+			}
+		case "readonly", "ro":
+			m.ReadOnly, err = strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for %s: %s", key, value)
+			}
+		case "mode":
+			mode, err := strconv.ParseUint(value, 8, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for %s: %s", key, value)
+			}
+			m.Mode = os.FileMode(mode)
+			modeSet = true
+		case "uid":
+			m.UID, err = strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for %s: %s", key, value)
+			}
+		case "gid":
+			m.GID, err = strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for %s: %s", key, value)
+			}
+		default:
+			return nil, fmt.Errorf("unexpected key '%s' in '%s'", key, field)
+		}
+	}
+
+	switch m.Type {
+	case runMountTypeSecret:
+		if m.ID == "" {
+			if m.Target == "" {
+				return nil, fmt.Errorf("secret mount requires an id or a target")
+			}
+			m.ID = path.Base(m.Target)
+		}
+	case runMountTypeSSH:
+		if m.ID == "" {
+			m.ID = defaultSSHID
+		}
+		if !modeSet {
+			m.Mode = defaultSSHMountMode
+		}
+	case runMountTypeCache:
+		if m.Target == "" {
+			return nil, fmt.Errorf("cache mount requires a target")
+		}
+		if m.ID == "" {
+			m.ID = m.Target
+		}
+	case "":
+		return nil, fmt.Errorf("mount type is required")
+	default:
+		return nil, fmt.Errorf("unsupported mount type '%s'", m.Type)
+	}
+	return m, nil
+}
+
+func parseRunMounts(values []string) ([]*runMount, error) {
+	var mounts []*runMount
+	for _, value := range values {
+		m, err := parseRunMount(value)
+		if err != nil {
+			return nil, err
+		}
+		mounts = append(mounts, m)
+	}
+	return mounts, nil
+}
+
+// runMountSession is the session of a build, which serves the secrets and
+// the SSH agents that RUN instructions mount.
+type runMountSession interface {
+	// Secret returns the value of the secret with the id, and false if
+	// there is no such secret.
+	Secret(id string) ([]byte, bool, error)
+	// ForwardSSH forwards the connections to the unix socket path to the
+	// SSH agent with the id, and returns false if there is no such agent.
+	ForwardSSH(id, path string) (io.Closer, bool, error)
+}
+
+// runMounts holds the host side of the mounts of a RUN instruction. They
+// only exist while the instruction runs, so nothing of them is committed.
+type runMounts struct {
+	mounts  []mount.Mount
+	env     []string
+	tmpDir  string
+	closers []io.Closer
+}
+
+// setupRunMounts prepares the mounts requested by a RUN instruction.
+// Release must be called once the instruction has run.
+func (b *Builder) setupRunMounts(requested []*runMount) (_ *runMounts, retErr error) {
+	rm := &runMounts{}
+	defer func() {
+		if retErr != nil {
+			rm.Release()
+		}
+	}()
+
+	sshMounts := 0
+	for _, m := range requested {
+		target := m.Target
+		if target != "" && !path.IsAbs(target) {
+			target = path.Join("/", b.runConfig.WorkingDir, target)
+		}
+
+		switch m.Type {
+		case runMountTypeSecret:
+			var (
+				data []byte
+				ok   bool
+				err  error
+			)
+			if b.session != nil {
+				if data, ok, err = b.session.Secret(m.ID); err != nil {
+					return nil, err
+				}
+			}
+			if !ok {
+				if m.Required {
+					return nil, fmt.Errorf("secret %s is required but was not provided, use docker build --secret id=%s,src=<file>", m.ID, m.ID)
+				}
+				logrus.Debugf("[BUILDER] skipping secret mount %s that was not provided", m.ID)
+				continue
+			}
+			if target == "" {
+				target = path.Join(defaultSecretMountDir, m.ID)
+			}
+			source, err := rm.writeSecret(data, m)
+			if err != nil {
+				return nil, err
+			}
+			rm.mounts = append(rm.mounts, mount.Mount{
+				Type:     mount.TypeBind,
+				Source:   source,
+				Target:   target,
+				ReadOnly: true,
+			})
+
+		case runMountTypeSSH:
+			var (
+				socket string
+				ok     bool
+			)
+			if b.session != nil {
+				var err error
+				if socket, ok, err = rm.forwardSSH(b.session, m); err != nil {
+					return nil, err
+				}
+			}
+			if !ok {
+				if m.Required {
+					return nil, fmt.Errorf("ssh agent %s is required but was not provided, use docker build --ssh %s", m.ID, m.ID)
+				}
+				logrus.Debugf("[BUILDER] skipping ssh mount %s that was not provided", m.ID)
+				continue
+			}
+			if target == "" {
+				target = defaultSSHMountPrefix + strconv.Itoa(sshMounts+1)
+			}
+			if sshMounts == 0 {
+				rm.env = append(rm.env, "SSH_AUTH_SOCK="+target)
+			}
+			sshMounts++
+			rm.mounts = append(rm.mounts, mount.Mount{
+				Type:   mount.TypeBind,
+				Source: socket,
+				Target: target,
+			})
+
+		case runMountTypeCache:
+			// The cache is a named volume, so it is kept by the volume
+			// store across builds and is never committed to the image.
+			rm.mounts = append(rm.mounts, mount.Mount{
+				Type:     mount.TypeVolume,
+				Source:   cacheMountVolumeName(m.ID),
+				Target:   target,
+				ReadOnly: m.ReadOnly,
+				VolumeOptions: &mount.VolumeOptions{
+					NoCopy: true,
+					Labels: map[string]string{builder.CacheMountLabel: m.ID},
+				},
+			})
+		}
+	}
+	return rm, nil
+}
+
+// cacheMountVolumeName returns the name of the volume of the cache with
+// the given id. Cache ids are free form, so they are hashed.
+func cacheMountVolumeName(id string) string {
+	return cacheMountVolumePrefix + digest.FromString(id).Hex()
+}
+
+// tmpPath returns the path of a new file of the host side of the mounts.
+func (rm *runMounts) tmpPath() (string, error) {
+	if rm.tmpDir == "" {
+		dir, err := ioutil.TempDir("", "docker-build-mounts-")
+		if err != nil {
+			return "", err
+		}
+		rm.tmpDir = dir
+	}
+	return filepath.Join(rm.tmpDir, strconv.Itoa(len(rm.mounts))), nil
+}
+
+// writeSecret writes the value of a secret to a file that is bind mounted
+// into the container.
+func (rm *runMounts) writeSecret(data []byte, m *runMount) (string, error) {
+	p, err := rm.tmpPath()
+	if err != nil {
+		return "", err
+	}
+	if err := ioutil.WriteFile(p, data, m.Mode); err != nil {
+		return "", err
+	}
+	return p, setRunMountOwner(p, m)
+}
+
+// forwardSSH creates a unix socket that is bind mounted into the container,
+// and forwards its connections to the SSH agent of the session. It returns
+// false if the session has no such agent.
+func (rm *runMounts) forwardSSH(s runMountSession, m *runMount) (string, bool, error) {
+	p, err := rm.tmpPath()
+	if err != nil {
+		return "", false, err
+	}
+	forwarder, ok, err := s.ForwardSSH(m.ID, p)
+	if err != nil || !ok {
+		return "", ok, err
+	}
+	rm.closers = append(rm.closers, forwarder)
+	return p, true, setRunMountOwner(p, m)
+}
+
+// setRunMountOwner sets the mode and the owner of the file p of a mount.
+func setRunMountOwner(p string, m *runMount) error {
+	// The files are created subject to the umask.
+	if err := os.Chmod(p, m.Mode); err != nil {
+		return err
+	}
+	return os.Lchown(p, m.UID, m.GID)
+}
+
+// targets returns the paths that the mounts are mounted at.
+func (rm *runMounts) targets() []string {
+	var targets []string
+	for _, m := range rm.mounts {
+		targets = append(targets, m.Target)
+	}
+	return targets
+}
+
+// Release stops forwarding the SSH agents, and removes the host side of the
+// mounts.
+func (rm *runMounts) Release() error {
+	for _, c := range rm.closers {
+		c.Close()
+	}
+	if rm.tmpDir == "" {
+		return nil
+	}
+	return os.RemoveAll(rm.tmpDir)
+}
@@ -0,0 +1,102 @@
+package dockerfile
+
+import (
+	"os"
+	"testing"
+
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/builder"
+	"github.com/docker/docker/pkg/testutil/assert"
+)
+
+func TestParseRunMount(t *testing.T) {
+	m, err := parseRunMount("type=secret,id=npmrc")
+	assert.NilError(t, err)
+	assert.Equal(t, m.Type, runMountTypeSecret)
+	assert.Equal(t, m.ID, "npmrc")
+	assert.Equal(t, m.Target, "")
+	assert.Equal(t, m.Required, false)
+	assert.Equal(t, m.Mode, os.FileMode(0400))
+
+	m, err = parseRunMount("type=secret,target=/root/.npmrc,required,mode=0440,uid=1000,gid=1000")
+	assert.NilError(t, err)
+	assert.Equal(t, m.ID, ".npmrc")
+	assert.Equal(t, m.Target, "/root/.npmrc")
+	assert.Equal(t, m.Required, true)
+	assert.Equal(t, m.Mode, os.FileMode(0440))
+	assert.Equal(t, m.UID, 1000)
+	assert.Equal(t, m.GID, 1000)
+
+	m, err = parseRunMount("type=ssh")
+	assert.NilError(t, err)
+	assert.Equal(t, m.Type, runMountTypeSSH)
+	assert.Equal(t, m.ID, defaultSSHID)
+	assert.Equal(t, m.Mode, os.FileMode(0600))
+
+	m, err = parseRunMount("type=ssh,id=github,required=true,mode=0666")
+	assert.NilError(t, err)
+	assert.Equal(t, m.ID, "github")
+	assert.Equal(t, m.Required, true)
+	assert.Equal(t, m.Mode, os.FileMode(0666))
+
+	m, err = parseRunMount("type=cache,target=/root/.cache/go-build")
+	assert.NilError(t, err)
+	assert.Equal(t, m.Type, runMountTypeCache)
+	assert.Equal(t, m.ID, "/root/.cache/go-build")
+	assert.Equal(t, m.ReadOnly, false)
+
+	m, err = parseRunMount("type=cache,id=apt,dst=/var/cache/apt,ro")
+	assert.NilError(t, err)
+	assert.Equal(t, m.ID, "apt")
+	assert.Equal(t, m.Target, "/var/cache/apt")
+	assert.Equal(t, m.ReadOnly, true)
+}
+
+func TestParseRunMountErrors(t *testing.T) {
+	testCases := []struct {
+		value         string
+		expectedError string
+	}{
+		{"id=npmrc", "mount type is required"},
+		{"type=bind,target=/mnt", "unsupported mount type 'bind'"},
+		{"type=secret", "secret mount requires an id or a target"},
+		{"type=secret,id=a,mode=rw", "invalid value for mode: rw"},
+		{"type=secret,id=a,uid=root", "invalid value for uid: root"},
+		{"type=secret,id=a,debug", "invalid field 'debug' must be a key=value pair"},
+		{"type=secret,id=a,foo=bar", "unexpected key 'foo' in 'foo=bar'"},
+		{"type=cache,id=apt", "cache mount requires a target"},
+		{"type=cache,target=/cache,readonly=maybe", "invalid value for readonly: maybe"},
+	}
+	for _, tc := range testCases {
+		_, err := parseRunMount(tc.value)
+		assert.Error(t, err, tc.expectedError)
+	}
+}
+
+func TestSetupRunMountsCache(t *testing.T) {
+	b := newBuilderWithMockBackend()
+	b.runConfig.WorkingDir = "/go/src/app"
+
+	rm, err := b.setupRunMounts([]*runMount{
+		{Type: runMountTypeCache, ID: "/root/.cache/go-build", Target: "/root/.cache/go-build"},
+		{Type: runMountTypeCache, ID: "vendor", Target: "vendor", ReadOnly: true},
+	})
+	assert.NilError(t, err)
+	defer rm.Release()
+
+	assert.Equal(t, len(rm.mounts), 2)
+	assert.Equal(t, rm.mounts[0].Type, mount.TypeVolume)
+	assert.Equal(t, rm.mounts[0].Source, cacheMountVolumeName("/root/.cache/go-build"))
+	assert.Equal(t, rm.mounts[0].Target, "/root/.cache/go-build")
+	assert.Equal(t, rm.mounts[0].ReadOnly, false)
+	assert.Equal(t, rm.mounts[0].VolumeOptions.NoCopy, true)
+	assert.DeepEqual(t, rm.mounts[0].VolumeOptions.Labels, map[string]string{builder.CacheMountLabel: "/root/.cache/go-build"})
+	assert.Equal(t, rm.mounts[1].Target, "/go/src/app/vendor")
+	assert.Equal(t, rm.mounts[1].ReadOnly, true)
+
+	// The same id always maps to the same volume.
+	assert.Equal(t, cacheMountVolumeName("vendor"), rm.mounts[1].Source)
+	if cacheMountVolumeName("vendor") == cacheMountVolumeName("apt") {
+		t.Fatal("expected different caches to use different volumes")
+	}
+}
@@ -0,0 +1,110 @@
+// +build !windows
+
+package dockerfile
+
+import (
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/pkg/testutil/assert"
+)
+
+// testRunMountSession is a session with the secrets, and the SSH agents
+// whose sockets are only listened on.
+type testRunMountSession struct {
+	secrets map[string][]byte
+	agents  map[string]bool
+}
+
+func (s *testRunMountSession) Secret(id string) ([]byte, bool, error) {
+	data, ok := s.secrets[id]
+	return data, ok, nil
+}
+
+func (s *testRunMountSession) ForwardSSH(id, path string) (io.Closer, bool, error) {
+	if !s.agents[id] {
+		return nil, false, nil
+	}
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, false, err
+	}
+	return l, true, nil
+}
+
+func TestSetupRunMountsSecret(t *testing.T) {
+	b := newBuilderWithMockBackend()
+	b.session = &testRunMountSession{secrets: map[string][]byte{"npmrc": []byte("token")}}
+	b.runConfig.WorkingDir = "/app"
+
+	rm, err := b.setupRunMounts([]*runMount{
+		{Type: runMountTypeSecret, ID: "npmrc", Mode: 0440, UID: os.Getuid(), GID: os.Getgid()},
+		{Type: runMountTypeSecret, ID: "npmrc", Target: ".npmrc", Mode: 0400, UID: os.Getuid(), GID: os.Getgid()},
+		{Type: runMountTypeSecret, ID: "missing"},
+	})
+	assert.NilError(t, err)
+
+	assert.Equal(t, len(rm.mounts), 2)
+	assert.Equal(t, rm.mounts[0].Type, mount.TypeBind)
+	assert.Equal(t, rm.mounts[0].Target, "/run/secrets/npmrc")
+	assert.Equal(t, rm.mounts[0].ReadOnly, true)
+	assert.Equal(t, rm.mounts[1].Target, "/app/.npmrc")
+	assert.Equal(t, len(rm.env), 0)
+
+	data, err := ioutil.ReadFile(rm.mounts[0].Source)
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), "token")
+	fi, err := os.Stat(rm.mounts[0].Source)
+	assert.NilError(t, err)
+	assert.Equal(t, fi.Mode().Perm(), os.FileMode(0440))
+
+	assert.NilError(t, rm.Release())
+	_, err = os.Stat(rm.mounts[0].Source)
+	assert.Equal(t, os.IsNotExist(err), true)
+
+	_, err = b.setupRunMounts([]*runMount{{Type: runMountTypeSecret, ID: "missing", Required: true}})
+	assert.Error(t, err, "secret missing is required")
+}
+
+func TestSetupRunMountsSSH(t *testing.T) {
+	b := newBuilderWithMockBackend()
+	b.session = &testRunMountSession{agents: map[string]bool{"default": true}}
+
+	rm, err := b.setupRunMounts([]*runMount{
+		{Type: runMountTypeSSH, ID: "default", Mode: 0600, UID: os.Getuid(), GID: os.Getgid()},
+		{Type: runMountTypeSSH, ID: "default", Target: "/ssh", Mode: 0660, UID: os.Getuid(), GID: os.Getgid()},
+		{Type: runMountTypeSSH, ID: "other"},
+	})
+	assert.NilError(t, err)
+
+	assert.Equal(t, len(rm.mounts), 2)
+	assert.Equal(t, rm.mounts[0].Type, mount.TypeBind)
+	assert.Equal(t, rm.mounts[0].Target, "/run/buildkit/ssh_agent.1")
+	assert.Equal(t, rm.mounts[1].Target, "/ssh")
+	assert.DeepEqual(t, rm.env, []string{"SSH_AUTH_SOCK=/run/buildkit/ssh_agent.1"})
+
+	// The sockets are forwarded to the agent of the session.
+	fi, err := os.Lstat(rm.mounts[0].Source)
+	assert.NilError(t, err)
+	assert.Equal(t, fi.Mode()&os.ModeSocket != 0, true)
+	assert.Equal(t, fi.Mode().Perm(), os.FileMode(0600))
+	fi, err = os.Lstat(rm.mounts[1].Source)
+	assert.NilError(t, err)
+	assert.Equal(t, fi.Mode().Perm(), os.FileMode(0660))
+
+	assert.NilError(t, rm.Release())
+	_, err = os.Lstat(rm.mounts[0].Source)
+	assert.Equal(t, os.IsNotExist(err), true)
+
+	_, err = b.setupRunMounts([]*runMount{{Type: runMountTypeSSH, ID: "other", Required: true}})
+	assert.Error(t, err, "ssh agent other is required")
+
+	// Without a session, the build has no SSH agent.
+	b.session = nil
+	_, err = b.setupRunMounts([]*runMount{{Type: runMountTypeSSH, ID: "default", Required: true}})
+	assert.Error(t, err, "ssh agent default is required")
+}
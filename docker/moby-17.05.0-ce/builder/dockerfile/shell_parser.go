@@ -7,6 +7,7 @@ package dockerfile
 // be added by adding code to the "special ${} format processing" section
 
 import (
+	"bytes"
 	"fmt"
 	"runtime"
 	"strings"
@@ -41,6 +42,21 @@ func ProcessWords(word string, env []string, escapeToken rune) ([]string, error)
 	return words, err
 }
 
+// ProcessHeredoc will use the 'env' list of environment variables, and
+// replace any env var references in the content of a here-document. Unlike
+// ProcessWord, quotes are taken as-is, and the escape token only escapes '$'
+// and itself, like in the here-documents of a shell.
+func ProcessHeredoc(content string, env []string, escapeToken rune) (string, error) {
+	sw := &shellWord{
+		word:        content,
+		envs:        env,
+		pos:         0,
+		escapeToken: escapeToken,
+	}
+	sw.scanner.Init(strings.NewReader(content))
+	return sw.processHeredoc()
+}
+
 func process(word string, env []string, escapeToken rune) (string, []string, error) {
 	sw := &shellWord{
 		word:        word,
@@ -160,6 +176,35 @@ func (sw *shellWord) processStopOn(stopChar rune) (string, []string, error) {
 	return result, words.getWords(), nil
 }
 
+// Process the content of a here-document, where only env var references and
+// escaped '$'s are special
+func (sw *shellWord) processHeredoc() (string, error) {
+	var result bytes.Buffer
+
+	for sw.scanner.Peek() != scanner.EOF {
+		ch := sw.scanner.Peek()
+		if ch == '$' {
+			tmp, err := sw.processDollar()
+			if err != nil {
+				return "", err
+			}
+			result.WriteString(tmp)
+			continue
+		}
+
+		ch = sw.scanner.Next()
+		if ch == sw.escapeToken {
+			chNext := sw.scanner.Peek()
+			if chNext == '$' || chNext == sw.escapeToken {
+				ch = sw.scanner.Next()
+			}
+		}
+		result.WriteRune(ch)
+	}
+
+	return result.String(), nil
+}
+
 func (sw *shellWord) processSingleQuote() (string, error) {
 	// All chars between single quotes are taken as-is
 	// Note, you can't escape '
@@ -108,6 +108,29 @@ func TestShellParser4Words(t *testing.T) {
 	}
 }
 
+func TestShellParserHeredoc(t *testing.T) {
+	envs := []string{"NAME=app", "PORT=8080"}
+	testCases := []struct {
+		content  string
+		expected string
+	}{
+		{content: "name=$NAME\n", expected: "name=app\n"},
+		{content: "listen ${PORT}\nhost ${HOST:-localhost}\n", expected: "listen 8080\nhost localhost\n"},
+		{content: `quoted "$NAME" '$NAME'`, expected: `quoted "app" 'app'`},
+		{content: `escaped \$NAME \\$NAME \n`, expected: `escaped $NAME \app \n`},
+		{content: "cost $ 5", expected: "cost $ 5"},
+	}
+
+	for _, testCase := range testCases {
+		result, err := ProcessHeredoc(testCase.content, envs, '\\')
+		assert.NilError(t, err)
+		assert.Equal(t, result, testCase.expected)
+	}
+
+	_, err := ProcessHeredoc("${NAME", envs, '\\')
+	assert.Error(t, err, "Missing ':' in substitution")
+}
+
 func TestGetEnv(t *testing.T) {
 	sw := &shellWord{
 		word: "",
@@ -0,0 +1,324 @@
+package dockerfile
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/builder/dockerfile/command"
+	"github.com/docker/docker/builder/dockerfile/parser"
+	"github.com/docker/docker/pkg/streamformatter"
+	"github.com/docker/docker/pkg/stringid"
+	"github.com/pkg/errors"
+)
+
+// buildStage is a FROM instruction and the instructions that follow it, up
+// to the next FROM.
+type buildStage struct {
+	index int
+	name  string
+	nodes []*parser.Node
+	step  int   // step number of the FROM instruction
+	deps  []int // indexes of the stages that the stage copies from or builds on
+}
+
+// splitStages splits the instructions of a Dockerfile into the instructions
+// that come before the first FROM, and the build stages.
+func splitStages(dockerfile *parser.Node) ([]*parser.Node, []*buildStage) {
+	var (
+		meta   []*parser.Node
+		stages []*buildStage
+	)
+	for i, n := range dockerfile.Children {
+		if n.Value == command.From {
+			stages = append(stages, &buildStage{
+				index: len(stages),
+				name:  stageName(n),
+				step:  i,
+			})
+		}
+		if len(stages) == 0 {
+			meta = append(meta, n)
+			continue
+		}
+		s := stages[len(stages)-1]
+		s.nodes = append(s.nodes, n)
+	}
+
+	for _, s := range stages {
+		s.deps = s.dependencies(stages)
+	}
+	return meta, stages
+}
+
+// stageName returns the name given to a stage with FROM <image> AS <name>.
+func stageName(n *parser.Node) string {
+	var args []string
+	for next := n.Next; next != nil; next = next.Next {
+		args = append(args, next.Value)
+	}
+	if len(args) == 3 && strings.EqualFold(args[1], "as") {
+		return strings.ToLower(args[2])
+	}
+	return ""
+}
+
+// dependencies returns the stages that FROM and COPY --from instructions
+// of the stage refer to.
+func (s *buildStage) dependencies(stages []*buildStage) []int {
+	var refs []string
+	for _, n := range s.nodes {
+		switch n.Value {
+		case command.From:
+			if n.Next != nil {
+				refs = append(refs, n.Next.Value)
+			}
+		case command.Copy:
+			for _, flag := range n.Flags {
+				if strings.HasPrefix(strings.ToLower(flag), "--from=") {
+					refs = append(refs, flag[len("--from="):])
+				}
+			}
+		}
+	}
+
+	deps := make(map[int]struct{})
+	for _, ref := range refs {
+		if strings.Contains(ref, "$") {
+			// The reference is only known once args are expanded, so the
+			// stage is assumed to depend on all of the stages before it.
+			for i := 0; i < s.index; i++ {
+				deps[i] = struct{}{}
+			}
+			continue
+		}
+		if i, err := strconv.Atoi(ref); err == nil {
+			if i >= 0 && i < s.index {
+				deps[i] = struct{}{}
+			}
+			continue
+		}
+		for _, other := range stages[:s.index] {
+			if other.name != "" && other.name == strings.ToLower(ref) {
+				deps[other.index] = struct{}{}
+			}
+		}
+	}
+
+	var result []int
+	for i := 0; i < s.index; i++ {
+		if _, ok := deps[i]; ok {
+			result = append(result, i)
+		}
+	}
+	return result
+}
+
+// requiredStages returns the stages that are needed to build the target
+// stage, in Dockerfile order, and the target stage. Without a target, all
+// the stages are built and the last one is the result.
+func requiredStages(stages []*buildStage, target string) ([]*buildStage, *buildStage, error) {
+	if target == "" {
+		return stages, stages[len(stages)-1], nil
+	}
+
+	var targetStage *buildStage
+	for _, s := range stages {
+		if strings.EqualFold(s.name, target) {
+			targetStage = s
+			break
+		}
+	}
+	if targetStage == nil {
+		return nil, nil, errors.Errorf("failed to reach build target %s in Dockerfile", target)
+	}
+
+	required := make([]bool, len(stages))
+	var visit func(s *buildStage)
+	visit = func(s *buildStage) {
+		if required[s.index] {
+			return
+		}
+		required[s.index] = true
+		for _, dep := range s.deps {
+			visit(stages[dep])
+		}
+	}
+	visit(targetStage)
+
+	var result []*buildStage
+	for _, s := range stages {
+		if required[s.index] {
+			result = append(result, s)
+		}
+	}
+	return result, targetStage, nil
+}
+
+type stageResult struct {
+	stage *buildStage
+	b     *Builder
+	err   error
+}
+
+// dispatchStages builds the stages, running up to the parallel limit of
+// stages whose dependencies are built at the same time. Stages are started
+// in Dockerfile order, so that with a limit of 1 the build is sequential.
+// It returns the builders of the stages that were built.
+func (b *Builder) dispatchStages(stages []*buildStage, total int) (map[int]*Builder, error) {
+	parallel := b.options.Parallel
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	var (
+		builders = make(map[int]*Builder, len(stages))
+		started  = make(map[int]bool, len(stages))
+		done     = make(map[int]bool, len(stages))
+		results  = make(chan stageResult)
+		running  int
+		firstErr error
+		// outMu serializes the writes of the stages to the output of the
+		// build, which is not safe for concurrent use.
+		outMu sync.Mutex
+	)
+
+	ready := func(s *buildStage) bool {
+		for _, dep := range s.deps {
+			if !done[dep] {
+				return false
+			}
+		}
+		return true
+	}
+
+	for len(done) < len(stages) {
+		if firstErr == nil {
+			for _, s := range stages {
+				if running >= parallel {
+					break
+				}
+				if started[s.index] || !ready(s) {
+					continue
+				}
+				started[s.index] = true
+				running++
+				sb := b.newStageBuilder(&outMu)
+				builders[s.index] = sb
+				go func(s *buildStage) {
+					results <- stageResult{stage: s, b: sb, err: sb.dispatchStage(s, total)}
+				}(s)
+			}
+		}
+
+		if running == 0 {
+			break
+		}
+		r := <-results
+		running--
+		done[r.stage.index] = true
+		if r.err != nil && firstErr == nil {
+			// Stop the other stages, and wait for them to clean up.
+			firstErr = r.err
+			b.cancel()
+		}
+	}
+
+	return builders, firstErr
+}
+
+// newStageBuilder returns a builder for a stage of the build. It shares the
+// options, the session, the image cache and the image contexts of b, and the
+// output of b, whose writes are serialized with outMu.
+func (b *Builder) newStageBuilder(outMu *sync.Mutex) *Builder {
+	var aux *streamformatter.AuxFormatter
+	if b.aux != nil {
+		aux = &streamformatter.AuxFormatter{
+			Writer:          newSyncWriter(outMu, b.aux.Writer),
+			StreamFormatter: b.aux.StreamFormatter,
+		}
+	}
+	return &Builder{
+		options:       b.options,
+		Stdout:        newSyncWriter(outMu, b.Stdout),
+		Stderr:        newSyncWriter(outMu, b.Stderr),
+		Output:        newSyncWriter(outMu, b.Output),
+		aux:           aux,
+		docker:        b.docker,
+		context:       b.context,
+		session:       b.session,
+		clientCtx:     b.clientCtx,
+		cancel:        b.cancel,
+		runConfig:     new(container.Config),
+		tmpContainers: map[string]struct{}{},
+		imageContexts: b.imageContexts,
+		buildArgs:     b.buildArgs.Clone(),
+		directive:     b.directive,
+		id:            b.id,
+		imageCache:    b.imageCache,
+	}
+}
+
+// syncWriter is a writer whose writes are serialized with the ones of the
+// other syncWriters sharing its mutex. Each write of the formatters of the
+// build output is a whole message, so the messages of the stages built at
+// the same time don't interleave.
+type syncWriter struct {
+	mu *sync.Mutex
+	w  io.Writer
+}
+
+func newSyncWriter(mu *sync.Mutex, w io.Writer) io.Writer {
+	if w == nil {
+		return nil
+	}
+	return &syncWriter{mu: mu, w: w}
+}
+
+func (w *syncWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.w.Write(p)
+}
+
+// dispatchStage dispatches the instructions of a stage.
+func (b *Builder) dispatchStage(s *buildStage, total int) error {
+	stage, err := b.imageContexts.stage(s.index)
+	if err != nil {
+		return err
+	}
+	b.stage = stage
+	return b.dispatchNodes(s.nodes, s.step, total)
+}
+
+// dispatchNodes dispatches instructions, the first of which is step number
+// firstStep of the Dockerfile.
+func (b *Builder) dispatchNodes(nodes []*parser.Node, firstStep, total int) error {
+	for i, n := range nodes {
+		select {
+		case <-b.clientCtx.Done():
+			logrus.Debug("Builder: build cancelled!")
+			fmt.Fprint(b.Stdout, "Build cancelled")
+			return errors.New("Build cancelled")
+		default:
+			// Not cancelled yet, keep going...
+		}
+
+		if err := b.dispatch(firstStep+i, total, n); err != nil {
+			if b.options.ForceRemove {
+				b.clearTmp()
+			}
+			return err
+		}
+
+		fmt.Fprintf(b.Stdout, " ---> %s\n", stringid.TruncateID(b.image))
+		if b.options.Remove {
+			b.clearTmp()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,291 @@
+package dockerfile
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/docker/docker/api/types/backend"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/builder"
+	"github.com/docker/docker/builder/dockerfile/parser"
+	"github.com/docker/docker/pkg/system"
+	"github.com/docker/docker/pkg/testutil/assert"
+	"golang.org/x/net/context"
+)
+
+func parseStages(t *testing.T, dockerfile string) ([]*parser.Node, []*buildStage) {
+	d := parser.Directive{}
+	parser.SetEscapeToken(parser.DefaultEscapeToken, &d)
+	nodes, err := parser.Parse(strings.NewReader(dockerfile), &d)
+	assert.NilError(t, err)
+	return splitStages(nodes)
+}
+
+func stageNames(stages []*buildStage) []string {
+	var names []string
+	for _, s := range stages {
+		names = append(names, s.name)
+	}
+	return names
+}
+
+const multiStageDockerfile = `ARG GO_VERSION=1.8
+FROM golang:${GO_VERSION} AS go
+RUN go build -o /bin/app .
+FROM node AS Web
+RUN npm run build
+FROM go AS test
+RUN go test ./...
+FROM alpine
+COPY --from=go /bin/app /bin/app
+COPY --from=web /dist /srv
+FROM scratch AS tools
+COPY --from=3 /bin/app /app
+`
+
+func TestSplitStages(t *testing.T) {
+	meta, stages := parseStages(t, multiStageDockerfile)
+
+	assert.Equal(t, len(meta), 1)
+	assert.Equal(t, meta[0].Value, "arg")
+	assert.DeepEqual(t, stageNames(stages), []string{"go", "web", "test", "", "tools"})
+
+	assert.Equal(t, stages[0].step, 1)
+	assert.Equal(t, len(stages[0].nodes), 2)
+	assert.Equal(t, stages[3].step, 7)
+	assert.Equal(t, len(stages[3].nodes), 3)
+
+	assert.DeepEqual(t, stages[0].deps, []int(nil))
+	assert.DeepEqual(t, stages[1].deps, []int(nil))
+	assert.DeepEqual(t, stages[2].deps, []int{0})
+	assert.DeepEqual(t, stages[3].deps, []int{0, 1})
+	assert.DeepEqual(t, stages[4].deps, []int{3})
+}
+
+func TestSplitStagesWithArgReference(t *testing.T) {
+	_, stages := parseStages(t, `FROM alpine AS a
+FROM alpine AS b
+FROM alpine
+ARG SRC=a
+COPY --from=${SRC} /x /x
+`)
+	assert.DeepEqual(t, stages[2].deps, []int{0, 1})
+}
+
+func TestSplitStagesWithoutFrom(t *testing.T) {
+	meta, stages := parseStages(t, "ARG foo=bar\n")
+	assert.Equal(t, len(meta), 1)
+	assert.Equal(t, len(stages), 0)
+}
+
+func TestRequiredStages(t *testing.T) {
+	_, stages := parseStages(t, multiStageDockerfile)
+
+	required, target, err := requiredStages(stages, "")
+	assert.NilError(t, err)
+	assert.Equal(t, len(required), 5)
+	assert.Equal(t, target.index, 4)
+
+	required, target, err = requiredStages(stages, "test")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, stageNames(required), []string{"go", "test"})
+	assert.Equal(t, target.name, "test")
+
+	required, target, err = requiredStages(stages, "Tools")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, stageNames(required), []string{"go", "web", "", "tools"})
+	assert.Equal(t, target.name, "tools")
+
+	_, _, err = requiredStages(stages, "missing")
+	assert.Error(t, err, "failed to reach build target missing in Dockerfile")
+}
+
+func TestImageContextsStageLookup(t *testing.T) {
+	b := newBuilderWithMockBackend()
+	ic := b.imageContexts
+	for _, name := range []string{"a", "", "b"} {
+		_, err := ic.new(name)
+		assert.NilError(t, err)
+	}
+	_, err := ic.new("a")
+	assert.Error(t, err, "duplicate name a")
+
+	// Stages can only refer to the stages before them.
+	_, ok := ic.byStageName("b", 1)
+	assert.Equal(t, ok, false)
+	im, ok := ic.byStageName("a", 1)
+	assert.Equal(t, ok, true)
+	assert.Equal(t, im.index, 0)
+
+	im, err = ic.get(b, "1", 2)
+	assert.NilError(t, err)
+	assert.Equal(t, im.index, 1)
+	_, err = ic.get(b, "2", 2)
+	assert.Error(t, err, "invalid from flag value 2 refers current build block")
+	im, err = ic.get(b, "B", 2)
+	assert.NilError(t, err)
+	assert.Equal(t, im.index, 2)
+}
+
+func TestImageMountRunConfigIsCopied(t *testing.T) {
+	im := &imageMount{}
+	im.update("id", &container.Config{
+		Env:    []string{"A=1"},
+		Labels: map[string]string{"a": "1"},
+	})
+
+	c := im.RunConfig()
+	c.Env = append(c.Env, "B=2")
+	c.Env[0] = "A=2"
+	c.Labels["b"] = "2"
+
+	orig := im.RunConfig()
+	assert.DeepEqual(t, orig.Env, []string{"A=1"})
+	assert.DeepEqual(t, orig.Labels, map[string]string{"a": "1"})
+	assert.Equal(t, orig.Cmd == nil, true)
+}
+
+func TestDispatchStagesParallel(t *testing.T) {
+	_, stages := parseStages(t, `FROM scratch AS a
+ENV A=1
+FROM scratch AS b
+ENV B=1
+FROM scratch AS c
+ENV C=1
+FROM a
+ENV D=1
+`)
+	if runtime.GOOS == "windows" {
+		t.Skip("Windows does not support FROM scratch")
+	}
+
+	var commits int32
+	b := newBuilderWithMockBackend()
+	b.docker.(*MockBackend).commitFunc = func(string, *backend.ContainerCommitConfig) (string, error) {
+		return fmt.Sprintf("image%d", atomic.AddInt32(&commits, 1)), nil
+	}
+	b.clientCtx, b.cancel = context.WithCancel(context.Background())
+	// The stages built at the same time share the output, which is not
+	// safe for concurrent use.
+	out := &bytes.Buffer{}
+	b.Stdout = out
+	b.options.Parallel = 3
+	for _, s := range stages {
+		_, err := b.imageContexts.new(s.name)
+		assert.NilError(t, err)
+	}
+
+	required, target, err := requiredStages(stages, "")
+	assert.NilError(t, err)
+	builders, err := b.dispatchStages(required, 8)
+	assert.NilError(t, err)
+	assert.Equal(t, len(builders), 4)
+	assert.DeepEqual(t, builders[2].runConfig.Env, []string{"PATH=" + system.DefaultPathEnv, "C=1"})
+	assert.DeepEqual(t, builders[target.index].runConfig.Env, []string{"PATH=" + system.DefaultPathEnv, "A=1", "D=1"})
+	assert.Equal(t, strings.Count(out.String(), "Step "), 8)
+}
+
+// cachingBackend is a MockBackend whose image cache holds the images it
+// committed, keyed by their parent and their environment.
+type cachingBackend struct {
+	*MockBackend
+	mu      sync.Mutex
+	images  map[string]string
+	commits int
+}
+
+func newCachingBackend() *cachingBackend {
+	c := &cachingBackend{MockBackend: &MockBackend{}, images: make(map[string]string)}
+	c.commitFunc = func(_ string, cfg *backend.ContainerCommitConfig) (string, error) {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.commits++
+		id := fmt.Sprintf("image%d", c.commits)
+		c.images[cfg.Config.Image+strings.Join(cfg.Config.Env, ",")] = id
+		return id, nil
+	}
+	return c
+}
+
+func (c *cachingBackend) MakeImageCache(cacheFrom []string) builder.ImageCache {
+	return c
+}
+
+func (c *cachingBackend) GetCache(parentID string, cfg *container.Config) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.images[parentID+strings.Join(cfg.Env, ",")], nil
+}
+
+func TestDispatchStagesParallelUsesCache(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Windows does not support FROM scratch")
+	}
+
+	docker := newCachingBackend()
+	build := func() (string, string) {
+		_, stages := parseStages(t, `FROM scratch AS a
+ENV A=1
+FROM a
+ENV B=1
+`)
+		b := newBuilderWithMockBackend()
+		b.docker = docker
+		b.clientCtx, b.cancel = context.WithCancel(context.Background())
+		out := &bytes.Buffer{}
+		b.Stdout = out
+		b.options.Parallel = 2
+		for _, s := range stages {
+			_, err := b.imageContexts.new(s.name)
+			assert.NilError(t, err)
+		}
+
+		required, target, err := requiredStages(stages, "")
+		assert.NilError(t, err)
+		builders, err := b.dispatchStages(required, 4)
+		assert.NilError(t, err)
+		return builders[target.index].image, out.String()
+	}
+
+	image, out := build()
+	assert.Equal(t, docker.commits, 2)
+	assert.Equal(t, strings.Count(out, "Using cache"), 0)
+
+	// The second build reuses the images of both stages.
+	cached, out := build()
+	assert.Equal(t, docker.commits, 2)
+	assert.Equal(t, strings.Count(out, "Using cache"), 2)
+	assert.Equal(t, cached, image)
+}
+
+func TestImageContextsPullWithStageOutput(t *testing.T) {
+	b := newBuilderWithMockBackend()
+	var pullOutput io.Writer
+	mock := b.docker.(*MockBackend)
+	mock.getImageOnBuildFunc = func(string) (builder.Image, error) {
+		return nil, errors.New("not found")
+	}
+	mock.pullOnBuildFunc = func(name string, output io.Writer) (builder.Image, error) {
+		pullOutput = output
+		return &mockImage{id: "pulled"}, nil
+	}
+	b.Output = &bytes.Buffer{}
+	_, err := b.imageContexts.new("")
+	assert.NilError(t, err)
+
+	// Images pulled by a stage write to its synchronized output, not to
+	// the one of the build.
+	var outMu sync.Mutex
+	sb := b.newStageBuilder(&outMu)
+	im, err := b.imageContexts.get(sb, "busybox", 0)
+	assert.NilError(t, err)
+	assert.Equal(t, im.ImageID(), "pulled")
+	assert.Equal(t, pullOutput, sb.Output)
+}
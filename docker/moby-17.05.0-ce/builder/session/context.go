@@ -0,0 +1,388 @@
+package session
+
+import (
+	"archive/tar"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net/rpc"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/builder"
+	"github.com/docker/docker/pkg/pools"
+	"github.com/docker/docker/pkg/symlink"
+	"github.com/docker/docker/pkg/tarsum"
+	"github.com/pkg/errors"
+)
+
+// sessionContext is a build context whose files are read from a client over
+// a session. The files are only written to the local directory of the
+// context when they are synced, which the builder does before it copies them
+// into a container.
+type sessionContext struct {
+	client *rpc.Client
+	store  *contentStore
+	root   string // local directory that the synced files are written to
+
+	mu      sync.Mutex // protects removed and synced, and serializes syncs
+	removed map[string]struct{}
+	synced  map[string]FileStat
+}
+
+func newSessionContext(client *rpc.Client, store *contentStore) (*sessionContext, error) {
+	root, err := ioutil.TempDir("", "docker-builder")
+	if err != nil {
+		return nil, err
+	}
+	return &sessionContext{
+		client:  client,
+		store:   store,
+		root:    root,
+		removed: make(map[string]struct{}),
+		synced:  make(map[string]FileStat),
+	}, nil
+}
+
+func (c *sessionContext) Close() error {
+	return os.RemoveAll(c.root)
+}
+
+func (c *sessionContext) Stat(p string) (string, builder.FileInfo, error) {
+	var resp StatResponse
+	if err := c.client.Call(serviceName+".Stat", StatRequest{Path: p}, &resp); err != nil {
+		return "", nil, convertError(err, p)
+	}
+	if c.isRemoved(resp.Stat.Path) {
+		return "", nil, &os.PathError{Op: "stat", Path: p, Err: os.ErrNotExist}
+	}
+	fi, err := c.fileInfo(resp.Stat)
+	if err != nil {
+		return "", nil, err
+	}
+	return filepath.FromSlash(resp.Stat.Path), fi, nil
+}
+
+func (c *sessionContext) Open(p string) (io.ReadCloser, error) {
+	_, fi, err := c.Stat(p)
+	if err != nil {
+		return nil, err
+	}
+	st := fi.(*fileInfo).stat
+	if !st.Mode.IsRegular() {
+		return nil, errors.Errorf("%s is not a regular file", p)
+	}
+
+	c.store.mu.RLock()
+	defer c.store.mu.RUnlock()
+	return c.store.open(st.Digest, c.fetch(st))
+}
+
+func (c *sessionContext) Walk(root string, walkFn builder.WalkFunc) error {
+	var resp WalkResponse
+	if err := c.client.Call(serviceName+".Walk", WalkRequest{Path: root}, &resp); err != nil {
+		return convertError(err, root)
+	}
+
+	var skipDir string
+	for _, st := range resp.Files {
+		if skipDir != "" && strings.HasPrefix(st.Path, skipDir+"/") {
+			continue
+		}
+		if c.isRemoved(st.Path) {
+			continue
+		}
+		fi, err := c.fileInfo(st)
+		if err != nil {
+			return err
+		}
+		if err := walkFn(filepath.FromSlash(st.Path), fi, nil); err != nil {
+			if err == filepath.SkipDir && st.Mode.IsDir() {
+				skipDir = st.Path
+				continue
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// Remove removes a file from the context. The files are not removed from
+// the client, but they are not part of the context anymore.
+func (c *sessionContext) Remove(p string) error {
+	p = cleanPath(p)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.removed[p] = struct{}{}
+	for synced := range c.synced {
+		if synced == p || strings.HasPrefix(synced, p+"/") {
+			delete(c.synced, synced)
+		}
+	}
+	return os.RemoveAll(filepath.Join(c.root, filepath.FromSlash(p)))
+}
+
+// Sync writes a file of the context, or a directory and all the files in
+// it, to the local directory of the context. It ignores the files that are
+// not from the context.
+func (c *sessionContext) Sync(fi builder.FileInfo) error {
+	f, ok := fi.(*fileInfo)
+	if !ok || f.ctx != c {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !f.stat.Mode.IsDir() {
+		return c.syncFile(f.stat)
+	}
+
+	var resp WalkResponse
+	if err := c.client.Call(serviceName+".Walk", WalkRequest{Path: f.stat.Path}, &resp); err != nil {
+		return convertError(err, f.stat.Path)
+	}
+	dirs := []FileStat{f.stat}
+	if err := c.syncFile(f.stat); err != nil {
+		return err
+	}
+	for _, st := range resp.Files {
+		if c.isRemovedLocked(st.Path) {
+			continue
+		}
+		if err := c.syncFile(st); err != nil {
+			return err
+		}
+		if st.Mode.IsDir() {
+			dirs = append(dirs, st)
+		}
+	}
+
+	// Writing files into a directory changes its modification time, so the
+	// times of the directories are set once all the files are written.
+	sort.Sort(sort.Reverse(byPath(dirs)))
+	for _, st := range dirs {
+		p, err := c.localPath(st.Path)
+		if err != nil {
+			return err
+		}
+		if err := os.Chtimes(p, st.ModTime, st.ModTime); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncFile writes a file to the local directory of the context, unless it
+// is already there. The caller must hold c.mu.
+func (c *sessionContext) syncFile(st FileStat) error {
+	if prev, ok := c.synced[st.Path]; ok && sameFile(prev, st) {
+		return nil
+	}
+
+	p, err := c.localPath(st.Path)
+	if err != nil {
+		return err
+	}
+	if st.Path != "." {
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case st.Mode.IsDir():
+		if fi, err := os.Lstat(p); err == nil && !fi.IsDir() {
+			if err := os.Remove(p); err != nil {
+				return err
+			}
+		}
+		if err := os.MkdirAll(p, 0755); err != nil {
+			return err
+		}
+	case st.Mode&os.ModeSymlink != 0:
+		if err := os.RemoveAll(p); err != nil {
+			return err
+		}
+		if err := os.Symlink(st.Linkname, p); err != nil {
+			return err
+		}
+		c.synced[st.Path] = st
+		return nil
+	case st.Mode.IsRegular():
+		if err := os.RemoveAll(p); err != nil {
+			return err
+		}
+		if err := c.writeFile(st, p); err != nil {
+			return err
+		}
+	default:
+		logrus.Debugf("[BUILDER] skipping %s from the build context session: unsupported file type", st.Path)
+		return nil
+	}
+
+	if err := os.Chmod(p, st.Mode&(os.ModePerm|os.ModeSetuid|os.ModeSetgid|os.ModeSticky)); err != nil {
+		return err
+	}
+	if err := os.Chtimes(p, st.ModTime, st.ModTime); err != nil {
+		return err
+	}
+	c.synced[st.Path] = st
+	return nil
+}
+
+// writeFile writes the content of a regular file to the path p, from the
+// content store.
+func (c *sessionContext) writeFile(st FileStat, p string) error {
+	c.store.mu.RLock()
+	defer c.store.mu.RUnlock()
+
+	src, err := c.store.open(st.Digest, c.fetch(st))
+	if err != nil {
+		return errors.Wrapf(err, "failed to transfer %s from the build context session", st.Path)
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(p, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := pools.Copy(dst, src); err != nil {
+		dst.Close()
+		return err
+	}
+	return dst.Close()
+}
+
+// fetch returns a function that reads the content of a regular file from
+// the client.
+func (c *sessionContext) fetch(st FileStat) func(w io.Writer) error {
+	return func(w io.Writer) error {
+		var offset int64
+		for {
+			var resp ReadResponse
+			req := ReadRequest{Path: st.Path, Offset: offset, Digest: st.Digest}
+			if err := c.client.Call(serviceName+".Read", req, &resp); err != nil {
+				return convertError(err, st.Path)
+			}
+			n, err := w.Write(resp.Data)
+			if err != nil {
+				return err
+			}
+			offset += int64(n)
+			if resp.EOF {
+				return nil
+			}
+			if n == 0 {
+				return errors.Errorf("failed to read %s: no data", st.Path)
+			}
+		}
+	}
+}
+
+// localPath returns the path in the local directory of the context that a
+// file is written to. Symlinks in the parent directories are followed
+// within the local directory.
+func (c *sessionContext) localPath(p string) (string, error) {
+	p = cleanPath(p)
+	if p == "." {
+		return c.root, nil
+	}
+	dir, err := symlink.FollowSymlinkInScope(filepath.Join(c.root, filepath.FromSlash(path.Dir(p))), c.root)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, path.Base(p)), nil
+}
+
+func (c *sessionContext) isRemoved(p string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.isRemovedLocked(p)
+}
+
+func (c *sessionContext) isRemovedLocked(p string) bool {
+	for ; p != "." && p != "/"; p = path.Dir(p) {
+		if _, ok := c.removed[p]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// fileInfo returns the FileInfo of a file of the context. The hash of the
+// file only depends on its path, metadata and content, so that the build
+// cache can be used across sessions.
+func (c *sessionContext) fileInfo(st FileStat) (*fileInfo, error) {
+	hdr, err := tar.FileInfoHeader(statInfo{st}, st.Linkname)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create hash for %s", st.Path)
+	}
+	hdr.Name = st.Path
+	h := sha256.New()
+	tarsum.WriteV1Header(hdr, h)
+	h.Write([]byte(st.Digest))
+
+	p, err := c.localPath(st.Path)
+	if err != nil {
+		return nil, err
+	}
+	return &fileInfo{
+		HashedFileInfo: builder.HashedFileInfo{
+			FileInfo: builder.PathFileInfo{FileInfo: statInfo{st}, FilePath: p},
+			FileHash: hex.EncodeToString(h.Sum(nil)),
+		},
+		stat: st,
+		ctx:  c,
+	}, nil
+}
+
+// fileInfo is the FileInfo of a file of a session context.
+type fileInfo struct {
+	builder.HashedFileInfo
+	stat FileStat
+	ctx  *sessionContext
+}
+
+// statInfo implements os.FileInfo for a FileStat.
+type statInfo struct {
+	st FileStat
+}
+
+func (s statInfo) Name() string       { return path.Base(s.st.Path) }
+func (s statInfo) Size() int64        { return s.st.Size }
+func (s statInfo) Mode() os.FileMode  { return s.st.Mode }
+func (s statInfo) ModTime() time.Time { return s.st.ModTime }
+func (s statInfo) IsDir() bool        { return s.st.Mode.IsDir() }
+func (s statInfo) Sys() interface{}   { return nil }
+
+func sameFile(a, b FileStat) bool {
+	return a.Mode == b.Mode && a.Size == b.Size && a.ModTime.Equal(b.ModTime) &&
+		a.Linkname == b.Linkname && a.Digest == b.Digest
+}
+
+type byPath []FileStat
+
+func (s byPath) Len() int           { return len(s) }
+func (s byPath) Less(i, j int) bool { return s[i].Path < s[j].Path }
+func (s byPath) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// convertError converts the errors that the client returns for files that
+// do not exist back to os.PathError.
+func convertError(err error, p string) error {
+	if serverErr, ok := err.(rpc.ServerError); ok {
+		if string(serverErr) == os.ErrNotExist.Error() {
+			return &os.PathError{Op: "stat", Path: p, Err: os.ErrNotExist}
+		}
+		return errors.New(string(serverErr))
+	}
+	return errors.Wrap(err, "build context session")
+}
@@ -0,0 +1,237 @@
+package session
+
+import (
+	"io/ioutil"
+	"net"
+	"net/rpc"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/builder"
+	"github.com/docker/docker/pkg/testutil/assert"
+	"golang.org/x/net/context"
+)
+
+type testSession struct {
+	ctx     *sessionContext
+	cleanup func()
+}
+
+// newTestSession serves the files of contextDir on a session, and returns
+// the build context that reads them.
+func newTestSession(t *testing.T, contextDir string, excludes []string, store *contentStore) *testSession {
+	server, err := NewFileSyncServer(contextDir, excludes)
+	assert.NilError(t, err)
+
+	serverConn, clientConn := net.Pipe()
+	go ServeConn(serverConn, server)
+	client := rpc.NewClient(clientConn)
+
+	ctx, err := newSessionContext(client, store)
+	assert.NilError(t, err)
+	return &testSession{
+		ctx: ctx,
+		cleanup: func() {
+			ctx.Close()
+			client.Close()
+		},
+	}
+}
+
+func newTestStore(t *testing.T) (*contentStore, func()) {
+	root, err := ioutil.TempDir("", "builder-session-store")
+	assert.NilError(t, err)
+	store, err := newContentStore(root)
+	assert.NilError(t, err)
+	return store, func() { os.RemoveAll(root) }
+}
+
+func writeTestFiles(t *testing.T, dir string, files map[string]string) {
+	for p, content := range files {
+		p = filepath.Join(dir, filepath.FromSlash(p))
+		assert.NilError(t, os.MkdirAll(filepath.Dir(p), 0755))
+		assert.NilError(t, ioutil.WriteFile(p, []byte(content), 0644))
+	}
+}
+
+func TestSessionContextStat(t *testing.T) {
+	contextDir, err := ioutil.TempDir("", "builder-session-context")
+	assert.NilError(t, err)
+	defer os.RemoveAll(contextDir)
+	writeTestFiles(t, contextDir, map[string]string{
+		"Dockerfile":    "FROM busybox\n",
+		"src/main.go":   "package main\n",
+		"secret/passwd": "root\n",
+	})
+	assert.NilError(t, os.Symlink("src/main.go", filepath.Join(contextDir, "link")))
+
+	store, cleanup := newTestStore(t)
+	defer cleanup()
+	s := newTestSession(t, contextDir, []string{"secret"}, store)
+	defer s.cleanup()
+
+	rel, fi, err := s.ctx.Stat("src/main.go")
+	assert.NilError(t, err)
+	assert.Equal(t, rel, filepath.Join("src", "main.go"))
+	assert.Equal(t, fi.Name(), "main.go")
+	assert.Equal(t, fi.Size(), int64(len("package main\n")))
+	assert.Equal(t, fi.Path(), filepath.Join(s.ctx.root, "src", "main.go"))
+	assert.Equal(t, fi.(builder.Hashed).Hash() != "", true)
+
+	// Symlinks are followed within the build context.
+	rel, _, err = s.ctx.Stat("link")
+	assert.NilError(t, err)
+	assert.Equal(t, rel, filepath.Join("src", "main.go"))
+
+	_, _, err = s.ctx.Stat("secret/passwd")
+	assert.Equal(t, os.IsNotExist(err), true)
+	_, _, err = s.ctx.Stat("missing")
+	assert.Equal(t, os.IsNotExist(err), true)
+
+	// Files are only written locally once they are synced.
+	_, err = os.Stat(fi.Path())
+	assert.Equal(t, os.IsNotExist(err), true)
+}
+
+func TestSessionContextOpen(t *testing.T) {
+	contextDir, err := ioutil.TempDir("", "builder-session-context")
+	assert.NilError(t, err)
+	defer os.RemoveAll(contextDir)
+	writeTestFiles(t, contextDir, map[string]string{"Dockerfile": "FROM busybox\n"})
+
+	store, cleanup := newTestStore(t)
+	defer cleanup()
+	s := newTestSession(t, contextDir, nil, store)
+	defer s.cleanup()
+
+	f, err := s.ctx.Open("Dockerfile")
+	assert.NilError(t, err)
+	content, err := ioutil.ReadAll(f)
+	f.Close()
+	assert.NilError(t, err)
+	assert.Equal(t, string(content), "FROM busybox\n")
+}
+
+func TestSessionContextWalkAndRemove(t *testing.T) {
+	contextDir, err := ioutil.TempDir("", "builder-session-context")
+	assert.NilError(t, err)
+	defer os.RemoveAll(contextDir)
+	writeTestFiles(t, contextDir, map[string]string{
+		"Dockerfile":  "FROM busybox\n",
+		"src/main.go": "package main\n",
+		"src/a/b.txt": "b\n",
+		"build/out":   "out\n",
+	})
+
+	store, cleanup := newTestStore(t)
+	defer cleanup()
+	s := newTestSession(t, contextDir, []string{"build"}, store)
+	defer s.cleanup()
+
+	assert.NilError(t, s.ctx.Remove("Dockerfile"))
+
+	var paths []string
+	err = s.ctx.Walk("", func(p string, fi builder.FileInfo, err error) error {
+		paths = append(paths, filepath.ToSlash(p))
+		return err
+	})
+	assert.NilError(t, err)
+	sort.Strings(paths)
+	assert.DeepEqual(t, paths, []string{"src", "src/a", "src/a/b.txt", "src/main.go"})
+
+	_, _, err = s.ctx.Stat("Dockerfile")
+	assert.Equal(t, os.IsNotExist(err), true)
+}
+
+func TestSessionContextSyncDirectory(t *testing.T) {
+	contextDir, err := ioutil.TempDir("", "builder-session-context")
+	assert.NilError(t, err)
+	defer os.RemoveAll(contextDir)
+	writeTestFiles(t, contextDir, map[string]string{
+		"src/main.go":  "package main\n",
+		"src/a/b.txt":  "b\n",
+		"src/a/ignore": "ignored\n",
+	})
+	assert.NilError(t, os.Chmod(filepath.Join(contextDir, "src", "main.go"), 0755))
+	modTime := time.Unix(1500000000, 0)
+	assert.NilError(t, os.Chtimes(filepath.Join(contextDir, "src", "a"), modTime, modTime))
+
+	store, cleanup := newTestStore(t)
+	defer cleanup()
+	s := newTestSession(t, contextDir, []string{"src/a/ignore"}, store)
+	defer s.cleanup()
+
+	_, fi, err := s.ctx.Stat("src")
+	assert.NilError(t, err)
+	assert.NilError(t, s.ctx.Sync(fi))
+
+	content, err := ioutil.ReadFile(filepath.Join(fi.Path(), "a", "b.txt"))
+	assert.NilError(t, err)
+	assert.Equal(t, string(content), "b\n")
+
+	st, err := os.Stat(filepath.Join(fi.Path(), "main.go"))
+	assert.NilError(t, err)
+	assert.Equal(t, st.Mode().Perm(), os.FileMode(0755))
+
+	st, err = os.Stat(filepath.Join(fi.Path(), "a"))
+	assert.NilError(t, err)
+	assert.Equal(t, st.ModTime().Equal(modTime), true)
+
+	_, err = os.Stat(filepath.Join(fi.Path(), "a", "ignore"))
+	assert.Equal(t, os.IsNotExist(err), true)
+
+	// FileInfos that are not from the context are ignored.
+	assert.NilError(t, s.ctx.Sync(builder.PathFileInfo{FilePath: "/nonexistent"}))
+}
+
+func TestSessionContextReusesContent(t *testing.T) {
+	store, cleanup := newTestStore(t)
+	defer cleanup()
+
+	var hashes []string
+	for i := 0; i < 2; i++ {
+		contextDir, err := ioutil.TempDir("", "builder-session-context")
+		assert.NilError(t, err)
+		defer os.RemoveAll(contextDir)
+		writeTestFiles(t, contextDir, map[string]string{"file": "content\n"})
+
+		s := newTestSession(t, contextDir, nil, store)
+		defer s.cleanup()
+
+		_, fi, err := s.ctx.Stat("file")
+		assert.NilError(t, err)
+		hashes = append(hashes, fi.(builder.Hashed).Hash())
+
+		if i == 1 {
+			// The client can't send the file anymore, but the content is
+			// already in the store.
+			assert.NilError(t, os.Remove(filepath.Join(contextDir, "file")))
+		}
+		assert.NilError(t, s.ctx.Sync(fi))
+		content, err := ioutil.ReadFile(fi.Path())
+		assert.NilError(t, err)
+		assert.Equal(t, string(content), "content\n")
+	}
+	assert.Equal(t, hashes[0], hashes[1])
+
+	reclaimed, err := store.prune()
+	assert.NilError(t, err)
+	assert.Equal(t, reclaimed, uint64(len("content\n")))
+}
+
+func TestManagerNewContextWithoutSession(t *testing.T) {
+	root, err := ioutil.TempDir("", "builder-session-manager")
+	assert.NilError(t, err)
+	defer os.RemoveAll(root)
+
+	m, err := NewManager(root)
+	assert.NilError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = m.NewContext(ctx, "unknown")
+	assert.Error(t, err, "no active session for unknown")
+}
@@ -0,0 +1,252 @@
+package session
+
+import (
+	"io"
+	"net/rpc"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/pkg/fileutils"
+	"github.com/docker/docker/pkg/symlink"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+)
+
+// FileSyncServer serves the files of a build context directory on a
+// session, for the daemon to read the files that the build uses.
+type FileSyncServer struct {
+	root string
+
+	mu      sync.Mutex // protects pm and digests
+	pm      *fileutils.PatternMatcher
+	digests map[string]fileDigest
+}
+
+// fileDigest is the digest of a file when it had the size and modification
+// time.
+type fileDigest struct {
+	size    int64
+	modTime time.Time
+	digest  digest.Digest
+}
+
+// NewFileSyncServer returns a FileSyncServer for the build context in the
+// root directory. The files that match the excludes patterns, as read from a
+// .dockerignore file, are not part of the build context.
+func NewFileSyncServer(root string, excludes []string) (*FileSyncServer, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+	if root, err = filepath.EvalSymlinks(root); err != nil {
+		return nil, err
+	}
+	pm, err := fileutils.NewPatternMatcher(excludes)
+	if err != nil {
+		return nil, err
+	}
+	return &FileSyncServer{
+		root:    root,
+		pm:      pm,
+		digests: make(map[string]fileDigest),
+	}, nil
+}
+
+// Register registers the service of the files on server.
+func (s *FileSyncServer) Register(server *rpc.Server) error {
+	return server.RegisterName(serviceName, &fileSyncService{s})
+}
+
+// fileSyncService holds the methods of FileSyncServer that are called over
+// RPC.
+type fileSyncService struct {
+	s *FileSyncServer
+}
+
+func (f *fileSyncService) Stat(req StatRequest, resp *StatResponse) error {
+	rel, fullPath, fi, err := f.s.lstat(req.Path)
+	if err != nil {
+		return err
+	}
+	resp.Stat, err = f.s.fileStat(rel, fullPath, fi)
+	return err
+}
+
+func (f *fileSyncService) Walk(req WalkRequest, resp *WalkResponse) error {
+	_, root, fi, err := f.s.lstat(req.Path)
+	if err != nil {
+		return err
+	}
+	if !fi.IsDir() {
+		return errors.Errorf("%s is not a directory", req.Path)
+	}
+
+	return filepath.Walk(root, func(fullPath string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if fullPath == root {
+			return nil
+		}
+		p, err := filepath.Rel(f.s.root, fullPath)
+		if err != nil {
+			return err
+		}
+		p = filepath.ToSlash(p)
+
+		excluded, err := f.s.excluded(p, fi.IsDir())
+		if err != nil {
+			return err
+		}
+		if excluded {
+			if fi.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		st, err := f.s.fileStat(p, fullPath, fi)
+		if err != nil {
+			return err
+		}
+		resp.Files = append(resp.Files, st)
+		return nil
+	})
+}
+
+func (f *fileSyncService) Read(req ReadRequest, resp *ReadResponse) error {
+	_, fullPath, fi, err := f.s.lstat(req.Path)
+	if err != nil {
+		return err
+	}
+	if !fi.Mode().IsRegular() {
+		return errors.Errorf("%s is not a regular file", req.Path)
+	}
+	dgst, err := f.s.digest(fullPath, fi)
+	if err != nil {
+		return err
+	}
+	if dgst != req.Digest {
+		return errors.Errorf("%s has changed during the build", req.Path)
+	}
+
+	file, err := os.Open(fullPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	resp.Data = make([]byte, readChunkSize)
+	n, err := file.ReadAt(resp.Data, req.Offset)
+	resp.Data = resp.Data[:n]
+	if err == io.EOF || req.Offset+int64(n) >= fi.Size() {
+		resp.EOF = true
+		return nil
+	}
+	return err
+}
+
+// lstat resolves the path p within the build context, and returns its path
+// relative to the root, its full path and its FileInfo. It returns
+// os.ErrNotExist if the file does not exist or is excluded from the build
+// context.
+func (s *FileSyncServer) lstat(p string) (string, string, os.FileInfo, error) {
+	fullPath, err := symlink.FollowSymlinkInScope(filepath.Join(s.root, filepath.FromSlash(cleanPath(p))), s.root)
+	if err != nil {
+		return "", "", nil, err
+	}
+	rel, err := filepath.Rel(s.root, fullPath)
+	if err != nil {
+		return "", "", nil, err
+	}
+	rel = filepath.ToSlash(rel)
+
+	fi, err := os.Lstat(fullPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", "", nil, os.ErrNotExist
+		}
+		return "", "", nil, err
+	}
+	excluded, err := s.excluded(rel, fi.IsDir())
+	if err != nil {
+		return "", "", nil, err
+	}
+	if excluded {
+		return "", "", nil, os.ErrNotExist
+	}
+	return rel, fullPath, fi, nil
+}
+
+// excluded returns whether the path p is excluded from the build context.
+// Like for the tarball of a build context, a directory is not excluded if
+// there are exceptions to the excludes for files in it.
+func (s *FileSyncServer) excluded(p string, isDir bool) (bool, error) {
+	if p == "." {
+		return false, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p = filepath.FromSlash(p)
+	skip, err := s.pm.Matches(p)
+	if err != nil || !skip || !isDir || !s.pm.Exclusions() {
+		return skip, err
+	}
+	dirSlash := p + string(filepath.Separator)
+	for _, pat := range s.pm.Patterns() {
+		if pat.Exclusion() && strings.HasPrefix(pat.String()+string(filepath.Separator), dirSlash) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// fileStat returns the FileStat of the file at fullPath.
+func (s *FileSyncServer) fileStat(rel, fullPath string, fi os.FileInfo) (FileStat, error) {
+	st := FileStat{
+		Path:    rel,
+		Mode:    fi.Mode(),
+		Size:    fi.Size(),
+		ModTime: fi.ModTime(),
+	}
+	var err error
+	switch {
+	case fi.Mode()&os.ModeSymlink != 0:
+		st.Linkname, err = os.Readlink(fullPath)
+	case fi.Mode().IsRegular():
+		st.Digest, err = s.digest(fullPath, fi)
+	}
+	return st, err
+}
+
+// digest returns the digest of the content of the regular file at
+// fullPath. The digests are cached for as long as the size and modification
+// time of the files do not change.
+func (s *FileSyncServer) digest(fullPath string, fi os.FileInfo) (digest.Digest, error) {
+	s.mu.Lock()
+	d, ok := s.digests[fullPath]
+	s.mu.Unlock()
+	if ok && d.size == fi.Size() && d.modTime.Equal(fi.ModTime()) {
+		return d.digest, nil
+	}
+
+	f, err := os.Open(fullPath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	dgst, err := digest.FromReader(f)
+	if err != nil {
+		return "", err
+	}
+
+	s.mu.Lock()
+	s.digests[fullPath] = fileDigest{size: fi.Size(), modTime: fi.ModTime(), digest: dgst}
+	s.mu.Unlock()
+	return dgst, nil
+}
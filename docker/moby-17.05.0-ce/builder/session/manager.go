@@ -0,0 +1,151 @@
+package session
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/rpc"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	apierrors "github.com/docker/docker/api/errors"
+	"github.com/docker/docker/builder"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+// sessionWaitTimeout is how long a build waits for the client to open the
+// session of the build.
+const sessionWaitTimeout = 10 * time.Second
+
+// Manager keeps track of the sessions that clients open with the daemon.
+type Manager struct {
+	store *contentStore
+
+	mu       sync.Mutex
+	sessions map[string]*Session
+	updated  chan struct{} // closed when a session is added
+}
+
+// NewManager returns a Manager that keeps the content of the files that it
+// receives over sessions in the root directory.
+func NewManager(root string) (*Manager, error) {
+	store, err := newContentStore(filepath.Join(root, "content"))
+	if err != nil {
+		return nil, err
+	}
+	return &Manager{
+		store:    store,
+		sessions: make(map[string]*Session),
+		updated:  make(chan struct{}),
+	}, nil
+}
+
+// HandleHTTPRequest handles a request to open a session. It hijacks the
+// connection of the request, and keeps the session until the client closes
+// the connection.
+func (m *Manager) HandleHTTPRequest(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	id := r.Header.Get(HeaderSessionID)
+	if id == "" {
+		return apierrors.NewBadRequestError(errors.Errorf("%s header is required", HeaderSessionID))
+	}
+
+	m.mu.Lock()
+	_, exists := m.sessions[id]
+	m.mu.Unlock()
+	if exists {
+		return apierrors.NewRequestConflictError(errors.Errorf("session %s already exists", id))
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		return errors.New("error opening session, hijack connection missing")
+	}
+	conn, _, err := hijacker.Hijack()
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(conn, "HTTP/1.1 101 UPGRADED\r\nContent-Type: application/vnd.docker.raw-stream\r\nConnection: Upgrade\r\nUpgrade: tcp\r\n\r\n")
+
+	closed := make(chan struct{})
+	client := rpc.NewClient(&notifyConn{Conn: conn, closed: closed})
+
+	m.mu.Lock()
+	if _, exists := m.sessions[id]; exists {
+		m.mu.Unlock()
+		return client.Close()
+	}
+	m.sessions[id] = &Session{client: client}
+	close(m.updated)
+	m.updated = make(chan struct{})
+	m.mu.Unlock()
+
+	logrus.Debugf("session %s opened", id)
+	<-closed
+
+	m.mu.Lock()
+	delete(m.sessions, id)
+	m.mu.Unlock()
+	logrus.Debugf("session %s closed", id)
+	return client.Close()
+}
+
+// NewContext returns the build context that the client serves on the
+// session with the ID. It waits for the client to open the session if it
+// has not done so yet.
+func (m *Manager) NewContext(ctx context.Context, id string) (builder.ModifiableContext, error) {
+	if id == "" {
+		return nil, apierrors.NewBadRequestError(errors.New("a session is required for the build context"))
+	}
+	s, err := m.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return newSessionContext(s.client, m.store)
+}
+
+// Get returns the session with the ID. It waits for the client to open the
+// session if it has not done so yet.
+func (m *Manager) Get(ctx context.Context, id string) (*Session, error) {
+	ctx, cancel := context.WithTimeout(ctx, sessionWaitTimeout)
+	defer cancel()
+	for {
+		m.mu.Lock()
+		s, ok := m.sessions[id]
+		updated := m.updated
+		m.mu.Unlock()
+		if ok {
+			return s, nil
+		}
+
+		select {
+		case <-updated:
+		case <-ctx.Done():
+			return nil, errors.Errorf("no active session for %s", id)
+		}
+	}
+}
+
+// Prune removes the content of the files that builds received over
+// sessions, and returns the size of the content that it removed.
+func (m *Manager) Prune() (uint64, error) {
+	return m.store.prune()
+}
+
+// notifyConn closes a channel once reading from the connection fails, which
+// is when the client closed the session.
+type notifyConn struct {
+	net.Conn
+	once   sync.Once
+	closed chan struct{}
+}
+
+func (c *notifyConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	if err != nil {
+		c.once.Do(func() { close(c.closed) })
+	}
+	return n, err
+}
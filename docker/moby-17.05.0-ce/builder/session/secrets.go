@@ -0,0 +1,67 @@
+package session
+
+import (
+	"io/ioutil"
+	"net/rpc"
+
+	"github.com/pkg/errors"
+)
+
+// SecretRequest is a request for the value of a secret.
+type SecretRequest struct {
+	ID string
+}
+
+// SecretResponse is the response to a SecretRequest.
+type SecretResponse struct {
+	// Found is false when the client has no secret with the ID.
+	Found bool
+	Data  []byte
+}
+
+// SecretsServer serves the secrets of a build on a session. The file of a
+// secret is only read when the daemon asks for it.
+type SecretsServer struct {
+	files map[string]string
+}
+
+// NewSecretsServer returns a SecretsServer for the secrets in files, keyed
+// by their ID.
+func NewSecretsServer(files map[string]string) *SecretsServer {
+	return &SecretsServer{files: files}
+}
+
+// Register registers the service of the secrets on server.
+func (s *SecretsServer) Register(server *rpc.Server) error {
+	return server.RegisterName(secretsServiceName, &secretsService{s})
+}
+
+// secretsService holds the methods of SecretsServer that are called over
+// RPC.
+type secretsService struct {
+	s *SecretsServer
+}
+
+func (s *secretsService) Get(req SecretRequest, resp *SecretResponse) error {
+	file, ok := s.s.files[req.ID]
+	if !ok {
+		return nil
+	}
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read secret %s", req.ID)
+	}
+	resp.Found = true
+	resp.Data = data
+	return nil
+}
+
+// Secret returns the value of the secret with the ID that the client serves
+// on the session. It returns false if the client has no such secret.
+func (s *Session) Secret(id string) ([]byte, bool, error) {
+	var resp SecretResponse
+	if err := s.client.Call(secretsServiceName+".Get", SecretRequest{ID: id}, &resp); err != nil {
+		return nil, false, errors.Wrapf(err, "failed to get secret %s", id)
+	}
+	return resp.Data, resp.Found, nil
+}
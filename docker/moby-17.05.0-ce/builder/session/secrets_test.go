@@ -0,0 +1,47 @@
+package session
+
+import (
+	"io/ioutil"
+	"net"
+	"net/rpc"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/docker/pkg/testutil/assert"
+)
+
+// newServiceSession serves the services on a session, and returns the
+// session that the daemon calls them on.
+func newServiceSession(services ...Service) (*Session, func()) {
+	serverConn, clientConn := net.Pipe()
+	go ServeConn(serverConn, services...)
+	client := rpc.NewClient(clientConn)
+	return &Session{client: client}, func() { client.Close() }
+}
+
+func TestSessionSecret(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "session-secrets")
+	assert.NilError(t, err)
+	defer os.RemoveAll(tmp)
+	file := filepath.Join(tmp, "npmrc")
+	assert.NilError(t, ioutil.WriteFile(file, []byte("token"), 0600))
+
+	s, cleanup := newServiceSession(NewSecretsServer(map[string]string{
+		"npmrc": file,
+		"gone":  filepath.Join(tmp, "gone"),
+	}))
+	defer cleanup()
+
+	data, found, err := s.Secret("npmrc")
+	assert.NilError(t, err)
+	assert.Equal(t, found, true)
+	assert.Equal(t, string(data), "token")
+
+	_, found, err = s.Secret("other")
+	assert.NilError(t, err)
+	assert.Equal(t, found, false)
+
+	_, _, err = s.Secret("gone")
+	assert.Error(t, err, "failed to read secret gone")
+}
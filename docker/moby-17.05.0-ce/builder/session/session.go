@@ -0,0 +1,134 @@
+// Package session implements the transfer of a build context over a session
+// between the client and the daemon.
+//
+// Instead of sending the whole build context as a tarball with every build,
+// the client opens a session with the daemon and serves the files of the
+// context directory on it. The daemon only asks the client for the files
+// that the ADD and COPY instructions of the build use, and it keeps the
+// content of the files it received in a content addressable store, so that
+// unchanged files are not transferred again by the next builds.
+//
+// The client also serves the secrets and the SSH agents of the build on its
+// session, for the RUN instructions which mount them. They are only sent to
+// the daemon when an instruction mounts them.
+package session
+
+import (
+	"io"
+	"net/rpc"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	digest "github.com/opencontainers/go-digest"
+)
+
+// ClientSessionRemote is the remote context of a build whose context is
+// transferred over the session of the build.
+const ClientSessionRemote = "client-session"
+
+// HeaderSessionID is the header of a /session request that holds the ID of
+// the session.
+const HeaderSessionID = "X-Docker-Session-ID"
+
+// serviceName is the name of the RPC service that the client serves the
+// files of the build context with.
+const serviceName = "FileSync"
+
+// secretsServiceName and sshServiceName are the names of the RPC services
+// that the client serves the secrets and the SSH agents of a build with.
+const (
+	secretsServiceName = "Secrets"
+	sshServiceName     = "SSH"
+)
+
+// Service is a service that a client serves on a session.
+type Service interface {
+	// Register registers the RPC service on server.
+	Register(server *rpc.Server) error
+}
+
+// ServeConn serves the services on the connection of a session. It blocks
+// until the daemon closes the session.
+func ServeConn(conn io.ReadWriteCloser, services ...Service) error {
+	server := rpc.NewServer()
+	for _, s := range services {
+		if err := s.Register(server); err != nil {
+			return err
+		}
+	}
+	server.ServeConn(conn)
+	return nil
+}
+
+// Session is a session that a client opened with the daemon.
+type Session struct {
+	sshConns uint64 // last ID of the connections forwarded to an SSH agent
+	client   *rpc.Client
+}
+
+// readChunkSize is the maximum number of bytes of a file that are sent in a
+// response to a read request.
+const readChunkSize = 1 << 20
+
+// FileStat describes a file of the build context.
+type FileStat struct {
+	// Path is the slash separated path of the file, relative to the root
+	// of the build context.
+	Path     string
+	Mode     os.FileMode
+	Size     int64
+	ModTime  time.Time
+	Linkname string
+	// Digest is the digest of the content of a regular file.
+	Digest digest.Digest
+}
+
+// StatRequest is a request for the FileStat of a path. Symlinks in the path
+// are followed within the build context.
+type StatRequest struct {
+	Path string
+}
+
+// StatResponse is the response to a StatRequest.
+type StatResponse struct {
+	// Stat describes the file that the path resolves to.
+	Stat FileStat
+}
+
+// WalkRequest is a request for the files under a directory.
+type WalkRequest struct {
+	Path string
+}
+
+// WalkResponse is the response to a WalkRequest. The files are in lexical
+// order, and the directory itself is not included.
+type WalkResponse struct {
+	Files []FileStat
+}
+
+// ReadRequest is a request for the content of a regular file.
+type ReadRequest struct {
+	Path   string
+	Offset int64
+	// Digest is the expected digest of the file. The request fails if the
+	// content of the file has changed.
+	Digest digest.Digest
+}
+
+// ReadResponse is the response to a ReadRequest.
+type ReadResponse struct {
+	Data []byte
+	EOF  bool
+}
+
+// cleanPath returns the slash separated path p relative to the root of a
+// build context, without any parent directory references.
+func cleanPath(p string) string {
+	p = path.Clean("/" + filepath.ToSlash(p))[1:]
+	if p == "" {
+		return "."
+	}
+	return p
+}
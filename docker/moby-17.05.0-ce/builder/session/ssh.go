@@ -0,0 +1,245 @@
+package session
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"net/rpc"
+	"sync"
+	"sync/atomic"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/pkg/errors"
+)
+
+// sshMaxMessageSize is the maximum size of a message of the SSH agent
+// protocol, as enforced by OpenSSH.
+const sshMaxMessageSize = 256 * 1024
+
+// SSHAgentRequest is a request for whether the client serves the SSH agent
+// with the ID.
+type SSHAgentRequest struct {
+	ID string
+}
+
+// SSHAgentResponse is the response to an SSHAgentRequest.
+type SSHAgentResponse struct {
+	Found bool
+}
+
+// SSHMessageRequest is a request of the SSH agent protocol, which the client
+// forwards to the SSH agent with the ID. The requests of a connection to the
+// agent in a container are forwarded on their own connection to the agent.
+type SSHMessageRequest struct {
+	ID   string
+	Conn uint64
+	// Data is the message, with its length.
+	Data []byte
+}
+
+// SSHMessageResponse is the response of the SSH agent to an
+// SSHMessageRequest.
+type SSHMessageResponse struct {
+	Data []byte
+}
+
+// SSHCloseRequest is a request to close the connection to an SSH agent.
+type SSHCloseRequest struct {
+	Conn uint64
+}
+
+// SSHServer forwards the requests of the SSH agent protocol that the daemon
+// sends on a session to the SSH agents of a build.
+type SSHServer struct {
+	sockets map[string]string
+
+	mu    sync.Mutex // protects conns
+	conns map[uint64]net.Conn
+}
+
+// NewSSHServer returns an SSHServer for the SSH agents listening on the unix
+// sockets, keyed by their ID.
+func NewSSHServer(sockets map[string]string) *SSHServer {
+	return &SSHServer{
+		sockets: sockets,
+		conns:   make(map[uint64]net.Conn),
+	}
+}
+
+// Register registers the service of the SSH agents on server.
+func (s *SSHServer) Register(server *rpc.Server) error {
+	return server.RegisterName(sshServiceName, &sshService{s})
+}
+
+// conn returns the connection to the SSH agent with the ID for the
+// connection connID of the daemon.
+func (s *SSHServer) conn(id string, connID uint64) (net.Conn, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if conn, ok := s.conns[connID]; ok {
+		return conn, nil
+	}
+	socket, ok := s.sockets[id]
+	if !ok {
+		return nil, errors.Errorf("no ssh agent %s", id)
+	}
+	conn, err := net.Dial("unix", socket)
+	if err != nil {
+		return nil, err
+	}
+	s.conns[connID] = conn
+	return conn, nil
+}
+
+// sshService holds the methods of SSHServer that are called over RPC.
+type sshService struct {
+	s *SSHServer
+}
+
+func (s *sshService) Agent(req SSHAgentRequest, resp *SSHAgentResponse) error {
+	_, resp.Found = s.s.sockets[req.ID]
+	return nil
+}
+
+func (s *sshService) Message(req SSHMessageRequest, resp *SSHMessageResponse) error {
+	conn, err := s.s.conn(req.ID, req.Conn)
+	if err != nil {
+		return err
+	}
+	if _, err := conn.Write(req.Data); err != nil {
+		return err
+	}
+	resp.Data, err = readSSHMessage(conn)
+	return err
+}
+
+func (s *sshService) Close(req SSHCloseRequest, resp *struct{}) error {
+	s.s.mu.Lock()
+	conn, ok := s.s.conns[req.Conn]
+	delete(s.s.conns, req.Conn)
+	s.s.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	return conn.Close()
+}
+
+// readSSHMessage reads a message of the SSH agent protocol, which is
+// prefixed with its length.
+func readSSHMessage(r io.Reader) ([]byte, error) {
+	var header [4]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+	size := binary.BigEndian.Uint32(header[:])
+	if size > sshMaxMessageSize {
+		return nil, errors.Errorf("ssh agent message of %d bytes is too large", size)
+	}
+	msg := make([]byte, 4+size)
+	copy(msg, header[:])
+	if _, err := io.ReadFull(r, msg[4:]); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// ForwardSSH listens on the unix socket path, and forwards the connections
+// to it to the SSH agent with the ID that the client serves on the session.
+// It returns false if the client has no such agent. The forwarding stops
+// when the returned Closer is closed.
+func (s *Session) ForwardSSH(id, path string) (io.Closer, bool, error) {
+	var resp SSHAgentResponse
+	if err := s.client.Call(sshServiceName+".Agent", SSHAgentRequest{ID: id}, &resp); err != nil {
+		return nil, false, errors.Wrapf(err, "failed to get ssh agent %s", id)
+	}
+	if !resp.Found {
+		return nil, false, nil
+	}
+
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, false, err
+	}
+	f := &sshForwarder{
+		s:     s,
+		id:    id,
+		l:     l,
+		conns: make(map[net.Conn]struct{}),
+	}
+	go f.serve()
+	return f, true, nil
+}
+
+// sshForwarder forwards the connections to a unix socket to an SSH agent
+// that the client serves on a session.
+type sshForwarder struct {
+	s  *Session
+	id string
+	l  net.Listener
+
+	mu     sync.Mutex // protects conns and closed
+	conns  map[net.Conn]struct{}
+	closed bool
+}
+
+func (f *sshForwarder) serve() {
+	for {
+		conn, err := f.l.Accept()
+		if err != nil {
+			return
+		}
+		f.mu.Lock()
+		if f.closed {
+			f.mu.Unlock()
+			conn.Close()
+			return
+		}
+		f.conns[conn] = struct{}{}
+		f.mu.Unlock()
+		go f.handle(conn)
+	}
+}
+
+// handle forwards the requests read from conn to the agent, and writes
+// their responses back.
+func (f *sshForwarder) handle(conn net.Conn) {
+	connID := atomic.AddUint64(&f.s.sshConns, 1)
+	defer func() {
+		conn.Close()
+		f.mu.Lock()
+		delete(f.conns, conn)
+		f.mu.Unlock()
+		if err := f.s.client.Call(sshServiceName+".Close", SSHCloseRequest{Conn: connID}, &struct{}{}); err != nil {
+			logrus.Debugf("failed to close the connection to ssh agent %s: %v", f.id, err)
+		}
+	}()
+
+	for {
+		msg, err := readSSHMessage(conn)
+		if err != nil {
+			if err != io.EOF {
+				logrus.Debugf("failed to read from the connection to ssh agent %s: %v", f.id, err)
+			}
+			return
+		}
+		var resp SSHMessageResponse
+		if err := f.s.client.Call(sshServiceName+".Message", SSHMessageRequest{ID: f.id, Conn: connID, Data: msg}, &resp); err != nil {
+			logrus.Debugf("failed to forward a request to ssh agent %s: %v", f.id, err)
+			return
+		}
+		if _, err := conn.Write(resp.Data); err != nil {
+			return
+		}
+	}
+}
+
+// Close stops listening and closes the connections being forwarded.
+func (f *sshForwarder) Close() error {
+	f.mu.Lock()
+	f.closed = true
+	for conn := range f.conns {
+		conn.Close()
+	}
+	f.mu.Unlock()
+	return f.l.Close()
+}
@@ -0,0 +1,83 @@
+// +build !windows
+
+package session
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/docker/pkg/testutil/assert"
+)
+
+func sshMessage(data string) []byte {
+	msg := make([]byte, 4+len(data))
+	binary.BigEndian.PutUint32(msg, uint32(len(data)))
+	copy(msg[4:], data)
+	return msg
+}
+
+// serveTestAgent answers the requests to an SSH agent on l with the request
+// prefixed with "re:".
+func serveTestAgent(l net.Listener) {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			defer conn.Close()
+			for {
+				msg, err := readSSHMessage(conn)
+				if err != nil {
+					return
+				}
+				if _, err := conn.Write(sshMessage("re:" + string(msg[4:]))); err != nil {
+					return
+				}
+			}
+		}()
+	}
+}
+
+func TestSessionForwardSSH(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "session-ssh")
+	assert.NilError(t, err)
+	defer os.RemoveAll(tmp)
+
+	agent, err := net.Listen("unix", filepath.Join(tmp, "agent.sock"))
+	assert.NilError(t, err)
+	defer agent.Close()
+	go serveTestAgent(agent)
+
+	s, cleanup := newServiceSession(NewSSHServer(map[string]string{"default": agent.Addr().String()}))
+	defer cleanup()
+
+	_, found, err := s.ForwardSSH("other", filepath.Join(tmp, "other.sock"))
+	assert.NilError(t, err)
+	assert.Equal(t, found, false)
+
+	socket := filepath.Join(tmp, "forward.sock")
+	forwarder, found, err := s.ForwardSSH("default", socket)
+	assert.NilError(t, err)
+	assert.Equal(t, found, true)
+	defer forwarder.Close()
+
+	for _, req := range []string{"identities", "sign"} {
+		conn, err := net.Dial("unix", socket)
+		assert.NilError(t, err)
+		_, err = conn.Write(sshMessage(req))
+		assert.NilError(t, err)
+		resp, err := readSSHMessage(conn)
+		assert.NilError(t, err)
+		assert.Equal(t, string(resp[4:]), "re:"+req)
+		conn.Close()
+	}
+
+	assert.NilError(t, forwarder.Close())
+	_, err = net.Dial("unix", socket)
+	assert.NotNil(t, err)
+}
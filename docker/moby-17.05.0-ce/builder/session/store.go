@@ -0,0 +1,94 @@
+package session
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	digest "github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+)
+
+// contentStore keeps the content of the files of build contexts by digest,
+// so that the files are only transferred again when their content changes.
+type contentStore struct {
+	root string
+	// mu is held for reading while content is added or used, and for
+	// writing while the store is pruned.
+	mu sync.RWMutex
+}
+
+func newContentStore(root string) (*contentStore, error) {
+	if err := os.MkdirAll(root, 0700); err != nil {
+		return nil, err
+	}
+	return &contentStore{root: root}, nil
+}
+
+func (s *contentStore) path(dgst digest.Digest) string {
+	return filepath.Join(s.root, string(dgst.Algorithm()), dgst.Hex())
+}
+
+// open opens the content with the digest. If the store does not have the
+// content yet, fetch is called to write it. The caller must hold s.mu for
+// reading.
+func (s *contentStore) open(dgst digest.Digest, fetch func(w io.Writer) error) (*os.File, error) {
+	if err := dgst.Validate(); err != nil {
+		return nil, err
+	}
+	p := s.path(dgst)
+	f, err := os.Open(p)
+	if err == nil || !os.IsNotExist(err) {
+		return f, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(p), 0700); err != nil {
+		return nil, err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(p), ".tmp-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+
+	verifier := dgst.Verifier()
+	err = fetch(io.MultiWriter(tmp, verifier))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !verifier.Verified() {
+		return nil, errors.Errorf("content does not match digest %s", dgst)
+	}
+	if err := os.Rename(tmp.Name(), p); err != nil {
+		return nil, err
+	}
+	return os.Open(p)
+}
+
+// prune removes all the content from the store, and returns the size of the
+// content that it removed.
+func (s *contentStore) prune() (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var size uint64
+	err := filepath.Walk(s.root, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !fi.Mode().IsRegular() {
+			return nil
+		}
+		if err := os.Remove(p); err != nil {
+			return err
+		}
+		size += uint64(fi.Size())
+		return nil
+	})
+	return size, err
+}
@@ -115,7 +115,10 @@ type containerOptions struct {
 	healthTimeout      time.Duration
 	healthStartPeriod  time.Duration
 	healthRetries      int
+	healthMemPressure  float64
+	healthMemPressureD time.Duration
 	runtime            string
+	lxcfs              string
 	autoRemove         bool
 	init               bool
 
@@ -234,6 +237,10 @@ func addFlags(flags *pflag.FlagSet) *containerOptions {
 	flags.DurationVar(&copts.healthTimeout, "health-timeout", 0, "Maximum time to allow one check to run (ns|us|ms|s|m|h) (default 0s)")
 	flags.DurationVar(&copts.healthStartPeriod, "health-start-period", 0, "Start period for the container to initialize before starting health-retries countdown (ns|us|ms|s|m|h) (default 0s)")
 	flags.SetAnnotation("health-start-period", "version", []string{"1.29"})
+	flags.Float64Var(&copts.healthMemPressure, "health-memory-pressure", 0, "Memory pressure, in percent of the time, above which the check fails")
+	flags.SetAnnotation("health-memory-pressure", "version", []string{"1.29"})
+	flags.DurationVar(&copts.healthMemPressureD, "health-memory-pressure-duration", 0, "Time the memory pressure must stay above --health-memory-pressure for the check to fail (ns|us|ms|s|m|h) (default 0s)")
+	flags.SetAnnotation("health-memory-pressure-duration", "version", []string{"1.29"})
 	flags.BoolVar(&copts.noHealthcheck, "no-healthcheck", false, "Disable any container-specified HEALTHCHECK")
 
 	// Resource management
@@ -280,6 +287,8 @@ func addFlags(flags *pflag.FlagSet) *containerOptions {
 	flags.Var(&copts.shmSize, "shm-size", "Size of /dev/shm")
 	flags.StringVar(&copts.utsMode, "uts", "", "UTS namespace to use")
 	flags.StringVar(&copts.runtime, "runtime", "", "Runtime to use for this container")
+	flags.StringVar(&copts.lxcfs, "lxcfs", "", "Mount the lxcfs /proc files into the container (off, auto or strict)")
+	flags.SetAnnotation("lxcfs", "version", []string{"1.29"})
 
 	flags.BoolVar(&copts.init, "init", false, "Run an init inside the container that forwards signals and reaps processes")
 	flags.SetAnnotation("init", "version", []string{"1.25"})
@@ -424,6 +433,11 @@ func parse(flags *pflag.FlagSet, copts *containerOptions) (*containerConfig, err
 		return nil, errors.Errorf("--ipc: invalid IPC mode")
 	}
 
+	lxcfsMode := container.LxcfsMode(copts.lxcfs)
+	if !lxcfsMode.Valid() {
+		return nil, errors.Errorf("--lxcfs: invalid lxcfs mode")
+	}
+
 	pidMode := container.PidMode(copts.pidMode)
 	if !pidMode.Valid() {
 		return nil, errors.Errorf("--pid: invalid PID mode")
@@ -465,7 +479,9 @@ func parse(flags *pflag.FlagSet, copts *containerOptions) (*containerConfig, err
 		copts.healthInterval != 0 ||
 		copts.healthTimeout != 0 ||
 		copts.healthStartPeriod != 0 ||
-		copts.healthRetries != 0
+		copts.healthRetries != 0 ||
+		copts.healthMemPressure != 0 ||
+		copts.healthMemPressureD != 0
 	if copts.noHealthcheck {
 		if haveHealthSettings {
 			return nil, errors.Errorf("--no-healthcheck conflicts with --health-* options")
@@ -490,13 +506,21 @@ func parse(flags *pflag.FlagSet, copts *containerOptions) (*containerConfig, err
 		if copts.healthStartPeriod < 0 {
 			return nil, fmt.Errorf("--health-start-period cannot be negative")
 		}
+		if copts.healthMemPressure < 0 || copts.healthMemPressure > 100 {
+			return nil, errors.Errorf("--health-memory-pressure should be between 0 and 100")
+		}
+		if copts.healthMemPressureD < 0 {
+			return nil, errors.Errorf("--health-memory-pressure-duration cannot be negative")
+		}
 
 		healthConfig = &container.HealthConfig{
-			Test:        probe,
-			Interval:    copts.healthInterval,
-			Timeout:     copts.healthTimeout,
-			StartPeriod: copts.healthStartPeriod,
-			Retries:     copts.healthRetries,
+			Test:                   probe,
+			Interval:               copts.healthInterval,
+			Timeout:                copts.healthTimeout,
+			StartPeriod:            copts.healthStartPeriod,
+			Retries:                copts.healthRetries,
+			MemoryPressure:         copts.healthMemPressure,
+			MemoryPressureDuration: copts.healthMemPressureD,
 		}
 	}
 
@@ -601,6 +625,7 @@ func parse(flags *pflag.FlagSet, copts *containerOptions) (*containerConfig, err
 		Tmpfs:          tmpfs,
 		Sysctls:        copts.sysctls.GetAll(),
 		Runtime:        copts.runtime,
+		Lxcfs:          lxcfsMode,
 		Mounts:         mounts,
 	}
 
@@ -413,6 +413,18 @@ func TestParseModes(t *testing.T) {
 	if !hostconfig.UTSMode.Valid() {
 		t.Fatalf("Expected a valid UTSMode, got %v", hostconfig.UTSMode)
 	}
+	// lxcfs ko
+	if _, _, _, err := parseRun([]string{"--lxcfs=always", "img", "cmd"}); err == nil || err.Error() != "--lxcfs: invalid lxcfs mode" {
+		t.Fatalf("Expected an error with message '--lxcfs: invalid lxcfs mode', got %v", err)
+	}
+	// lxcfs ok
+	_, hostconfig, _, err = parseRun([]string{"--lxcfs=strict", "img", "cmd"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !hostconfig.Lxcfs.IsStrict() {
+		t.Fatalf("Expected a strict Lxcfs mode, got %v", hostconfig.Lxcfs)
+	}
 	// shm-size ko
 	expectedErr := `invalid argument "a128m" for --shm-size=a128m: invalid size: 'a128m'`
 	if _, _, _, err = parseRun([]string{"--shm-size=a128m", "img", "cmd"}); err == nil || err.Error() != expectedErr {
@@ -505,6 +517,14 @@ func TestParseHealth(t *testing.T) {
 	if health.Timeout != 2*time.Second || health.Retries != 3 || health.Interval != 4500*time.Millisecond || health.StartPeriod != 5*time.Second {
 		t.Fatalf("--health-*: got %#v", health)
 	}
+
+	health = checkOk("--health-memory-pressure=20", "--health-memory-pressure-duration=1m", "img", "cmd")
+	if len(health.Test) != 0 || health.MemoryPressure != 20 || health.MemoryPressureDuration != time.Minute {
+		t.Fatalf("--health-memory-pressure: got %#v", health)
+	}
+
+	checkError("--health-memory-pressure should be between 0 and 100",
+		"--health-memory-pressure=120", "img", "cmd")
 }
 
 func TestParseLoggingOpts(t *testing.T) {
@@ -33,7 +33,7 @@ CONTAINER ID        IMAGE               COMMAND             LOCAL VOLUMES
 
 Local Volumes space usage:
 
-VOLUME NAME         LINKS               SIZE
+VOLUME NAME         LINKS               SIZE                LAST USED
 `,
 		},
 	}
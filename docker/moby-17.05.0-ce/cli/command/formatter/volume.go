@@ -3,6 +3,7 @@ package formatter
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/docker/docker/api/types"
 	units "github.com/docker/go-units"
@@ -15,6 +16,7 @@ const (
 	volumeNameHeader = "VOLUME NAME"
 	mountpointHeader = "MOUNTPOINT"
 	linksHeader      = "LINKS"
+	lastUsedHeader   = "LAST USED"
 	// Status header ?
 )
 
@@ -73,6 +75,7 @@ func newVolumeContext() *volumeContext {
 		"Labels":     labelsHeader,
 		"Links":      linksHeader,
 		"Size":       sizeHeader,
+		"LastUsed":   lastUsedHeader,
 	}
 	return &volumeCtx
 }
@@ -124,8 +127,19 @@ func (c *volumeContext) Links() string {
 }
 
 func (c *volumeContext) Size() string {
-	if c.v.UsageData == nil {
+	if c.v.UsageData == nil || c.v.UsageData.Size < 0 {
 		return "N/A"
 	}
 	return units.HumanSize(float64(c.v.UsageData.Size))
 }
+
+func (c *volumeContext) LastUsed() string {
+	if c.v.UsageData == nil || c.v.UsageData.LastUsed == "" {
+		return "N/A"
+	}
+	lastUsed, err := time.Parse(time.RFC3339Nano, c.v.UsageData.LastUsed)
+	if err != nil {
+		return "N/A"
+	}
+	return units.HumanDuration(time.Now().UTC().Sub(lastUsed)) + " ago"
+}
@@ -4,6 +4,7 @@ import (
 	"archive/tar"
 	"bufio"
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -11,6 +12,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"strconv"
 	"time"
 
 	"github.com/docker/distribution/reference"
@@ -18,6 +20,7 @@ import (
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/builder/dockerignore"
+	"github.com/docker/docker/builder/session"
 	"github.com/docker/docker/cli"
 	"github.com/docker/docker/cli/command"
 	"github.com/docker/docker/cli/command/image/build"
@@ -60,11 +63,18 @@ type buildOptions struct {
 	forceRm        bool
 	pull           bool
 	cacheFrom      []string
+	cacheTo        string
 	compress       bool
 	securityOpt    []string
 	networkMode    string
 	squash         bool
 	target         string
+	parallel       int
+	secrets        []string
+	ssh            []string
+	stream         bool
+	output         string
+	sourceEpoch    string
 }
 
 // NewBuildCommand creates a new `docker build` command
@@ -110,13 +120,27 @@ func NewBuildCommand(dockerCli *command.DockerCli) *cobra.Command {
 	flags.BoolVar(&options.forceRm, "force-rm", false, "Always remove intermediate containers")
 	flags.BoolVarP(&options.quiet, "quiet", "q", false, "Suppress the build output and print image ID on success")
 	flags.BoolVar(&options.pull, "pull", false, "Always attempt to pull a newer version of the image")
-	flags.StringSliceVar(&options.cacheFrom, "cache-from", []string{}, "Images to consider as cache sources")
+	flags.StringSliceVar(&options.cacheFrom, "cache-from", []string{}, "Images to consider as cache sources, or build caches to import (format: \"registry://<repository>:<tag>\")")
+	flags.StringVar(&options.cacheTo, "cache-to", "", "Build cache to export to a registry (format: \"registry://<repository>:<tag>\")")
+	flags.SetAnnotation("cache-to", "version", []string{"1.29"})
 	flags.BoolVar(&options.compress, "compress", false, "Compress the build context using gzip")
 	flags.StringSliceVar(&options.securityOpt, "security-opt", []string{}, "Security options")
 	flags.StringVar(&options.networkMode, "network", "default", "Set the networking mode for the RUN instructions during build")
 	flags.SetAnnotation("network", "version", []string{"1.25"})
 	flags.Var(&options.extraHosts, "add-host", "Add a custom host-to-IP mapping (host:ip)")
 	flags.StringVar(&options.target, "target", "", "Set the target build stage to build.")
+	flags.IntVar(&options.parallel, "parallel", 1, "Maximum number of independent build stages to build at the same time")
+	flags.SetAnnotation("parallel", "version", []string{"1.29"})
+	flags.StringArrayVar(&options.secrets, "secret", []string{}, "Secret file to expose to RUN --mount=type=secret (format: \"id=mysecret,src=/local/secret\")")
+	flags.SetAnnotation("secret", "version", []string{"1.29"})
+	flags.StringArrayVar(&options.ssh, "ssh", []string{}, "SSH agent socket to expose to RUN --mount=type=ssh (format: \"default|<id>[=<socket>]\")")
+	flags.SetAnnotation("ssh", "version", []string{"1.29"})
+	flags.BoolVar(&options.stream, "stream", false, "Send the files of the build context on demand, and only the files that changed since the last build")
+	flags.SetAnnotation("stream", "version", []string{"1.29"})
+	flags.StringVarP(&options.output, "output", "o", "", "Export the build result instead of tagging an image (format: \"type=local|tar[,dest=path][,src=path]\")")
+	flags.SetAnnotation("output", "version", []string{"1.29"})
+	flags.StringVar(&options.sourceEpoch, "source-date-epoch", "", "Build a reproducible image, with file times clamped to and images created at this Unix time (default $SOURCE_DATE_EPOCH)")
+	flags.SetAnnotation("source-date-epoch", "version", []string{"1.29"})
 
 	command.AddTrustVerificationFlags(flags)
 
@@ -153,11 +177,43 @@ func runBuild(dockerCli *command.DockerCli, options buildOptions) error {
 		relDockerfile string
 		progBuff      io.Writer
 		buildBuff     io.Writer
+		syncServer    *session.FileSyncServer
+		output        *buildOutput
 	)
 
 	specifiedContext := options.context
 	progBuff = dockerCli.Out()
 	buildBuff = dockerCli.Out()
+	resultOut := io.Writer(dockerCli.Out())
+	isTerminal := dockerCli.Out().IsTerminal()
+
+	if options.output != "" {
+		var err error
+		output, err = parseBuildOutput(options.output)
+		if err != nil {
+			return err
+		}
+		if len(options.tags.GetAll()) > 0 {
+			return errors.New("--output can't be used with --tag")
+		}
+		if output.toStdout() {
+			if dockerCli.Out().IsTerminal() {
+				return errors.New("Cowardly refusing to write the build result to a terminal. Use dest= or redirect.")
+			}
+			// The build result is written to stdout, so the build
+			// output goes to stderr.
+			progBuff = dockerCli.Err()
+			buildBuff = dockerCli.Err()
+			resultOut = dockerCli.Err()
+			isTerminal = false
+		}
+	}
+
+	sourceDateEpoch, err := parseSourceDateEpoch(options.sourceEpoch)
+	if err != nil {
+		return err
+	}
+
 	if options.quiet {
 		progBuff = bytes.NewBuffer(nil)
 		buildBuff = bytes.NewBuffer(nil)
@@ -195,6 +251,15 @@ func runBuild(dockerCli *command.DockerCli, options buildOptions) error {
 		contextDir = tempDir
 	}
 
+	if options.stream {
+		if buildCtx != nil || dockerfileCtx != nil {
+			return errors.New("--stream is only supported for a build context and a Dockerfile in a directory")
+		}
+		if command.IsTrusted() {
+			return errors.New("--stream is not supported with content trust")
+		}
+	}
+
 	if buildCtx == nil {
 		// And canonicalize dockerfile name to a platform-independent one
 		relDockerfile, err = archive.CanonicalTarNameForPath(relDockerfile)
@@ -235,16 +300,23 @@ func runBuild(dockerCli *command.DockerCli, options buildOptions) error {
 			excludes = append(excludes, "!"+relDockerfile)
 		}
 
-		compression := archive.Uncompressed
-		if options.compress {
-			compression = archive.Gzip
-		}
-		buildCtx, err = archive.TarWithOptions(contextDir, &archive.TarOptions{
-			Compression:     compression,
-			ExcludePatterns: excludes,
-		})
-		if err != nil {
-			return err
+		if options.stream {
+			syncServer, err = session.NewFileSyncServer(contextDir, excludes)
+			if err != nil {
+				return err
+			}
+		} else {
+			compression := archive.Uncompressed
+			if options.compress {
+				compression = archive.Gzip
+			}
+			buildCtx, err = archive.TarWithOptions(contextDir, &archive.TarOptions{
+				Compression:     compression,
+				ExcludePatterns: excludes,
+			})
+			if err != nil {
+				return err
+			}
 		}
 	}
 
@@ -274,7 +346,19 @@ func runBuild(dockerCli *command.DockerCli, options buildOptions) error {
 		progressOutput = &lastProgressOutput{output: progressOutput}
 	}
 
-	var body io.Reader = progress.NewProgressReader(buildCtx, progressOutput, 0, "", "Sending build context to Docker daemon")
+	var body io.Reader
+	if buildCtx != nil {
+		body = progress.NewProgressReader(buildCtx, progressOutput, 0, "", "Sending build context to Docker daemon")
+	}
+
+	secrets, err := parseBuildSecrets(options.secrets)
+	if err != nil {
+		return err
+	}
+	sshSockets, err := parseBuildSSH(options.ssh)
+	if err != nil {
+		return err
+	}
 
 	authConfigs, _ := dockerCli.GetAllCredentials()
 	buildOptions := types.ImageBuildOptions{
@@ -300,11 +384,40 @@ func runBuild(dockerCli *command.DockerCli, options buildOptions) error {
 		AuthConfigs:    authConfigs,
 		Labels:         runconfigopts.ConvertKVStringsToMap(options.labels.GetAll()),
 		CacheFrom:      options.cacheFrom,
+		CacheTo:        options.cacheTo,
 		SecurityOpt:    options.securityOpt,
 		NetworkMode:    options.networkMode,
 		Squash:         options.squash,
 		ExtraHosts:     options.extraHosts.GetAll(),
 		Target:         options.target,
+		Parallel:       options.parallel,
+	}
+	buildOptions.SourceDateEpoch = sourceDateEpoch
+	if output != nil {
+		buildOptions.ExportPath = output.src
+	}
+
+	// The daemon asks for the files of the build context, the secrets and
+	// the SSH agents over the session, for as long as the build runs.
+	var services []session.Service
+	if syncServer != nil {
+		buildOptions.RemoteContext = session.ClientSessionRemote
+		services = append(services, syncServer)
+	}
+	if len(secrets) > 0 {
+		services = append(services, session.NewSecretsServer(secrets))
+	}
+	if len(sshSockets) > 0 {
+		services = append(services, session.NewSSHServer(sshSockets))
+	}
+	if len(services) > 0 {
+		buildOptions.SessionID = stringid.GenerateRandomID()
+		conn, err := dockerCli.Client().DialSession(ctx, buildOptions.SessionID)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		go session.ServeConn(conn, services...)
 	}
 
 	response, err := dockerCli.Client().ImageBuild(ctx, body, buildOptions)
@@ -316,7 +429,24 @@ func runBuild(dockerCli *command.DockerCli, options buildOptions) error {
 	}
 	defer response.Body.Close()
 
-	err = jsonmessage.DisplayJSONMessagesStream(response.Body, buildBuff, dockerCli.Out().FD(), dockerCli.Out().IsTerminal(), nil)
+	var (
+		exporter    *buildExporter
+		auxCallback func(*json.RawMessage)
+	)
+	if output != nil {
+		exporter, err = startBuildExport(output, dockerCli.Out())
+		if err != nil {
+			return err
+		}
+		auxCallback = exporter.handleAux
+	}
+
+	err = jsonmessage.DisplayJSONMessagesStream(response.Body, buildBuff, dockerCli.Out().FD(), isTerminal, auxCallback)
+	if exporter != nil {
+		if exportErr := exporter.wait(err); err == nil && exportErr != nil {
+			return errors.Wrap(exportErr, "failed to export build result")
+		}
+	}
 	if err != nil {
 		if jerr, ok := err.(*jsonmessage.JSONError); ok {
 			// If no error code is set, default to 1
@@ -344,7 +474,7 @@ func runBuild(dockerCli *command.DockerCli, options buildOptions) error {
 	// Everything worked so if -q was provided the output from the daemon
 	// should be just the image ID and we'll print that to stdout.
 	if options.quiet {
-		fmt.Fprintf(dockerCli.Out(), "%s", buildBuff)
+		fmt.Fprintf(resultOut, "%s", buildBuff)
 	}
 
 	if command.IsTrusted() {
@@ -360,6 +490,22 @@ func runBuild(dockerCli *command.DockerCli, options buildOptions) error {
 	return nil
 }
 
+// parseSourceDateEpoch parses the --source-date-epoch option, which defaults to
+// the SOURCE_DATE_EPOCH environment variable of reproducible builds.
+func parseSourceDateEpoch(value string) (*int64, error) {
+	if value == "" {
+		value = os.Getenv("SOURCE_DATE_EPOCH")
+	}
+	if value == "" {
+		return nil, nil
+	}
+	epoch, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || epoch < 0 {
+		return nil, errors.Errorf("invalid source date epoch %q: must be a number of seconds since the Unix epoch", value)
+	}
+	return &epoch, nil
+}
+
 func addDockerfileToBuildContext(dockerfileCtx io.ReadCloser, buildCtx io.ReadCloser) (io.ReadCloser, string, error) {
 	file, err := ioutil.ReadAll(dockerfileCtx)
 	dockerfileCtx.Close()
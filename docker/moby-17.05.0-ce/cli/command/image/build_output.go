@@ -0,0 +1,219 @@
+package image
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/archive"
+	"github.com/pkg/errors"
+)
+
+const (
+	buildOutputLocal = "local"
+	buildOutputTar   = "tar"
+)
+
+// buildOutput is where the result of a build is exported to with --output.
+type buildOutput struct {
+	typ string
+	// dest is the directory of a local output, or the file of a tar
+	// output. A tar archive is written to stdout if dest is empty or "-".
+	dest string
+	// src is the path in the build result to export.
+	src string
+}
+
+// parseBuildOutput parses --output type=<local|tar>[,dest=<path>][,src=<path>].
+func parseBuildOutput(value string) (*buildOutput, error) {
+	csvReader := csv.NewReader(strings.NewReader(value))
+	fields, err := csvReader.Read()
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid output %q", value)
+	}
+
+	out := &buildOutput{src: "/"}
+	for _, field := range fields {
+		parts := strings.SplitN(field, "=", 2)
+		if len(parts) != 2 {
+			return nil, errors.Errorf("invalid field '%s' must be a key=value pair", field)
+		}
+		switch strings.ToLower(parts[0]) {
+		case "type":
+			out.typ = strings.ToLower(parts[1])
+		case "dest", "destination":
+			out.dest = parts[1]
+		case "src", "source":
+			out.src = parts[1]
+		default:
+			return nil, errors.Errorf("unexpected key '%s' in '%s'", parts[0], field)
+		}
+	}
+
+	switch out.typ {
+	case buildOutputLocal:
+		if out.dest == "" || out.dest == "-" {
+			return nil, errors.Errorf("invalid output %q: dest is required for type=local", value)
+		}
+	case buildOutputTar:
+	case "":
+		return nil, errors.Errorf("invalid output %q: type is required", value)
+	default:
+		return nil, errors.Errorf("invalid output %q: unsupported type %s", value, out.typ)
+	}
+	if out.src == "" {
+		return nil, errors.Errorf("invalid output %q: src must not be empty", value)
+	}
+	return out, nil
+}
+
+// toStdout returns whether the build result is written to stdout.
+func (o *buildOutput) toStdout() bool {
+	return o.typ == buildOutputTar && (o.dest == "" || o.dest == "-")
+}
+
+// buildExporter writes the tar archive of the build result, which the daemon
+// sends in the aux field of the build output, to a build output. Nothing is
+// left at the destination of the output if the build or the export fails.
+type buildExporter struct {
+	pw   *io.PipeWriter
+	done chan error
+	err  error
+	// commit moves the exported result to the destination of the output,
+	// and abort removes it, once it is written.
+	commit func() error
+	abort  func()
+}
+
+// startBuildExport starts to export the build result to out. stdout is
+// where a tar archive is written to if the output has no destination file.
+func startBuildExport(out *buildOutput, stdout io.Writer) (*buildExporter, error) {
+	e := &buildExporter{
+		done:   make(chan error, 1),
+		commit: func() error { return nil },
+		abort:  func() {},
+	}
+	var write func(r io.Reader) error
+	switch out.typ {
+	case buildOutputLocal:
+		// The result is extracted next to the destination, which is only
+		// updated once the build succeeded.
+		parent := filepath.Dir(out.dest)
+		if err := os.MkdirAll(parent, 0755); err != nil {
+			return nil, err
+		}
+		tmp, err := ioutil.TempDir(parent, "."+filepath.Base(out.dest)+"-")
+		if err != nil {
+			return nil, err
+		}
+		write = func(r io.Reader) error {
+			return archive.Untar(r, tmp, &archive.TarOptions{NoLchown: true})
+		}
+		e.commit = func() error {
+			defer os.RemoveAll(tmp)
+			return mergeDir(tmp, out.dest)
+		}
+		e.abort = func() {
+			os.RemoveAll(tmp)
+		}
+	case buildOutputTar:
+		if out.toStdout() {
+			write = func(r io.Reader) error {
+				_, err := io.Copy(stdout, r)
+				return err
+			}
+			break
+		}
+		f, err := os.Create(out.dest)
+		if err != nil {
+			return nil, err
+		}
+		write = func(r io.Reader) error {
+			_, err := io.Copy(f, r)
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			return err
+		}
+		e.abort = func() {
+			os.Remove(out.dest)
+		}
+	}
+
+	pr, pw := io.Pipe()
+	e.pw = pw
+	go func() {
+		err := write(pr)
+		pr.CloseWithError(err)
+		e.done <- err
+	}()
+	return e, nil
+}
+
+// mergeDir moves the content of the directory src to the directory dst,
+// replacing the files of dst with the ones of src and merging their
+// subdirectories.
+func mergeDir(src, dst string) error {
+	if err := os.MkdirAll(dst, 0755); err != nil {
+		return err
+	}
+	entries, err := ioutil.ReadDir(src)
+	if err != nil {
+		return err
+	}
+	for _, fi := range entries {
+		srcPath, dstPath := filepath.Join(src, fi.Name()), filepath.Join(dst, fi.Name())
+		if fi.IsDir() {
+			if dfi, err := os.Lstat(dstPath); err == nil && dfi.IsDir() {
+				if err := mergeDir(srcPath, dstPath); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if err := os.RemoveAll(dstPath); err != nil {
+			return err
+		}
+		if err := os.Rename(srcPath, dstPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// handleAux handles the aux messages of the build output.
+func (e *buildExporter) handleAux(msg *json.RawMessage) {
+	if e.err != nil {
+		return
+	}
+	var data types.BuildExportData
+	if err := json.Unmarshal(*msg, &data); err != nil {
+		e.err = err
+		return
+	}
+	if _, err := e.pw.Write(data.Data); err != nil {
+		e.err = err
+	}
+}
+
+// wait waits for the build result to be written, and returns the error of
+// the export. The result is moved to the destination of the output if
+// neither the build, which failed with buildErr if not nil, nor the export
+// failed, and removed otherwise.
+func (e *buildExporter) wait(buildErr error) error {
+	e.pw.Close()
+	err := <-e.done
+	if e.err != nil {
+		err = e.err
+	}
+	if buildErr != nil || err != nil {
+		e.abort()
+		return err
+	}
+	return e.commit()
+}
@@ -0,0 +1,164 @@
+package image
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/pkg/testutil/assert"
+)
+
+func TestParseBuildOutput(t *testing.T) {
+	out, err := parseBuildOutput("type=local,dest=./out")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, out, &buildOutput{typ: "local", dest: "./out", src: "/"})
+	assert.Equal(t, out.toStdout(), false)
+
+	out, err = parseBuildOutput("type=tar,src=/go/bin")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, out, &buildOutput{typ: "tar", src: "/go/bin"})
+	assert.Equal(t, out.toStdout(), true)
+
+	out, err = parseBuildOutput("type=tar,dest=out.tar")
+	assert.NilError(t, err)
+	assert.Equal(t, out.toStdout(), false)
+
+	testCases := []struct {
+		value         string
+		expectedError string
+	}{
+		{"dest=./out", "type is required"},
+		{"type=image", "unsupported type image"},
+		{"type=local", "dest is required for type=local"},
+		{"type=local,dest=-", "dest is required for type=local"},
+		{"type=tar,src=", "src must not be empty"},
+		{"type=tar,dest", "invalid field 'dest' must be a key=value pair"},
+		{"type=tar,compression=gzip", "unexpected key 'compression'"},
+	}
+	for _, tc := range testCases {
+		_, err := parseBuildOutput(tc.value)
+		assert.Error(t, err, tc.expectedError)
+	}
+}
+
+// exportTestArchive sends the tar archive of dir to the exporter in aux
+// messages, as the daemon does.
+func exportTestArchive(t *testing.T, e *buildExporter, dir string) {
+	rc, err := archive.Tar(dir, archive.Uncompressed)
+	assert.NilError(t, err)
+	defer rc.Close()
+	data, err := ioutil.ReadAll(rc)
+	assert.NilError(t, err)
+
+	for len(data) > 0 {
+		n := 100
+		if n > len(data) {
+			n = len(data)
+		}
+		msg, err := json.Marshal(types.BuildExportData{Data: data[:n]})
+		assert.NilError(t, err)
+		raw := json.RawMessage(msg)
+		e.handleAux(&raw)
+		data = data[n:]
+	}
+}
+
+func TestBuildExportLocal(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "build-output")
+	assert.NilError(t, err)
+	defer os.RemoveAll(tmp)
+	src := filepath.Join(tmp, "src")
+	assert.NilError(t, os.MkdirAll(filepath.Join(src, "bin"), 0755))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(src, "bin", "app"), []byte("app"), 0755))
+
+	dest := filepath.Join(tmp, "out")
+	e, err := startBuildExport(&buildOutput{typ: "local", dest: dest, src: "/"}, nil)
+	assert.NilError(t, err)
+	exportTestArchive(t, e, src)
+	assert.NilError(t, e.wait(nil))
+
+	content, err := ioutil.ReadFile(filepath.Join(dest, "bin", "app"))
+	assert.NilError(t, err)
+	assert.Equal(t, string(content), "app")
+}
+
+func TestBuildExportTarToStdout(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "build-output")
+	assert.NilError(t, err)
+	defer os.RemoveAll(tmp)
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(tmp, "app"), []byte("app"), 0755))
+
+	stdout := bytes.NewBuffer(nil)
+	e, err := startBuildExport(&buildOutput{typ: "tar", src: "/"}, stdout)
+	assert.NilError(t, err)
+	exportTestArchive(t, e, tmp)
+	assert.NilError(t, e.wait(nil))
+
+	dest := filepath.Join(tmp, "out")
+	assert.NilError(t, archive.Untar(stdout, dest, &archive.TarOptions{NoLchown: true}))
+	content, err := ioutil.ReadFile(filepath.Join(dest, "app"))
+	assert.NilError(t, err)
+	assert.Equal(t, string(content), "app")
+}
+
+func TestBuildExportLocalMerge(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "build-output")
+	assert.NilError(t, err)
+	defer os.RemoveAll(tmp)
+	src := filepath.Join(tmp, "src")
+	assert.NilError(t, os.MkdirAll(filepath.Join(src, "bin"), 0755))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(src, "bin", "app"), []byte("app"), 0755))
+
+	dest := filepath.Join(tmp, "out")
+	assert.NilError(t, os.MkdirAll(filepath.Join(dest, "bin"), 0755))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dest, "bin", "app"), []byte("old"), 0755))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dest, "bin", "other"), []byte("other"), 0755))
+
+	e, err := startBuildExport(&buildOutput{typ: "local", dest: dest, src: "/"}, nil)
+	assert.NilError(t, err)
+	exportTestArchive(t, e, src)
+	assert.NilError(t, e.wait(nil))
+
+	content, err := ioutil.ReadFile(filepath.Join(dest, "bin", "app"))
+	assert.NilError(t, err)
+	assert.Equal(t, string(content), "app")
+	content, err = ioutil.ReadFile(filepath.Join(dest, "bin", "other"))
+	assert.NilError(t, err)
+	assert.Equal(t, string(content), "other")
+
+	// The result was extracted next to the destination.
+	entries, err := ioutil.ReadDir(tmp)
+	assert.NilError(t, err)
+	assert.Equal(t, len(entries), 2)
+}
+
+func TestBuildExportFailedBuild(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "build-output")
+	assert.NilError(t, err)
+	defer os.RemoveAll(tmp)
+	src := filepath.Join(tmp, "src")
+	assert.NilError(t, os.MkdirAll(src, 0755))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(src, "app"), []byte("app"), 0755))
+
+	for _, out := range []*buildOutput{
+		{typ: "local", dest: filepath.Join(tmp, "out"), src: "/"},
+		{typ: "tar", dest: filepath.Join(tmp, "out.tar"), src: "/"},
+	} {
+		e, err := startBuildExport(out, nil)
+		assert.NilError(t, err)
+		exportTestArchive(t, e, src)
+		assert.NilError(t, e.wait(errors.New("build failed")))
+
+		// Nothing is left behind, partial or not.
+		entries, err := ioutil.ReadDir(tmp)
+		assert.NilError(t, err)
+		assert.Equal(t, len(entries), 1)
+		assert.Equal(t, entries[0].Name(), "src")
+	}
+}
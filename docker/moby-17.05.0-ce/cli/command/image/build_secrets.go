@@ -0,0 +1,99 @@
+package image
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// parseBuildSecrets parses the secrets given with --secret id=<id>,src=<file>
+// and returns their files, by id. The files are served on the session of the
+// build, and only sent to the daemon when a RUN instruction mounts them.
+func parseBuildSecrets(values []string) (map[string]string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	secrets := make(map[string]string, len(values))
+	for _, value := range values {
+		csvReader := csv.NewReader(strings.NewReader(value))
+		fields, err := csvReader.Read()
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid secret %q", value)
+		}
+
+		var id, src string
+		for _, field := range fields {
+			parts := strings.SplitN(field, "=", 2)
+			if len(parts) != 2 {
+				return nil, errors.Errorf("invalid field '%s' must be a key=value pair", field)
+			}
+			switch strings.ToLower(parts[0]) {
+			case "id":
+				id = parts[1]
+			case "src", "source":
+				src = parts[1]
+			default:
+				return nil, errors.Errorf("unexpected key '%s' in '%s'", parts[0], field)
+			}
+		}
+		if id == "" || src == "" {
+			return nil, errors.Errorf("invalid secret %q: id and src are required", value)
+		}
+		if _, ok := secrets[id]; ok {
+			return nil, errors.Errorf("duplicate secret id %s", id)
+		}
+
+		src, err = filepath.Abs(src)
+		if err != nil {
+			return nil, err
+		}
+		f, err := os.Open(src)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read secret %s", id)
+		}
+		f.Close()
+		secrets[id] = src
+	}
+	return secrets, nil
+}
+
+// parseBuildSSH reads the SSH agent sockets given with --ssh <id>[=<socket>].
+// The socket defaults to $SSH_AUTH_SOCK. The requests of the RUN instructions
+// which mount an agent are forwarded to it over the session of the build.
+func parseBuildSSH(values []string) (map[string]string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	sockets := make(map[string]string, len(values))
+	for _, value := range values {
+		parts := strings.SplitN(value, "=", 2)
+		id := parts[0]
+		if id == "" {
+			return nil, errors.Errorf("invalid ssh agent %q: id is required", value)
+		}
+
+		var socket string
+		if len(parts) == 2 {
+			socket = parts[1]
+		} else {
+			socket = os.Getenv("SSH_AUTH_SOCK")
+			if socket == "" {
+				return nil, errors.Errorf("invalid ssh agent %q: SSH_AUTH_SOCK is not set", value)
+			}
+		}
+		socket, err := filepath.Abs(socket)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := sockets[id]; ok {
+			return nil, errors.Errorf("duplicate ssh agent id %s", id)
+		}
+		sockets[id] = socket
+	}
+	return sockets, nil
+}
@@ -0,0 +1,66 @@
+package image
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/docker/pkg/testutil/assert"
+)
+
+func TestParseBuildSecrets(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "build-secrets")
+	assert.NilError(t, err)
+	defer os.RemoveAll(tmp)
+	src := filepath.Join(tmp, "npmrc")
+	assert.NilError(t, ioutil.WriteFile(src, []byte("token"), 0600))
+
+	secrets, err := parseBuildSecrets([]string{"id=npmrc,src=" + src, "id=other,source=" + src})
+	assert.NilError(t, err)
+	assert.Equal(t, len(secrets), 2)
+	assert.Equal(t, secrets["npmrc"], src)
+	assert.Equal(t, secrets["other"], src)
+
+	secrets, err = parseBuildSecrets(nil)
+	assert.NilError(t, err)
+	assert.Equal(t, len(secrets), 0)
+
+	testCases := []struct {
+		value         string
+		expectedError string
+	}{
+		{"id=npmrc", "id and src are required"},
+		{"src=" + src, "id and src are required"},
+		{"id=npmrc,src", "invalid field 'src' must be a key=value pair"},
+		{"id=npmrc,src=" + src + ",mode=0400", "unexpected key 'mode'"},
+		{"id=npmrc,src=" + filepath.Join(tmp, "missing"), "failed to read secret npmrc"},
+	}
+	for _, tc := range testCases {
+		_, err := parseBuildSecrets([]string{tc.value})
+		assert.Error(t, err, tc.expectedError)
+	}
+
+	_, err = parseBuildSecrets([]string{"id=npmrc,src=" + src, "id=npmrc,src=" + src})
+	assert.Error(t, err, "duplicate secret id npmrc")
+}
+
+func TestParseBuildSSH(t *testing.T) {
+	defer os.Setenv("SSH_AUTH_SOCK", os.Getenv("SSH_AUTH_SOCK"))
+
+	os.Setenv("SSH_AUTH_SOCK", "/tmp/agent.sock")
+	sockets, err := parseBuildSSH([]string{"default", "github=/tmp/github.sock"})
+	assert.NilError(t, err)
+	assert.Equal(t, sockets["default"], "/tmp/agent.sock")
+	assert.Equal(t, sockets["github"], "/tmp/github.sock")
+
+	_, err = parseBuildSSH([]string{"=/tmp/agent.sock"})
+	assert.Error(t, err, "id is required")
+
+	_, err = parseBuildSSH([]string{"default", "default"})
+	assert.Error(t, err, "duplicate ssh agent id default")
+
+	os.Unsetenv("SSH_AUTH_SOCK")
+	_, err = parseBuildSSH([]string{"default"})
+	assert.Error(t, err, "SSH_AUTH_SOCK is not set")
+}
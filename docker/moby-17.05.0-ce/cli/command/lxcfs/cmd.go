@@ -16,6 +16,7 @@ func NewLxcfsCommand(dockerCli *command.DockerCli) *cobra.Command {
 	}
 	cmd.AddCommand(
 		NewInfoCommand(dockerCli),
+		newInspectCommand(dockerCli),
 	)
 
 	return cmd
@@ -0,0 +1,77 @@
+package lxcfs
+
+import (
+	"fmt"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/docker/docker/cli/command/inspect"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+type inspectOptions struct {
+	format    string
+	container string
+}
+
+func newInspectCommand(dockerCli *command.DockerCli) *cobra.Command {
+	var opts inspectOptions
+
+	cmd := &cobra.Command{
+		Use:   "inspect [OPTIONS] CONTAINER",
+		Short: "Display the lxcfs /proc files as seen from inside a container",
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.container = args[0]
+			return runInspect(dockerCli, opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.format, "format", "f", "", "Format the output using the given Go template")
+
+	return cmd
+}
+
+func runInspect(dockerCli *command.DockerCli, opts inspectOptions) error {
+	client := dockerCli.Client()
+
+	ctx := context.Background()
+
+	if opts.format != "" {
+		getLxcfsFunc := func(name string) (interface{}, []byte, error) {
+			i, err := client.ContainerLxcfs(ctx, name)
+			return i, nil, err
+		}
+		return inspect.Inspect(dockerCli.Out(), []string{opts.container}, opts.format, getLxcfsFunc)
+	}
+
+	lxcfs, err := client.ContainerLxcfs(ctx, opts.container)
+	if err != nil {
+		return err
+	}
+	printProcFiles(dockerCli, lxcfs)
+	return nil
+}
+
+func printProcFiles(dockerCli *command.DockerCli, lxcfs types.ContainerLxcfs) {
+	files := []struct {
+		path    string
+		content string
+	}{
+		{"/proc/meminfo", lxcfs.Meminfo},
+		{"/proc/cpuinfo", lxcfs.Cpuinfo},
+		{"/proc/stat", lxcfs.Stat},
+		{"/proc/uptime", lxcfs.Uptime},
+		{"/proc/diskstats", lxcfs.Diskstats},
+	}
+
+	for i, f := range files {
+		if i > 0 {
+			fmt.Fprintln(dockerCli.Out())
+		}
+		fmt.Fprintf(dockerCli.Out(), "==> %s <==\n", f.path)
+		fmt.Fprint(dockerCli.Out(), f.content)
+	}
+}
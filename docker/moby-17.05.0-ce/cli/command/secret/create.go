@@ -18,6 +18,7 @@ import (
 
 type createOptions struct {
 	name   string
+	driver string
 	file   string
 	labels opts.ListOpts
 }
@@ -28,17 +29,21 @@ func newSecretCreateCommand(dockerCli command.Cli) *cobra.Command {
 	}
 
 	cmd := &cobra.Command{
-		Use:   "create [OPTIONS] SECRET file|-",
+		Use:   "create [OPTIONS] SECRET [file|-]",
 		Short: "Create a secret from a file or STDIN as content",
-		Args:  cli.ExactArgs(2),
+		Args:  cli.RequiresRangeArgs(1, 2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			createOpts.name = args[0]
-			createOpts.file = args[1]
+			if len(args) == 2 {
+				createOpts.file = args[1]
+			}
 			return runSecretCreate(dockerCli, createOpts)
 		},
 	}
 	flags := cmd.Flags()
 	flags.VarP(&createOpts.labels, "label", "l", "Secret labels")
+	flags.StringVarP(&createOpts.driver, "driver", "d", "", "Secret driver")
+	flags.SetAnnotation("driver", "version", []string{"1.29"})
 
 	return cmd
 }
@@ -47,27 +52,26 @@ func runSecretCreate(dockerCli command.Cli, options createOptions) error {
 	client := dockerCli.Client()
 	ctx := context.Background()
 
-	var in io.Reader = dockerCli.In()
-	if options.file != "-" {
-		file, err := system.OpenSequential(options.file)
-		if err != nil {
-			return err
-		}
-		in = file
-		defer file.Close()
-	}
-
-	secretData, err := ioutil.ReadAll(in)
-	if err != nil {
-		return errors.Errorf("Error reading content from %q: %v", options.file, err)
-	}
-
 	spec := swarm.SecretSpec{
 		Annotations: swarm.Annotations{
 			Name:   options.name,
 			Labels: runconfigopts.ConvertKVStringsToMap(options.labels.GetAll()),
 		},
-		Data: secretData,
+	}
+
+	switch {
+	case options.driver != "" && options.file != "":
+		return errors.Errorf("A file or STDIN cannot be used with a secret driver")
+	case options.driver != "":
+		spec.Driver = &swarm.Driver{Name: options.driver}
+	case options.file == "":
+		return errors.Errorf("A file or STDIN is required when no secret driver is specified")
+	default:
+		secretData, err := readSecretData(dockerCli.In(), options.file)
+		if err != nil {
+			return err
+		}
+		spec.Data = secretData
 	}
 
 	r, err := client.SecretCreate(ctx, spec)
@@ -78,3 +82,20 @@ func runSecretCreate(dockerCli command.Cli, options createOptions) error {
 	fmt.Fprintln(dockerCli.Out(), r.ID)
 	return nil
 }
+
+func readSecretData(in io.Reader, filename string) ([]byte, error) {
+	if filename != "-" {
+		file, err := system.OpenSequential(filename)
+		if err != nil {
+			return nil, err
+		}
+		in = file
+		defer file.Close()
+	}
+
+	secretData, err := ioutil.ReadAll(in)
+	if err != nil {
+		return nil, errors.Errorf("Error reading content from %q: %v", filename, err)
+	}
+	return secretData, nil
+}
@@ -25,10 +25,10 @@ func TestSecretCreateErrors(t *testing.T) {
 	}{
 		{
 			args:          []string{"too_few"},
-			expectedError: "requires exactly 2 argument(s)",
+			expectedError: "A file or STDIN is required",
 		},
 		{args: []string{"too", "many", "arguments"},
-			expectedError: "requires exactly 2 argument(s)",
+			expectedError: "requires at least 1 and at most 2 argument(s)",
 		},
 		{
 			args: []string{"name", filepath.Join("testdata", secretDataFile)},
@@ -124,3 +124,35 @@ func compareMap(actual map[string]string, expected map[string]string) bool {
 	}
 	return true
 }
+
+func TestSecretCreateWithDriver(t *testing.T) {
+	name := "foo"
+
+	buf := new(bytes.Buffer)
+	cli := test.NewFakeCli(&fakeClient{
+		secretCreateFunc: func(spec swarm.SecretSpec) (types.SecretCreateResponse, error) {
+			if spec.Driver == nil || spec.Driver.Name != "vault" {
+				return types.SecretCreateResponse{}, errors.Errorf("expected driver %q, got %v", "vault", spec.Driver)
+			}
+			if len(spec.Data) != 0 {
+				return types.SecretCreateResponse{}, errors.Errorf("expected no data, got %q", spec.Data)
+			}
+
+			return types.SecretCreateResponse{
+				ID: "ID-" + spec.Name,
+			}, nil
+		},
+	}, buf)
+
+	cmd := newSecretCreateCommand(cli)
+	cmd.SetArgs([]string{name})
+	cmd.Flags().Set("driver", "vault")
+	assert.NilError(t, cmd.Execute())
+	assert.Equal(t, strings.TrimSpace(buf.String()), "ID-"+name)
+
+	cmd = newSecretCreateCommand(cli)
+	cmd.SetArgs([]string{name, filepath.Join("testdata", secretDataFile)})
+	cmd.Flags().Set("driver", "vault")
+	cmd.SetOutput(ioutil.Discard)
+	assert.Error(t, cmd.Execute(), "cannot be used with a secret driver")
+}
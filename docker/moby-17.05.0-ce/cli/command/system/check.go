@@ -0,0 +1,65 @@
+package system
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+type checkOptions struct {
+	repair bool
+}
+
+// NewCheckCommand creates a new cobra.Command for `docker system check`
+func NewCheckCommand(dockerCli *command.DockerCli) *cobra.Command {
+	var opts checkOptions
+
+	cmd := &cobra.Command{
+		Use:   "check [OPTIONS]",
+		Short: "Check the integrity of the images and containers storage",
+		Args:  cli.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCheck(dockerCli, opts)
+		},
+		Tags: map[string]string{"version": "1.29"},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&opts.repair, "repair", false, "Repair the problems found")
+
+	return cmd
+}
+
+func runCheck(dockerCli *command.DockerCli, opts checkOptions) error {
+	report, err := dockerCli.Client().SystemCheck(context.Background(), opts.repair)
+	if err != nil {
+		return err
+	}
+
+	if len(report.Problems) == 0 {
+		fmt.Fprintln(dockerCli.Out(), "No problem found")
+		return nil
+	}
+
+	unrepaired := 0
+	w := tabwriter.NewWriter(dockerCli.Out(), 20, 1, 3, ' ', 0)
+	fmt.Fprintln(w, "TYPE\tID\tPROBLEM\tREPAIR")
+	for _, p := range report.Problems {
+		repair := p.Repair
+		if repair == "" {
+			repair = "none"
+			unrepaired++
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", p.Type, p.ID, p.Description, repair)
+	}
+	w.Flush()
+
+	if unrepaired > 0 {
+		return cli.StatusError{StatusCode: 1}
+	}
+	return nil
+}
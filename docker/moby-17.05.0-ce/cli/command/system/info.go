@@ -76,6 +76,7 @@ func prettyPrintInfo(dockerCli *command.DockerCli, info types.Info) error {
 	}
 	ioutils.FprintfIfNotEmpty(dockerCli.Out(), "Logging Driver: %s\n", info.LoggingDriver)
 	ioutils.FprintfIfNotEmpty(dockerCli.Out(), "Cgroup Driver: %s\n", info.CgroupDriver)
+	ioutils.FprintfIfNotEmpty(dockerCli.Out(), "Cgroup Version: %s\n", info.CgroupVersion)
 
 	fmt.Fprintf(dockerCli.Out(), "Plugins: \n")
 	fmt.Fprintf(dockerCli.Out(), " Volume:")
@@ -149,6 +150,16 @@ func prettyPrintInfo(dockerCli *command.DockerCli, info types.Info) error {
 		fmt.Fprintf(dockerCli.Out(), "Default Runtime: %s\n", info.DefaultRuntime)
 	}
 
+	if info.Lxcfs != nil {
+		fmt.Fprintf(dockerCli.Out(), "Lxcfs:\n")
+		fmt.Fprintf(dockerCli.Out(), " Pid: %d\n", info.Lxcfs.Pid)
+		fmt.Fprintf(dockerCli.Out(), " Uptime: %s\n", units.HumanDuration(info.Lxcfs.Uptime))
+		fmt.Fprintf(dockerCli.Out(), " Restart Count: %d\n", info.Lxcfs.RestartCount)
+		fmt.Fprintf(dockerCli.Out(), " Mount Path: %s\n", info.Lxcfs.MountPath)
+		fmt.Fprintf(dockerCli.Out(), " Healthy: %v\n", info.Lxcfs.Healthy)
+		fmt.Fprintf(dockerCli.Out(), " Health Check Latency: %s\n", info.Lxcfs.HealthCheckLatency)
+	}
+
 	if info.OSType == "linux" {
 		fmt.Fprintf(dockerCli.Out(), "Init Binary: %v\n", info.InitBinary)
 
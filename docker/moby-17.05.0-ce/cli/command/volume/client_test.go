@@ -1,6 +1,10 @@
 package volume
 
 import (
+	"io"
+	"io/ioutil"
+	"strings"
+
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/filters"
 	volumetypes "github.com/docker/docker/api/types/volume"
@@ -10,11 +14,15 @@ import (
 
 type fakeClient struct {
 	client.Client
-	volumeCreateFunc  func(volumetypes.VolumesCreateBody) (types.Volume, error)
-	volumeInspectFunc func(volumeID string) (types.Volume, error)
-	volumeListFunc    func(filter filters.Args) (volumetypes.VolumesListOKBody, error)
-	volumeRemoveFunc  func(volumeID string, force bool) error
-	volumePruneFunc   func(filter filters.Args) (types.VolumesPruneReport, error)
+	volumeCreateFunc   func(volumetypes.VolumesCreateBody) (types.Volume, error)
+	volumeInspectFunc  func(volumeID string) (types.Volume, error)
+	volumeListFunc     func(filter filters.Args) (volumetypes.VolumesListOKBody, error)
+	volumeRemoveFunc   func(volumeID string, force bool) error
+	volumePruneFunc    func(filter filters.Args) (types.VolumesPruneReport, error)
+	volumeCloneFunc    func(volumeID string, options volumetypes.VolumeCloneBody) (types.Volume, error)
+	volumeSnapshotFunc func(volumeID string, options volumetypes.VolumeSnapshotBody) (types.Volume, error)
+	volumeExportFunc   func(volumeID string) (io.ReadCloser, error)
+	volumeImportFunc   func(volumeID string, input io.Reader) error
 }
 
 func (c *fakeClient) VolumeCreate(ctx context.Context, options volumetypes.VolumesCreateBody) (types.Volume, error) {
@@ -51,3 +59,31 @@ func (c *fakeClient) VolumeRemove(ctx context.Context, volumeID string, force bo
 	}
 	return nil
 }
+
+func (c *fakeClient) VolumeClone(ctx context.Context, volumeID string, options volumetypes.VolumeCloneBody) (types.Volume, error) {
+	if c.volumeCloneFunc != nil {
+		return c.volumeCloneFunc(volumeID, options)
+	}
+	return types.Volume{}, nil
+}
+
+func (c *fakeClient) VolumeSnapshot(ctx context.Context, volumeID string, options volumetypes.VolumeSnapshotBody) (types.Volume, error) {
+	if c.volumeSnapshotFunc != nil {
+		return c.volumeSnapshotFunc(volumeID, options)
+	}
+	return types.Volume{}, nil
+}
+
+func (c *fakeClient) VolumeExport(ctx context.Context, volumeID string) (io.ReadCloser, error) {
+	if c.volumeExportFunc != nil {
+		return c.volumeExportFunc(volumeID)
+	}
+	return ioutil.NopCloser(strings.NewReader("")), nil
+}
+
+func (c *fakeClient) VolumeImport(ctx context.Context, volumeID string, input io.Reader) error {
+	if c.volumeImportFunc != nil {
+		return c.volumeImportFunc(volumeID, input)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package volume
+
+import (
+	"fmt"
+
+	volumetypes "github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/docker/docker/opts"
+	runconfigopts "github.com/docker/docker/runconfig/opts"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+type cloneOptions struct {
+	source     string
+	name       string
+	driverOpts opts.MapOpts
+	labels     opts.ListOpts
+}
+
+func newCloneCommand(dockerCli command.Cli) *cobra.Command {
+	opts := cloneOptions{
+		driverOpts: *opts.NewMapOpts(nil, nil),
+		labels:     opts.NewListOpts(opts.ValidateEnv),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "clone [OPTIONS] SOURCE [VOLUME]",
+		Short: "Create a volume with a copy of the data of another volume",
+		Args:  cli.RequiresRangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.source = args[0]
+			if len(args) == 2 {
+				opts.name = args[1]
+			}
+			return runClone(dockerCli, opts)
+		},
+		Tags: map[string]string{"version": "1.29"},
+	}
+	flags := cmd.Flags()
+	flags.VarP(&opts.driverOpts, "opt", "o", "Set driver specific options")
+	flags.Var(&opts.labels, "label", "Set metadata for a volume")
+
+	return cmd
+}
+
+func runClone(dockerCli command.Cli, opts cloneOptions) error {
+	client := dockerCli.Client()
+
+	cloneReq := volumetypes.VolumeCloneBody{
+		DriverOpts: opts.driverOpts.GetAll(),
+		Name:       opts.name,
+		Labels:     runconfigopts.ConvertKVStringsToMap(opts.labels.GetAll()),
+	}
+
+	vol, err := client.VolumeClone(context.Background(), opts.source, cloneReq)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(dockerCli.Out(), "%s\n", vol.Name)
+	return nil
+}
@@ -0,0 +1,82 @@
+package volume
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	volumetypes "github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/cli/internal/test"
+	"github.com/docker/docker/pkg/testutil/assert"
+	"github.com/pkg/errors"
+)
+
+func TestVolumeCloneErrors(t *testing.T) {
+	testCases := []struct {
+		args            []string
+		volumeCloneFunc func(string, volumetypes.VolumeCloneBody) (types.Volume, error)
+		expectedError   string
+	}{
+		{
+			expectedError: "requires at least 1 and at most 2 argument(s)",
+		},
+		{
+			args:          []string{"too", "many", "args"},
+			expectedError: "requires at least 1 and at most 2 argument(s)",
+		},
+		{
+			args: []string{"source"},
+			volumeCloneFunc: func(volumeID string, cloneBody volumetypes.VolumeCloneBody) (types.Volume, error) {
+				return types.Volume{}, errors.Errorf("error cloning volume")
+			},
+			expectedError: "error cloning volume",
+		},
+	}
+	for _, tc := range testCases {
+		buf := new(bytes.Buffer)
+		cmd := newCloneCommand(
+			test.NewFakeCli(&fakeClient{
+				volumeCloneFunc: tc.volumeCloneFunc,
+			}, buf),
+		)
+		cmd.SetArgs(tc.args)
+		cmd.SetOutput(ioutil.Discard)
+		assert.Error(t, cmd.Execute(), tc.expectedError)
+	}
+}
+
+func TestVolumeCloneWithFlags(t *testing.T) {
+	expectedOpts := map[string]string{
+		"bar": "1",
+	}
+	expectedLabels := map[string]string{
+		"lbl1": "v1",
+	}
+
+	buf := new(bytes.Buffer)
+	cli := test.NewFakeCli(&fakeClient{
+		volumeCloneFunc: func(volumeID string, body volumetypes.VolumeCloneBody) (types.Volume, error) {
+			if volumeID != "source" {
+				return types.Volume{}, errors.Errorf("expected source %q, got %q", "source", volumeID)
+			}
+			if !compareMap(body.DriverOpts, expectedOpts) {
+				return types.Volume{}, errors.Errorf("expected drivers opts %v, got %v", expectedOpts, body.DriverOpts)
+			}
+			if !compareMap(body.Labels, expectedLabels) {
+				return types.Volume{}, errors.Errorf("expected labels %v, got %v", expectedLabels, body.Labels)
+			}
+			return types.Volume{
+				Name: body.Name,
+			}, nil
+		},
+	}, buf)
+
+	cmd := newCloneCommand(cli)
+	cmd.SetArgs([]string{"source", "banana"})
+	cmd.Flags().Set("opt", "bar=1")
+	cmd.Flags().Set("label", "lbl1=v1")
+	assert.NilError(t, cmd.Execute())
+	assert.Equal(t, strings.TrimSpace(buf.String()), "banana")
+}
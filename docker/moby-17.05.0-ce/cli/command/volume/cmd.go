@@ -16,10 +16,14 @@ func NewVolumeCommand(dockerCli *command.DockerCli) *cobra.Command {
 		Tags:  map[string]string{"version": "1.21"},
 	}
 	cmd.AddCommand(
+		newCloneCommand(dockerCli),
 		newCreateCommand(dockerCli),
+		newExportCommand(dockerCli),
+		newImportCommand(dockerCli),
 		newInspectCommand(dockerCli),
 		newListCommand(dockerCli),
 		newRemoveCommand(dockerCli),
+		newSnapshotCommand(dockerCli),
 		NewPruneCommand(dockerCli),
 	)
 	return cmd
@@ -0,0 +1,71 @@
+package volume
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/cli/internal/test"
+	"github.com/docker/docker/pkg/testutil/assert"
+	"github.com/pkg/errors"
+)
+
+func TestVolumeExportErrors(t *testing.T) {
+	testCases := []struct {
+		args             []string
+		volumeExportFunc func(volumeID string) (io.ReadCloser, error)
+		expectedError    string
+	}{
+		{
+			expectedError: "requires exactly 1 argument(s)",
+		},
+		{
+			args: []string{"nodeID"},
+			volumeExportFunc: func(volumeID string) (io.ReadCloser, error) {
+				return nil, errors.Errorf("error exporting volume")
+			},
+			expectedError: "error exporting volume",
+		},
+	}
+	for _, tc := range testCases {
+		cmd := newExportCommand(
+			test.NewFakeCli(&fakeClient{
+				volumeExportFunc: tc.volumeExportFunc,
+			}, new(bytes.Buffer)),
+		)
+		cmd.SetArgs(tc.args)
+		cmd.SetOutput(ioutil.Discard)
+		assert.Error(t, cmd.Execute(), tc.expectedError)
+	}
+}
+
+func TestVolumeExportImport(t *testing.T) {
+	buf := new(bytes.Buffer)
+	cli := test.NewFakeCli(&fakeClient{
+		volumeExportFunc: func(volumeID string) (io.ReadCloser, error) {
+			return ioutil.NopCloser(strings.NewReader("archive of " + volumeID)), nil
+		},
+		volumeImportFunc: func(volumeID string, input io.Reader) error {
+			b, err := ioutil.ReadAll(input)
+			if err != nil {
+				return err
+			}
+			if string(b) != "archive of source" {
+				return errors.Errorf("expected the archive of source, got %q", b)
+			}
+			return nil
+		},
+	}, buf)
+
+	cmd := newExportCommand(cli)
+	cmd.SetArgs([]string{"source"})
+	assert.NilError(t, cmd.Execute())
+	assert.Equal(t, buf.String(), "archive of source")
+
+	cli.SetIn(ioutil.NopCloser(bytes.NewReader(buf.Bytes())))
+	cmd = newImportCommand(cli)
+	cmd.SetArgs([]string{"destination"})
+	assert.NilError(t, cmd.Execute())
+}
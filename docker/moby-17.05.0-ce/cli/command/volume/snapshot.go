@@ -0,0 +1,60 @@
+package volume
+
+import (
+	"fmt"
+
+	volumetypes "github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/cli"
+	"github.com/docker/docker/cli/command"
+	"github.com/docker/docker/opts"
+	runconfigopts "github.com/docker/docker/runconfig/opts"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+type snapshotOptions struct {
+	source string
+	name   string
+	labels opts.ListOpts
+}
+
+func newSnapshotCommand(dockerCli command.Cli) *cobra.Command {
+	opts := snapshotOptions{
+		labels: opts.NewListOpts(opts.ValidateEnv),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "snapshot [OPTIONS] VOLUME [SNAPSHOT]",
+		Short: "Create a volume with a point-in-time copy of the data of a volume",
+		Args:  cli.RequiresRangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.source = args[0]
+			if len(args) == 2 {
+				opts.name = args[1]
+			}
+			return runSnapshot(dockerCli, opts)
+		},
+		Tags: map[string]string{"version": "1.29"},
+	}
+	flags := cmd.Flags()
+	flags.Var(&opts.labels, "label", "Set metadata for the snapshot")
+
+	return cmd
+}
+
+func runSnapshot(dockerCli command.Cli, opts snapshotOptions) error {
+	client := dockerCli.Client()
+
+	snapshotReq := volumetypes.VolumeSnapshotBody{
+		Name:   opts.name,
+		Labels: runconfigopts.ConvertKVStringsToMap(opts.labels.GetAll()),
+	}
+
+	vol, err := client.VolumeSnapshot(context.Background(), opts.source, snapshotReq)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(dockerCli.Out(), "%s\n", vol.Name)
+	return nil
+}
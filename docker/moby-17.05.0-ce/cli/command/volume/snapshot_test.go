@@ -0,0 +1,63 @@
+package volume
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	volumetypes "github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/cli/internal/test"
+	"github.com/docker/docker/pkg/testutil/assert"
+	"github.com/pkg/errors"
+)
+
+func TestVolumeSnapshotErrors(t *testing.T) {
+	testCases := []struct {
+		args               []string
+		volumeSnapshotFunc func(string, volumetypes.VolumeSnapshotBody) (types.Volume, error)
+		expectedError      string
+	}{
+		{
+			expectedError: "requires at least 1 and at most 2 argument(s)",
+		},
+		{
+			args: []string{"source"},
+			volumeSnapshotFunc: func(volumeID string, snapshotBody volumetypes.VolumeSnapshotBody) (types.Volume, error) {
+				return types.Volume{}, errors.Errorf("error snapshotting volume")
+			},
+			expectedError: "error snapshotting volume",
+		},
+	}
+	for _, tc := range testCases {
+		buf := new(bytes.Buffer)
+		cmd := newSnapshotCommand(
+			test.NewFakeCli(&fakeClient{
+				volumeSnapshotFunc: tc.volumeSnapshotFunc,
+			}, buf),
+		)
+		cmd.SetArgs(tc.args)
+		cmd.SetOutput(ioutil.Discard)
+		assert.Error(t, cmd.Execute(), tc.expectedError)
+	}
+}
+
+func TestVolumeSnapshotWithoutName(t *testing.T) {
+	buf := new(bytes.Buffer)
+	cli := test.NewFakeCli(&fakeClient{
+		volumeSnapshotFunc: func(volumeID string, body volumetypes.VolumeSnapshotBody) (types.Volume, error) {
+			if body.Name != "" {
+				return types.Volume{}, errors.Errorf("expected empty name, got %q", body.Name)
+			}
+			return types.Volume{
+				Name: volumeID + "-20170501T120000Z",
+			}, nil
+		},
+	}, buf)
+
+	cmd := newSnapshotCommand(cli)
+	cmd.SetArgs([]string{"source"})
+	assert.NilError(t, cmd.Execute())
+	assert.Equal(t, strings.TrimSpace(buf.String()), "source-20170501T120000Z")
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/pkg/testutil/assert"
+	"golang.org/x/net/context"
+)
+
+func TestBuildCachePruneError(t *testing.T) {
+	client := &Client{
+		client:  newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+		version: "1.29",
+	}
+
+	_, err := client.BuildCachePrune(context.Background(), filters.NewArgs())
+	assert.Error(t, err, "Error response from daemon: Server error")
+}
+
+func TestBuildCachePruneVersion(t *testing.T) {
+	client := &Client{
+		client:  newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+		version: "1.28",
+	}
+
+	_, err := client.BuildCachePrune(context.Background(), filters.NewArgs())
+	assert.Error(t, err, `"builder prune" requires API version 1.29`)
+}
+
+func TestBuildCachePrune(t *testing.T) {
+	expectedURL := "/v1.29/build/prune"
+
+	labelFilters := filters.NewArgs()
+	labelFilters.Add("label", "label1=foo")
+
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(req.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			assert.Equal(t, req.URL.Query().Get("filters"), `{"label":{"label1=foo":true}}`)
+			content, err := json.Marshal(types.BuildCachePruneReport{
+				CachesDeleted:  []string{"/root/.cache/go-build", "apt"},
+				SpaceReclaimed: 9999,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(content)),
+			}, nil
+		}),
+		version: "1.29",
+	}
+
+	report, err := client.BuildCachePrune(context.Background(), labelFilters)
+	assert.NilError(t, err)
+	assert.Equal(t, len(report.CachesDeleted), 2)
+	assert.Equal(t, report.SpaceReclaimed, uint64(9999))
+}
@@ -0,0 +1,25 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+// ContainerLxcfs returns the lxcfs virtualized /proc files of a running container.
+func (cli *Client) ContainerLxcfs(ctx context.Context, containerID string) (types.ContainerLxcfs, error) {
+	var response types.ContainerLxcfs
+	resp, err := cli.get(ctx, "/containers/"+containerID+"/lxcfs", nil, nil)
+	if err != nil {
+		if resp.statusCode == http.StatusNotFound {
+			return response, containerNotFoundError{containerID}
+		}
+		return response, err
+	}
+
+	err = json.NewDecoder(resp.body).Decode(&response)
+	ensureReaderClosed(resp)
+	return response, err
+}
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+func TestContainerLxcfsError(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+	}
+	_, err := client.ContainerLxcfs(context.Background(), "nothing")
+	if err == nil || err.Error() != "Error response from daemon: Server error" {
+		t.Fatalf("expected a Server Error, got %v", err)
+	}
+}
+
+func TestContainerLxcfsNotFound(t *testing.T) {
+	client := &Client{
+		client: newMockClient(errorMock(http.StatusNotFound, "Server error")),
+	}
+	_, err := client.ContainerLxcfs(context.Background(), "unknown")
+	if err == nil || !IsErrContainerNotFound(err) {
+		t.Fatalf("expected a containerNotFound error, got %v", err)
+	}
+}
+
+func TestContainerLxcfs(t *testing.T) {
+	expectedURL := "/containers/container_id/lxcfs"
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(req.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			b, err := json.Marshal(types.ContainerLxcfs{
+				ID:      "container_id",
+				Pid:     42,
+				Meminfo: "MemTotal:        1048576 kB\n",
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(b)),
+			}, nil
+		}),
+	}
+
+	lxcfs, err := client.ContainerLxcfs(context.Background(), "container_id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lxcfs.ID != "container_id" || lxcfs.Pid != 42 {
+		t.Fatalf("expected container_id with pid 42, got %s with pid %d", lxcfs.ID, lxcfs.Pid)
+	}
+	if lxcfs.Meminfo != "MemTotal:        1048576 kB\n" {
+		t.Fatalf("unexpected meminfo %q", lxcfs.Meminfo)
+	}
+}
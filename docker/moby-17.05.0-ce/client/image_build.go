@@ -96,6 +96,33 @@ func (cli *Client) imageBuildOptionsToQuery(options types.ImageBuildOptions) (ur
 	query.Set("shmsize", strconv.FormatInt(options.ShmSize, 10))
 	query.Set("dockerfile", options.Dockerfile)
 	query.Set("target", options.Target)
+	if options.SessionID != "" {
+		if err := cli.NewVersionError("1.29", "session"); err != nil {
+			return query, err
+		}
+		query.Set("session", options.SessionID)
+	}
+	if options.ExportPath != "" {
+		if err := cli.NewVersionError("1.29", "output"); err != nil {
+			return query, err
+		}
+		query.Set("exportpath", options.ExportPath)
+	}
+	if options.CacheTo != "" {
+		if err := cli.NewVersionError("1.29", "cache-to"); err != nil {
+			return query, err
+		}
+		query.Set("cacheto", options.CacheTo)
+	}
+	if options.SourceDateEpoch != nil {
+		if err := cli.NewVersionError("1.29", "source-date-epoch"); err != nil {
+			return query, err
+		}
+		query.Set("sourcedateepoch", strconv.FormatInt(*options.SourceDateEpoch, 10))
+	}
+	if options.Parallel > 1 {
+		query.Set("parallel", strconv.Itoa(options.Parallel))
+	}
 
 	ulimitsJSON, err := json.Marshal(options.Ulimits)
 	if err != nil {
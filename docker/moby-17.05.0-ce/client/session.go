@@ -0,0 +1,75 @@
+package client
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+// DialSession opens a session with the daemon, and returns the hijacked
+// connection of the session. The session is closed when the connection is
+// closed.
+func (cli *Client) DialSession(ctx context.Context, id string) (net.Conn, error) {
+	if err := cli.NewVersionError("1.29", "session"); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", cli.getAPIPath("/session", nil), nil)
+	if err != nil {
+		return nil, err
+	}
+	req = cli.addHeaders(req, headers{"X-Docker-Session-ID": {id}})
+	req.Host = cli.addr
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "tcp")
+
+	conn, err := dial(cli.proto, cli.addr, resolveTLSConfig(cli.client.Transport))
+	if err != nil {
+		if strings.Contains(err.Error(), "connection refused") {
+			return nil, fmt.Errorf("Cannot connect to the Docker daemon. Is 'docker daemon' running on this host?")
+		}
+		return nil, err
+	}
+
+	clientconn := httputil.NewClientConn(conn, nil)
+	resp, err := clientconn.Do(req)
+	if err != nil && err != httputil.ErrPersistEOF {
+		conn.Close()
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		defer conn.Close()
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		errorMessage := string(body)
+		var errorResponse types.ErrorResponse
+		if resp.Header.Get("Content-Type") == "application/json" && json.Unmarshal(body, &errorResponse) == nil {
+			errorMessage = errorResponse.Message
+		}
+		return nil, fmt.Errorf("Error response from daemon: %s", strings.TrimSpace(errorMessage))
+	}
+
+	rwc, br := clientconn.Hijack()
+	return &hijackedConn{Conn: rwc, r: br}, nil
+}
+
+// hijackedConn is a hijacked connection that reads the data that was
+// buffered while the response of the request was read first.
+type hijackedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *hijackedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
@@ -0,0 +1,37 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/docker/docker/api/types"
+	"golang.org/x/net/context"
+)
+
+// SystemCheck requests the daemon to check the integrity of its storage, and
+// to repair the problems it finds if repair is set.
+func (cli *Client) SystemCheck(ctx context.Context, repair bool) (types.SystemCheckReport, error) {
+	var report types.SystemCheckReport
+
+	if err := cli.NewVersionError("1.29", "system check"); err != nil {
+		return report, err
+	}
+
+	query := url.Values{}
+	if repair {
+		query.Set("repair", "1")
+	}
+
+	serverResp, err := cli.post(ctx, "/system/check", query, nil, nil)
+	if err != nil {
+		return report, err
+	}
+	defer ensureReaderClosed(serverResp)
+
+	if err := json.NewDecoder(serverResp.body).Decode(&report); err != nil {
+		return report, fmt.Errorf("Error retrieving system check report: %v", err)
+	}
+
+	return report, nil
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/testutil/assert"
+	"golang.org/x/net/context"
+)
+
+func TestSystemCheckError(t *testing.T) {
+	client := &Client{
+		client:  newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+		version: "1.29",
+	}
+
+	_, err := client.SystemCheck(context.Background(), false)
+	assert.Error(t, err, "Error response from daemon: Server error")
+}
+
+func TestSystemCheckVersion(t *testing.T) {
+	client := &Client{
+		client:  newMockClient(errorMock(http.StatusInternalServerError, "Server error")),
+		version: "1.28",
+	}
+
+	_, err := client.SystemCheck(context.Background(), false)
+	assert.Error(t, err, `"system check" requires API version 1.29`)
+}
+
+func TestSystemCheck(t *testing.T) {
+	expectedURL := "/v1.29/system/check"
+
+	client := &Client{
+		client: newMockClient(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasPrefix(req.URL.Path, expectedURL) {
+				return nil, fmt.Errorf("Expected URL '%s', got '%s'", expectedURL, req.URL)
+			}
+			if req.Method != "POST" {
+				return nil, fmt.Errorf("expected POST method, got %s", req.Method)
+			}
+			assert.Equal(t, req.URL.Query().Get("repair"), "1")
+			content, err := json.Marshal(types.SystemCheckReport{
+				Problems: []types.SystemCheckProblem{
+					{Type: "reference", ID: "busybox:latest", Description: "missing image", Repair: "removed"},
+				},
+			})
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewReader(content)),
+			}, nil
+		}),
+		version: "1.29",
+	}
+
+	report, err := client.SystemCheck(context.Background(), true)
+	assert.NilError(t, err)
+	assert.Equal(t, len(report.Problems), 1)
+	assert.Equal(t, report.Problems[0].Repair, "removed")
+}
@@ -0,0 +1,17 @@
+package client
+
+import (
+	"io"
+	"net/url"
+
+	"golang.org/x/net/context"
+)
+
+// VolumeImport extracts the tar archive input into a volume, creating the
+// volume if it doesn't exist.
+func (cli *Client) VolumeImport(ctx context.Context, volumeID string, input io.Reader) error {
+	headers := map[string][]string{"Content-Type": {"application/x-tar"}}
+	resp, err := cli.postRaw(ctx, "/volumes/"+volumeID+"/import", url.Values{}, input, headers)
+	ensureReaderClosed(resp)
+	return err
+}
@@ -17,3 +17,9 @@ func installConfigFlags(conf *config.Config, flags *pflag.FlagSet) {
 
 	attachExperimentalFlags(conf, flags)
 }
+
+// setRootlessDefaults does nothing, as rootless mode isn't supported on this
+// platform.
+func setRootlessDefaults(conf *config.Config) error {
+	return nil
+}
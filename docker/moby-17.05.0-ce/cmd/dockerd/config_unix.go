@@ -3,8 +3,11 @@
 package main
 
 import (
+	"path/filepath"
+
 	"github.com/docker/docker/daemon/config"
 	"github.com/docker/docker/opts"
+	"github.com/docker/docker/pkg/rootless"
 	units "github.com/docker/go-units"
 	"github.com/spf13/pflag"
 )
@@ -37,6 +40,7 @@ func installConfigFlags(conf *config.Config, flags *pflag.FlagSet) {
 	flags.MarkDeprecated("api-enable-cors", "Please use --api-cors-header")
 	flags.StringVar(&conf.CgroupParent, "cgroup-parent", "", "Set parent cgroup for all containers")
 	flags.StringVar(&conf.RemappedRoot, "userns-remap", "", "User/Group setting for user namespaces")
+	flags.BoolVar(&conf.Rootless, "rootless", false, "Run the daemon as an unprivileged user in a user namespace")
 	flags.StringVar(&conf.ContainerdAddr, "containerd", "", "Path to containerd socket")
 
     flags.BoolVar(&conf.LxcfsAutoStart, "lxcfs-autostart", true, "running lxcfs when docked start up")
@@ -46,6 +50,7 @@ func installConfigFlags(conf *config.Config, flags *pflag.FlagSet) {
 	flags.StringVar(&conf.LxcfsLogPath, "lxcfs-log-path", "", "set lxcfs log path")
 	flags.BoolVar(&conf.LxcfsOffMultithread, "lxcfs-off-multithread", true, "turn off multi-threading as libnih-dbus isn't thread safe")
 	flags.BoolVar(&conf.LxcfsAllowOther, "lxcfs-allow-other", true, "required to have non-root user be able to access the filesystem")
+	flags.StringVar(&conf.LxcfsMode, "lxcfs-mode", "off", "Default lxcfs mode for containers (off, auto or strict)")
 
 
 	flags.BoolVar(&conf.LiveRestoreEnabled, "live-restore", false, "Enable live restore of docker when containers are still running")
@@ -59,3 +64,39 @@ func installConfigFlags(conf *config.Config, flags *pflag.FlagSet) {
 
 	attachExperimentalFlags(conf, flags)
 }
+
+// setRootlessDefaults moves the files of the daemon that are in system
+// directories by default to the directories of the user in rootless mode.
+func setRootlessDefaults(conf *config.Config) error {
+	if !conf.Rootless {
+		return nil
+	}
+	dataHome, err := rootless.DataHome()
+	if err != nil {
+		return err
+	}
+	runtimeDir, err := rootless.RuntimeDir()
+	if err != nil {
+		return err
+	}
+
+	if conf.Root == defaultDataRoot {
+		conf.Root = filepath.Join(dataHome, "docker")
+	}
+	if conf.ExecRoot == defaultExecRoot {
+		conf.ExecRoot = filepath.Join(runtimeDir, "docker")
+	}
+	if conf.Pidfile == defaultPidFile {
+		conf.Pidfile = filepath.Join(runtimeDir, "docker.pid")
+	}
+	if len(conf.Hosts) == 0 {
+		conf.Hosts = []string{"unix://" + filepath.Join(runtimeDir, "docker.sock")}
+	}
+	// The socket is only accessible to the user.
+	if conf.SocketGroup == "docker" {
+		conf.SocketGroup = ""
+	}
+	// lxcfs needs to mount its FUSE filesystem on the host.
+	conf.LxcfsAutoStart = false
+	return nil
+}
@@ -3,6 +3,7 @@
 package main
 
 import (
+	"os"
 	"runtime"
 	"testing"
 
@@ -30,3 +31,23 @@ func TestDaemonParseShmSize(t *testing.T) {
 		t.Fatalf("expected default shm size %d, got %d", expectedValue, conf.ShmSize.Value())
 	}
 }
+
+func TestSetRootlessDefaults(t *testing.T) {
+	defer os.Setenv("XDG_DATA_HOME", os.Getenv("XDG_DATA_HOME"))
+	defer os.Setenv("XDG_RUNTIME_DIR", os.Getenv("XDG_RUNTIME_DIR"))
+	os.Setenv("XDG_DATA_HOME", "/home/user/data")
+	os.Setenv("XDG_RUNTIME_DIR", "/run/user/1000")
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	conf := &config.Config{}
+	installConfigFlags(conf, flags)
+	assert.NilError(t, flags.Set("rootless", "true"))
+	assert.NilError(t, flags.Set("pidfile", "/tmp/docker.pid"))
+
+	assert.NilError(t, setRootlessDefaults(conf))
+	assert.Equal(t, conf.Root, "/home/user/data/docker")
+	assert.Equal(t, conf.ExecRoot, "/run/user/1000/docker")
+	assert.Equal(t, conf.Pidfile, "/tmp/docker.pid")
+	assert.DeepEqual(t, conf.Hosts, []string{"unix:///run/user/1000/docker.sock"})
+	assert.Equal(t, conf.SocketGroup, "")
+}
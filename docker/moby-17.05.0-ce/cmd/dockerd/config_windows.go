@@ -23,3 +23,9 @@ func installConfigFlags(conf *config.Config, flags *pflag.FlagSet) {
 	flags.StringVarP(&conf.BridgeConfig.Iface, "bridge", "b", "", "Attach containers to a virtual switch")
 	flags.StringVarP(&conf.SocketGroup, "group", "G", "", "Users or groups that can access the named pipe")
 }
+
+// setRootlessDefaults does nothing, as rootless mode isn't supported on this
+// platform.
+func setRootlessDefaults(conf *config.Config) error {
+	return nil
+}
@@ -23,10 +23,12 @@ import (
 	"github.com/docker/docker/api/server/router/network"
 	pluginrouter "github.com/docker/docker/api/server/router/plugin"
 	swarmrouter "github.com/docker/docker/api/server/router/swarm"
+	sessionrouter "github.com/docker/docker/api/server/router/session"
 	systemrouter "github.com/docker/docker/api/server/router/system"
 	lxcfsrouter "github.com/docker/docker/api/server/router/lxcfs"
 	"github.com/docker/docker/api/server/router/volume"
 	"github.com/docker/docker/builder/dockerfile"
+	"github.com/docker/docker/builder/session"
 	cliconfig "github.com/docker/docker/cli/config"
 	"github.com/docker/docker/cli/debug"
 	cliflags "github.com/docker/docker/cli/flags"
@@ -42,6 +44,7 @@ import (
 	"github.com/docker/docker/pkg/listeners"
 	"github.com/docker/docker/pkg/pidfile"
 	"github.com/docker/docker/pkg/plugingetter"
+	"github.com/docker/docker/pkg/rootless"
 	"github.com/docker/docker/pkg/signal"
 	"github.com/docker/docker/pkg/system"
 	"github.com/docker/docker/plugin"
@@ -75,7 +78,7 @@ func migrateKey(config *config.Config) (err error) {
 
 	// Migrate trust key if exists at ~/.docker/key.json and owned by current user
 	oldPath := filepath.Join(cliconfig.Dir(), cliflags.DefaultTrustKeyFile)
-	newPath := filepath.Join(getDaemonConfDir(config.Root), cliflags.DefaultTrustKeyFile)
+	newPath := filepath.Join(getDaemonConfDir(config), cliflags.DefaultTrustKeyFile)
 	if _, statErr := os.Stat(newPath); os.IsNotExist(statErr) && currentUserIsOwner(oldPath) {
 		defer func() {
 			// Ensure old path is removed if no error occurred
@@ -87,7 +90,7 @@ func migrateKey(config *config.Config) (err error) {
 			}
 		}()
 
-		if err := system.MkdirAll(getDaemonConfDir(config.Root), os.FileMode(0644)); err != nil {
+		if err := system.MkdirAll(getDaemonConfDir(config), os.FileMode(0644)); err != nil {
 			return fmt.Errorf("Unable to create daemon configuration directory: %s", err)
 		}
 
@@ -129,9 +132,22 @@ func (cli *DaemonCli) start(opts daemonOptions) (err error) {
 	cli.configFile = &opts.configFile //默认/etc/docker/daemon.json
 	cli.flags = opts.flags
 
+	if cli.Config.IsRootless() {
+		if !rootless.RunningInChild() {
+			if os.Geteuid() == 0 {
+				return fmt.Errorf("--rootless is for running the daemon as an unprivileged user")
+			}
+			// Start the daemon again in a user namespace, where it is root.
+			return rootless.Run(cli.Config.GetExecRoot())
+		}
+		if err := rootless.InitChild(); err != nil {
+			return err
+		}
+	}
+
 	if opts.common.TrustKey == "" {
 		opts.common.TrustKey = filepath.Join(
-			getDaemonConfDir(cli.Config.Root),
+			getDaemonConfDir(cli.Config),
 			cliflags.DefaultTrustKeyFile)
 	}
 
@@ -301,7 +317,7 @@ func (cli *DaemonCli) start(opts daemonOptions) (err error) {
 		if !d.HasExperimental() {
 			return fmt.Errorf("metrics-addr is only supported when experimental is enabled")
 		}
-		if err := startMetricsServer(cli.Config.MetricsAddress); err != nil {
+		if err := startMetricsServer(cli.Config.MetricsAddress, d.ContainerMetricsHandler()); err != nil {
 			return err
 		}
 	}
@@ -336,8 +352,14 @@ func (cli *DaemonCli) start(opts daemonOptions) (err error) {
 	cli.d = d
 
 	d.SetCluster(c)
+
+	sm, err := session.NewManager(filepath.Join(cli.Config.Root, "builder"))
+	if err != nil {
+		return fmt.Errorf("failed to create session manager: %v", err)
+	}
+
 	// 注册api消息处理函数,daemon命令的处理可以在这里面查
-	initRouter(api, d, c)
+	initRouter(api, d, c, sm)
 
 	//重新信号处理，受到指定信号，加载/etc/docker/daemon.json 配置到内存
 	cli.setupConfigReloadTrap()  //  设置一个系统调用重新加载配置
@@ -471,6 +493,10 @@ func loadDaemonCliConfig(opts daemonOptions) (*config.Config, error) {
 		}
 	}
 
+	if err := setRootlessDefaults(conf); err != nil {
+		return nil, err
+	}
+
 	if err := config.Validate(conf); err != nil {
 		return nil, err
 	}
@@ -510,7 +536,7 @@ func loadDaemonCliConfig(opts daemonOptions) (*config.Config, error) {
 
 //在 cmd/dockerd/daemon.go 文件 initRouter 函数中 初始化 router
 //客户端通过//newDockerCommand->AddCommands构建命令请求发往daemon，daemon收到后通过initRouter中初始化的handler来执行对应job
-func initRouter(s *apiserver.Server, d *daemon.Daemon, c *cluster.Cluster) {
+func initRouter(s *apiserver.Server, d *daemon.Daemon, c *cluster.Cluster, sm *session.Manager) {
 	decoder := runconfig.ContainerDecoder{}
 
 	//routers数组存储各种router信息，NewRouter中对应各种API的匹配
@@ -528,7 +554,8 @@ func initRouter(s *apiserver.Server, d *daemon.Daemon, c *cluster.Cluster) {
 		systemrouter.NewRouter(d, c),
 		lxcfsrouter.NewRouter(d),
 		volume.NewRouter(d),
-		build.NewRouter(dockerfile.NewBuildManager(d)),
+		build.NewRouter(dockerfile.NewBuildManager(d, sm)),
+		sessionrouter.NewRouter(sm),
 		swarmrouter.NewRouter(c),
 		pluginrouter.NewRouter(d.PluginManager()),
 	}
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"syscall"
 
+	"github.com/docker/docker/daemon/config"
 	"github.com/docker/docker/libcontainerd"
 	"github.com/docker/docker/pkg/system"
 )
@@ -38,7 +39,7 @@ func setDefaultUmask() error {
 	return nil
 }
 
-func getDaemonConfDir(_ string) string {
+func getDaemonConfDir(_ *config.Config) string {
 	return "/etc/docker"
 }
 
@@ -13,7 +13,9 @@ import (
 
 	"github.com/docker/docker/cmd/dockerd/hack"
 	"github.com/docker/docker/daemon"
+	"github.com/docker/docker/daemon/config"
 	"github.com/docker/docker/libcontainerd"
+	"github.com/docker/docker/pkg/rootless"
 	"github.com/docker/docker/pkg/system"
 	"github.com/docker/libnetwork/portallocator"
 )
@@ -44,7 +46,12 @@ func setDefaultUmask() error {
 	return nil
 }
 
-func getDaemonConfDir(_ string) string {
+func getDaemonConfDir(conf *config.Config) string {
+	if conf.Rootless {
+		if dir, err := rootless.ConfigHome(); err == nil {
+			return filepath.Join(dir, "docker")
+		}
+	}
 	return "/etc/docker"
 }
 
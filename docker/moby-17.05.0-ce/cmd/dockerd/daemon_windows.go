@@ -8,6 +8,7 @@ import (
 	"syscall"
 
 	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/daemon/config"
 	"github.com/docker/docker/libcontainerd"
 	"github.com/docker/docker/pkg/system"
 )
@@ -25,8 +26,8 @@ func setDefaultUmask() error {
 	return nil
 }
 
-func getDaemonConfDir(root string) string {
-	return filepath.Join(root, `\config`)
+func getDaemonConfDir(conf *config.Config) string {
+	return filepath.Join(conf.Root, `\config`)
 }
 
 // preNotifySystem sends a message to the host when the API is active, but before the daemon is
@@ -8,7 +8,7 @@ import (
 	metrics "github.com/docker/go-metrics"
 )
 
-func startMetricsServer(addr string) error {
+func startMetricsServer(addr string, containers http.Handler) error {
 	if err := allocateDaemonPort(addr); err != nil {
 		return err
 	}
@@ -18,6 +18,7 @@ func startMetricsServer(addr string) error {
 	}
 	mux := http.NewServeMux()
 	mux.Handle("/metrics", metrics.Handler())
+	mux.Handle("/metrics/containers", containers)
 	go func() {
 		if err := http.Serve(l, mux); err != nil {
 			logrus.Errorf("serve metrics api: %s", err)
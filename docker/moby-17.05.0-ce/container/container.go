@@ -24,6 +24,7 @@ import (
 	"github.com/docker/docker/daemon/exec"
 	"github.com/docker/docker/daemon/logger"
 	"github.com/docker/docker/daemon/logger/jsonfilelog"
+	"github.com/docker/docker/daemon/logger/loggerutils/cache"
 	"github.com/docker/docker/daemon/network"
 	"github.com/docker/docker/image"
 	"github.com/docker/docker/layer"
@@ -302,6 +303,12 @@ func (container *Container) GetRootResourcePath(path string) (string, error) {
 	return symlink.FollowSymlinkInScope(filepath.Join(container.Root, cleanPath), container.Root)
 }
 
+// LogCachePath returns the path to the local log cache of the container,
+// which is written alongside logging drivers that cannot read logs.
+func (container *Container) LogCachePath() (string, error) {
+	return container.GetRootResourcePath(fmt.Sprintf("%s-cache.log", container.ID))
+}
+
 // ExitOnNext signals to the monitor that it should not restart the container
 // after we send the kill signal.
 func (container *Container) ExitOnNext() {
@@ -358,6 +365,27 @@ func (container *Container) StartLogger() (logger.Logger, error) {
 		return nil, err
 	}
 
+	if cache.Enabled(cfg.Config) {
+		cachePath, err := container.LogCachePath()
+		if err != nil {
+			l.Close()
+			return nil, err
+		}
+		cached, err := cache.WithLocalCache(l, info, cachePath)
+		if err != nil {
+			l.Close()
+			return nil, err
+		}
+		l = cached
+	}
+
+	parser, err := logger.NewParserLogger(l, info)
+	if err != nil {
+		l.Close()
+		return nil, err
+	}
+	l = parser
+
 	if containertypes.LogMode(cfg.Config["mode"]) == containertypes.LogModeNonBlock {
 		bufferSize := int64(-1)
 		if s, exists := cfg.Config["max-buffer-size"]; exists {
@@ -914,16 +942,21 @@ func (container *Container) startLogging() error {
 		return fmt.Errorf("failed to initialize logging driver: %v", err)
 	}
 
+	// set LogPath field only for json-file logdriver, which may be wrapped
+	// by the log parser
+	if container.HostConfig.LogConfig.Type == jsonfilelog.Name {
+		container.LogPath, err = container.GetRootResourcePath(fmt.Sprintf("%s-json.log", container.ID))
+		if err != nil {
+			l.Close()
+			return err
+		}
+	}
+
 	copier := logger.NewCopier(map[string]io.Reader{"stdout": container.StdoutPipe(), "stderr": container.StderrPipe()}, l)
 	container.LogCopier = copier
 	copier.Run()
 	container.LogDriver = l
 
-	// set LogPath field only for json-file logdriver
-	if jl, ok := l.(*jsonfilelog.JSONFileLogger); ok {
-		container.LogPath = jl.LogPath()
-	}
-
 	return nil
 }
 
@@ -1,9 +1,20 @@
 package daemon
 
 import (
+	"io"
+
 	"github.com/Sirupsen/logrus"
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/builder"
+	"github.com/docker/docker/distribution"
+	progressutils "github.com/docker/docker/distribution/utils"
+	"github.com/docker/docker/image"
 	"github.com/docker/docker/image/cache"
+	"github.com/docker/docker/pkg/progress"
+	"github.com/docker/docker/registry"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
 )
 
 // MakeImageCache creates a stateful image cache.
@@ -25,3 +36,111 @@ func (daemon *Daemon) MakeImageCache(sourceRefs []string) builder.ImageCache {
 
 	return cache
 }
+
+// ImportBuildCache pulls the build cache `name` from a registry, and returns
+// the IDs of its images, which can be used as the sources of an image cache.
+func (daemon *Daemon) ImportBuildCache(ctx context.Context, name string, authConfigs map[string]types.AuthConfig, output io.Writer) ([]string, error) {
+	ref, err := reference.ParseNormalizedNamed(name)
+	if err != nil {
+		return nil, err
+	}
+	ref = reference.TagNameOnly(ref)
+	authConfig, err := daemon.resolveBuildAuthConfig(ref, authConfigs)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []image.ID
+	err = daemon.withDistributionProgress(ctx, output, func(ctx context.Context, progressOutput progress.Output) error {
+		ids, err = distribution.PullBuildCache(ctx, ref, daemon.imageStore, daemon.layerStore, &distribution.ImagePullConfig{
+			Config: distribution.Config{
+				AuthConfig:       authConfig,
+				ProgressOutput:   progressOutput,
+				RegistryService:  daemon.RegistryService,
+				ImageEventLogger: func(string, string, string) {},
+				MetadataStore:    daemon.distributionMetadataStore,
+			},
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var imageIDs []string
+	for _, id := range ids {
+		imageIDs = append(imageIDs, id.String())
+	}
+	return imageIDs, nil
+}
+
+// ExportBuildCache pushes the images with the IDs to a registry as the build
+// cache `name`.
+func (daemon *Daemon) ExportBuildCache(ctx context.Context, name string, imageIDs []string, authConfigs map[string]types.AuthConfig, output io.Writer) error {
+	ref, err := reference.ParseNormalizedNamed(name)
+	if err != nil {
+		return err
+	}
+	tagged, ok := reference.TagNameOnly(ref).(reference.NamedTagged)
+	if !ok {
+		return errors.Errorf("build cache %s must be a tag", name)
+	}
+	authConfig, err := daemon.resolveBuildAuthConfig(tagged, authConfigs)
+	if err != nil {
+		return err
+	}
+
+	var ids []image.ID
+	for _, id := range imageIDs {
+		ids = append(ids, image.ID(id))
+	}
+	return daemon.withDistributionProgress(ctx, output, func(ctx context.Context, progressOutput progress.Output) error {
+		return distribution.PushBuildCache(ctx, tagged, ids, daemon.imageStore, daemon.layerStore, &distribution.ImagePushConfig{
+			Config: distribution.Config{
+				AuthConfig:       authConfig,
+				ProgressOutput:   progressOutput,
+				RegistryService:  daemon.RegistryService,
+				ImageEventLogger: func(string, string, string) {},
+				MetadataStore:    daemon.distributionMetadataStore,
+			},
+			TrustKey:      daemon.trustKey,
+			UploadManager: daemon.uploadManager,
+		})
+	})
+}
+
+// resolveBuildAuthConfig returns the auth config of the registry of ref from
+// the auth configs of a build.
+func (daemon *Daemon) resolveBuildAuthConfig(ref reference.Named, authConfigs map[string]types.AuthConfig) (*types.AuthConfig, error) {
+	if len(authConfigs) == 0 {
+		return &types.AuthConfig{}, nil
+	}
+	// The request came with a full auth config file, we prefer to use that
+	repoInfo, err := daemon.RegistryService.ResolveRepository(ref)
+	if err != nil {
+		return nil, err
+	}
+	resolvedConfig := registry.ResolveAuthConfig(authConfigs, repoInfo.Index)
+	return &resolvedConfig, nil
+}
+
+// withDistributionProgress runs fn, and writes the progress that fn reports
+// to outStream.
+func (daemon *Daemon) withDistributionProgress(ctx context.Context, outStream io.Writer, fn func(context.Context, progress.Output) error) error {
+	// Include a buffer so that slow client connections don't affect
+	// transfer performance.
+	progressChan := make(chan progress.Progress, 100)
+	writesDone := make(chan struct{})
+
+	ctx, cancelFunc := context.WithCancel(ctx)
+
+	go func() {
+		progressutils.WriteDistributionProgress(cancelFunc, outStream, progressChan)
+		close(writesDone)
+	}()
+
+	err := fn(ctx, progress.ChanOutput(progressChan))
+	close(progressChan)
+	<-writesDone
+	return err
+}
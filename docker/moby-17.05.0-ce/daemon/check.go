@@ -0,0 +1,66 @@
+package daemon
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/image"
+	"github.com/docker/docker/layer"
+	refstore "github.com/docker/docker/reference"
+)
+
+// SystemCheck checks the integrity of the layers, the read-write layers of
+// the containers, the data of the storage driver and the image references.
+// When repair is set, the problems which can be repaired are.
+func (daemon *Daemon) SystemCheck(repair bool) (*types.SystemCheckReport, error) {
+	report := &types.SystemCheckReport{Problems: []types.SystemCheckProblem{}}
+
+	if cs, ok := daemon.layerStore.(layer.CheckableStore); ok {
+		problems, err := cs.Check(layer.CheckOptions{
+			Repair: repair,
+			MountInUse: func(name string) bool {
+				// The read-write layers are named after their container.
+				_, err := os.Stat(filepath.Join(daemon.repository, name))
+				return err == nil
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range problems {
+			report.Problems = append(report.Problems, types.SystemCheckProblem{
+				Type:        p.Type,
+				ID:          p.ID,
+				Description: p.Description,
+				Repair:      p.Repair,
+			})
+		}
+	}
+
+	if rs, ok := daemon.referenceStore.(refstore.ListableStore); ok {
+		for _, a := range rs.Associations() {
+			if _, err := daemon.imageStore.Get(image.IDFromDigest(a.ID)); err == nil {
+				continue
+			}
+			p := types.SystemCheckProblem{
+				Type:        "reference",
+				ID:          reference.FamiliarString(a.Ref),
+				Description: "the image " + a.ID.String() + " of the reference doesn't exist",
+			}
+			if repair {
+				if _, err := rs.Delete(a.Ref); err != nil {
+					logrus.Errorf("Failed to remove the reference %s: %v", p.ID, err)
+				} else {
+					p.Repair = "removed"
+					daemon.LogImageEvent(a.ID.String(), p.ID, "untag")
+				}
+			}
+			report.Problems = append(report.Problems, p)
+		}
+	}
+
+	return report, nil
+}
@@ -1,11 +1,20 @@
 package convert
 
 import (
+	"encoding/json"
+	"fmt"
+
 	swarmtypes "github.com/docker/docker/api/types/swarm"
 	swarmapi "github.com/docker/swarmkit/api"
 	gogotypes "github.com/gogo/protobuf/types"
 )
 
+// SecretDriverLabel marks secrets whose value is supplied by a secret
+// provider plugin. The swarm store has no field for the driver, so the
+// payload of such a secret is the JSON encoded driver instead of its value.
+// The label is reserved, it can't be set by users.
+const SecretDriverLabel = "com.docker.secret.driver"
+
 // SecretFromGRPC converts a grpc Secret to a Secret.
 func SecretFromGRPC(s *swarmapi.Secret) swarmtypes.Secret {
 	secret := swarmtypes.Secret{
@@ -16,6 +25,25 @@ func SecretFromGRPC(s *swarmapi.Secret) swarmtypes.Secret {
 		},
 	}
 
+	if name, ok := s.Spec.Annotations.Labels[SecretDriverLabel]; ok {
+		// The payload is scrubbed by the managers, so the driver
+		// options are usually not available here.
+		driver, err := SecretDriverFromGRPC(s)
+		if err != nil || driver == nil {
+			driver = &swarmtypes.Driver{Name: name}
+		}
+		secret.Spec.Driver = driver
+		secret.Spec.Data = nil
+
+		labels := make(map[string]string, len(secret.Spec.Labels))
+		for k, v := range secret.Spec.Labels {
+			if k != SecretDriverLabel {
+				labels[k] = v
+			}
+		}
+		secret.Spec.Labels = labels
+	}
+
 	secret.Version.Index = s.Meta.Version.Index
 	// Meta
 	secret.CreatedAt, _ = gogotypes.TimestampFromProto(s.Meta.CreatedAt)
@@ -25,14 +53,81 @@ func SecretFromGRPC(s *swarmapi.Secret) swarmtypes.Secret {
 }
 
 // SecretSpecToGRPC converts Secret to a grpc Secret.
-func SecretSpecToGRPC(s swarmtypes.SecretSpec) swarmapi.SecretSpec {
-	return swarmapi.SecretSpec{
+func SecretSpecToGRPC(s swarmtypes.SecretSpec) (swarmapi.SecretSpec, error) {
+	if _, ok := s.Labels[SecretDriverLabel]; ok {
+		return swarmapi.SecretSpec{}, fmt.Errorf("label %s is reserved", SecretDriverLabel)
+	}
+	spec := swarmapi.SecretSpec{
 		Annotations: swarmapi.Annotations{
 			Name:   s.Name,
 			Labels: s.Labels,
 		},
 		Data: s.Data,
 	}
+
+	if s.Driver != nil {
+		data, err := json.Marshal(s.Driver)
+		if err != nil {
+			return swarmapi.SecretSpec{}, err
+		}
+		labels := make(map[string]string, len(s.Labels)+1)
+		for k, v := range s.Labels {
+			labels[k] = v
+		}
+		labels[SecretDriverLabel] = s.Driver.Name
+		spec.Annotations.Labels = labels
+		spec.Data = data
+	}
+
+	return spec, nil
+}
+
+// SecretUpdateSpecToGRPC converts the Secret of an update of the grpc Secret
+// current to a grpc Secret. Only the labels of a secret can be updated, so
+// the payload of a secret of a secret provider plugin, which holds the driver
+// and its options, is kept as is.
+func SecretUpdateSpecToGRPC(s swarmtypes.SecretSpec, current *swarmapi.Secret) (swarmapi.SecretSpec, error) {
+	name, ok := current.Spec.Annotations.Labels[SecretDriverLabel]
+	if !ok {
+		return SecretSpecToGRPC(s)
+	}
+	if _, ok := s.Labels[SecretDriverLabel]; ok {
+		return swarmapi.SecretSpec{}, fmt.Errorf("label %s is reserved", SecretDriverLabel)
+	}
+	if len(s.Data) > 0 || (s.Driver != nil && s.Driver.Name != name) {
+		return swarmapi.SecretSpec{}, fmt.Errorf("only updates to Labels are allowed")
+	}
+
+	labels := make(map[string]string, len(s.Labels)+1)
+	for k, v := range s.Labels {
+		labels[k] = v
+	}
+	labels[SecretDriverLabel] = name
+	// A spec without data keeps the payload of the secret.
+	return swarmapi.SecretSpec{
+		Annotations: swarmapi.Annotations{
+			Name:   s.Name,
+			Labels: labels,
+		},
+	}, nil
+}
+
+// SecretDriverFromGRPC returns the secret provider driver of a grpc Secret,
+// or nil if the value of the secret is stored in the swarm. The payload of
+// the secret is only available to the agents the secret was sent to.
+func SecretDriverFromGRPC(s *swarmapi.Secret) (*swarmtypes.Driver, error) {
+	if _, ok := s.Spec.Annotations.Labels[SecretDriverLabel]; !ok {
+		return nil, nil
+	}
+	if len(s.Spec.Data) == 0 {
+		return nil, nil
+	}
+
+	var driver swarmtypes.Driver
+	if err := json.Unmarshal(s.Spec.Data, &driver); err != nil {
+		return nil, err
+	}
+	return &driver, nil
 }
 
 // SecretReferencesFromGRPC converts a slice of grpc SecretReference to SecretReference
@@ -0,0 +1,61 @@
+package convert
+
+import (
+	"encoding/json"
+	"testing"
+
+	swarmtypes "github.com/docker/docker/api/types/swarm"
+	"github.com/docker/docker/pkg/testutil/assert"
+	swarmapi "github.com/docker/swarmkit/api"
+)
+
+func TestSecretSpecToGRPCReservedLabel(t *testing.T) {
+	_, err := SecretSpecToGRPC(swarmtypes.SecretSpec{
+		Annotations: swarmtypes.Annotations{
+			Name:   "db",
+			Labels: map[string]string{SecretDriverLabel: "vault"},
+		},
+		Data: []byte("secret"),
+	})
+	assert.Error(t, err, "is reserved")
+}
+
+func TestSecretUpdateSpecToGRPC(t *testing.T) {
+	driver := &swarmtypes.Driver{Name: "vault", Options: map[string]string{"path": "secret/db"}}
+	spec, err := SecretSpecToGRPC(swarmtypes.SecretSpec{
+		Annotations: swarmtypes.Annotations{Name: "db"},
+		Driver:      driver,
+	})
+	assert.NilError(t, err)
+	current := &swarmapi.Secret{ID: "id", Spec: spec}
+
+	// The update is made from the inspect result, where the options of the
+	// driver are scrubbed.
+	update := SecretFromGRPC(&swarmapi.Secret{
+		ID: "id",
+		Spec: swarmapi.SecretSpec{
+			Annotations: current.Spec.Annotations,
+		},
+	}).Spec
+	assert.DeepEqual(t, update.Driver, &swarmtypes.Driver{Name: "vault"})
+	update.Labels = map[string]string{"env": "prod"}
+
+	updated, err := SecretUpdateSpecToGRPC(update, current)
+	assert.NilError(t, err)
+	assert.Equal(t, len(updated.Data), 0)
+	assert.DeepEqual(t, updated.Annotations.Labels, map[string]string{"env": "prod", SecretDriverLabel: "vault"})
+
+	// The payload kept by the swarm still holds the options.
+	var stored swarmtypes.Driver
+	assert.NilError(t, json.Unmarshal(current.Spec.Data, &stored))
+	assert.DeepEqual(t, &stored, driver)
+
+	update.Labels = map[string]string{SecretDriverLabel: "other"}
+	_, err = SecretUpdateSpecToGRPC(update, current)
+	assert.Error(t, err, "is reserved")
+
+	update.Labels = nil
+	update.Driver = &swarmtypes.Driver{Name: "other"}
+	_, err = SecretUpdateSpecToGRPC(update, current)
+	assert.Error(t, err, "only updates to Labels are allowed")
+}
@@ -1,6 +1,8 @@
 package cluster
 
 import (
+	"fmt"
+
 	apitypes "github.com/docker/docker/api/types"
 	types "github.com/docker/docker/api/types/swarm"
 	"github.com/docker/docker/daemon/cluster/convert"
@@ -61,7 +63,14 @@ func (c *Cluster) GetSecrets(options apitypes.SecretListOptions) ([]types.Secret
 func (c *Cluster) CreateSecret(s types.SecretSpec) (string, error) {
 	var resp *swarmapi.CreateSecretResponse
 	if err := c.lockedManagerAction(func(ctx context.Context, state nodeState) error {
-		secretSpec := convert.SecretSpecToGRPC(s)
+		if s.Driver != nil && len(s.Data) > 0 {
+			return fmt.Errorf("secret data must be empty when a secret driver is specified")
+		}
+
+		secretSpec, err := convert.SecretSpecToGRPC(s)
+		if err != nil {
+			return err
+		}
 
 		r, err := state.controlClient.CreateSecret(ctx,
 			&swarmapi.CreateSecretRequest{Spec: &secretSpec})
@@ -102,7 +111,10 @@ func (c *Cluster) UpdateSecret(input string, version uint64, spec types.SecretSp
 			return err
 		}
 
-		secretSpec := convert.SecretSpecToGRPC(spec)
+		secretSpec, err := convert.SecretUpdateSpecToGRPC(spec, secret)
+		if err != nil {
+			return err
+		}
 
 		_, err = state.controlClient.UpdateSecret(ctx,
 			&swarmapi.UpdateSecretRequest{
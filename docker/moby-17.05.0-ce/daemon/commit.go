@@ -15,6 +15,7 @@ import (
 	"github.com/docker/docker/dockerversion"
 	"github.com/docker/docker/image"
 	"github.com/docker/docker/layer"
+	"github.com/docker/docker/pkg/archive"
 	"github.com/docker/docker/pkg/ioutils"
 	"github.com/pkg/errors"
 )
@@ -100,6 +101,12 @@ func merge(userConf, imageConf *containertypes.Config) error {
 			if userConf.Healthcheck.Retries == 0 {
 				userConf.Healthcheck.Retries = imageConf.Healthcheck.Retries
 			}
+			if userConf.Healthcheck.MemoryPressure == 0 {
+				userConf.Healthcheck.MemoryPressure = imageConf.Healthcheck.MemoryPressure
+			}
+			if userConf.Healthcheck.MemoryPressureDuration == 0 {
+				userConf.Healthcheck.MemoryPressureDuration = imageConf.Healthcheck.MemoryPressureDuration
+			}
 		}
 	}
 
@@ -159,6 +166,14 @@ func (daemon *Daemon) Commit(name string, c *backend.ContainerCommitConfig) (str
 			rwTar.Close()
 		}
 	}()
+	if !c.SourceDateEpoch.IsZero() {
+		reproducibleTar, err := archive.ReproducibleTar(rwTar, c.SourceDateEpoch)
+		if err != nil {
+			return "", err
+		}
+		rwTar.Close()
+		rwTar = reproducibleTar
+	}
 
 	var history []image.History
 	rootFS := image.NewRootFS()
@@ -182,9 +197,20 @@ func (daemon *Daemon) Commit(name string, c *backend.ContainerCommitConfig) (str
 	}
 	defer layer.ReleaseAndLog(daemon.layerStore, l)
 
+	created := time.Now().UTC()
+	containerID := container.ID
+	containerConfig := *container.Config
+	if !c.SourceDateEpoch.IsZero() {
+		// The image doesn't depend on the container that it was committed
+		// from.
+		created = c.SourceDateEpoch.UTC()
+		containerID = ""
+		containerConfig.Hostname = ""
+	}
+
 	h := image.History{
 		Author:     c.Author,
-		Created:    time.Now().UTC(),
+		Created:    created,
 		CreatedBy:  strings.Join(container.Config.Cmd, " "),
 		Comment:    c.Comment,
 		EmptyLayer: true,
@@ -203,8 +229,8 @@ func (daemon *Daemon) Commit(name string, c *backend.ContainerCommitConfig) (str
 			Config:          newConfig,
 			Architecture:    runtime.GOARCH,
 			OS:              runtime.GOOS,
-			Container:       container.ID,
-			ContainerConfig: *container.Config,
+			Container:       containerID,
+			ContainerConfig: containerConfig,
 			Author:          c.Author,
 			Created:         h.Created,
 		},
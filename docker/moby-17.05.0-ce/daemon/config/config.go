@@ -110,6 +110,9 @@ type CommonConfig struct { //包含在config\config_unix.go中的 Config 结构
 	GraphDriver          string                    `json:"storage-driver,omitempty"`
 	//可设置的存储驱动选项
 	GraphOptions         []string                  `json:"storage-opts,omitempty"`
+	// MigrateStorageFrom is the storage driver whose images and containers
+	// are migrated to GraphDriver when the daemon starts.
+	MigrateStorageFrom   string                    `json:"migrate-storage-from,omitempty"`
 	Labels               []string                  `json:"labels,omitempty"`
 	//赋值见setDefaultMtu
 	Mtu                  int                       `json:"mtu,omitempty"`
@@ -154,6 +157,10 @@ type CommonConfig struct { //包含在config\config_unix.go中的 Config 结构
 	// to stop when daemon is being shutdown
 	ShutdownTimeout int `json:"shutdown-timeout,omitempty"`
 
+	// AnonymousVolumeGCDays is the number of days after which the unused
+	// anonymous local volumes are removed. They are never removed if it is 0.
+	AnonymousVolumeGCDays int `json:"anonymous-volume-gc-days,omitempty"`
+
 	//赋值见loadDaemonCliConfig
 	Debug     bool     `json:"debug,omitempty"`
 	Hosts     []string `json:"hosts,omitempty"`
@@ -505,6 +512,10 @@ func Validate(config *Config) error {
 	if config.MaxConcurrentUploads != nil && *config.MaxConcurrentUploads < 0 {
 		return fmt.Errorf("invalid max concurrent uploads: %d", *config.MaxConcurrentUploads)
 	}
+	// validate AnonymousVolumeGCDays
+	if config.AnonymousVolumeGCDays < 0 {
+		return fmt.Errorf("invalid anonymous volume gc days: %d", config.AnonymousVolumeGCDays)
+	}
 
 	// validate that "default" runtime is not reset
 	if runtimes := config.GetAllRuntimes(); len(runtimes) > 0 {
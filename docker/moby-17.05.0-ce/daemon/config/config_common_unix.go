@@ -26,6 +26,8 @@ type CommonUnixConfig struct {
 	LxcfsOffMultithread  bool                `json:"lxcfs-off-multithread,omitempty"`
 	LxcfsAllowOther   bool                   `json:"lxcfs-allow-other,omitempty"`
 	LxcfsMountPath     string                   `json:"lxcfs-mount-path,omitempty"`
+	// LxcfsMode is the lxcfs mode of containers that do not set HostConfig.Lxcfs
+	LxcfsMode          string                   `json:"lxcfs-mode,omitempty"`
 	
 	//runc成员赋值见 verifyDaemonSettings
 	Runtimes          map[string]types.Runtime `json:"runtimes,omitempty"`
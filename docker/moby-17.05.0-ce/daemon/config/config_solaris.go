@@ -27,3 +27,9 @@ type BridgeConfig struct {
 func (conf *Config) IsSwarmCompatible() error {
 	return nil
 }
+
+// IsRootless returns whether the daemon runs as an unprivileged user, which
+// isn't supported on this platform.
+func (conf *Config) IsRootless() bool {
+	return false
+}
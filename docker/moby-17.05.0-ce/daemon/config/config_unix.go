@@ -34,6 +34,7 @@ type Config struct {
 	SeccompProfile       string                   `json:"seccomp-profile,omitempty"`
 	ShmSize              opts.MemBytes            `json:"default-shm-size,omitempty"`
 	NoNewPrivileges      bool                     `json:"no-new-privileges,omitempty"`
+	Rootless             bool                     `json:"rootless,omitempty"`
 }
 
 // BridgeConfig stores all the bridge driver specific
@@ -67,3 +68,8 @@ func (conf *Config) IsSwarmCompatible() error {
 	}
 	return nil
 }
+
+// IsRootless returns whether the daemon runs as an unprivileged user.
+func (conf *Config) IsRootless() bool {
+	return conf.Rootless
+}
@@ -50,3 +50,9 @@ func (conf *Config) GetExecRoot() string {
 func (conf *Config) IsSwarmCompatible() error {
 	return nil
 }
+
+// IsRootless returns whether the daemon runs as an unprivileged user, which
+// isn't supported on this platform.
+func (conf *Config) IsRootless() bool {
+	return false
+}
@@ -285,6 +285,14 @@ func (daemon *Daemon) verifyContainerSettings(hostConfig *containertypes.HostCon
 			if config.Healthcheck.StartPeriod < 0 {
 				return nil, fmt.Errorf("StartPeriod in Healthcheck cannot be negative")
 			}
+
+			if config.Healthcheck.MemoryPressure < 0 || config.Healthcheck.MemoryPressure > 100 {
+				return nil, fmt.Errorf("MemoryPressure in Healthcheck should be between 0 and 100")
+			}
+
+			if config.Healthcheck.MemoryPressureDuration < 0 {
+				return nil, fmt.Errorf("MemoryPressureDuration in Healthcheck cannot be negative")
+			}
 		}
 	}
 
@@ -571,6 +571,10 @@ func (daemon *Daemon) allocateNetwork(container *container.Container) error {
 
 	}
 
+	if err := daemon.forwardRootlessPorts(container); err != nil {
+		return err
+	}
+
 	//hostconfig写入hostconfig.json文件
 	if err := container.WriteHostConfig(); err != nil {
 		return err
@@ -945,6 +949,7 @@ func (daemon *Daemon) releaseNetwork(container *container.Container) {
 
 	sid := container.NetworkSettings.SandboxID
 	settings := container.NetworkSettings.Networks
+	daemon.releaseRootlessPorts(container)
 	container.NetworkSettings.Ports = nil
 
 	if sid == "" {
@@ -202,7 +202,11 @@ func (daemon *Daemon) setupSecretDir(c *container.Container) (setupErr error) {
 		if secret == nil {
 			return fmt.Errorf("unable to get secret from secret store")
 		}
-		if err := ioutil.WriteFile(fPath, secret.Spec.Data, s.File.Mode); err != nil {
+		data, err := daemon.secretData(c, secret)
+		if err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(fPath, data, s.File.Mode); err != nil {
 			return errors.Wrap(err, "error injecting secret")
 		}
 
@@ -24,8 +24,10 @@ func (daemon *Daemon) createContainerPlatformSpecificSettings(container *contain
 		}
 
 		// If the mountpoint doesn't have a name, generate one.
+		var labels map[string]string
 		if len(mp.Name) == 0 {
 			mp.Name = stringid.GenerateNonCryptoID()
+			labels = map[string]string{volume.AnonymousLabel: ""}
 		}
 
 		// Skip volumes for which we already have something mounted on that
@@ -38,7 +40,7 @@ func (daemon *Daemon) createContainerPlatformSpecificSettings(container *contain
 
 		// Create the volume in the volume driver. If it doesn't exist,
 		// a new one will be created.
-		v, err := daemon.volumes.CreateWithRef(mp.Name, volumeDriver, container.ID, nil, nil)
+		v, err := daemon.volumes.CreateWithRef(mp.Name, volumeDriver, container.ID, nil, labels)
 		if err != nil {
 			return err
 		}
@@ -122,6 +122,15 @@ type Daemon struct { //赋值见NewDaemon 见 NewDaemon
 	//赋值见 NewDaemon   通过NewStoreFromOptions返回  实际上为 layerStore 类型，实现有type Store interface {}中包含的函数
 	//layerStore 存储相关的接口方法，结构，源头都在这里
 	layerStore                layer.Store
+	// migrateLayerStore is the layer store of the storage driver migrated
+	// from, until the containers are restored.
+	migrateLayerStore         layer.Store
+	// migrateFailed are the IDs of the containers whose read-write layer
+	// could not be migrated.
+	migrateFailed             []string
+	// stopVolumesGC is closed on shutdown to stop the garbage collection
+	// of the anonymous volumes.
+	stopVolumesGC             chan struct{}
 	//Store 存储相关的接口方法(docker\image\store.go)，结构，源头都在这里
 	// type store struct (imageConfigStore 包含该类) 这两个结构共同实现image.Store接口的相关方法
 	// (daemon *Daemon) pullImageWithReference 中赋值给 distribution.Config.ImageStore
@@ -147,6 +156,11 @@ type Daemon struct { //赋值见NewDaemon 见 NewDaemon
 
 	seccompProfile     []byte
 	seccompProfilePath string
+
+	// The IDs of the forwardings of the published ports of the containers
+	// in rootless mode.
+	rootlessPortsMu sync.Mutex
+	rootlessPorts   map[string][]int
 }
 
 // HasExperimental returns whether the experimental features of the daemon are enabled or not
@@ -181,8 +195,19 @@ func (daemon *Daemon) restore() error {
 			continue
 		}
 
-		// Ignore the container if it does not support the current driver being used by the graph
-		if (container.Driver == "" && currentDriver == "aufs") || container.Driver == currentDriver {
+		if daemon.isMigratedContainer(container) {
+			rwlayer, err := daemon.migrateContainerLayer(container)
+			if err != nil {
+				logrus.Errorf("Failed to migrate container %v to storage driver %s: %v", id, currentDriver, err)
+				daemon.migrateFailed = append(daemon.migrateFailed, id)
+				continue
+			}
+			container.RWLayer = rwlayer
+			logrus.Debugf("Migrated container %v", container.ID)
+
+			containers[container.ID] = container
+		} else if (container.Driver == "" && currentDriver == "aufs") || container.Driver == currentDriver {
+			// Ignore the container if it does not support the current driver being used by the graph
 			rwlayer, err := daemon.layerStore.GetRWLayer(container.ID)
 			if err != nil {
 				logrus.Errorf("Failed to load container mount %v: %v", id, err)
@@ -587,7 +612,7 @@ func NewDaemon(config *config.Config, registryService registry.Service, containe
 	//建立temp文件的存放路径，读取环境变量DOCKER_TMPDIR值，如果没有那么直接在根路径下建立tmp子目录 ，即/var/lib/docker/tmp
 	os.Setenv("TMPDIR", realTmp)
 
-	d := &Daemon{configStore: config}  //创建个daem 实例
+	d := &Daemon{configStore: config, stopVolumesGC: make(chan struct{})}  //创建个daem 实例
 	// Ensure the daemon is properly shutdown if there is a failure during
 	// initialization
 	defer func() {  //如果该函数有异常， 函数退出时 daemon 关闭
@@ -623,7 +648,10 @@ func NewDaemon(config *config.Config, registryService registry.Service, containe
 		logrus.Warnf("Failed to configure golang's threads limit: %v", err)
 	}
 
-	if err := ensureDefaultAppArmorProfile(); err != nil {// 如果使能 AppArmor ，则加载
+	if config.IsRootless() {
+		// The profile can't be loaded by an unprivileged user.
+		logrus.Info("AppArmor is disabled in rootless mode")
+	} else if err := ensureDefaultAppArmorProfile(); err != nil {// 如果使能 AppArmor ，则加载
 		logrus.Errorf(err.Error())
 	}
 	//创建容器的存储目录，在根目录下创建container子目录，/var/log/docker/container
@@ -642,6 +670,14 @@ func NewDaemon(config *config.Config, registryService registry.Service, containe
 	if driverName == "" {
 		driverName = config.GraphDriver
 	}
+	if driverName == "" && config.IsRootless() {
+		driverName = rootlessGraphDriver(config.Root)
+	}
+
+	pluginExecRoot := getPluginExecRoot(config.Root)
+	if config.IsRootless() {
+		pluginExecRoot = filepath.Join(config.GetExecRoot(), "plugins")
+	}
 
 	d.RegistryService = registryService
 	d.PluginStore = pluginStore
@@ -650,7 +686,7 @@ func NewDaemon(config *config.Config, registryService registry.Service, containe
 	// Plugin system initialization should happen before restore. Do not change order.
 	d.pluginManager, err = plugin.NewManager(plugin.ManagerConfig{ // plugin/manager.go  创建plugin manager 实例
 		Root:               filepath.Join(config.Root, "plugins"),
-		ExecRoot:           getPluginExecRoot(config.Root),
+		ExecRoot:           pluginExecRoot,
 		Store:              d.PluginStore,
 		Executor:           containerdRemote,
 		RegistryService:    registryService,
@@ -665,11 +701,22 @@ func NewDaemon(config *config.Config, registryService registry.Service, containe
 	//设立graph driver，graphdriver主要是来管理镜像，以及镜像与镜像之间关系的实现方法。由于config.GraphDriver的默认值为空，所以主要的处理流程在graphdriver.New()中；
 	//加载的优先级的顺序为 priority = []string{"aufs","btrfs","zfs","devicemapper","overlay","vfs"}，
 	// NewStoreFromOptions creates a new Store instance  // lay/layer_store.go 创建graphDriver实例，从driver创建layer仓库的实例
+	graphOptions := config.GraphOptions
+	var migrateGraphOptions []string
+	if config.MigrateStorageFrom != "" {
+		if driverName == "" {
+			return nil, fmt.Errorf("a storage driver must be set to migrate from storage driver %s", config.MigrateStorageFrom)
+		}
+		if config.LiveRestoreEnabled {
+			return nil, fmt.Errorf("--migrate-storage-from can't be used with --live-restore")
+		}
+		migrateGraphOptions, graphOptions = splitGraphOptions(config.MigrateStorageFrom, config.GraphOptions)
+	}
 	d.layerStore, err = layer.NewStoreFromOptions(layer.StoreOptions { //初始化/var/lib/docker/image/devicemapper/layerdb/相关操作的接口并初始化存储驱动devicemapper等
 		StorePath:                 config.Root,
 		MetadataStorePathTemplate: filepath.Join(config.Root, "image", "%s", "layerdb"),
 		GraphDriver:               driverName,
-		GraphDriverOptions:        config.GraphOptions,
+		GraphDriverOptions:        graphOptions,
 		UIDMaps:                   uidMaps,
 		GIDMaps:                   gidMaps,
 		PluginGetter:              d.PluginStore,
@@ -681,6 +728,39 @@ func NewDaemon(config *config.Config, registryService registry.Service, containe
 
 	graphDriver := d.layerStore.DriverName() // 取layer里面的graphDriver   devicemapper等
 	imageRoot := filepath.Join(config.Root, "image", graphDriver)  ///var/lib/docker/image/devicemapper/
+
+	// The layers of the images are migrated before the image store is
+	// created, and the layers of the containers when they are restored.
+	var migratedLayers []layer.Layer
+	if config.MigrateStorageFrom != "" {
+		if config.MigrateStorageFrom == graphDriver {
+			return nil, fmt.Errorf("cannot migrate from storage driver %s to itself", graphDriver)
+		}
+		d.migrateLayerStore, err = layer.NewStoreFromOptions(layer.StoreOptions{
+			StorePath:                 config.Root,
+			MetadataStorePathTemplate: filepath.Join(config.Root, "image", "%s", "layerdb"),
+			GraphDriver:               config.MigrateStorageFrom,
+			GraphDriverOptions:        migrateGraphOptions,
+			UIDMaps:                   uidMaps,
+			GIDMaps:                   gidMaps,
+			PluginGetter:              d.PluginStore,
+			ExperimentalEnabled:       config.Experimental,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		logrus.Infof("Migrating images from storage driver %s to %s", config.MigrateStorageFrom, graphDriver)
+		migrationStart := time.Now()
+		migratedLayers, err = migrateLayers(d.migrateLayerStore, d.layerStore)
+		if err != nil {
+			return nil, err
+		}
+		if err := migrateImageMetadata(filepath.Join(config.Root, "image"), config.MigrateStorageFrom, graphDriver); err != nil {
+			return nil, err
+		}
+		logrus.Infof("Migration of %d layers took %.2f seconds", len(migratedLayers), time.Since(migrationStart).Seconds())
+	}
 	//设置系统是否使用SELinux，SElinux有个问题是不能和btrfs的graphdriver一起使用；
 	// Configure and validate the kernels security support
 	if err := configureKernelSecuritySupport(config, graphDriver); err != nil {// linux内核安全支持的配置
@@ -707,6 +787,9 @@ func NewDaemon(config *config.Config, registryService registry.Service, containe
 	if err != nil {
 		return nil, err
 	}
+	for _, l := range migratedLayers {
+		layer.ReleaseAndLog(d.layerStore, l)
+	}
 
 	// Configure the volumes driver
 	volStore, err := d.configureVolumes(rootUID, rootGID) // 创建 volumes driver实例
@@ -752,8 +835,8 @@ func NewDaemon(config *config.Config, registryService registry.Service, containe
 
 	sysInfo := sysinfo.New(false) // 获取系统信息，linux 不支持cgroup则返回
 	// Check if Devices cgroup is mounted, it is hard requirement for container security,
-	// on Linux.
-	if runtime.GOOS == "linux" && !sysInfo.CgroupDevicesEnabled {
+	// on Linux. There is no devices controller in the unified hierarchy.
+	if runtime.GOOS == "linux" && !sysInfo.CgroupDevicesEnabled && !sysInfo.CgroupUnified {
 		return nil, errors.New("Devices cgroup isn't mounted")
 	}
 
@@ -795,6 +878,30 @@ func NewDaemon(config *config.Config, registryService registry.Service, containe
 		return nil, err
 	}
 
+	if d.migrateLayerStore != nil {
+		if err := d.migrateLayerStore.Cleanup(); err != nil {
+			logrus.Errorf("Error during layer Store.Cleanup() of storage driver %s: %v", config.MigrateStorageFrom, err)
+		}
+		d.migrateLayerStore = nil
+		if len(d.migrateFailed) > 0 {
+			// The containers which failed are left in the driver migrated
+			// from, so the migration can be run again for them.
+			logrus.Errorf("Migration from storage driver %s failed for %d containers, which are not loaded: %s. Its data must be kept to run the migration again", config.MigrateStorageFrom, len(d.migrateFailed), strings.Join(d.migrateFailed, ", "))
+		} else {
+			logrus.Infof("Migration from storage driver %s done. Its data can be removed once the images and containers are checked", config.MigrateStorageFrom)
+		}
+	}
+
+	// The references of the containers to their volumes are restored, so
+	// the unused ones can be told apart.
+	go d.anonymousVolumesGC()
+
+	if lxcfsRemote != nil {
+		lxcfsRemote.SetBackend(d)
+		// lxcfs was started before the daemon could be told about it.
+		d.LxcfsStateChanged(libcontainerd.LxcfsStateStart, lxcfsRemote.Status())
+	}
+
 	// FIXME: this method never returns an error
 	info, _ := d.SystemInfo()  // 获取host server系统信息
 
@@ -919,6 +1026,9 @@ yang test ....44444444444444444......... ExecuteC
 //shutdownDaemon->(daemon *Daemon) Shutdown
 func (daemon *Daemon) Shutdown() error {
 	daemon.shutdown = true
+	if daemon.stopVolumesGC != nil {
+		close(daemon.stopVolumesGC)
+	}
 	// Keep mounts and networking running on daemon shutdown if
 	// we are to keep containers running and restore them.
 
@@ -960,6 +1070,12 @@ func (daemon *Daemon) Shutdown() error {
 		}
 	}
 
+	if daemon.migrateLayerStore != nil {
+		if err := daemon.migrateLayerStore.Cleanup(); err != nil {
+			logrus.Errorf("Error during layer Store.Cleanup(): %v", err)
+		}
+	}
+
 	// If we are part of a cluster, clean up cluster's stuff
 	if daemon.clusterProvider != nil {
 		logrus.Debugf("start clean shutdown of cluster resources...")
@@ -1210,6 +1326,28 @@ func copyBlkioEntry(entries []*containerd.BlkioStatsEntry) []types.BlkioStatEntr
 	return out
 }
 
+func copyPSI(psi *containerd.PSIStats) *types.PSIStats {
+	if psi == nil {
+		return nil
+	}
+	return &types.PSIStats{
+		Some: copyPSIData(psi.Some),
+		Full: copyPSIData(psi.Full),
+	}
+}
+
+func copyPSIData(data *containerd.PSIData) types.PSIData {
+	if data == nil {
+		return types.PSIData{}
+	}
+	return types.PSIData{
+		Avg10:  data.Avg10,
+		Avg60:  data.Avg60,
+		Avg300: data.Avg300,
+		Total:  data.Total,
+	}
+}
+
 // GetCluster returns the cluster
 func (daemon *Daemon) GetCluster() Cluster {
 	return daemon.cluster
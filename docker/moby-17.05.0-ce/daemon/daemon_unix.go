@@ -580,6 +580,16 @@ func verifyPlatformContainerSettings(daemon *Daemon, hostConfig *containertypes.
 		}
 	}
 
+	if !hostConfig.Lxcfs.Valid() {
+		return warnings, fmt.Errorf("Invalid lxcfs mode %q, it should be one of off, auto or strict", hostConfig.Lxcfs)
+	}
+
+	// The memory pressure check would always pass without the pressure
+	// stall information, which is only available with cgroup v2.
+	if config != nil && config.Healthcheck != nil && config.Healthcheck.MemoryPressure > 0 && !sysInfo.MemoryPressure {
+		return warnings, fmt.Errorf("Your kernel does not provide the memory pressure stall information needed by the MemoryPressure healthcheck, which requires cgroup v2 and a kernel with PSI enabled")
+	}
+
 	return warnings, nil
 }
 
@@ -642,6 +652,22 @@ func verifyDaemonSettings(conf *config.Config) error { //   检查配置是否
 			return fmt.Errorf("cgroup-parent for systemd cgroup should be a valid slice named as \"xxx.slice\"")
 		}
 	}
+	if UsingSystemd(conf) && cgroups.IsCgroup2UnifiedMode() {
+		return fmt.Errorf("the systemd cgroup driver is not supported on hosts using the unified cgroup hierarchy, use the cgroupfs driver")
+	}
+
+	if conf.Rootless {
+		if conf.RemappedRoot != "" {
+			return fmt.Errorf("--userns-remap is incompatible with --rootless, as the daemon already runs in a user namespace")
+		}
+		if UsingSystemd(conf) {
+			return fmt.Errorf("the systemd cgroup driver is not supported in rootless mode")
+		}
+	}
+
+	if !containertypes.LxcfsMode(conf.LxcfsMode).Valid() {
+		return fmt.Errorf("Invalid lxcfs mode %q, it should be one of off, auto or strict", conf.LxcfsMode)
+	}
 
 	if conf.DefaultRuntime == "" {
 		conf.DefaultRuntime = config.StockRuntimeName
@@ -1212,6 +1238,7 @@ func (daemon *Daemon) stats(c *container.Container) (*types.StatsJSON, error) {
 			IoMergedRecursive:       copyBlkioEntry(cgs.BlkioStats.IoMergedRecursive),
 			IoTimeRecursive:         copyBlkioEntry(cgs.BlkioStats.IoTimeRecursive),
 			SectorsRecursive:        copyBlkioEntry(cgs.BlkioStats.SectorsRecursive),
+			PSI:                     copyPSI(cgs.BlkioStats.Psi),
 		}
 		cpu := cgs.CpuStats
 		s.CPUStats = types.CPUStats{
@@ -1226,6 +1253,7 @@ func (daemon *Daemon) stats(c *container.Container) (*types.StatsJSON, error) {
 				ThrottledPeriods: cpu.ThrottlingData.ThrottledPeriods,
 				ThrottledTime:    cpu.ThrottlingData.ThrottledTime,
 			},
+			PSI: copyPSI(cpu.Psi),
 		}
 		mem := cgs.MemoryStats.Usage
 		s.MemoryStats = types.MemoryStats{
@@ -1234,6 +1262,16 @@ func (daemon *Daemon) stats(c *container.Container) (*types.StatsJSON, error) {
 			Stats:    cgs.MemoryStats.Stats,
 			Failcnt:  mem.Failcnt,
 			Limit:    mem.Limit,
+			PSI:      copyPSI(cgs.MemoryStats.Psi),
+		}
+		if e := cgs.MemoryStats.Events; e != nil {
+			s.MemoryStats.Events = &types.MemoryEvents{
+				Low:     e.Low,
+				High:    e.High,
+				Max:     e.Max,
+				Oom:     e.Oom,
+				OomKill: e.OomKill,
+			}
 		}
 		// if the container does not set memory limit, use the machineMemory
 		if mem.Limit > daemon.machineMemory && daemon.machineMemory > 0 {
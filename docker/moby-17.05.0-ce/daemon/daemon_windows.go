@@ -212,6 +212,10 @@ func verifyPlatformContainerSettings(daemon *Daemon, hostConfig *containertypes.
 		return warnings, fmt.Errorf("Windows client operating systems only support Hyper-V containers")
 	}
 
+	if config != nil && config.Healthcheck != nil && config.Healthcheck.MemoryPressure > 0 {
+		return warnings, fmt.Errorf("MemoryPressure in Healthcheck is not supported on Windows")
+	}
+
 	w, err := verifyContainerResources(&hostConfig.Resources, hyperv)
 	warnings = append(warnings, w...)
 	return warnings, err
@@ -2,6 +2,7 @@ package daemon
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/api/types"
@@ -9,9 +10,53 @@ import (
 	"github.com/docker/docker/layer"
 	"github.com/docker/docker/pkg/directory"
 	"github.com/docker/docker/volume"
+	volumedrivers "github.com/docker/docker/volume/drivers"
 	"github.com/opencontainers/go-digest"
 )
 
+// diskUsageDriver is a volume driver that knows the disk usage of its
+// volumes, like the local driver for the volumes with a quota.
+type diskUsageDriver interface {
+	DiskUsage(name string) (int64, error)
+}
+
+// volumeSize returns the disk space used by the local volume v.
+func volumeSize(v volume.Volume) (int64, error) {
+	if vd, err := volumedrivers.GetDriver(v.DriverName()); err == nil {
+		if d, ok := vd.(diskUsageDriver); ok {
+			return d.DiskUsage(v.Name())
+		}
+	}
+	return directory.Size(v.Path())
+}
+
+// volumeUsage returns the usage data of the local volume v. The size is -1
+// if it isn't requested, as it may take long to compute, or if it can't be
+// determined.
+func (daemon *Daemon) volumeUsage(v volume.Volume, withSize bool) *types.VolumeUsageData {
+	name := v.Name()
+	usage := &types.VolumeUsageData{
+		RefCount: int64(len(daemon.volumes.Refs(v))),
+		Size:     -1,
+	}
+	if withSize {
+		size, err := volumeSize(v)
+		if err != nil {
+			logrus.Warnf("failed to determine size of volume %v", name)
+		} else {
+			usage.Size = size
+		}
+	}
+	lastUsed, err := daemon.volumes.LastUsed(v)
+	if err != nil {
+		logrus.Warnf("failed to determine last use of volume %v: %v", name, err)
+	}
+	if !lastUsed.IsZero() {
+		usage.LastUsed = lastUsed.Format(time.RFC3339Nano)
+	}
+	return usage
+}
+
 func (daemon *Daemon) getLayerRefs() map[layer.ChainID]int {
 	tmpImages := daemon.imageStore.Map()
 	layerRefs := map[layer.ChainID]int{}
@@ -53,16 +98,8 @@ func (daemon *Daemon) SystemDiskUsage() (*types.DiskUsage, error) {
 	// Get all local volumes
 	allVolumes := []*types.Volume{}
 	getLocalVols := func(v volume.Volume) error {
-		name := v.Name()
-		refs := daemon.volumes.Refs(v)
-
 		tv := volumeToAPIType(v)
-		sz, err := directory.Size(v.Path())
-		if err != nil {
-			logrus.Warnf("failed to determine size of volume %v", name)
-			sz = -1
-		}
-		tv.UsageData = &types.VolumeUsageData{Size: sz, RefCount: int64(len(refs))}
+		tv.UsageData = daemon.volumeUsage(v, true)
 		allVolumes = append(allVolumes, tv)
 
 		return nil
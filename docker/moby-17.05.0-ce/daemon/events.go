@@ -1,6 +1,7 @@
 package daemon
 
 import (
+	"strconv"
 	"strings"
 	"time"
 
@@ -95,6 +96,16 @@ func (daemon *Daemon) LogNetworkEventWithAttributes(nw libnetwork.Network, actio
 	daemon.EventsService.Log(action, events.NetworkEventType, actor)
 }
 
+// LogLxcfsEvent generates an event related to the lxcfs instance supervised
+// by the daemon.
+func (daemon *Daemon) LogLxcfsEvent(pid int, action string, attributes map[string]string) {
+	actor := events.Actor{
+		ID:         strconv.Itoa(pid),
+		Attributes: attributes,
+	}
+	daemon.EventsService.Log(action, events.LxcfsEventType, actor)
+}
+
 // LogDaemonEventWithAttributes generates an event related to the daemon itself with specific given attributes.
 //(daemon *Daemon) Reload 重新加载/etc/docker/daemon.json中的配置后，这里记录log事件
 func (daemon *Daemon) LogDaemonEventWithAttributes(action string, attributes map[string]string) {
@@ -0,0 +1,42 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+)
+
+func TestFilterLxcfsEvents(t *testing.T) {
+	args := filters.NewArgs()
+	args.Add("type", events.LxcfsEventType)
+	args.Add("event", "health_status")
+	ef := NewFilter(args)
+
+	lxcfs := events.Message{
+		Type:   events.LxcfsEventType,
+		Action: "health_status: unhealthy",
+		Actor:  events.Actor{ID: "1234"},
+	}
+	if !ef.Include(lxcfs) {
+		t.Fatalf("expected %v to be included", lxcfs)
+	}
+
+	container := events.Message{
+		Type:   events.ContainerEventType,
+		Action: "health_status: unhealthy",
+		Actor:  events.Actor{ID: "cont"},
+	}
+	if ef.Include(container) {
+		t.Fatalf("expected %v to be excluded", container)
+	}
+
+	start := events.Message{
+		Type:   events.LxcfsEventType,
+		Action: "start",
+		Actor:  events.Actor{ID: "1234"},
+	}
+	if ef.Include(start) {
+		t.Fatalf("expected %v to be excluded", start)
+	}
+}
@@ -197,6 +197,11 @@ func (a *Driver) GetMetadata(id string) (map[string]string, error) {
 	return nil, nil
 }
 
+// List returns the ids of the layers of the driver.
+func (a *Driver) List() ([]string, error) {
+	return loadIds(path.Join(a.rootPath(), "layers"))
+}
+
 // Exists returns true if the given id is registered with
 // this driver
 func (a *Driver) Exists(id string) bool {
@@ -1,6 +1,9 @@
 // +build linux
 
-package overlay
+// Package copy copies directory trees with the metadata of their files, for
+// the storage drivers and the volume drivers which keep their data in
+// directories.
+package copy
 
 import (
 	"fmt"
@@ -14,12 +17,24 @@ import (
 	rsystem "github.com/opencontainers/runc/libcontainer/system"
 )
 
-type copyFlags int
+// Mode indicates whether the regular files are copied or hard linked.
+type Mode int
 
 const (
-	copyHardlink copyFlags = 1 << iota
+	// Content copies the content of the regular files. The hard links
+	// between the files of the tree are kept.
+	Content Mode = iota
+	// Hardlink hard links the regular files to the ones of the source.
+	Hardlink
 )
 
+// ficlone is the FICLONE ioctl, which makes a file share the blocks of
+// another one on the filesystems supporting reflinks, like btrfs and xfs.
+const ficlone = 0x40049409
+
+// copyRegular copies the content of srcPath to dstPath. The file is cloned
+// with a reflink when the filesystem supports it, so that the copy is fast
+// and shares the blocks of srcPath until they are written.
 func copyRegular(srcPath, dstPath string, mode os.FileMode) error {
 	srcFile, err := os.Open(srcPath)
 	if err != nil {
@@ -27,14 +42,16 @@ func copyRegular(srcPath, dstPath string, mode os.FileMode) error {
 	}
 	defer srcFile.Close()
 
-	dstFile, err := os.OpenFile(dstPath, os.O_WRONLY|os.O_CREATE, mode)
+	dstFile, err := os.OpenFile(dstPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
 	if err != nil {
 		return err
 	}
 	defer dstFile.Close()
 
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, dstFile.Fd(), ficlone, srcFile.Fd()); errno == 0 {
+		return nil
+	}
 	_, err = pools.Copy(dstFile, srcFile)
-
 	return err
 }
 
@@ -51,8 +68,15 @@ func copyXattr(srcPath, dstPath, attr string) error {
 	return nil
 }
 
-func copyDir(srcDir, dstDir string, flags copyFlags) error {
-	err := filepath.Walk(srcDir, func(srcPath string, f os.FileInfo, err error) error {
+// DirCopy copies the content of srcDir to dstDir, with the owners, modes,
+// times and capabilities of the files.
+func DirCopy(srcDir, dstDir string, copyMode Mode) error {
+	type inode struct {
+		dev, ino uint64
+	}
+	links := make(map[inode]string)
+
+	return filepath.Walk(srcDir, func(srcPath string, f os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
@@ -62,30 +86,29 @@ func copyDir(srcDir, dstDir string, flags copyFlags) error {
 		if err != nil {
 			return err
 		}
-
 		dstPath := filepath.Join(dstDir, relPath)
-		if err != nil {
-			return err
-		}
 
 		stat, ok := f.Sys().(*syscall.Stat_t)
 		if !ok {
 			return fmt.Errorf("Unable to get raw syscall.Stat_t data for %s", srcPath)
 		}
 
-		isHardlink := false
-
 		switch f.Mode() & os.ModeType {
 		case 0: // Regular file
-			if flags&copyHardlink != 0 {
-				isHardlink = true
-				if err := os.Link(srcPath, dstPath); err != nil {
-					return err
-				}
-			} else {
-				if err := copyRegular(srcPath, dstPath, f.Mode()); err != nil {
-					return err
+			if copyMode == Hardlink {
+				// The metadata is shared with the source.
+				return os.Link(srcPath, dstPath)
+			}
+			if stat.Nlink > 1 {
+				in := inode{uint64(stat.Dev), uint64(stat.Ino)}
+				if link, ok := links[in]; ok {
+					// The metadata is shared with the first link.
+					return os.Link(link, dstPath)
 				}
+				links[in] = dstPath
+			}
+			if err := copyRegular(srcPath, dstPath, f.Mode()); err != nil {
+				return err
 			}
 
 		case os.ModeDir:
@@ -98,14 +121,11 @@ func copyDir(srcDir, dstDir string, flags copyFlags) error {
 			if err != nil {
 				return err
 			}
-
 			if err := os.Symlink(link, dstPath); err != nil {
 				return err
 			}
 
-		case os.ModeNamedPipe:
-			fallthrough
-		case os.ModeSocket:
+		case os.ModeNamedPipe, os.ModeSocket:
 			if rsystem.RunningInUserNS() {
 				// cannot create a device if running in user namespace
 				return nil
@@ -114,19 +134,13 @@ func copyDir(srcDir, dstDir string, flags copyFlags) error {
 				return err
 			}
 
-		case os.ModeDevice:
+		case os.ModeDevice, os.ModeDevice | os.ModeCharDevice:
 			if err := syscall.Mknod(dstPath, stat.Mode, int(stat.Rdev)); err != nil {
 				return err
 			}
 
 		default:
-			return fmt.Errorf("Unknown file type for %s\n", srcPath)
-		}
-
-		// Everything below is copying metadata from src to dst. All this metadata
-		// already shares an inode for hardlinks.
-		if isHardlink {
-			return nil
+			return fmt.Errorf("Unknown file type for %s", srcPath)
 		}
 
 		if err := os.Lchown(dstPath, int(stat.Uid), int(stat.Gid)); err != nil {
@@ -145,30 +159,17 @@ func copyDir(srcDir, dstDir string, flags copyFlags) error {
 			return err
 		}
 
-		isSymlink := f.Mode()&os.ModeSymlink != 0
-
 		// There is no LChmod, so ignore mode for symlink. Also, this
 		// must happen after chown, as that can modify the file mode
-		if !isSymlink {
+		if f.Mode()&os.ModeSymlink == 0 {
 			if err := os.Chmod(dstPath, f.Mode()); err != nil {
 				return err
 			}
-		}
-
-		// system.Chtimes doesn't support a NOFOLLOW flag atm
-		if !isSymlink {
 			aTime := time.Unix(int64(stat.Atim.Sec), int64(stat.Atim.Nsec))
 			mTime := time.Unix(int64(stat.Mtim.Sec), int64(stat.Mtim.Nsec))
-			if err := system.Chtimes(dstPath, aTime, mTime); err != nil {
-				return err
-			}
-		} else {
-			ts := []syscall.Timespec{stat.Atim, stat.Mtim}
-			if err := system.LUtimesNano(dstPath, ts); err != nil {
-				return err
-			}
+			return system.Chtimes(dstPath, aTime, mTime)
 		}
-		return nil
+		// system.Chtimes doesn't support a NOFOLLOW flag atm
+		return system.LUtimesNano(dstPath, []syscall.Timespec{stat.Atim, stat.Mtim})
 	})
-	return err
 }
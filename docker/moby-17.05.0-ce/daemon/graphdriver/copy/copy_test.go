@@ -0,0 +1,72 @@
+// +build linux
+
+package copy
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDirCopy(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test-copy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	src := filepath.Join(dir, "src")
+	if err := os.MkdirAll(filepath.Join(src, "dir"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(src, "dir", "file"), []byte("data"), 0640); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Link(filepath.Join(src, "dir", "file"), filepath.Join(src, "link")); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, mode := range []Mode{Content, Hardlink} {
+		dst := filepath.Join(dir, "dst")
+		if err := os.Mkdir(dst, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := DirCopy(src, dst, mode); err != nil {
+			t.Fatal(err)
+		}
+
+		fi, err := os.Stat(filepath.Join(dst, "dir"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fi.Mode().Perm() != 0700 {
+			t.Fatalf("expected the directory mode to be 0700, got %v", fi.Mode().Perm())
+		}
+		srcFi, err := os.Stat(filepath.Join(src, "dir", "file"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		fi1, err := os.Stat(filepath.Join(dst, "dir", "file"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		fi2, err := os.Stat(filepath.Join(dst, "link"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !os.SameFile(fi1, fi2) {
+			t.Fatalf("expected the hard link to be kept with mode %d", mode)
+		}
+		if os.SameFile(srcFi, fi1) != (mode == Hardlink) {
+			t.Fatalf("expected the file to be linked to the source only with the Hardlink mode, got mode %d", mode)
+		}
+		if b, err := ioutil.ReadFile(filepath.Join(dst, "dir", "file")); err != nil || string(b) != "data" {
+			t.Fatalf("expected the file to contain %q, got %q, %v", "data", b, err)
+		}
+
+		if err := os.RemoveAll(dst); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -154,6 +154,14 @@ type DiffGetterDriver interface {
 	DiffGetter(id string) (FileGetCloser, error)
 }
 
+// ListDriver is the interface for layered file system drivers that can list
+// the filesystem layers they hold.
+type ListDriver interface {
+	Driver
+	// List returns the ids of the filesystem layers held by the driver.
+	List() ([]string, error)
+}
+
 // FileGetCloser extends the storage.FileGetter interface with a Close method
 // for cleaning up.
 type FileGetCloser interface {
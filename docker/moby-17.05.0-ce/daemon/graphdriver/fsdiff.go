@@ -169,3 +169,14 @@ func (gdw *NaiveDiffDriver) DiffSize(id, parent string) (size int64, err error)
 
 	return archive.ChangesSize(layerFs, changes), nil
 }
+
+// List returns the ids of the filesystem layers held by the wrapped driver,
+// if it can list them.
+func (gdw *NaiveDiffDriver) List() ([]string, error) {
+	if d, ok := gdw.ProtoDriver.(interface {
+		List() ([]string, error)
+	}); ok {
+		return d.List()
+	}
+	return nil, ErrNotSupported
+}
@@ -34,6 +34,7 @@ import (
 	units "github.com/docker/go-units"
 
 	"github.com/opencontainers/runc/libcontainer/label"
+	rsystem "github.com/opencontainers/runc/libcontainer/system"
 )
 
 var (
@@ -163,6 +164,14 @@ func Init(home string, options []string, uidMaps, gidMaps []idtools.IDMap) (grap
 		return nil, err
 	}
 
+	if rsystem.RunningInUserNS() {
+		// Not all kernels allow overlay mounts in a user namespace.
+		if err := overlayutils.SupportsMount(home); err != nil {
+			logrus.Errorf("'overlay2' is not supported in the user namespace: %v", err)
+			return nil, graphdriver.ErrNotSupported
+		}
+	}
+
 	if err := mount.MakePrivate(home); err != nil {
 		return nil, err
 	}
@@ -582,6 +591,21 @@ func (d *Driver) Put(id string) error {
 	return nil
 }
 
+// List returns the ids of the layers of the driver.
+func (d *Driver) List() ([]string, error) {
+	fis, err := ioutil.ReadDir(d.home)
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, fi := range fis {
+		if fi.IsDir() && fi.Name() != linkDir {
+			ids = append(ids, fi.Name())
+		}
+	}
+	return ids, nil
+}
+
 // Exists checks to see if the id is already mounted.
 func (d *Driver) Exists(id string) bool {
 	_, err := os.Stat(d.dir(id))
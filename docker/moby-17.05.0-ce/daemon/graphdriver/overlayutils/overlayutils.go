@@ -5,6 +5,11 @@ package overlayutils
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/docker/docker/pkg/mount"
 )
 
 // ErrDTypeNotSupported denotes that the backing filesystem doesn't support d_type.
@@ -16,3 +21,25 @@ func ErrDTypeNotSupported(driver, backingFs string) error {
 	msg += " Running without d_type support will no longer be supported in Docker 17.12."
 	return errors.New(msg)
 }
+
+// SupportsMount checks whether an overlay filesystem can be mounted in dir,
+// which not all kernels allow in a user namespace.
+func SupportsMount(dir string) error {
+	td, err := ioutil.TempDir(dir, "check-overlayfs-support")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(td)
+
+	for _, d := range []string{"lower", "upper", "work", "merged"} {
+		if err := os.Mkdir(filepath.Join(td, d), 0755); err != nil {
+			return err
+		}
+	}
+	merged := filepath.Join(td, "merged")
+	opts := fmt.Sprintf("lowerdir=%s,upperdir=%s,workdir=%s", filepath.Join(td, "lower"), filepath.Join(td, "upper"), filepath.Join(td, "work"))
+	if err := mount.Mount("overlay", merged, "overlay", opts); err != nil {
+		return fmt.Errorf("failed to mount overlay: %v", err)
+	}
+	return mount.Unmount(merged)
+}
@@ -1,4 +1,4 @@
-// +build linux
+// +build linux,cgo
 
 //
 // projectquota.go - implements XFS project quota controls
@@ -55,12 +55,16 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"sync"
 	"syscall"
 	"unsafe"
 
 	"github.com/Sirupsen/logrus"
 )
 
+// xfsSuperMagic is the magic number of xfs filesystems
+const xfsSuperMagic = 0x58465342
+
 // Quota limit params - currently we only control blocks hard limit
 type Quota struct {
 	Size uint64
@@ -70,10 +74,43 @@ type Quota struct {
 // who wants to apply project quotas to container dirs
 type Control struct {
 	backingFsBlockDev string
-	nextProjectID     uint32
+	projectIDs        *projectIDs
 	quotas            map[string]uint32
 }
 
+// projectIDs allocates the project ids of a filesystem. The controls of the
+// directories of a filesystem, like the ones of the overlay2 driver and of
+// the local volumes, share it, so they never assign the same project id,
+// which would share one limit and one usage count.
+type projectIDs struct {
+	mu   sync.Mutex
+	next uint32
+}
+
+// reserve makes sure that the ids up to id are not allocated.
+func (p *projectIDs) reserve(id uint32) {
+	p.mu.Lock()
+	if p.next <= id {
+		p.next = id + 1
+	}
+	p.mu.Unlock()
+}
+
+func (p *projectIDs) allocate() uint32 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	id := p.next
+	p.next++
+	return id
+}
+
+var (
+	fsProjectIDsMu sync.Mutex
+	// fsProjectIDs are the project id allocators, keyed by the device of
+	// their filesystem.
+	fsProjectIDs = make(map[uint64]*projectIDs)
+)
+
 // NewControl - initialize project quota support.
 // Test to make sure that quota can be set on a test dir and find
 // the first project id to be used for the next container create.
@@ -97,6 +134,26 @@ type Control struct {
 // project ids.
 //
 func NewControl(basePath string) (*Control, error) {
+	//
+	// Make sure the backing fs is xfs before creating the device node,
+	// as other filesystems also support getting the project id
+	//
+	var buf syscall.Statfs_t
+	if err := syscall.Statfs(basePath, &buf); err != nil {
+		return nil, err
+	}
+	if buf.Type != xfsSuperMagic {
+		return nil, fmt.Errorf("backing filesystem of %s is not xfs", basePath)
+	}
+	var st syscall.Stat_t
+	if err := syscall.Stat(basePath, &st); err != nil {
+		return nil, err
+	}
+
+	fsProjectIDsMu.Lock()
+	defer fsProjectIDsMu.Unlock()
+	ids, shared := fsProjectIDs[uint64(st.Dev)]
+
 	//
 	// Get project id of parent dir as minimal id to be used by driver
 	//
@@ -116,18 +173,24 @@ func NewControl(basePath string) (*Control, error) {
 
 	//
 	// Test if filesystem supports project quotas by trying to set
-	// a quota on the first available project id
+	// a quota on the first available project id. It is already known
+	// when another control uses the filesystem, whose ids may include
+	// that one.
 	//
-	quota := Quota{
-		Size: 0,
-	}
-	if err := setProjectQuota(backingFsBlockDev, minProjectID, quota); err != nil {
-		return nil, err
+	if !shared {
+		quota := Quota{
+			Size: 0,
+		}
+		if err := setProjectQuota(backingFsBlockDev, minProjectID, quota); err != nil {
+			return nil, err
+		}
+		ids = &projectIDs{}
 	}
+	ids.reserve(minProjectID)
 
 	q := Control{
 		backingFsBlockDev: backingFsBlockDev,
-		nextProjectID:     minProjectID + 1,
+		projectIDs:        ids,
 		quotas:            make(map[string]uint32),
 	}
 
@@ -138,8 +201,9 @@ func NewControl(basePath string) (*Control, error) {
 	if err != nil {
 		return nil, err
 	}
+	fsProjectIDs[uint64(st.Dev)] = ids
 
-	logrus.Debugf("NewControl(%s): nextProjectID = %d", basePath, q.nextProjectID)
+	logrus.Debugf("NewControl(%s): %d project ids in use", basePath, len(q.quotas))
 	return &q, nil
 }
 
@@ -149,7 +213,7 @@ func (q *Control) SetQuota(targetPath string, quota Quota) error {
 
 	projectID, ok := q.quotas[targetPath]
 	if !ok {
-		projectID = q.nextProjectID
+		projectID = q.projectIDs.allocate()
 
 		//
 		// assign project id to new container directory
@@ -160,7 +224,6 @@ func (q *Control) SetQuota(targetPath string, quota Quota) error {
 		}
 
 		q.quotas[targetPath] = projectID
-		q.nextProjectID++
 	}
 
 	//
@@ -197,10 +260,31 @@ func setProjectQuota(backingFsBlockDev string, projectID uint32, quota Quota) er
 
 // GetQuota - get the quota limits of a directory that was configured with SetQuota
 func (q *Control) GetQuota(targetPath string, quota *Quota) error {
+	d, err := q.getProjectQuota(targetPath)
+	if err != nil {
+		return err
+	}
+	quota.Size = uint64(d.d_blk_hardlimit) * 512
 
+	return nil
+}
+
+// GetUsage - get the number of bytes used in a directory that was configured
+// with SetQuota, which is counted by the filesystem for its project id
+func (q *Control) GetUsage(targetPath string) (uint64, error) {
+	d, err := q.getProjectQuota(targetPath)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(d.d_bcount) * 512, nil
+}
+
+// getProjectQuota - get the quota of the project id of a directory that was
+// configured with SetQuota
+func (q *Control) getProjectQuota(targetPath string) (*C.fs_disk_quota_t, error) {
 	projectID, ok := q.quotas[targetPath]
 	if !ok {
-		return fmt.Errorf("quota not found for path : %s", targetPath)
+		return nil, fmt.Errorf("quota not found for path : %s", targetPath)
 	}
 
 	//
@@ -215,12 +299,11 @@ func (q *Control) GetQuota(targetPath string, quota *Quota) error {
 		uintptr(unsafe.Pointer(cs)), uintptr(C.__u32(projectID)),
 		uintptr(unsafe.Pointer(&d)), 0, 0)
 	if errno != 0 {
-		return fmt.Errorf("Failed to get quota limit for projid %d on %s: %v",
+		return nil, fmt.Errorf("Failed to get quota limit for projid %d on %s: %v",
 			projectID, q.backingFsBlockDev, errno.Error())
 	}
-	quota.Size = uint64(d.d_blk_hardlimit) * 512
 
-	return nil
+	return &d, nil
 }
 
 // getProjectID - get the project id of path on xfs
@@ -285,9 +368,7 @@ func (q *Control) findNextProjectID(home string) error {
 		if projid > 0 {
 			q.quotas[path] = projid
 		}
-		if q.nextProjectID <= projid {
-			q.nextProjectID = projid + 1
-		}
+		q.projectIDs.reserve(projid)
 	}
 
 	return nil
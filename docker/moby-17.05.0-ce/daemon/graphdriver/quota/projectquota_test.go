@@ -0,0 +1,79 @@
+// +build linux,cgo
+
+package quota
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestSharedProjectIDs checks that the controls of two directories of one
+// filesystem, like the ones of overlay2 and of the local volumes, never
+// assign the same project id. It needs TMPDIR to be on xfs mounted with the
+// pquota option, and to be run as root.
+func TestSharedProjectIDs(t *testing.T) {
+	root, err := ioutil.TempDir("", "docker-quota-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	var ctls []*Control
+	var dirs []string
+	for _, name := range []string{"overlay2", "volumes"} {
+		home := filepath.Join(root, name)
+		if err := os.Mkdir(home, 0700); err != nil {
+			t.Fatal(err)
+		}
+		ctl, err := NewControl(home)
+		if err != nil {
+			t.Skipf("project quotas are not supported: %v", err)
+		}
+		ctls = append(ctls, ctl)
+		dirs = append(dirs, home)
+	}
+
+	seen := make(map[uint32]string)
+	for i := 0; i < 2; i++ {
+		for j, ctl := range ctls {
+			dir, err := ioutil.TempDir(dirs[j], "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := ctl.SetQuota(dir, Quota{Size: 1024 * 1024}); err != nil {
+				t.Fatal(err)
+			}
+			id, err := getProjectID(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if other, ok := seen[id]; ok {
+				t.Fatalf("project id %d of %s is also the one of %s", id, dir, other)
+			}
+			seen[id] = dir
+		}
+	}
+
+	// A control created again for a directory in use, as on a restart,
+	// doesn't assign the ids of the others either.
+	ctl, err := NewControl(dirs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, err := ioutil.TempDir(dirs[0], "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ctl.SetQuota(dir, Quota{Size: 1024 * 1024}); err != nil {
+		t.Fatal(err)
+	}
+	id, err := getProjectID(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other, ok := seen[id]; ok {
+		t.Fatalf("project id %d of %s is also the one of %s", id, dir, other)
+	}
+}
@@ -0,0 +1,36 @@
+// +build !linux !cgo
+
+package quota
+
+import "errors"
+
+var errQuotaNotSupported = errors.New("project quotas are not supported on this platform")
+
+// Quota limit params - currently we only control blocks hard limit
+type Quota struct {
+	Size uint64
+}
+
+// Control - Context to be used by storage driver (e.g. overlay)
+// who wants to apply project quotas to container dirs
+type Control struct{}
+
+// NewControl - project quotas are only supported on Linux
+func NewControl(basePath string) (*Control, error) {
+	return nil, errQuotaNotSupported
+}
+
+// SetQuota - project quotas are only supported on Linux
+func (q *Control) SetQuota(targetPath string, quota Quota) error {
+	return errQuotaNotSupported
+}
+
+// GetQuota - project quotas are only supported on Linux
+func (q *Control) GetQuota(targetPath string, quota *Quota) error {
+	return errQuotaNotSupported
+}
+
+// GetUsage - project quotas are only supported on Linux
+func (q *Control) GetUsage(targetPath string) (uint64, error) {
+	return 0, errQuotaNotSupported
+}
@@ -2,6 +2,7 @@ package vfs
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 
@@ -138,6 +139,24 @@ func (d *Driver) Put(id string) error {
 	return nil
 }
 
+// List returns the ids of the directories of the driver.
+func (d *Driver) List() ([]string, error) {
+	fis, err := ioutil.ReadDir(filepath.Join(d.home, "dir"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var ids []string
+	for _, fi := range fis {
+		if fi.IsDir() {
+			ids = append(ids, fi.Name())
+		}
+	}
+	return ids, nil
+}
+
 // Exists checks to see if the directory exists for the given id.
 func (d *Driver) Exists(id string) bool {
 	_, err := os.Stat(d.dir(id))
@@ -128,6 +128,54 @@ func (p *cmdProbe) run(ctx context.Context, d *Daemon, container *container.Cont
 	}, nil
 }
 
+// memoryPressureProbe fails when the memory pressure of the container stays
+// above the threshold of its healthcheck configuration, and runs the next
+// probe, if any, otherwise.
+type memoryPressureProbe struct {
+	next probe
+	// since is when the memory pressure went above the threshold.
+	since time.Time
+}
+
+func (p *memoryPressureProbe) run(ctx context.Context, d *Daemon, container *container.Container) (*types.HealthcheckResult, error) {
+	config := container.Config.Healthcheck
+	stats, err := d.stats(container)
+	if err != nil {
+		return nil, err
+	}
+	// The pressure stall information is only available with the unified
+	// cgroup hierarchy, on kernels providing it.
+	psi := stats.MemoryStats.PSI
+	output := "memory pressure information is not available"
+	if psi != nil {
+		pressure := psi.Some.Avg10
+		output = fmt.Sprintf("memory pressure %.2f%%", pressure)
+		if pressure > config.MemoryPressure {
+			now := time.Now()
+			if p.since.IsZero() {
+				p.since = now
+			}
+			if now.Sub(p.since) >= config.MemoryPressureDuration {
+				return &types.HealthcheckResult{
+					End:      now,
+					ExitCode: exitStatusUnhealthy,
+					Output:   fmt.Sprintf("memory pressure %.2f%% above %.2f%% since %s", pressure, config.MemoryPressure, p.since.Format(time.RFC3339)),
+				}, nil
+			}
+		} else {
+			p.since = time.Time{}
+		}
+	}
+	if p.next != nil {
+		return p.next.run(ctx, d, container)
+	}
+	return &types.HealthcheckResult{
+		End:      time.Now(),
+		ExitCode: exitStatusHealthy,
+		Output:   output,
+	}, nil
+}
+
 // Update the container's Status.Health struct based on the latest probe's result.
 func handleProbeResult(d *Daemon, c *container.Container, result *types.HealthcheckResult, done chan struct{}) {
 	c.Lock()
@@ -253,18 +301,27 @@ func monitor(d *Daemon, c *container.Container, stop chan struct{}, probe probe)
 // Nil will be returned if no healthcheck was configured or NONE was set.
 func getProbe(c *container.Container) probe {
 	config := c.Config.Healthcheck
-	if config == nil || len(config.Test) == 0 {
+	if config == nil {
 		return nil
 	}
-	switch config.Test[0] {
-	case "CMD":
-		return &cmdProbe{shell: false}
-	case "CMD-SHELL":
-		return &cmdProbe{shell: true}
-	default:
-		logrus.Warnf("Unknown healthcheck type '%s' (expected 'CMD') in container %s", config.Test[0], c.ID)
-		return nil
+	var p probe
+	if len(config.Test) > 0 {
+		switch config.Test[0] {
+		case "CMD":
+			p = &cmdProbe{shell: false}
+		case "CMD-SHELL":
+			p = &cmdProbe{shell: true}
+		case "NONE":
+			return nil
+		default:
+			logrus.Warnf("Unknown healthcheck type '%s' (expected 'CMD') in container %s", config.Test[0], c.ID)
+			return nil
+		}
+	}
+	if config.MemoryPressure > 0 {
+		return &memoryPressureProbe{next: p}
 	}
+	return p
 }
 
 // Ensure the health-check monitor is running or not, depending on the current
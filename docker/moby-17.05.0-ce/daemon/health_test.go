@@ -39,6 +39,34 @@ func TestNoneHealthcheck(t *testing.T) {
 	}
 }
 
+func TestMemoryPressureProbe(t *testing.T) {
+	c := &container.Container{
+		CommonContainer: container.CommonContainer{
+			ID: "container_id",
+			Config: &containertypes.Config{
+				Healthcheck: &containertypes.HealthConfig{
+					MemoryPressure: 10,
+				},
+			},
+		},
+	}
+	p, ok := getProbe(c).(*memoryPressureProbe)
+	if !ok || p.next != nil {
+		t.Fatalf("Expecting a memory pressure probe alone, got %#v", getProbe(c))
+	}
+
+	c.Config.Healthcheck.Test = []string{"CMD", "true"}
+	p, ok = getProbe(c).(*memoryPressureProbe)
+	if !ok || p.next == nil {
+		t.Fatalf("Expecting a memory pressure probe running a command, got %#v", getProbe(c))
+	}
+
+	c.Config.Healthcheck.Test = []string{"NONE"}
+	if probe := getProbe(c); probe != nil {
+		t.Fatalf("Expecting no probe, got %#v", probe)
+	}
+}
+
 // FIXME(vdemeester) This takes around 3s… This is *way* too long
 func TestHealthStates(t *testing.T) {
 	e := events.New()
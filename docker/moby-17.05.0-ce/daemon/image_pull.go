@@ -60,19 +60,9 @@ func (daemon *Daemon) PullOnBuild(ctx context.Context, name string, authConfigs
 	}
 	ref = reference.TagNameOnly(ref)
 
-	pullRegistryAuth := &types.AuthConfig{}
-	if len(authConfigs) > 0 {
-		// The request came with a full auth config file, we prefer to use that
-		repoInfo, err := daemon.RegistryService.ResolveRepository(ref)
-		if err != nil {
-			return nil, err
-		}
-
-		resolvedConfig := registry.ResolveAuthConfig(
-			authConfigs,
-			repoInfo.Index,
-		)
-		pullRegistryAuth = &resolvedConfig
+	pullRegistryAuth, err := daemon.resolveBuildAuthConfig(ref, authConfigs)
+	if err != nil {
+		return nil, err
 	}
 
 	if err := daemon.pullImageWithReference(ctx, ref, nil, pullRegistryAuth, output); err != nil {
@@ -5,6 +5,7 @@ package daemon
 import (
 	"context"
 	"os/exec"
+	"runtime"
 	"strings"
 
 	"github.com/Sirupsen/logrus"
@@ -25,9 +26,16 @@ func (daemon *Daemon) FillPlatformInfo(v *types.Info, sysInfo *sysinfo.SysInfo)
 	v.CPUCfsQuota = sysInfo.CPUCfsQuota
 	v.CPUShares = sysInfo.CPUShares
 	v.CPUSet = sysInfo.Cpuset
+	if runtime.GOOS == "linux" {
+		v.CgroupVersion = "1"
+		if sysInfo.CgroupUnified {
+			v.CgroupVersion = "2"
+		}
+	}
 	v.Runtimes = daemon.configStore.GetAllRuntimes()
 	v.DefaultRuntime = daemon.configStore.GetDefaultRuntimeName()
 	v.InitBinary = daemon.configStore.GetInitPath()
+	v.Lxcfs = daemon.lxcfsStatus()
 
 	v.ContainerdCommit.Expected = dockerversion.ContainerdCommitID
 	if sv, err := daemon.containerd.GetServerVersion(context.Background()); err == nil {
@@ -11,6 +11,7 @@ import (
 	"github.com/docker/docker/api/types/versions/v1p20"
 	"github.com/docker/docker/container"
 	"github.com/docker/docker/daemon/network"
+	"github.com/docker/docker/volume"
 	"github.com/docker/go-connections/nat"
 )
 
@@ -228,6 +229,9 @@ func (daemon *Daemon) VolumeInspect(name string) (*types.Volume, error) {
 	apiV := volumeToAPIType(v)
 	apiV.Mountpoint = v.Path()
 	apiV.Status = v.Status()
+	if v.DriverName() == volume.DefaultDriverName {
+		apiV.UsageData = daemon.volumeUsage(v, false)
+	}
 	return apiV, nil
 }
 
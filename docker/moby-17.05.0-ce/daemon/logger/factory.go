@@ -2,6 +2,7 @@ package logger
 
 import (
 	"fmt"
+	"strconv"
 	"sync"
 
 	containertypes "github.com/docker/docker/api/types/container"
@@ -100,8 +101,16 @@ func GetLogDriver(name string) (Creator, error) {
 }
 
 var builtInLogOpts = map[string]bool{
-	"mode":            true,
-	"max-buffer-size": true,
+	"mode":              true,
+	"max-buffer-size":   true,
+	multilinePatternKey: true,
+	multilineMaxSizeKey: true,
+	multilineTimeoutKey: true,
+	parseJSONKey:        true,
+
+	"local-cache":          true,
+	"local-cache-max-size": true,
+	"local-cache-max-file": true,
 }
 
 // ValidateLogOpts checks the options for the given log driver. The
@@ -126,6 +135,14 @@ func ValidateLogOpts(name string, cfg map[string]string) error {
 		}
 	}
 
+	if _, err := parseParserConfig(cfg); err != nil {
+		return err
+	}
+
+	if err := validateLocalCacheOpts(cfg); err != nil {
+		return err
+	}
+
 	if !factory.driverRegistered(name) {
 		return fmt.Errorf("logger: no log driver named '%s' is registered", name)
 	}
@@ -143,3 +160,34 @@ func ValidateLogOpts(name string, cfg map[string]string) error {
 	}
 	return nil
 }
+
+// validateLocalCacheOpts checks the options of the local log cache, which
+// is written by the daemon alongside drivers that cannot read logs.
+func validateLocalCacheOpts(cfg map[string]string) error {
+	enabled := false
+	if s, ok := cfg["local-cache"]; ok {
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return errors.Wrap(err, "error parsing option local-cache")
+		}
+		enabled = b
+	}
+
+	for _, key := range []string{"local-cache-max-size", "local-cache-max-file"} {
+		if _, ok := cfg[key]; ok && !enabled {
+			return fmt.Errorf("logger: %s option is only supported with 'local-cache=true'", key)
+		}
+	}
+
+	if s, ok := cfg["local-cache-max-size"]; ok {
+		if size, err := units.FromHumanSize(s); err != nil || size <= 0 {
+			return fmt.Errorf("logger: invalid local-cache-max-size: %s", s)
+		}
+	}
+	if s, ok := cfg["local-cache-max-file"]; ok {
+		if n, err := strconv.Atoi(s); err != nil || n < 1 {
+			return fmt.Errorf("logger: invalid local-cache-max-file: %s", s)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package logger
+
+import "testing"
+
+func TestValidateLocalCacheOpts(t *testing.T) {
+	valid := []map[string]string{
+		{},
+		{"local-cache": "false"},
+		{"local-cache": "true", "local-cache-max-size": "10m", "local-cache-max-file": "3"},
+	}
+	for _, cfg := range valid {
+		if err := validateLocalCacheOpts(cfg); err != nil {
+			t.Fatalf("unexpected error for %v: %v", cfg, err)
+		}
+	}
+
+	invalid := []map[string]string{
+		{"local-cache": "yes please"},
+		{"local-cache-max-size": "10m"},
+		{"local-cache": "false", "local-cache-max-file": "3"},
+		{"local-cache": "true", "local-cache-max-size": "big"},
+		{"local-cache": "true", "local-cache-max-file": "0"},
+	}
+	for _, cfg := range invalid {
+		if err := validateLocalCacheOpts(cfg); err == nil {
+			t.Fatalf("expected error for %v", cfg)
+		}
+	}
+}
@@ -138,6 +138,12 @@ func (s *gelfLogger) Log(msg *logger.Message) error {
 		Level:    level,
 		RawExtra: s.rawExtra,
 	}
+	if len(msg.Attrs) > 0 {
+		m.Extra = make(map[string]interface{}, len(msg.Attrs))
+		for k, v := range msg.Attrs {
+			m.Extra["_"+k] = v
+		}
+	}
 	logger.PutMessage(msg)
 
 	if err := s.writer.WriteMessage(&m); err != nil {
@@ -109,6 +109,12 @@ func (s *journald) Log(msg *logger.Message) error {
 	if msg.Partial {
 		vars["CONTAINER_PARTIAL_MESSAGE"] = "true"
 	}
+	for k, v := range msg.Attrs {
+		k = sanitizeKeyMod(k)
+		if _, ok := vars[k]; k != "" && !ok {
+			vars[k] = v
+		}
+	}
 
 	line := string(msg.Line)
 	logger.PutMessage(msg)
@@ -27,6 +27,7 @@ type JSONFileLogger struct {
 	mu      sync.Mutex
 	readers map[*logger.LogWatcher]struct{} // stores the active log followers
 	extra   []byte                          // json-encoded extra attributes
+	attrs   map[string]string               // extra attributes
 }
 
 func init() {
@@ -61,7 +62,19 @@ func New(info logger.Info) (logger.Logger, error) {
 		}
 	}
 
-	writer, err := loggerutils.NewRotateFileWriter(info.LogPath, capval, maxFiles)
+	var compress bool
+	if compressString, ok := info.Config["compress"]; ok {
+		var err error
+		compress, err = strconv.ParseBool(compressString)
+		if err != nil {
+			return nil, err
+		}
+		if compress && maxFiles < 2 {
+			return nil, fmt.Errorf("compress cannot be true when max-file is less than 2")
+		}
+	}
+
+	writer, err := loggerutils.NewRotateFileWriter(info.LogPath, capval, maxFiles, compress)
 	if err != nil {
 		return nil, err
 	}
@@ -84,6 +97,7 @@ func New(info logger.Info) (logger.Logger, error) {
 		writer:  writer,
 		readers: make(map[*logger.LogWatcher]struct{}),
 		extra:   extra,
+		attrs:   attrs,
 	}, nil
 }
 
@@ -93,6 +107,10 @@ func (l *JSONFileLogger) Log(msg *logger.Message) error {
 	if err != nil {
 		return err
 	}
+	extra, err := l.messageAttrs(msg)
+	if err != nil {
+		return err
+	}
 	l.mu.Lock()
 	logline := msg.Line
 	if !msg.Partial {
@@ -102,7 +120,7 @@ func (l *JSONFileLogger) Log(msg *logger.Message) error {
 		Log:      logline,
 		Stream:   msg.Source,
 		Created:  timestamp,
-		RawAttrs: l.extra,
+		RawAttrs: extra,
 	}).MarshalJSONBuf(l.buf)
 	logger.PutMessage(msg)
 	if err != nil {
@@ -118,12 +136,30 @@ func (l *JSONFileLogger) Log(msg *logger.Message) error {
 	return err
 }
 
-// ValidateLogOpt looks for json specific log options max-file & max-size.
+// messageAttrs returns the json-encoded attributes of the message merged
+// with the extra attributes of the logger.
+func (l *JSONFileLogger) messageAttrs(msg *logger.Message) ([]byte, error) {
+	if len(msg.Attrs) == 0 {
+		return l.extra, nil
+	}
+	attrs := make(map[string]string, len(l.attrs)+len(msg.Attrs))
+	for k, v := range msg.Attrs {
+		attrs[k] = v
+	}
+	for k, v := range l.attrs {
+		attrs[k] = v
+	}
+	return json.Marshal(attrs)
+}
+
+// ValidateLogOpt looks for json specific log options max-file, max-size &
+// compress.
 func ValidateLogOpt(cfg map[string]string) error {
 	for key := range cfg {
 		switch key {
 		case "max-file":
 		case "max-size":
+		case "compress":
 		case "labels":
 		case "env":
 		case "env-regex":
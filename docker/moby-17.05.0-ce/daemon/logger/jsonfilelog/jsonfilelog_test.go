@@ -1,6 +1,7 @@
 package jsonfilelog
 
 import (
+	"compress/gzip"
 	"encoding/json"
 	"io/ioutil"
 	"os"
@@ -11,6 +12,7 @@ import (
 	"time"
 
 	"github.com/docker/docker/daemon/logger"
+	"github.com/docker/docker/daemon/logger/loggerutils"
 	"github.com/docker/docker/pkg/jsonlog"
 )
 
@@ -152,6 +154,141 @@ func TestJSONFileLoggerWithOpts(t *testing.T) {
 
 }
 
+func TestJSONFileLoggerCompress(t *testing.T) {
+	cid := "a7317399f3f857173c6179d44823594f8294678dea9999662e5c625b5a1c7657"
+	tmp, err := ioutil.TempDir("", "docker-logger-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	filename := filepath.Join(tmp, "container.log")
+	config := map[string]string{"max-file": "3", "max-size": "1k", "compress": "true"}
+	l, err := New(logger.Info{
+		ContainerID: cid,
+		LogPath:     filename,
+		Config:      config,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Messages are written after they were produced.
+	start := time.Now().Add(-time.Hour)
+	for i := 0; i < 100; i++ {
+		msg := &logger.Message{Line: []byte("line" + strconv.Itoa(i)), Source: "src1", Timestamp: start.Add(time.Duration(i) * time.Second)}
+		if err := l.Log(msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{filename + ".1.gz", filename + ".2.gz"} {
+		if _, err := os.Stat(name); err != nil {
+			t.Fatalf("expected compressed log file: %v", err)
+		}
+	}
+	for _, name := range []string{filename + ".1", filename + ".2"} {
+		if _, err := os.Stat(name); !os.IsNotExist(err) {
+			t.Fatalf("expected uncompressed log file %s to be removed: %v", name, err)
+		}
+	}
+
+	l, err = New(logger.Info{
+		ContainerID: cid,
+		LogPath:     filename,
+		Config:      config,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	readLines := func(cfg logger.ReadConfig) []string {
+		watcher := l.(logger.LogReader).ReadLogs(cfg)
+		defer watcher.Close()
+		var lines []string
+		for {
+			select {
+			case msg, ok := <-watcher.Msg:
+				if !ok {
+					return lines
+				}
+				lines = append(lines, string(msg.Line))
+			case err := <-watcher.Err:
+				t.Fatal(err)
+			}
+		}
+	}
+
+	// The oldest lines were rotated out, the others are read in order
+	// from the two compressed files and the current one.
+	lines := readLines(logger.ReadConfig{Tail: -1})
+	if len(lines) < 20 || len(lines) >= 100 {
+		t.Fatalf("unexpected number of lines: %d", len(lines))
+	}
+	first := 100 - len(lines)
+	for i, line := range lines {
+		if expected := "line" + strconv.Itoa(first+i) + "\n"; line != expected {
+			t.Fatalf("expected %q, got %q", expected, line)
+		}
+	}
+
+	lines = readLines(logger.ReadConfig{Tail: 25})
+	if len(lines) != 25 || lines[0] != "line75\n" {
+		t.Fatalf("unexpected tail: %q", lines)
+	}
+
+	since := first + 5
+	lines = readLines(logger.ReadConfig{Tail: -1, Since: start.Add(time.Duration(since) * time.Second)})
+	if len(lines) != 100-since || lines[0] != "line"+strconv.Itoa(since)+"\n" {
+		t.Fatalf("unexpected lines since: %q", lines)
+	}
+}
+
+func TestOpenRotatedFileSinceSubsecond(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "docker-logger-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	name := filepath.Join(tmp, "container.log.1")
+	f, err := os.Create(name + loggerutils.CompressedFileSuffix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The modification time in the header only has a precision of a
+	// second.
+	lastWrite := time.Unix(1500000000, 0)
+	gz := gzip.NewWriter(f)
+	gz.ModTime = lastWrite
+	if _, err := gz.Write([]byte("line\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	rotated, err := openRotatedFile(name, lastWrite.Add(500*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rotated == nil {
+		t.Fatal("expected the file written during the second of since to be read")
+	}
+	rotated.Close()
+
+	rotated, err = openRotatedFile(name, lastWrite.Add(time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rotated != nil {
+		rotated.Close()
+		t.Fatal("expected the file written before since to be skipped")
+	}
+}
+
 func TestJSONFileLoggerWithLabelsEnv(t *testing.T) {
 	cid := "a7317399f3f857173c6179d44823594f8294678dea9999662e5c625b5a1c7657"
 	tmp, err := ioutil.TempDir("", "docker-logger-")
@@ -2,10 +2,12 @@ package jsonfilelog
 
 import (
 	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"time"
 
@@ -14,9 +16,11 @@ import (
 
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/daemon/logger"
+	"github.com/docker/docker/daemon/logger/loggerutils"
 	"github.com/docker/docker/pkg/filenotify"
 	"github.com/docker/docker/pkg/ioutils"
 	"github.com/docker/docker/pkg/jsonlog"
+	"github.com/docker/docker/pkg/pools"
 	"github.com/docker/docker/pkg/tailfile"
 )
 
@@ -55,12 +59,12 @@ func (l *JSONFileLogger) readLogs(logWatcher *logger.LogWatcher, config logger.R
 	pth := l.writer.LogPath()
 	var files []io.ReadSeeker
 	for i := l.writer.MaxFiles(); i > 1; i-- {
-		f, err := os.Open(fmt.Sprintf("%s.%d", pth, i-1))
+		f, err := openRotatedFile(fmt.Sprintf("%s.%d", pth, i-1), config.Since)
 		if err != nil {
-			if !os.IsNotExist(err) {
-				logWatcher.Err <- err
-				break
-			}
+			logWatcher.Err <- err
+			break
+		}
+		if f == nil {
 			continue
 		}
 		defer f.Close()
@@ -113,6 +117,59 @@ func (l *JSONFileLogger) readLogs(logWatcher *logger.LogWatcher, config logger.R
 	l.writer.NotifyRotateEvict(notifyRotate)
 }
 
+// openRotatedFile opens a rotated log file, which may have been compressed.
+// Compressed files are decompressed to an unlinked temporary file, since
+// tailing needs to seek. It returns nil if the file does not exist or only
+// holds entries from before since.
+func openRotatedFile(name string, since time.Time) (*os.File, error) {
+	// The uncompressed file is preferred while it is being compressed.
+	f, err := os.Open(name)
+	if err == nil {
+		return f, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	compressed, err := os.Open(name + loggerutils.CompressedFileSuffix)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer compressed.Close()
+
+	gz, err := gzip.NewReader(compressed)
+	if err != nil {
+		return nil, fmt.Errorf("error reading compressed log file %s: %v", compressed.Name(), err)
+	}
+	defer gz.Close()
+
+	// The header holds the time of the last write to the file, in seconds.
+	if !since.IsZero() && gz.ModTime.Before(since.Truncate(time.Second)) {
+		return nil, nil
+	}
+
+	tmp, err := ioutil.TempFile("", "docker-log-decompressed-")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Remove(tmp.Name()); err != nil {
+		tmp.Close()
+		return nil, err
+	}
+	if _, err := pools.Copy(tmp, gz); err != nil {
+		tmp.Close()
+		return nil, fmt.Errorf("error decompressing log file %s: %v", compressed.Name(), err)
+	}
+	if _, err := tmp.Seek(0, os.SEEK_SET); err != nil {
+		tmp.Close()
+		return nil, err
+	}
+	return tmp, nil
+}
+
 func tailFile(f io.ReadSeeker, logWatcher *logger.LogWatcher, tail int, since time.Time) {
 	var rdr io.Reader
 	rdr = f
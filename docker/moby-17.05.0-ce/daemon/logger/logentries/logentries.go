@@ -61,6 +61,11 @@ func (f *logentries) Log(msg *logger.Message) error {
 	for k, v := range f.extra {
 		data[k] = v
 	}
+	for k, v := range msg.Attrs {
+		if _, ok := data[k]; !ok {
+			data[k] = v
+		}
+	}
 	ts := msg.Timestamp
 	logger.PutMessage(msg)
 	f.writer.Println(f.tag, ts, data)
@@ -0,0 +1,107 @@
+// Package cache provides a local log cache that is written alongside
+// logging drivers that cannot read logs back, so that `docker logs`
+// works for them too.
+package cache
+
+import (
+	"strconv"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/daemon/logger"
+	"github.com/docker/docker/daemon/logger/jsonfilelog"
+)
+
+const (
+	// EnabledKey is the log option that enables the local cache
+	EnabledKey = "local-cache"
+	// MaxSizeKey is the log option for the size at which the cache is rotated
+	MaxSizeKey = "local-cache-max-size"
+	// MaxFileKey is the log option for the number of cache files kept
+	MaxFileKey = "local-cache-max-file"
+
+	defaultMaxSize = "20m"
+	defaultMaxFile = "5"
+)
+
+// Enabled returns whether the local cache is enabled by the log options.
+func Enabled(cfg map[string]string) bool {
+	enabled, _ := strconv.ParseBool(cfg[EnabledKey])
+	return enabled
+}
+
+// loggerWithCache writes every message to the local cache before passing
+// it on to the logging driver, and reads logs from the cache.
+type loggerWithCache struct {
+	l     logger.Logger
+	cache logger.Logger
+}
+
+// WithLocalCache returns a logger that writes the messages to a local cache
+// at path in addition to driver, if the local cache is enabled and driver
+// cannot read logs by itself. Otherwise driver is returned unchanged.
+func WithLocalCache(driver logger.Logger, info logger.Info, path string) (logger.Logger, error) {
+	if !Enabled(info.Config) {
+		return driver, nil
+	}
+	if _, ok := driver.(logger.LogReader); ok {
+		return driver, nil
+	}
+
+	cache, err := New(info, path)
+	if err != nil {
+		return nil, err
+	}
+	return &loggerWithCache{l: driver, cache: cache}, nil
+}
+
+// New opens the local cache at path with the size limits of the log
+// options. The returned logger implements logger.LogReader.
+func New(info logger.Info, path string) (logger.Logger, error) {
+	maxSize := defaultMaxSize
+	if s, ok := info.Config[MaxSizeKey]; ok {
+		maxSize = s
+	}
+	maxFile := defaultMaxFile
+	if s, ok := info.Config[MaxFileKey]; ok {
+		maxFile = s
+	}
+
+	cacheInfo := info
+	cacheInfo.LogPath = path
+	cacheInfo.Config = map[string]string{
+		"max-size": maxSize,
+		"max-file": maxFile,
+	}
+	return jsonfilelog.New(cacheInfo)
+}
+
+func (l *loggerWithCache) Log(msg *logger.Message) error {
+	// The driver owns msg once Log is called, so the cache gets a copy.
+	dup := logger.NewMessage()
+	dup.Line = append(dup.Line, msg.Line...)
+	dup.Source = msg.Source
+	dup.Timestamp = msg.Timestamp
+	dup.Attrs = msg.Attrs
+	dup.Partial = msg.Partial
+
+	if err := l.cache.Log(dup); err != nil {
+		logrus.Errorf("Failed to write msg to local log cache for logger %s: %s", l.l.Name(), err)
+	}
+	return l.l.Log(msg)
+}
+
+func (l *loggerWithCache) Name() string {
+	return l.l.Name()
+}
+
+func (l *loggerWithCache) ReadLogs(config logger.ReadConfig) *logger.LogWatcher {
+	return l.cache.(logger.LogReader).ReadLogs(config)
+}
+
+func (l *loggerWithCache) Close() error {
+	err := l.l.Close()
+	if cacheErr := l.cache.Close(); err == nil {
+		err = cacheErr
+	}
+	return err
+}
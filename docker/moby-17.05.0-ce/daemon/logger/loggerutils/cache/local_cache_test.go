@@ -0,0 +1,144 @@
+package cache
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/daemon/logger"
+)
+
+type mockLogger struct {
+	msgs   []string
+	closed bool
+}
+
+func (l *mockLogger) Log(msg *logger.Message) error {
+	l.msgs = append(l.msgs, string(msg.Line))
+	logger.PutMessage(msg)
+	return nil
+}
+
+func (l *mockLogger) Name() string {
+	return "mock"
+}
+
+func (l *mockLogger) Close() error {
+	l.closed = true
+	return nil
+}
+
+type mockReader struct {
+	mockLogger
+}
+
+func (l *mockReader) ReadLogs(logger.ReadConfig) *logger.LogWatcher {
+	return logger.NewLogWatcher()
+}
+
+func readAll(t *testing.T, r logger.LogReader, config logger.ReadConfig) []string {
+	watcher := r.ReadLogs(config)
+	defer watcher.Close()
+
+	var lines []string
+	for {
+		select {
+		case msg, ok := <-watcher.Msg:
+			if !ok {
+				return lines
+			}
+			lines = append(lines, string(msg.Line))
+		case err := <-watcher.Err:
+			t.Fatal(err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout reading logs")
+		}
+	}
+}
+
+func TestWithLocalCache(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "local-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	path := filepath.Join(tmp, "container-cache.log")
+
+	info := logger.Info{
+		Config:      map[string]string{EnabledKey: "true"},
+		ContainerID: "container",
+	}
+	driver := &mockLogger{}
+	l, err := WithLocalCache(driver, info, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reader, ok := l.(logger.LogReader)
+	if !ok {
+		t.Fatalf("expected a log reader, got %T", l)
+	}
+
+	now := time.Now()
+	for i, line := range []string{"one", "two", "three"} {
+		msg := logger.NewMessage()
+		msg.Line = append(msg.Line, line...)
+		msg.Source = "stdout"
+		msg.Timestamp = now.Add(time.Duration(i) * time.Second)
+		if err := l.Log(msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(driver.msgs) != 3 {
+		t.Fatalf("expected 3 messages sent to the driver, got %v", driver.msgs)
+	}
+
+	lines := readAll(t, reader, logger.ReadConfig{Tail: 2})
+	if len(lines) != 2 || lines[0] != "two\n" || lines[1] != "three\n" {
+		t.Fatalf("unexpected tail: %q", lines)
+	}
+
+	lines = readAll(t, reader, logger.ReadConfig{Tail: -1, Since: now.Add(2 * time.Second)})
+	if len(lines) != 1 || lines[0] != "three\n" {
+		t.Fatalf("unexpected lines since: %q", lines)
+	}
+
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !driver.closed {
+		t.Fatal("expected the driver to be closed")
+	}
+
+	// A stopped container is served from the cache alone.
+	cache, err := New(info, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cache.Close()
+	lines = readAll(t, cache.(logger.LogReader), logger.ReadConfig{Tail: -1})
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 cached lines, got %q", lines)
+	}
+}
+
+func TestWithLocalCacheSkipped(t *testing.T) {
+	driver := &mockLogger{}
+	l, err := WithLocalCache(driver, logger.Info{Config: map[string]string{}}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l != driver {
+		t.Fatalf("expected the driver to be returned unchanged when the cache is disabled, got %T", l)
+	}
+
+	reader := &mockReader{}
+	l, err = WithLocalCache(reader, logger.Info{Config: map[string]string{EnabledKey: "true"}}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l != reader {
+		t.Fatalf("expected a reading driver to be returned unchanged, got %T", l)
+	}
+}
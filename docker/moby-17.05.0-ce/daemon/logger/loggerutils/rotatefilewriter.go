@@ -1,25 +1,37 @@
 package loggerutils
 
 import (
+	"compress/gzip"
+	"io"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strconv"
 	"sync"
 
+	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/pkg/pubsub"
 )
 
+// CompressedFileSuffix is the suffix of rotated log files that are compressed.
+const CompressedFileSuffix = ".gz"
+
 // RotateFileWriter is Logger implementation for default Docker logging.
 type RotateFileWriter struct {
 	f            *os.File // store for closing
 	mu           sync.Mutex
-	capacity     int64 //maximum size of each file
-	currentSize  int64 // current size of the latest file
-	maxFiles     int   //maximum number of files
+	capacity     int64  //maximum size of each file
+	currentSize  int64  // current size of the latest file
+	maxFiles     int    //maximum number of files
+	compress     bool   // whether rotated files are compressed
+	rotations    uint64 // number of rotations of the file
+	compressJobs sync.WaitGroup
 	notifyRotate *pubsub.Publisher
 }
 
-//NewRotateFileWriter creates new RotateFileWriter
-func NewRotateFileWriter(logPath string, capacity int64, maxFiles int) (*RotateFileWriter, error) {
+//NewRotateFileWriter creates new RotateFileWriter. If compress is set, rotated
+//files are compressed with gzip in the background.
+func NewRotateFileWriter(logPath string, capacity int64, maxFiles int, compress bool) (*RotateFileWriter, error) {
 	log, err := os.OpenFile(logPath, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0640)
 	if err != nil {
 		return nil, err
@@ -35,6 +47,7 @@ func NewRotateFileWriter(logPath string, capacity int64, maxFiles int) (*RotateF
 		capacity:     capacity,
 		currentSize:  size,
 		maxFiles:     maxFiles,
+		compress:     compress,
 		notifyRotate: pubsub.NewPublisher(0, 1),
 	}, nil
 }
@@ -68,6 +81,25 @@ func (w *RotateFileWriter) checkCapacityAndRotate() error {
 		if err := rotate(name, w.maxFiles); err != nil {
 			return err
 		}
+		w.rotations++
+		if w.compress && w.maxFiles > 1 {
+			// The file is opened before it can be shifted by the next
+			// rotation.
+			rotated, err := os.Open(name + ".1")
+			if err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			if err == nil {
+				rotation := w.rotations
+				w.compressJobs.Add(1)
+				go func() {
+					defer w.compressJobs.Done()
+					if err := w.compressRotated(rotated, name, rotation); err != nil {
+						logrus.Errorf("Error compressing log file %s: %v", rotated.Name(), err)
+					}
+				}()
+			}
+		}
 		file, err := os.OpenFile(name, os.O_WRONLY|os.O_TRUNC|os.O_CREATE, 06400)
 		if err != nil {
 			return err
@@ -87,17 +119,91 @@ func rotate(name string, maxFiles int) error {
 	for i := maxFiles - 1; i > 1; i-- {
 		toPath := name + "." + strconv.Itoa(i)
 		fromPath := name + "." + strconv.Itoa(i-1)
-		if err := os.Rename(fromPath, toPath); err != nil && !os.IsNotExist(err) {
-			return err
+		// Shift both variants, the compression setting may have changed
+		// since the generation was rotated.
+		for _, suffix := range []string{"", CompressedFileSuffix} {
+			if err := os.Rename(fromPath+suffix, toPath+suffix); err != nil && !os.IsNotExist(err) {
+				return err
+			}
 		}
 	}
 
+	// Remove the stale compressed variant of the first generation.
+	if err := os.Remove(name + ".1" + CompressedFileSuffix); err != nil && !os.IsNotExist(err) {
+		return err
+	}
 	if err := os.Rename(name, name+".1"); err != nil && !os.IsNotExist(err) {
 		return err
 	}
 	return nil
 }
 
+// compressRotated compresses the file that the rotation number rotation of
+// the log file at name moved to name.1, and replaces it with the compressed
+// file. The file is compressed without holding the lock of w, so later
+// rotations may have shifted it to an older generation, or removed it, by
+// the time the compressed file is moved in place.
+func (w *RotateFileWriter) compressRotated(file *os.File, name string, rotation uint64) error {
+	tmpPath, err := compressFile(file)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	generation := w.rotations - rotation + 1
+	if generation >= uint64(w.maxFiles) {
+		return os.Remove(tmpPath)
+	}
+	path := name + "." + strconv.FormatUint(generation, 10)
+	// Readers prefer the uncompressed file while both exist.
+	if err := os.Rename(tmpPath, path+CompressedFileSuffix); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return os.Remove(path)
+}
+
+// compressFile writes the file compressed with gzip to a temporary file
+// next to it, and closes it. The modification time of the file is kept in
+// the gzip header, so readers can skip files that are too old without
+// decompressing them. It returns the path of the temporary file.
+func compressFile(file *os.File) (_ string, retErr error) {
+	defer file.Close()
+
+	fi, err := file.Stat()
+	if err != nil {
+		return "", err
+	}
+
+	out, err := ioutil.TempFile(filepath.Dir(file.Name()), filepath.Base(file.Name())+CompressedFileSuffix+".tmp-")
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		out.Close()
+		if retErr != nil {
+			os.Remove(out.Name())
+		}
+	}()
+	if err := out.Chmod(0640); err != nil {
+		return "", err
+	}
+
+	gz := gzip.NewWriter(out)
+	gz.ModTime = fi.ModTime()
+	if _, err := io.Copy(gz, file); err != nil {
+		return "", err
+	}
+	if err := gz.Close(); err != nil {
+		return "", err
+	}
+	if err := out.Close(); err != nil {
+		return "", err
+	}
+	return out.Name(), nil
+}
+
 // LogPath returns the location the given writer logs to.
 func (w *RotateFileWriter) LogPath() string {
 	return w.f.Name()
@@ -120,5 +226,6 @@ func (w *RotateFileWriter) NotifyRotateEvict(sub chan interface{}) {
 
 // Close closes underlying file and signals all readers to stop.
 func (w *RotateFileWriter) Close() error {
+	w.compressJobs.Wait()
 	return w.f.Close()
 }
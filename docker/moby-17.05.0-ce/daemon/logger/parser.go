@@ -0,0 +1,272 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	units "github.com/docker/go-units"
+	"github.com/pkg/errors"
+)
+
+const (
+	multilinePatternKey = "multiline-pattern"
+	multilineMaxSizeKey = "multiline-max-size"
+	multilineTimeoutKey = "multiline-timeout"
+	parseJSONKey        = "parse-json"
+
+	defaultMultilineMaxSize = 64 * 1024
+	defaultMultilineTimeout = time.Second
+)
+
+// parserConfig holds the parsed options of the parser stage.
+type parserConfig struct {
+	pattern   *regexp.Regexp
+	maxSize   int
+	timeout   time.Duration
+	parseJSON bool
+}
+
+// parseParserConfig reads the parser options from the log options. It
+// returns nil if no parsing was requested.
+func parseParserConfig(cfg map[string]string) (*parserConfig, error) {
+	pc := &parserConfig{
+		maxSize: defaultMultilineMaxSize,
+		timeout: defaultMultilineTimeout,
+	}
+
+	if s, ok := cfg[parseJSONKey]; ok {
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error parsing option %s", parseJSONKey)
+		}
+		pc.parseJSON = b
+	}
+
+	if s, ok := cfg[multilinePatternKey]; ok {
+		if s == "" {
+			return nil, fmt.Errorf("logger: %s must not be empty", multilinePatternKey)
+		}
+		re, err := regexp.Compile(s)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error parsing option %s", multilinePatternKey)
+		}
+		pc.pattern = re
+	}
+
+	if s, ok := cfg[multilineMaxSizeKey]; ok {
+		if pc.pattern == nil {
+			return nil, fmt.Errorf("logger: %s option is only supported with %s", multilineMaxSizeKey, multilinePatternKey)
+		}
+		size, err := units.RAMInBytes(s)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error parsing option %s", multilineMaxSizeKey)
+		}
+		if size <= 0 {
+			return nil, fmt.Errorf("logger: %s must be positive", multilineMaxSizeKey)
+		}
+		pc.maxSize = int(size)
+	}
+
+	if s, ok := cfg[multilineTimeoutKey]; ok {
+		if pc.pattern == nil {
+			return nil, fmt.Errorf("logger: %s option is only supported with %s", multilineTimeoutKey, multilinePatternKey)
+		}
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error parsing option %s", multilineTimeoutKey)
+		}
+		if d <= 0 {
+			return nil, fmt.Errorf("logger: %s must be positive", multilineTimeoutKey)
+		}
+		pc.timeout = d
+	}
+
+	if pc.pattern == nil && !pc.parseJSON {
+		return nil, nil
+	}
+	return pc, nil
+}
+
+// ParserLogger sits between the Copier and a logging driver. It joins
+// the lines of multi-line messages, such as stack traces, into a single
+// message and lifts the fields of JSON lines into the message attributes.
+type ParserLogger struct {
+	l       Logger
+	cfg     *parserConfig
+	mu      sync.Mutex
+	pending map[string]*pendingMessage // source -> message being joined
+	closed  bool
+}
+
+type pendingMessage struct {
+	source string
+	msg    *Message
+	timer  *time.Timer
+}
+
+type parserWithReader struct {
+	*ParserLogger
+}
+
+func (p *parserWithReader) ReadLogs(cfg ReadConfig) *LogWatcher {
+	reader, ok := p.l.(LogReader)
+	if !ok {
+		// something is wrong if we get here
+		panic("expected log reader")
+	}
+	return reader.ReadLogs(cfg)
+}
+
+// NewParserLogger wraps the passed in logger with the parser stage
+// configured by the log options in logInfo. The logger is returned
+// unchanged if no parsing was requested.
+func NewParserLogger(driver Logger, logInfo Info) (Logger, error) {
+	cfg, err := parseParserConfig(logInfo.Config)
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		return driver, nil
+	}
+
+	p := &ParserLogger{
+		l:       driver,
+		cfg:     cfg,
+		pending: make(map[string]*pendingMessage),
+	}
+	if _, ok := driver.(LogReader); ok {
+		return &parserWithReader{p}, nil
+	}
+	return p, nil
+}
+
+// Log parses the message and passes it on to the underlying logger once
+// it is complete.
+func (p *ParserLogger) Log(msg *Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return errClosed
+	}
+
+	if p.cfg.parseJSON && liftJSONAttrs(msg) {
+		// A JSON line is a complete record on its own.
+		if err := p.flush(msg.Source); err != nil {
+			logrus.Errorf("Failed to log msg for logger %s: %s", p.l.Name(), err)
+		}
+		return p.l.Log(msg)
+	}
+
+	if p.cfg.pattern == nil {
+		return p.l.Log(msg)
+	}
+
+	pm, ok := p.pending[msg.Source]
+	if ok && (pm.msg.Partial || !p.cfg.pattern.Match(msg.Line)) &&
+		len(pm.msg.Line)+len(msg.Line)+1 <= p.cfg.maxSize {
+		// Partial lines were split by the Copier, not by a newline.
+		if !pm.msg.Partial {
+			pm.msg.Line = append(pm.msg.Line, '\n')
+		}
+		pm.msg.Line = append(pm.msg.Line, msg.Line...)
+		pm.msg.Partial = msg.Partial
+		PutMessage(msg)
+		pm.timer.Reset(p.cfg.timeout)
+		return nil
+	}
+
+	var err error
+	if ok {
+		err = p.flush(msg.Source)
+	}
+
+	pm = &pendingMessage{source: msg.Source, msg: msg}
+	pm.timer = time.AfterFunc(p.cfg.timeout, func() {
+		p.flushTimeout(pm)
+	})
+	p.pending[msg.Source] = pm
+	return err
+}
+
+// flush passes the pending message of source on to the underlying logger.
+// It must be called with p.mu held.
+func (p *ParserLogger) flush(source string) error {
+	pm, ok := p.pending[source]
+	if !ok {
+		return nil
+	}
+	delete(p.pending, source)
+	pm.timer.Stop()
+	return p.l.Log(pm.msg)
+}
+
+func (p *ParserLogger) flushTimeout(pm *pendingMessage) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// The message may have been flushed while the timer fired.
+	if p.closed || p.pending[pm.source] != pm {
+		return
+	}
+	if err := p.flush(pm.source); err != nil {
+		logrus.Errorf("Failed to log msg for logger %s: %s", p.l.Name(), err)
+	}
+}
+
+// Name returns the name of the underlying logger
+func (p *ParserLogger) Name() string {
+	return p.l.Name()
+}
+
+// Close flushes the pending messages and closes the underlying logger.
+func (p *ParserLogger) Close() error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	for source := range p.pending {
+		if err := p.flush(source); err != nil {
+			logrus.Errorf("Failed to log msg for logger %s: %s", p.l.Name(), err)
+		}
+	}
+	p.closed = true
+	p.mu.Unlock()
+
+	return p.l.Close()
+}
+
+// liftJSONAttrs stores the top-level fields of a message that is a JSON
+// object in its attributes. Fields that are not strings are stored in their
+// JSON encoding. It reports whether the message was a JSON object.
+func liftJSONAttrs(msg *Message) bool {
+	line := bytes.TrimSpace(msg.Line)
+	if msg.Partial || len(line) < 2 || line[0] != '{' || line[len(line)-1] != '}' {
+		return false
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(line, &fields); err != nil {
+		return false
+	}
+
+	if msg.Attrs == nil {
+		msg.Attrs = make(map[string]string, len(fields))
+	}
+	for k, raw := range fields {
+		var s string
+		if err := json.Unmarshal(raw, &s); err == nil {
+			msg.Attrs[k] = s
+			continue
+		}
+		msg.Attrs[k] = string(raw)
+	}
+	return true
+}
@@ -0,0 +1,210 @@
+package logger
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestParser(t *testing.T, cfg map[string]string) (*ParserLogger, *mockLogger) {
+	mockLog := &mockLogger{make(chan *Message, 10)}
+	l, err := NewParserLogger(mockLog, Info{Config: cfg})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, ok := l.(*ParserLogger)
+	if !ok {
+		t.Fatalf("expected a parser logger, got %T", l)
+	}
+	return p, mockLog
+}
+
+func logLines(t *testing.T, l Logger, lines ...string) {
+	for _, line := range lines {
+		if err := l.Log(&Message{Source: "stdout", Line: []byte(line)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func expectLine(t *testing.T, c chan *Message, expected string) *Message {
+	select {
+	case msg := <-c:
+		if string(msg.Line) != expected {
+			t.Fatalf("expected %q, got %q", expected, string(msg.Line))
+		}
+		return msg
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timeout waiting for %q", expected)
+	}
+	return nil
+}
+
+func expectNoLine(t *testing.T, c chan *Message) {
+	select {
+	case msg := <-c:
+		t.Fatalf("unexpected message %q", string(msg.Line))
+	default:
+	}
+}
+
+func TestParserLoggerPassthrough(t *testing.T) {
+	mockLog := &mockLogger{make(chan *Message)}
+	l, err := NewParserLogger(mockLog, Info{Config: map[string]string{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l != mockLog {
+		t.Fatalf("expected the driver to be returned unchanged, got %T", l)
+	}
+}
+
+func TestParserLoggerMultiline(t *testing.T) {
+	p, mockLog := newTestParser(t, map[string]string{
+		multilinePatternKey: `^\d{4}-`,
+		multilineTimeoutKey: "1h",
+	})
+
+	logLines(t, p,
+		"2017-05-04 Exception in thread main",
+		"\tat Foo.bar(Foo.java:10)",
+		"\tat Foo.main(Foo.java:5)",
+		"2017-05-04 done",
+	)
+	expectLine(t, mockLog.c, "2017-05-04 Exception in thread main\n\tat Foo.bar(Foo.java:10)\n\tat Foo.main(Foo.java:5)")
+	expectNoLine(t, mockLog.c)
+
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+	expectLine(t, mockLog.c, "2017-05-04 done")
+}
+
+func TestParserLoggerMultilinePartial(t *testing.T) {
+	p, mockLog := newTestParser(t, map[string]string{
+		multilinePatternKey: `^start`,
+		multilineTimeoutKey: "1h",
+	})
+
+	logLines(t, p, "start")
+	if err := p.Log(&Message{Source: "stdout", Line: []byte("one"), Partial: true}); err != nil {
+		t.Fatal(err)
+	}
+	logLines(t, p, "start two", "start")
+	expectLine(t, mockLog.c, "start\nonestart two")
+	p.Close()
+}
+
+func TestParserLoggerMultilineTimeout(t *testing.T) {
+	p, mockLog := newTestParser(t, map[string]string{
+		multilinePatternKey: `^start`,
+		multilineTimeoutKey: "10ms",
+	})
+	defer p.Close()
+
+	logLines(t, p, "start", "more")
+	expectLine(t, mockLog.c, "start\nmore")
+}
+
+func TestParserLoggerMultilineMaxSize(t *testing.T) {
+	p, mockLog := newTestParser(t, map[string]string{
+		multilinePatternKey: `^start`,
+		multilineMaxSizeKey: "10",
+		multilineTimeoutKey: "1h",
+	})
+
+	logLines(t, p, "start", "1234", "5678")
+	expectLine(t, mockLog.c, "start\n1234")
+	p.Close()
+	expectLine(t, mockLog.c, "5678")
+}
+
+func TestParserLoggerMultilineSources(t *testing.T) {
+	p, mockLog := newTestParser(t, map[string]string{
+		multilinePatternKey: `^start`,
+		multilineTimeoutKey: "1h",
+	})
+
+	logLines(t, p, "start out")
+	if err := p.Log(&Message{Source: "stderr", Line: []byte("err")}); err != nil {
+		t.Fatal(err)
+	}
+	logLines(t, p, "more", "start")
+	expectLine(t, mockLog.c, "start out\nmore")
+	p.Close()
+}
+
+func TestParserLoggerJSON(t *testing.T) {
+	p, mockLog := newTestParser(t, map[string]string{
+		parseJSONKey: "true",
+	})
+	defer p.Close()
+
+	logLines(t, p, `{"level":"error","code":42,"ctx":{"a":1}}`, "plain")
+
+	msg := expectLine(t, mockLog.c, `{"level":"error","code":42,"ctx":{"a":1}}`)
+	expected := map[string]string{
+		"level": "error",
+		"code":  "42",
+		"ctx":   `{"a":1}`,
+	}
+	if len(msg.Attrs) != len(expected) {
+		t.Fatalf("expected attrs %v, got %v", expected, msg.Attrs)
+	}
+	for k, v := range expected {
+		if msg.Attrs[k] != v {
+			t.Fatalf("expected attr %s to be %q, got %q", k, v, msg.Attrs[k])
+		}
+	}
+
+	msg = expectLine(t, mockLog.c, "plain")
+	if len(msg.Attrs) != 0 {
+		t.Fatalf("expected no attrs, got %v", msg.Attrs)
+	}
+}
+
+func TestParserLoggerJSONFlushesMultiline(t *testing.T) {
+	p, mockLog := newTestParser(t, map[string]string{
+		parseJSONKey:        "true",
+		multilinePatternKey: `^start`,
+		multilineTimeoutKey: "1h",
+	})
+	defer p.Close()
+
+	logLines(t, p, "start", "more", `{"msg":"hi"}`)
+	expectLine(t, mockLog.c, "start\nmore")
+	msg := expectLine(t, mockLog.c, `{"msg":"hi"}`)
+	if msg.Attrs["msg"] != "hi" {
+		t.Fatalf("expected attr msg to be %q, got %q", "hi", msg.Attrs["msg"])
+	}
+}
+
+func TestParserLoggerValidation(t *testing.T) {
+	invalid := []map[string]string{
+		{parseJSONKey: "maybe"},
+		{multilinePatternKey: ""},
+		{multilinePatternKey: "("},
+		{multilineMaxSizeKey: "1k"},
+		{multilineTimeoutKey: "1s"},
+		{multilinePatternKey: "^a", multilineMaxSizeKey: "0"},
+		{multilinePatternKey: "^a", multilineTimeoutKey: "soon"},
+	}
+	for _, cfg := range invalid {
+		if _, err := parseParserConfig(cfg); err == nil {
+			t.Fatalf("expected error for %v", cfg)
+		}
+	}
+
+	cfg, err := parseParserConfig(map[string]string{multilinePatternKey: "^a", multilineMaxSizeKey: "1k"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.maxSize != 1024 || cfg.timeout != defaultMultilineTimeout {
+		t.Fatalf("unexpected config %+v", cfg)
+	}
+
+	err = ValidateLogOpts("json-file", map[string]string{multilineTimeoutKey: "1s"})
+	if err == nil || !strings.Contains(err.Error(), multilinePatternKey) {
+		t.Fatalf("expected error about %s, got %v", multilinePatternKey, err)
+	}
+}
@@ -338,6 +338,7 @@ func (l *splunkLoggerInline) Log(msg *logger.Message) error {
 	event := *l.nullEvent
 	event.Line = string(msg.Line)
 	event.Source = msg.Source
+	event.Attrs = eventAttrs(event.Attrs, msg)
 
 	message.Event = &event
 	logger.PutMessage(msg)
@@ -356,6 +357,7 @@ func (l *splunkLoggerJSON) Log(msg *logger.Message) error {
 	}
 
 	event.Source = msg.Source
+	event.Attrs = eventAttrs(event.Attrs, msg)
 
 	message.Event = &event
 	logger.PutMessage(msg)
@@ -370,6 +372,22 @@ func (l *splunkLoggerRaw) Log(msg *logger.Message) error {
 	return l.queueMessageAsync(message)
 }
 
+// eventAttrs merges the attributes of the message into the extra
+// attributes of the logger.
+func eventAttrs(extra map[string]string, msg *logger.Message) map[string]string {
+	if len(msg.Attrs) == 0 {
+		return extra
+	}
+	attrs := make(map[string]string, len(extra)+len(msg.Attrs))
+	for k, v := range msg.Attrs {
+		attrs[k] = v
+	}
+	for k, v := range extra {
+		attrs[k] = v
+	}
+	return attrs
+}
+
 func (l *splunkLogger) queueMessageAsync(message *splunkMessage) error {
 	l.lock.RLock()
 	defer l.lock.RUnlock()
@@ -2,6 +2,7 @@ package daemon
 
 import (
 	"errors"
+	"os"
 	"strconv"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	timetypes "github.com/docker/docker/api/types/time"
 	"github.com/docker/docker/container"
 	"github.com/docker/docker/daemon/logger"
+	"github.com/docker/docker/daemon/logger/loggerutils/cache"
 )
 
 // ContainerLogs copies the container's log channel to the channel provided in
@@ -152,6 +154,23 @@ func (daemon *Daemon) getLogger(container *container.Container) (logger.Logger,
 	if container.LogDriver != nil && container.IsRunning() {
 		return container.LogDriver, nil
 	}
+
+	// Serve the logs of stopped containers from the local cache when it
+	// was written, instead of connecting to a driver that cannot read.
+	cfg := container.HostConfig.LogConfig
+	if cache.Enabled(cfg.Config) {
+		cachePath, err := container.LogCachePath()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := os.Stat(cachePath); err == nil {
+			return cache.New(logger.Info{
+				Config:        cfg.Config,
+				ContainerID:   container.ID,
+				ContainerName: container.Name,
+			}, cachePath)
+		}
+	}
 	return container.StartLogger()
 }
 
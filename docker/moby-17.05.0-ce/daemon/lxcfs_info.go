@@ -73,3 +73,42 @@ func (daemon *Daemon) LxcfsInfo() (*types.LxcfsInfo, error){
 	return info, nil
 }
 
+
+// ContainerLxcfs returns the lxcfs virtualized /proc files as they are seen
+// from inside the running container identified by name.
+func (daemon *Daemon) ContainerLxcfs(name string) (*types.ContainerLxcfs, error) {
+	container, err := daemon.GetContainer(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if !container.IsRunning() {
+		return nil, errNotRunning{container.ID}
+	}
+
+	if container.IsRestarting() {
+		return nil, errContainerIsRestarting(container.ID)
+	}
+
+	remote := daemon.lxcfsRemote
+	if remote == nil {
+		return nil, errors.New("lxcfs is not enabled on this daemon")
+	}
+
+	files, err := remote.LxcfsProcFiles(container.State.GetPID())
+	if err != nil {
+		logrus.Errorf("Error getting lxcfs view of container %s: %v", container.ID, err)
+		return nil, err
+	}
+
+	return &types.ContainerLxcfs{
+		ID:        container.ID,
+		Name:      container.Name,
+		Pid:       container.State.GetPID(),
+		Meminfo:   files["/proc/meminfo"],
+		Cpuinfo:   files["/proc/cpuinfo"],
+		Stat:      files["/proc/stat"],
+		Uptime:    files["/proc/uptime"],
+		Diskstats: files["/proc/diskstats"],
+	}, nil
+}
@@ -0,0 +1,47 @@
+package daemon
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/pkg/registrar"
+	"github.com/docker/docker/pkg/truncindex"
+)
+
+func TestContainerLxcfs(t *testing.T) {
+	stopped := &container.Container{
+		CommonContainer: container.CommonContainer{
+			ID:    "5a4ff6a163ad4533d22d69a2b8960bf7fafdcba06e72d2febdba229008b0bf57",
+			Name:  "stopped",
+			State: container.NewState(),
+		},
+	}
+	running := &container.Container{
+		CommonContainer: container.CommonContainer{
+			ID:    "3cdbd1aa394fd68559fd1441d6eff2ab7c1e6363582c82febfaa8045df3bd8de",
+			Name:  "running",
+			State: container.NewState(),
+		},
+	}
+	running.SetRunning(1234, true)
+
+	store := container.NewMemoryStore()
+	index := truncindex.NewTruncIndex([]string{})
+	for _, c := range []*container.Container{stopped, running} {
+		store.Add(c.ID, c)
+		index.Add(c.ID)
+	}
+	daemon := &Daemon{
+		containers: store,
+		idIndex:    index,
+		nameIndex:  registrar.NewRegistrar(),
+	}
+
+	if _, err := daemon.ContainerLxcfs(stopped.ID); err == nil || !strings.Contains(err.Error(), "is not running") {
+		t.Fatalf("expected a not running error, got %v", err)
+	}
+	if _, err := daemon.ContainerLxcfs(running.ID); err == nil || !strings.Contains(err.Error(), "lxcfs is not enabled") {
+		t.Fatalf("expected an lxcfs not enabled error, got %v", err)
+	}
+}
@@ -0,0 +1,193 @@
+package daemon
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/libcontainerd"
+)
+
+// lxcfsFiles are the files lxcfs virtualizes for the cgroups of the reading
+// process. Each one is bind-mounted from the lxcfs mount path to the same
+// path inside the container.
+var lxcfsFiles = []string{
+	"/proc/cpuinfo",
+	"/proc/diskstats",
+	"/proc/meminfo",
+	"/proc/stat",
+	"/proc/swaps",
+	"/proc/uptime",
+	"/sys/devices/system/cpu/online",
+}
+
+// lxcfsMode returns the lxcfs mode of the container, falling back to the
+// daemon-wide default when the container does not set one.
+func (daemon *Daemon) lxcfsMode(c *container.Container) containertypes.LxcfsMode {
+	if !c.HostConfig.Lxcfs.IsDefault() {
+		return c.HostConfig.Lxcfs
+	}
+	return containertypes.LxcfsMode(daemon.configStore.LxcfsMode)
+}
+
+// lxcfsMounts returns the lxcfs bind mounts for the container. In strict
+// mode an error is returned when lxcfs is not healthy, in auto mode the
+// container is started without them.
+func (daemon *Daemon) lxcfsMounts(c *container.Container, userMounts []container.Mount) ([]container.Mount, error) {
+	mode := daemon.lxcfsMode(c)
+	if !mode.IsAuto() && !mode.IsStrict() {
+		return nil, nil
+	}
+
+	remote := daemon.lxcfsRemote
+	if remote == nil {
+		if mode.IsStrict() {
+			return nil, fmt.Errorf("lxcfs mode of container %s is strict, but lxcfs is not enabled on this daemon", c.ID)
+		}
+		return nil, nil
+	}
+
+	if err := remote.CheckHealth(); err != nil {
+		if mode.IsStrict() {
+			return nil, fmt.Errorf("lxcfs mode of container %s is strict, but lxcfs is not healthy: %v", c.ID, err)
+		}
+		logrus.Warnf("Starting container %s without lxcfs mounts: %v", c.ID, err)
+		return nil, nil
+	}
+
+	return lxcfsFileMounts(remote.MountPath(), userMounts)
+}
+
+// lxcfsFileMounts returns a read-only bind mount for each of the lxcfsFiles
+// found under mountPath, unless the user already mounted something there.
+func lxcfsFileMounts(mountPath string, userMounts []container.Mount) ([]container.Mount, error) {
+	existing := make(map[string]struct{})
+	for _, m := range userMounts {
+		existing[m.Destination] = struct{}{}
+	}
+
+	var ms []container.Mount
+	for _, f := range lxcfsFiles {
+		if _, ok := existing[f]; ok {
+			continue
+		}
+
+		source := filepath.Join(mountPath, f)
+		if _, err := os.Stat(source); err != nil {
+			if os.IsNotExist(err) {
+				// Older lxcfs versions do not provide every file.
+				logrus.Debugf("lxcfs: %s does not exist, not mounting it", source)
+				continue
+			}
+			return nil, err
+		}
+
+		ms = append(ms, container.Mount{
+			Source:      source,
+			Destination: f,
+		})
+	}
+	return ms, nil
+}
+
+// LxcfsRestarted is called by the lxcfs remote after lxcfs crashed and was
+// restarted. The lxcfs files mounted into running containers still point to
+// the FUSE filesystem of the dead instance, so they are replaced by the ones
+// of the new instance.
+func (daemon *Daemon) LxcfsRestarted(pid int) {
+	remote := daemon.lxcfsRemote
+	if remote == nil {
+		return
+	}
+
+	lxcfsRestartsCounter.Inc()
+
+	var remounted, failed int
+	for _, c := range daemon.List() {
+		if !c.IsRunning() {
+			continue
+		}
+		mode := daemon.lxcfsMode(c)
+		if !mode.IsAuto() && !mode.IsStrict() {
+			continue
+		}
+
+		files, err := libcontainerd.LxcfsRemount(c.State.GetPID(), remote.MountPath(), lxcfsFiles)
+		if err != nil {
+			logrus.Errorf("Error remounting lxcfs into container %s: %v", c.ID, err)
+			failed++
+			continue
+		}
+		if len(files) > 0 {
+			logrus.Debugf("Remounted lxcfs files %v into container %s", files, c.ID)
+			remounted++
+		}
+	}
+
+	daemon.LogLxcfsEvent(pid, "recover", map[string]string{
+		"mountPath":  remote.MountPath(),
+		"containers": strconv.Itoa(remounted),
+		"failed":     strconv.Itoa(failed),
+	})
+}
+
+// LxcfsStateChanged is called by the lxcfs remote when lxcfs starts or
+// stops, and when it becomes healthy or unhealthy.
+func (daemon *Daemon) LxcfsStateChanged(state string, status libcontainerd.LxcfsStatus) {
+	action := state
+	switch state {
+	case libcontainerd.LxcfsStateStart:
+		lxcfsStartTime.Set(float64(status.StartedAt.Unix()))
+	case libcontainerd.LxcfsStateStop:
+		lxcfsUp.Set(0)
+	case libcontainerd.LxcfsStateHealthy:
+		lxcfsUp.Set(1)
+		action = "health_status: " + state
+	case libcontainerd.LxcfsStateUnhealthy:
+		lxcfsUp.Set(0)
+		action = "health_status: " + state
+	}
+
+	daemon.LogLxcfsEvent(status.Pid, action, map[string]string{
+		"mountPath":    status.MountPath,
+		"restartCount": strconv.Itoa(status.RestartCount),
+	})
+}
+
+// LxcfsHealthChecked is called by the lxcfs remote after every health check.
+func (daemon *Daemon) LxcfsHealthChecked(status libcontainerd.LxcfsStatus, err error) {
+	lxcfsHealthChecksCounter.Inc()
+	if err != nil {
+		lxcfsHealthChecksFailedCounter.Inc()
+		logrus.Debugf("lxcfs health check failed: %v", err)
+	}
+	lxcfsHealthCheckLatency.Set(status.HealthCheckLatency.Seconds())
+}
+
+// lxcfsStatus returns the status of lxcfs for the info endpoint, or nil
+// when lxcfs is not enabled.
+func (daemon *Daemon) lxcfsStatus() *types.LxcfsStatus {
+	remote := daemon.lxcfsRemote
+	if remote == nil {
+		return nil
+	}
+
+	status := remote.Status()
+	s := &types.LxcfsStatus{
+		Pid:                status.Pid,
+		RestartCount:       status.RestartCount,
+		MountPath:          status.MountPath,
+		Healthy:            status.Healthy,
+		HealthCheckLatency: status.HealthCheckLatency,
+	}
+	if !status.StartedAt.IsZero() {
+		s.Uptime = time.Since(status.StartedAt)
+	}
+	return s
+}
@@ -0,0 +1,86 @@
+// +build linux
+
+package daemon
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/daemon/config"
+)
+
+func TestLxcfsFileMounts(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "lxcfs-mounts-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	for _, f := range []string{"/proc/meminfo", "/proc/cpuinfo", "/proc/uptime"} {
+		p := filepath.Join(tmp, f)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	userMounts := []container.Mount{{Source: "/custom/uptime", Destination: "/proc/uptime"}}
+	ms, err := lxcfsFileMounts(tmp, userMounts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{
+		"/proc/cpuinfo": filepath.Join(tmp, "/proc/cpuinfo"),
+		"/proc/meminfo": filepath.Join(tmp, "/proc/meminfo"),
+	}
+	if len(ms) != len(expected) {
+		t.Fatalf("expected %d mounts, got %v", len(expected), ms)
+	}
+	for _, m := range ms {
+		if expected[m.Destination] != m.Source {
+			t.Fatalf("unexpected mount %s:%s", m.Source, m.Destination)
+		}
+		if m.Writable {
+			t.Fatalf("expected %s to be mounted read-only", m.Destination)
+		}
+	}
+}
+
+func TestLxcfsMountsWithoutRemote(t *testing.T) {
+	d := &Daemon{configStore: &config.Config{}}
+	c := &container.Container{
+		CommonContainer: container.CommonContainer{
+			ID:         "lxcfs",
+			HostConfig: &containertypes.HostConfig{},
+		},
+	}
+
+	for _, mode := range []string{"", "off", "auto"} {
+		d.configStore.LxcfsMode = mode
+		ms, err := d.lxcfsMounts(c, nil)
+		if err != nil {
+			t.Fatalf("lxcfs mode %q: unexpected error %v", mode, err)
+		}
+		if len(ms) != 0 {
+			t.Fatalf("lxcfs mode %q: expected no mounts, got %v", mode, ms)
+		}
+	}
+
+	d.configStore.LxcfsMode = "strict"
+	if _, err := d.lxcfsMounts(c, nil); err == nil {
+		t.Fatal("expected an error for strict mode without lxcfs")
+	}
+
+	// The container setting takes precedence over the daemon default.
+	c.HostConfig.Lxcfs = "off"
+	if _, err := d.lxcfsMounts(c, nil); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
@@ -11,6 +11,13 @@ var (
 	engineMemory              metrics.Gauge
 	healthChecksCounter       metrics.Counter
 	healthChecksFailedCounter metrics.Counter
+
+	lxcfsUp                        metrics.Gauge
+	lxcfsStartTime                 metrics.Gauge
+	lxcfsRestartsCounter           metrics.Counter
+	lxcfsHealthChecksCounter       metrics.Counter
+	lxcfsHealthChecksFailedCounter metrics.Counter
+	lxcfsHealthCheckLatency        metrics.Gauge
 )
 
 func init() {
@@ -38,5 +45,11 @@ func init() {
 	healthChecksCounter = ns.NewCounter("health_checks", "The total number of health checks")
 	healthChecksFailedCounter = ns.NewCounter("health_checks_failed", "The total number of failed health checks")
 	imageActions = ns.NewLabeledTimer("image_actions", "The number of seconds it takes to process each image action", "action")
+	lxcfsUp = ns.NewGauge("lxcfs_up", "Whether lxcfs is running and answering health checks", metrics.Unit("info"))
+	lxcfsStartTime = ns.NewGauge("lxcfs_start_time", "The time lxcfs was last started, since the unix epoch", metrics.Seconds)
+	lxcfsRestartsCounter = ns.NewCounter("lxcfs_restarts", "The total number of times lxcfs had to be restarted")
+	lxcfsHealthChecksCounter = ns.NewCounter("lxcfs_health_checks", "The total number of lxcfs health checks")
+	lxcfsHealthChecksFailedCounter = ns.NewCounter("lxcfs_health_checks_failed", "The total number of failed lxcfs health checks")
+	lxcfsHealthCheckLatency = ns.NewGauge("lxcfs_health_check_latency", "The duration of the last lxcfs health check", metrics.Seconds)
 	metrics.Register(ns)
 }
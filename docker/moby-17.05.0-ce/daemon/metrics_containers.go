@@ -0,0 +1,327 @@
+package daemon
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/container"
+	"github.com/golang/protobuf/proto"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+// containerMetricsPrefix is the prefix of the names of the per-container
+// metrics, next to the engine_daemon ones of the daemon itself.
+const containerMetricsPrefix = "engine_container_"
+
+// containerMetricLabels maps the labels of containers copied to the metrics to
+// the names of the metric labels.
+var containerMetricLabels = []struct {
+	label, name string
+}{
+	{"com.docker.compose.project", "compose_project"},
+	{"com.docker.compose.service", "compose_service"},
+	{"com.docker.swarm.service.name", "service_name"},
+	{"com.docker.swarm.task.name", "task_name"},
+}
+
+// ContainerMetricsHandler returns the handler serving the resource usage of
+// the running containers in the prometheus format. The stats are the last
+// ones collected by the stats collector, as for the stats API, so a container
+// only has metrics from the scrape after the one it is first seen running by.
+func (daemon *Daemon) ContainerMetricsHandler() http.Handler {
+	m := newContainerMetrics(daemon.statsCollector)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		families := m.collect(daemon.List())
+
+		format := expfmt.Negotiate(r.Header)
+		w.Header().Set("Content-Type", string(format))
+		enc := expfmt.NewEncoder(w, format)
+		for _, mf := range families.sorted() {
+			if err := enc.Encode(mf); err != nil {
+				logrus.Errorf("serve container metrics: %v", err)
+				return
+			}
+		}
+	})
+}
+
+// statsSubscriber is the stats collector, as used by containerMetrics.
+type statsSubscriber interface {
+	Collect(c *container.Container) chan interface{}
+	Unsubscribe(c *container.Container, ch chan interface{})
+}
+
+// containerMetrics keeps the last stats that the stats collector collected
+// for the running containers. The containers are subscribed to while they
+// are running, so the collector only collects their stats once per interval
+// whatever the number of scrapes.
+type containerMetrics struct {
+	collector statsSubscriber
+
+	mu   sync.Mutex // protects subs, held for the whole of a scrape
+	subs map[string]*containerStatsSub
+}
+
+// containerStatsSub is the subscription to the stats of a container.
+type containerStatsSub struct {
+	c    *container.Container
+	ch   chan interface{}
+	seen bool // whether the container was seen running by the last scrape
+
+	mu   sync.Mutex // protects last
+	last *types.StatsJSON
+}
+
+func newContainerMetrics(collector statsSubscriber) *containerMetrics {
+	return &containerMetrics{
+		collector: collector,
+		subs:      make(map[string]*containerStatsSub),
+	}
+}
+
+// collect returns the metrics of the containers from their last collected
+// stats, and unsubscribes from the stats of the containers which aren't
+// running anymore. The scrapes are serialized, so that a scrape doesn't
+// unsubscribe from the containers that a concurrent one just saw running.
+func (m *containerMetrics) collect(containers []*container.Container) metricFamilies {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	families := newMetricFamilies()
+	for _, c := range containers {
+		if stats := m.stats(c); stats != nil {
+			families.addContainerStats(c, stats)
+		}
+	}
+	m.prune()
+	return families
+}
+
+// stats returns the last stats collected for the container c if it is
+// running, and subscribes to them if it isn't already. It returns nil if
+// none were collected yet. m.mu must be held.
+func (m *containerMetrics) stats(c *container.Container) *types.StatsJSON {
+	if !c.IsRunning() || c.IsRestarting() {
+		return nil
+	}
+	sub, ok := m.subs[c.ID]
+	if !ok {
+		sub = &containerStatsSub{c: c, ch: m.collector.Collect(c)}
+		m.subs[c.ID] = sub
+		go sub.receive()
+	}
+	sub.seen = true
+
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	return sub.last
+}
+
+// prune unsubscribes from the stats of the containers that the last scrape
+// didn't see running. m.mu must be held.
+func (m *containerMetrics) prune() {
+	for id, sub := range m.subs {
+		if sub.seen {
+			sub.seen = false
+			continue
+		}
+		m.collector.Unsubscribe(sub.c, sub.ch)
+		delete(m.subs, id)
+	}
+}
+
+// receive keeps the last stats published for the container, until the
+// subscription is closed.
+func (sub *containerStatsSub) receive() {
+	for v := range sub.ch {
+		stats, ok := v.(types.StatsJSON)
+		if !ok {
+			continue
+		}
+		sub.mu.Lock()
+		if stats.Read.IsZero() {
+			// The container isn't running anymore.
+			sub.last = nil
+		} else {
+			sub.last = &stats
+		}
+		sub.mu.Unlock()
+	}
+}
+
+// metricFamilies holds the metrics of the containers, by metric name.
+type metricFamilies map[string]*dto.MetricFamily
+
+func newMetricFamilies() metricFamilies {
+	return make(metricFamilies)
+}
+
+// sorted returns the metric families sorted by name.
+func (f metricFamilies) sorted() []*dto.MetricFamily {
+	names := make([]string, 0, len(f))
+	for name := range f {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	families := make([]*dto.MetricFamily, 0, len(names))
+	for _, name := range names {
+		families = append(families, f[name])
+	}
+	return families
+}
+
+func (f metricFamilies) add(name, help string, typ dto.MetricType, value float64, labels []*dto.LabelPair) {
+	name = containerMetricsPrefix + name
+	mf, ok := f[name]
+	if !ok {
+		mf = &dto.MetricFamily{
+			Name: proto.String(name),
+			Help: proto.String(help),
+			Type: typ.Enum(),
+		}
+		f[name] = mf
+	}
+	m := &dto.Metric{Label: labels}
+	switch typ {
+	case dto.MetricType_COUNTER:
+		m.Counter = &dto.Counter{Value: proto.Float64(value)}
+	default:
+		m.Gauge = &dto.Gauge{Value: proto.Float64(value)}
+	}
+	mf.Metric = append(mf.Metric, m)
+}
+
+func (f metricFamilies) counter(name, help string, value float64, labels []*dto.LabelPair) {
+	f.add(name, help, dto.MetricType_COUNTER, value, labels)
+}
+
+func (f metricFamilies) gauge(name, help string, value float64, labels []*dto.LabelPair) {
+	f.add(name, help, dto.MetricType_GAUGE, value, labels)
+}
+
+// containerLabelPairs returns the labels identifying the container c in its
+// metrics, followed by extra name and value pairs.
+func containerLabelPairs(c *container.Container, extra ...string) []*dto.LabelPair {
+	labels := []*dto.LabelPair{
+		labelPair("id", c.ID),
+		labelPair("name", strings.TrimPrefix(c.Name, "/")),
+		labelPair("image", c.Config.Image),
+	}
+	for _, l := range containerMetricLabels {
+		// The labels are always set, so that all the metrics of a family
+		// have the same labels.
+		labels = append(labels, labelPair(l.name, c.Config.Labels[l.label]))
+	}
+	for i := 0; i+1 < len(extra); i += 2 {
+		labels = append(labels, labelPair(extra[i], extra[i+1]))
+	}
+	return labels
+}
+
+func labelPair(name, value string) *dto.LabelPair {
+	return &dto.LabelPair{Name: proto.String(name), Value: proto.String(value)}
+}
+
+// addContainerStats adds the metrics of the container c from its stats s.
+func (f metricFamilies) addContainerStats(c *container.Container, s *types.StatsJSON) {
+	labels := containerLabelPairs(c)
+
+	cpu := s.CPUStats
+	f.counter("cpu_usage_seconds_total", "The total CPU time consumed by the container", float64(cpu.CPUUsage.TotalUsage)/1e9, labels)
+	f.counter("cpu_user_seconds_total", "The CPU time consumed by the container in user mode", float64(cpu.CPUUsage.UsageInUsermode)/1e9, labels)
+	f.counter("cpu_kernel_seconds_total", "The CPU time consumed by the container in kernel mode", float64(cpu.CPUUsage.UsageInKernelmode)/1e9, labels)
+	f.counter("cpu_throttled_periods_total", "The number of periods the container was throttled in", float64(cpu.ThrottlingData.ThrottledPeriods), labels)
+	f.counter("cpu_throttled_seconds_total", "The total time the container was throttled for", float64(cpu.ThrottlingData.ThrottledTime)/1e9, labels)
+
+	mem := s.MemoryStats
+	f.gauge("memory_usage_bytes", "The memory usage of the container", float64(mem.Usage), labels)
+	if mem.Limit != 0 {
+		f.gauge("memory_limit_bytes", "The memory limit of the container", float64(mem.Limit), labels)
+	}
+	if mem.MaxUsage != 0 {
+		f.gauge("memory_max_usage_bytes", "The maximum memory usage of the container", float64(mem.MaxUsage), labels)
+	}
+	// The cgroup v1 and v2 names of the page cache and anonymous memory.
+	for _, k := range []struct{ name, help, v1, v2 string }{
+		{"memory_cache_bytes", "The page cache memory of the container", "cache", "file"},
+		{"memory_rss_bytes", "The anonymous memory of the container", "rss", "anon"},
+	} {
+		if v, ok := mem.Stats[k.v1]; ok {
+			f.gauge(k.name, k.help, float64(v), labels)
+		} else if v, ok := mem.Stats[k.v2]; ok {
+			f.gauge(k.name, k.help, float64(v), labels)
+		}
+	}
+	if mem.Events != nil {
+		f.counter("memory_oom_kills_total", "The number of processes of the container killed by the OOM killer", float64(mem.Events.OomKill), labels)
+	}
+
+	// The totals of the devices are left out, as they are the sums of the
+	// other operations.
+	for _, e := range s.BlkioStats.IoServiceBytesRecursive {
+		if e.Op == "Total" {
+			continue
+		}
+		f.counter("blkio_bytes_total", "The number of bytes transferred by the container from and to the device", float64(e.Value), containerBlkioLabels(c, e))
+	}
+	for _, e := range s.BlkioStats.IoServicedRecursive {
+		if e.Op == "Total" {
+			continue
+		}
+		f.counter("blkio_operations_total", "The number of I/O operations of the container on the device", float64(e.Value), containerBlkioLabels(c, e))
+	}
+
+	for _, iface := range sortedNetworks(s.Networks) {
+		n := s.Networks[iface]
+		l := containerLabelPairs(c, "interface", iface)
+		f.counter("network_receive_bytes_total", "The number of bytes received by the container on the interface", float64(n.RxBytes), l)
+		f.counter("network_receive_packets_total", "The number of packets received by the container on the interface", float64(n.RxPackets), l)
+		f.counter("network_receive_errors_total", "The number of errors receiving on the interface of the container", float64(n.RxErrors), l)
+		f.counter("network_receive_dropped_total", "The number of received packets dropped on the interface of the container", float64(n.RxDropped), l)
+		f.counter("network_transmit_bytes_total", "The number of bytes sent by the container on the interface", float64(n.TxBytes), l)
+		f.counter("network_transmit_packets_total", "The number of packets sent by the container on the interface", float64(n.TxPackets), l)
+		f.counter("network_transmit_errors_total", "The number of errors sending on the interface of the container", float64(n.TxErrors), l)
+		f.counter("network_transmit_dropped_total", "The number of sent packets dropped on the interface of the container", float64(n.TxDropped), l)
+	}
+
+	f.gauge("pids_current", "The number of processes of the container", float64(s.PidsStats.Current), labels)
+	if s.PidsStats.Limit != 0 {
+		f.gauge("pids_limit", "The maximum number of processes of the container", float64(s.PidsStats.Limit), labels)
+	}
+
+	for _, p := range []struct {
+		resource string
+		psi      *types.PSIStats
+	}{
+		{"cpu", cpu.PSI},
+		{"memory", mem.PSI},
+		{"io", s.BlkioStats.PSI},
+	} {
+		if p.psi == nil {
+			continue
+		}
+		// Totals are in microseconds.
+		f.counter("pressure_stalled_seconds_total", "The total time some or all the tasks of the container were stalled on the resource", float64(p.psi.Some.Total)/1e6, containerLabelPairs(c, "resource", p.resource, "kind", "some"))
+		f.counter("pressure_stalled_seconds_total", "The total time some or all the tasks of the container were stalled on the resource", float64(p.psi.Full.Total)/1e6, containerLabelPairs(c, "resource", p.resource, "kind", "full"))
+	}
+}
+
+func containerBlkioLabels(c *container.Container, e types.BlkioStatEntry) []*dto.LabelPair {
+	return containerLabelPairs(c,
+		"device", strconv.FormatUint(e.Major, 10)+":"+strconv.FormatUint(e.Minor, 10),
+		"op", strings.ToLower(e.Op))
+}
+
+func sortedNetworks(networks map[string]types.NetworkStats) []string {
+	names := make([]string, 0, len(networks))
+	for name := range networks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
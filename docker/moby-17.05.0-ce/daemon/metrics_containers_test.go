@@ -0,0 +1,192 @@
+package daemon
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	containertypes "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/container"
+	"github.com/prometheus/common/expfmt"
+)
+
+func TestContainerMetrics(t *testing.T) {
+	c := &container.Container{
+		CommonContainer: container.CommonContainer{
+			ID:   "abc",
+			Name: "/web_1",
+			Config: &containertypes.Config{
+				Image: "nginx",
+				Labels: map[string]string{
+					"com.docker.compose.project": "shop",
+					"com.docker.compose.service": "web",
+				},
+			},
+		},
+	}
+	stats := &types.StatsJSON{}
+	stats.CPUStats.CPUUsage.TotalUsage = 1500000000
+	stats.MemoryStats.Usage = 4096
+	stats.MemoryStats.Stats = map[string]uint64{"anon": 1024}
+	stats.BlkioStats.IoServiceBytesRecursive = []types.BlkioStatEntry{
+		{Major: 8, Minor: 0, Op: "Read", Value: 512},
+		{Major: 8, Minor: 0, Op: "Total", Value: 512},
+	}
+	stats.Networks = map[string]types.NetworkStats{"eth0": {RxBytes: 100}}
+	stats.PidsStats.Current = 3
+
+	families := newMetricFamilies()
+	families.addContainerStats(c, stats)
+
+	var buf bytes.Buffer
+	enc := expfmt.NewEncoder(&buf, expfmt.FmtText)
+	for _, mf := range families.sorted() {
+		if err := enc.Encode(mf); err != nil {
+			t.Fatal(err)
+		}
+	}
+	out := buf.String()
+
+	labels := `id="abc",name="web_1",image="nginx",compose_project="shop",compose_service="web",service_name="",task_name=""`
+	for _, expected := range []string{
+		"# TYPE engine_container_cpu_usage_seconds_total counter\n",
+		"engine_container_cpu_usage_seconds_total{" + labels + "} 1.5\n",
+		"engine_container_memory_usage_bytes{" + labels + "} 4096\n",
+		"engine_container_memory_rss_bytes{" + labels + "} 1024\n",
+		"engine_container_blkio_bytes_total{" + labels + `,device="8:0",op="read"} 512` + "\n",
+		"engine_container_network_receive_bytes_total{" + labels + `,interface="eth0"} 100` + "\n",
+		"engine_container_pids_current{" + labels + "} 3\n",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("expected %q in:\n%s", expected, out)
+		}
+	}
+	for _, unexpected := range []string{
+		`op="total"`,
+		"engine_container_memory_limit_bytes",
+		"engine_container_memory_oom_kills_total",
+		"engine_container_pressure_stalled_seconds_total",
+	} {
+		if strings.Contains(out, unexpected) {
+			t.Errorf("unexpected %q in:\n%s", unexpected, out)
+		}
+	}
+}
+
+type fakeStatsSubscriber struct {
+	mu   sync.Mutex
+	subs map[*container.Container]chan interface{}
+}
+
+func (s *fakeStatsSubscriber) Collect(c *container.Container) chan interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ch := make(chan interface{})
+	s.subs[c] = ch
+	return ch
+}
+
+func (s *fakeStatsSubscriber) Unsubscribe(c *container.Container, ch chan interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.subs, c)
+	close(ch)
+}
+
+func (s *fakeStatsSubscriber) subscribed(c *container.Container) chan interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.subs[c]
+}
+
+// pidsCurrent returns the pids_current metric of the families, or -1 if
+// there is none.
+func pidsCurrent(families metricFamilies) float64 {
+	mf, ok := families[containerMetricsPrefix+"pids_current"]
+	if !ok || len(mf.Metric) == 0 {
+		return -1
+	}
+	return mf.Metric[0].GetGauge().GetValue()
+}
+
+func TestContainerMetricsStats(t *testing.T) {
+	collector := &fakeStatsSubscriber{subs: make(map[*container.Container]chan interface{})}
+	m := newContainerMetrics(collector)
+	c := &container.Container{
+		CommonContainer: container.CommonContainer{
+			ID:     "abc",
+			State:  container.NewState(),
+			Config: &containertypes.Config{},
+		},
+	}
+	containers := []*container.Container{c}
+
+	if pidsCurrent(m.collect(containers)) != -1 || collector.subscribed(c) != nil {
+		t.Fatal("expected no stats for a container which isn't running")
+	}
+
+	c.SetRunning(1, true)
+	if pidsCurrent(m.collect(containers)) != -1 {
+		t.Fatal("expected no stats before they are collected")
+	}
+	ch := collector.subscribed(c)
+	if ch == nil {
+		t.Fatal("expected the running container to be subscribed to")
+	}
+	stats := types.StatsJSON{}
+	stats.Read = time.Now()
+	stats.PidsStats.Current = 3
+	ch <- stats
+	// The stats are kept once the next ones are received.
+	ch <- stats
+	if v := pidsCurrent(m.collect(containers)); v != 3 {
+		t.Fatalf("expected the collected stats, got %v", v)
+	}
+
+	// The container is unsubscribed from once a scrape doesn't see it
+	// running.
+	c.SetStopped(&container.ExitStatus{})
+	if pidsCurrent(m.collect(containers)) != -1 {
+		t.Fatal("expected no stats for a stopped container")
+	}
+	if collector.subscribed(c) != nil {
+		t.Fatal("expected the stopped container to be unsubscribed from")
+	}
+}
+
+func TestContainerMetricsConcurrentScrapes(t *testing.T) {
+	collector := &fakeStatsSubscriber{subs: make(map[*container.Container]chan interface{})}
+	m := newContainerMetrics(collector)
+	c := &container.Container{
+		CommonContainer: container.CommonContainer{
+			ID:     "abc",
+			State:  container.NewState(),
+			Config: &containertypes.Config{},
+		},
+	}
+	c.SetRunning(1, true)
+	containers := []*container.Container{c}
+
+	m.collect(containers)
+	ch := collector.subscribed(c)
+
+	// A scrape doesn't unsubscribe from the running container because a
+	// concurrent one reset its bookkeeping.
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				m.collect(containers)
+			}
+		}()
+	}
+	wg.Wait()
+	if collector.subscribed(c) != ch {
+		t.Fatal("expected the running container to stay subscribed to")
+	}
+}
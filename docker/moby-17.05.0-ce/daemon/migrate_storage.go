@@ -0,0 +1,289 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/distribution"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/layer"
+	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/pkg/chrootarchive"
+	"github.com/docker/docker/pkg/ioutils"
+	"github.com/opencontainers/go-digest"
+)
+
+// splitGraphOptions splits the storage driver options between the ones of
+// the driver migrated from and the ones of the driver in use, by their
+// prefix.
+func splitGraphOptions(from string, options []string) (fromOptions, toOptions []string) {
+	prefix := from + "."
+	if from == "devicemapper" {
+		prefix = "dm."
+	}
+	for _, option := range options {
+		if strings.HasPrefix(strings.ToLower(option), prefix) {
+			fromOptions = append(fromOptions, option)
+		} else {
+			toOptions = append(toOptions, option)
+		}
+	}
+	return fromOptions, toOptions
+}
+
+// migrateLayers registers the image layers of the store from in the store
+// to, parents first. The layers which are already in to are kept. The
+// returned layers hold a reference to each layer of from in to, which must be
+// released once the image store holds its own references, so that they are
+// not deleted in between.
+func migrateLayers(from, to layer.Store) ([]layer.Layer, error) {
+	var layers []layer.Layer
+	for _, l := range sortLayersByDepth(from.Map()) {
+		if nl, err := to.Get(l.ChainID()); err == nil {
+			layers = append(layers, nl)
+			continue
+		}
+
+		nl, err := migrateLayer(l, to)
+		if err != nil {
+			return layers, fmt.Errorf("error while migrating layer %s: %v", l.ChainID(), err)
+		}
+		layers = append(layers, nl)
+		logrus.Debugf("Migrated layer %s", l.ChainID())
+	}
+	return layers, nil
+}
+
+// sortLayersByDepth returns the layers of m with the parents before their
+// children.
+func sortLayersByDepth(m map[layer.ChainID]layer.Layer) []layer.Layer {
+	var byDepth [][]layer.Layer
+	for _, l := range m {
+		depth := 0
+		for p := l.Parent(); p != nil; p = p.Parent() {
+			depth++
+		}
+		for len(byDepth) <= depth {
+			byDepth = append(byDepth, nil)
+		}
+		byDepth[depth] = append(byDepth[depth], l)
+	}
+
+	layers := make([]layer.Layer, 0, len(m))
+	for _, ls := range byDepth {
+		layers = append(layers, ls...)
+	}
+	return layers
+}
+
+func migrateLayer(l layer.Layer, to layer.Store) (layer.Layer, error) {
+	var parent layer.ChainID
+	if p := l.Parent(); p != nil {
+		parent = p.ChainID()
+	}
+
+	ts, err := l.TarStream()
+	if err != nil {
+		return nil, err
+	}
+	defer ts.Close()
+
+	var nl layer.Layer
+	if d, ok := l.(distribution.Describable); ok && d.Descriptor().Digest != "" {
+		ds, ok := to.(layer.DescribableStore)
+		if !ok {
+			return nil, fmt.Errorf("storage driver %s does not support foreign layers", to.DriverName())
+		}
+		nl, err = ds.RegisterWithDescriptor(ts, parent, d.Descriptor())
+	} else {
+		nl, err = to.Register(ts, parent)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if nl.ChainID() != l.ChainID() {
+		layer.ReleaseAndLog(to, nl)
+		return nil, fmt.Errorf("layer was registered as %s", nl.ChainID())
+	}
+	return nl, nil
+}
+
+// migrateImageMetadata copies the image, distribution and reference metadata
+// of the storage driver from to the storage driver to, under imageRoot. The
+// metadata already present for the driver to is kept.
+func migrateImageMetadata(imageRoot, from, to string) error {
+	for _, dir := range []string{"imagedb", "distribution"} {
+		if err := copyMissingFiles(filepath.Join(imageRoot, from, dir), filepath.Join(imageRoot, to, dir)); err != nil {
+			return err
+		}
+	}
+	return mergeReferences(filepath.Join(imageRoot, from, "repositories.json"), filepath.Join(imageRoot, to, "repositories.json"))
+}
+
+// copyMissingFiles copies the files of the directory src which don't exist
+// in the directory dst.
+func copyMissingFiles(src, dst string) error {
+	return filepath.Walk(src, func(path string, f os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == src {
+				return nil
+			}
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		if f.IsDir() {
+			if err := os.MkdirAll(target, f.Mode().Perm()); err != nil {
+				return err
+			}
+			return nil
+		}
+		if _, err := os.Lstat(target); err == nil {
+			return nil
+		}
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return ioutils.AtomicWriteFile(target, b, f.Mode().Perm())
+	})
+}
+
+// mergeReferences adds the image references of the reference store file src
+// to the reference store file dst. The references already in dst are kept.
+func mergeReferences(src, dst string) error {
+	type references struct {
+		Repositories map[string]map[string]digest.Digest
+	}
+	load := func(path string) (*references, error) {
+		refs := &references{Repositories: make(map[string]map[string]digest.Digest)}
+		f, err := os.Open(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return refs, nil
+			}
+			return nil, err
+		}
+		defer f.Close()
+		if err := json.NewDecoder(f).Decode(refs); err != nil {
+			return nil, err
+		}
+		if refs.Repositories == nil {
+			refs.Repositories = make(map[string]map[string]digest.Digest)
+		}
+		return refs, nil
+	}
+
+	srcRefs, err := load(src)
+	if err != nil {
+		return err
+	}
+	if len(srcRefs.Repositories) == 0 {
+		return nil
+	}
+	dstRefs, err := load(dst)
+	if err != nil {
+		return err
+	}
+
+	for name, repo := range srcRefs.Repositories {
+		if dstRefs.Repositories[name] == nil {
+			dstRefs.Repositories[name] = make(map[string]digest.Digest)
+		}
+		for ref, id := range repo {
+			if _, ok := dstRefs.Repositories[name][ref]; !ok {
+				dstRefs.Repositories[name][ref] = id
+			}
+		}
+	}
+
+	b, err := json.Marshal(dstRefs)
+	if err != nil {
+		return err
+	}
+	return ioutils.AtomicWriteFile(dst, b, 0600)
+}
+
+// isMigratedContainer returns whether the container c was created with the
+// storage driver the daemon migrates from.
+func (daemon *Daemon) isMigratedContainer(c *container.Container) bool {
+	if daemon.migrateLayerStore == nil {
+		return false
+	}
+	from := daemon.migrateLayerStore.DriverName()
+	return c.Driver == from || (c.Driver == "" && from == "aufs")
+}
+
+// migrateContainerLayer creates the read-write layer of the container c in
+// the layer store of the daemon, with the changes of its read-write layer in
+// the store migrated from, and records the new storage driver of c.
+func (daemon *Daemon) migrateContainerLayer(c *container.Container) (layer.RWLayer, error) {
+	old, err := daemon.migrateLayerStore.GetRWLayer(c.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The layer left by an interrupted migration is created again.
+	if rwLayer, err := daemon.layerStore.GetRWLayer(c.ID); err == nil {
+		if _, err := daemon.layerStore.ReleaseRWLayer(rwLayer); err != nil {
+			return nil, err
+		}
+	}
+
+	var parent layer.ChainID
+	if p := old.Parent(); p != nil {
+		parent = p.ChainID()
+	}
+	rwLayer, err := daemon.layerStore.CreateRWLayer(c.ID, parent, &layer.CreateRWLayerOpts{
+		MountLabel: c.MountLabel,
+		InitFunc:   daemon.getLayerInit(),
+		StorageOpt: c.HostConfig.StorageOpt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := daemon.copyRWLayer(old, rwLayer, c.MountLabel); err != nil {
+		if _, rmErr := daemon.layerStore.ReleaseRWLayer(rwLayer); rmErr != nil {
+			logrus.Errorf("Failed to remove the migrated layer of container %s: %v", c.ID, rmErr)
+		}
+		return nil, err
+	}
+
+	c.Driver = daemon.GraphDriverName()
+	if err := c.ToDisk(); err != nil {
+		return nil, err
+	}
+	return rwLayer, nil
+}
+
+// copyRWLayer applies the changes of the read-write layer src to dst.
+func (daemon *Daemon) copyRWLayer(src, dst layer.RWLayer, mountLabel string) error {
+	changes, err := src.TarStream()
+	if err != nil {
+		return err
+	}
+	defer changes.Close()
+
+	dir, err := dst.Mount(mountLabel)
+	if err != nil {
+		return err
+	}
+	defer dst.Unmount()
+
+	_, err = chrootarchive.ApplyUncompressedLayer(dir, changes, &archive.TarOptions{
+		UIDMaps: daemon.uidMaps,
+		GIDMaps: daemon.gidMaps,
+	})
+	return err
+}
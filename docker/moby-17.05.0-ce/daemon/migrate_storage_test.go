@@ -0,0 +1,124 @@
+package daemon
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/layer"
+)
+
+type fakeLayer struct {
+	layer.Layer
+	id     layer.ChainID
+	parent *fakeLayer
+}
+
+func (l *fakeLayer) ChainID() layer.ChainID {
+	return l.id
+}
+
+func (l *fakeLayer) Parent() layer.Layer {
+	if l.parent == nil {
+		return nil
+	}
+	return l.parent
+}
+
+func TestSortLayersByDepth(t *testing.T) {
+	base := &fakeLayer{id: "base"}
+	middle := &fakeLayer{id: "middle", parent: base}
+	top := &fakeLayer{id: "top", parent: middle}
+	other := &fakeLayer{id: "other", parent: base}
+
+	layers := sortLayersByDepth(map[layer.ChainID]layer.Layer{
+		"top": top, "other": other, "middle": middle, "base": base,
+	})
+	depths := map[layer.ChainID]int{"base": 0, "middle": 1, "other": 1, "top": 2}
+	if len(layers) != 4 {
+		t.Fatalf("expected 4 layers, got %d", len(layers))
+	}
+	for i := 1; i < len(layers); i++ {
+		if depths[layers[i-1].ChainID()] > depths[layers[i].ChainID()] {
+			t.Fatalf("expected the parents before their children, got %s before %s", layers[i-1].ChainID(), layers[i].ChainID())
+		}
+	}
+}
+
+func TestCopyMissingFiles(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "migrate-files")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	src := filepath.Join(tmp, "src")
+	dst := filepath.Join(tmp, "dst")
+	for path, content := range map[string]string{
+		filepath.Join(src, "content", "a"): "a",
+		filepath.Join(src, "content", "b"): "b",
+		filepath.Join(dst, "content", "b"): "new b",
+	} {
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := copyMissingFiles(src, dst); err != nil {
+		t.Fatal(err)
+	}
+	for name, expected := range map[string]string{"a": "a", "b": "new b"} {
+		b, err := ioutil.ReadFile(filepath.Join(dst, "content", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != expected {
+			t.Fatalf("expected %s to contain %q, got %q", name, expected, b)
+		}
+	}
+
+	if err := copyMissingFiles(filepath.Join(tmp, "missing"), dst); err != nil {
+		t.Fatalf("expected a missing source to be ignored, got %v", err)
+	}
+}
+
+func TestMergeReferences(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "migrate-references")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	src := filepath.Join(tmp, "src.json")
+	dst := filepath.Join(tmp, "dst.json")
+	if err := ioutil.WriteFile(src, []byte(`{"Repositories":{"busybox":{"busybox:latest":"sha256:aaa","busybox:1":"sha256:bbb"}}}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(dst, []byte(`{"Repositories":{"busybox":{"busybox:latest":"sha256:ccc"}}}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := mergeReferences(src, dst); err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadFile(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"Repositories":{"busybox":{"busybox:1":"sha256:bbb","busybox:latest":"sha256:ccc"}}}`
+	if string(b) != expected {
+		t.Fatalf("expected %s, got %s", expected, b)
+	}
+}
+
+func TestSplitGraphOptions(t *testing.T) {
+	from, to := splitGraphOptions("devicemapper", []string{"dm.thinpooldev=pool", "overlay2.size=1G"})
+	if !reflect.DeepEqual(from, []string{"dm.thinpooldev=pool"}) || !reflect.DeepEqual(to, []string{"overlay2.size=1G"}) {
+		t.Fatalf("unexpected split of the storage options: %v, %v", from, to)
+	}
+}
@@ -0,0 +1,76 @@
+package daemon
+
+import (
+	"os"
+	"path"
+	"sort"
+)
+
+// ContainerMountStubs records which of the paths, and of their parent
+// directories, don't exist in the container yet. The mounts of the container
+// create them when it starts. The returned function removes the ones which
+// are still empty once the container has run, so that the builder doesn't
+// commit the mountpoints of the mounts of a RUN instruction.
+func (daemon *Daemon) ContainerMountStubs(cID string, paths []string) (func() error, error) {
+	container, err := daemon.GetContainer(cID)
+	if err != nil {
+		return nil, err
+	}
+	if err := daemon.Mount(container); err != nil {
+		return nil, err
+	}
+	defer daemon.Unmount(container)
+
+	var stubs []string
+	seen := make(map[string]bool)
+	for _, p := range paths {
+		for p = path.Clean("/" + p); p != "/" && !seen[p]; p = path.Dir(p) {
+			seen[p] = true
+			resolved, err := container.GetResourcePath(p)
+			if err != nil {
+				return nil, err
+			}
+			if _, err := os.Lstat(resolved); err == nil {
+				break
+			} else if !os.IsNotExist(err) {
+				return nil, err
+			}
+			stubs = append(stubs, p)
+		}
+	}
+
+	return func() error {
+		if len(stubs) == 0 {
+			return nil
+		}
+		if err := daemon.Mount(container); err != nil {
+			return err
+		}
+		defer daemon.Unmount(container)
+
+		// The paths are removed before their parent directories.
+		sort.Sort(sort.Reverse(sort.StringSlice(stubs)))
+		for _, p := range stubs {
+			resolved, err := container.GetResourcePath(p)
+			if err != nil {
+				return err
+			}
+			fi, err := os.Lstat(resolved)
+			if err != nil {
+				if os.IsNotExist(err) {
+					continue
+				}
+				return err
+			}
+			if fi.Mode().IsRegular() && fi.Size() > 0 {
+				continue
+			}
+			// The directories which are not empty are kept, os.IsExist
+			// is true for ENOTEMPTY.
+			if err := os.Remove(resolved); err != nil && !os.IsExist(err) {
+				return err
+			}
+		}
+		return nil
+	}, nil
+}
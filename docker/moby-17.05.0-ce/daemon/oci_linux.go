@@ -664,6 +664,10 @@ func (daemon *Daemon) createSpec(c *container.Container) (*specs.Spec, error) {
 	if useSystemd {
 		parent = "system.slice"
 	}
+	cgroupsDelegated := true
+	if daemon.configStore.Rootless {
+		parent, cgroupsDelegated = rootlessCgroupParent()
+	}
 
 	if c.HostConfig.CgroupParent != "" {
 		parent = c.HostConfig.CgroupParent
@@ -751,6 +755,12 @@ func (daemon *Daemon) createSpec(c *container.Container) (*specs.Spec, error) {
 		ms = append(ms, *m)
 	}
 
+	lxcfsMounts, err := daemon.lxcfsMounts(c, ms)
+	if err != nil {
+		return nil, err
+	}
+	ms = append(ms, lxcfsMounts...)
+
 	sort.Sort(mounts(ms))
 	if err := setMounts(daemon, &s, c, ms); err != nil {
 		return nil, fmt.Errorf("linux mounts: %v", err)
@@ -772,7 +782,7 @@ func (daemon *Daemon) createSpec(c *container.Container) (*specs.Spec, error) {
 		}
 	}
 
-	if apparmor.IsEnabled() {
+	if apparmor.IsEnabled() && !daemon.configStore.Rootless {
 		var appArmorProfile string
 		if c.AppArmorProfile != "" {
 			appArmorProfile = c.AppArmorProfile
@@ -800,6 +810,13 @@ func (daemon *Daemon) createSpec(c *container.Container) (*specs.Spec, error) {
 	s.Process.NoNewPrivileges = c.NoNewPrivileges
 	s.Linux.MountLabel = c.MountLabel
 
+	if !cgroupsDelegated {
+		// The runtime can't create the cgroup of the container, nor
+		// apply its resource limits.
+		s.Linux.CgroupsPath = nil
+		s.Linux.Resources = nil
+	}
+
 	return (*specs.Spec)(&s), nil
 }
 
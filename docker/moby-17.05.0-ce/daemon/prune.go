@@ -10,11 +10,12 @@ import (
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/filters"
 	timetypes "github.com/docker/docker/api/types/time"
+	"github.com/docker/docker/builder"
 	"github.com/docker/docker/image"
 	"github.com/docker/docker/layer"
-	"github.com/docker/docker/pkg/directory"
 	"github.com/docker/docker/runconfig"
 	"github.com/docker/docker/volume"
+	units "github.com/docker/go-units"
 	"github.com/docker/libnetwork"
 	digest "github.com/opencontainers/go-digest"
 )
@@ -58,6 +59,15 @@ func (daemon *Daemon) ContainersPrune(pruneFilters filters.Args) (*types.Contain
 func (daemon *Daemon) VolumesPrune(pruneFilters filters.Args) (*types.VolumesPruneReport, error) {
 	rep := &types.VolumesPruneReport{}
 
+	until, err := getUntilFromPruneFilters(pruneFilters)
+	if err != nil {
+		return nil, err
+	}
+	minSize, err := getSizeFromPruneFilters(pruneFilters)
+	if err != nil {
+		return nil, err
+	}
+
 	pruneVols := func(v volume.Volume) error {
 		name := v.Name()
 		refs := daemon.volumes.Refs(v)
@@ -65,13 +75,30 @@ func (daemon *Daemon) VolumesPrune(pruneFilters filters.Args) (*types.VolumesPru
 		if len(refs) == 0 {
 			detailedVolume, ok := v.(volume.DetailedVolume)
 			if ok {
+				// The volumes of the cache mounts of RUN instructions are
+				// only removed by CacheMountsPrune.
+				if _, ok := detailedVolume.Labels()[builder.CacheMountLabel]; ok {
+					return nil
+				}
 				if !matchLabels(pruneFilters, detailedVolume.Labels()) {
 					return nil
 				}
 			}
-			vSize, err := directory.Size(v.Path())
+			if !until.IsZero() {
+				lastUsed, err := daemon.volumes.LastUsed(v)
+				if err != nil || lastUsed.IsZero() || lastUsed.After(until) {
+					return nil
+				}
+			}
+			vSize, err := volumeSize(v)
 			if err != nil {
 				logrus.Warnf("could not determine size of volume %s: %v", name, err)
+				if minSize >= 0 {
+					return nil
+				}
+			}
+			if minSize >= 0 && vSize < minSize {
+				return nil
 			}
 			err = daemon.volumes.Remove(v)
 			if err != nil {
@@ -85,7 +112,84 @@ func (daemon *Daemon) VolumesPrune(pruneFilters filters.Args) (*types.VolumesPru
 		return nil
 	}
 
-	err := daemon.traverseLocalVolumes(pruneVols)
+	err = daemon.traverseLocalVolumes(pruneVols)
+
+	return rep, err
+}
+
+// anonymousVolumesGC removes the anonymous local volumes which have been
+// unused for longer than configured, once at startup and then every hour,
+// until the daemon is shut down.
+func (daemon *Daemon) anonymousVolumesGC() {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for {
+		daemon.pruneAnonymousVolumes()
+		select {
+		case <-ticker.C:
+		case <-daemon.stopVolumesGC:
+			return
+		}
+	}
+}
+
+// pruneAnonymousVolumes removes the anonymous local volumes which have been
+// unused for longer than configured, if configured.
+func (daemon *Daemon) pruneAnonymousVolumes() {
+	daemon.configStore.Lock()
+	days := daemon.configStore.AnonymousVolumeGCDays
+	daemon.configStore.Unlock()
+	if days <= 0 {
+		return
+	}
+
+	pruneFilters := filters.NewArgs()
+	pruneFilters.Add("label", volume.AnonymousLabel)
+	pruneFilters.Add("until", fmt.Sprintf("%dh", days*24))
+	rep, err := daemon.VolumesPrune(pruneFilters)
+	if err != nil {
+		logrus.Errorf("failed to remove unused anonymous volumes: %v", err)
+		return
+	}
+	if len(rep.VolumesDeleted) > 0 {
+		logrus.Infof("removed %d anonymous volumes unused for %d days, reclaiming %d bytes", len(rep.VolumesDeleted), days, rep.SpaceReclaimed)
+	}
+}
+
+// CacheMountsPrune removes the unused volumes that back the cache mounts of
+// RUN instructions.
+func (daemon *Daemon) CacheMountsPrune(pruneFilters filters.Args) (*types.BuildCachePruneReport, error) {
+	rep := &types.BuildCachePruneReport{}
+
+	pruneCaches := func(v volume.Volume) error {
+		// Volumes without labels are never cache mounts.
+		detailedVolume, ok := v.(volume.DetailedVolume)
+		if !ok {
+			return nil
+		}
+		labels := detailedVolume.Labels()
+		if _, ok := labels[builder.CacheMountLabel]; !ok || !matchLabels(pruneFilters, labels) {
+			return nil
+		}
+		name := v.Name()
+		if len(daemon.volumes.Refs(v)) != 0 {
+			return nil
+		}
+
+		vSize, err := volumeSize(v)
+		if err != nil {
+			logrus.Warnf("could not determine size of build cache volume %s: %v", name, err)
+		}
+		if err := daemon.volumes.Remove(v); err != nil {
+			logrus.Warnf("could not remove build cache volume %s: %v", name, err)
+			return nil
+		}
+		rep.SpaceReclaimed += uint64(vSize)
+		rep.CachesDeleted = append(rep.CachesDeleted, labels[builder.CacheMountLabel])
+		return nil
+	}
+
+	err := daemon.traverseLocalVolumes(pruneCaches)
 
 	return rep, err
 }
@@ -315,6 +419,23 @@ func getUntilFromPruneFilters(pruneFilters filters.Args) (time.Time, error) {
 	return until, nil
 }
 
+// getSizeFromPruneFilters returns the size given by the size> filter, or -1
+// if there is none.
+func getSizeFromPruneFilters(pruneFilters filters.Args) (int64, error) {
+	if !pruneFilters.Include("size>") {
+		return -1, nil
+	}
+	sizeFilters := pruneFilters.Get("size>")
+	if len(sizeFilters) > 1 {
+		return -1, fmt.Errorf("more than one size> filter specified")
+	}
+	size, err := units.RAMInBytes(sizeFilters[0])
+	if err != nil {
+		return -1, fmt.Errorf("invalid size> filter %q: %v", sizeFilters[0], err)
+	}
+	return size, nil
+}
+
 func matchLabels(pruneFilters filters.Args, labels map[string]string) bool {
 	if !pruneFilters.MatchKVList("label", labels) {
 		return false
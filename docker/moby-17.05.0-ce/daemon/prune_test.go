@@ -0,0 +1,118 @@
+package daemon
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/builder"
+	"github.com/docker/docker/daemon/config"
+	"github.com/docker/docker/volume"
+	volumedrivers "github.com/docker/docker/volume/drivers"
+	"github.com/docker/docker/volume/local"
+	"github.com/docker/docker/volume/store"
+)
+
+func TestVolumesPruneFilters(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "docker-daemon-volumes-prune-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	drv, err := local.New(tmp, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Replace the local driver other tests may have registered.
+	volumedrivers.Unregister(volume.DefaultDriverName)
+	volumedrivers.Register(drv, volume.DefaultDriverName)
+	defer volumedrivers.Unregister(volume.DefaultDriverName)
+
+	volStore, err := store.New(tmp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer volStore.Shutdown()
+	daemon := &Daemon{volumes: volStore}
+
+	big, err := volStore.Create("big", volume.DefaultDriverName, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(big.Path(), "file"), make([]byte, 4096), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := volStore.Create("small", volume.DefaultDriverName, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := volStore.Create("anonymous", volume.DefaultDriverName, nil, map[string]string{volume.AnonymousLabel: ""}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := volStore.Create("cache", volume.DefaultDriverName, nil, map[string]string{builder.CacheMountLabel: "/root/.cache"}); err != nil {
+		t.Fatal(err)
+	}
+
+	prune := func(args ...string) []string {
+		pruneFilters := filters.NewArgs()
+		for i := 0; i+1 < len(args); i += 2 {
+			pruneFilters.Add(args[i], args[i+1])
+		}
+		rep, err := daemon.VolumesPrune(pruneFilters)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rep.VolumesDeleted
+	}
+
+	// The volumes were all used after the until filter.
+	if deleted := prune("until", "1h"); len(deleted) != 0 {
+		t.Fatalf("expected no volume to be pruned, got %v", deleted)
+	}
+	// The size filter includes the volumes of exactly the given size.
+	if deleted := prune("size>", "4KB"); !reflect.DeepEqual(deleted, []string{"big"}) {
+		t.Fatalf("expected the big volume to be pruned, got %v", deleted)
+	}
+	if deleted := prune("label!", volume.AnonymousLabel); !reflect.DeepEqual(deleted, []string{"small"}) {
+		t.Fatalf("expected the small volume to be pruned, got %v", deleted)
+	}
+	if deleted := prune("label", volume.AnonymousLabel); !reflect.DeepEqual(deleted, []string{"anonymous"}) {
+		t.Fatalf("expected the anonymous volume to be pruned, got %v", deleted)
+	}
+	// The volumes of cache mounts are left to the build cache prune.
+	if deleted := prune(); len(deleted) != 0 {
+		t.Fatalf("expected the cache mount volume to be kept, got %v", deleted)
+	}
+	rep, err := daemon.CacheMountsPrune(filters.NewArgs())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(rep.CachesDeleted, []string{"/root/.cache"}) {
+		t.Fatalf("expected the cache mount volume to be pruned, got %v", rep.CachesDeleted)
+	}
+
+	invalid := filters.NewArgs()
+	invalid.Add("size>", "lots")
+	if _, err := daemon.VolumesPrune(invalid); err == nil {
+		t.Fatal("expected an invalid size> filter to be rejected")
+	}
+}
+
+func TestAnonymousVolumesGCStops(t *testing.T) {
+	daemon := &Daemon{configStore: &config.Config{}, stopVolumesGC: make(chan struct{})}
+	done := make(chan struct{})
+	go func() {
+		daemon.anonymousVolumesGC()
+		close(done)
+	}()
+	close(daemon.stopVolumesGC)
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("expected the garbage collection to stop on shutdown")
+	}
+}
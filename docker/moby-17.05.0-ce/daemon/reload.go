@@ -18,6 +18,7 @@ import (
 // - Daemon max concurrent downloads
 // - Daemon max concurrent uploads
 // - Daemon shutdown timeout (in seconds)
+// - Anonymous volume garbage collection (in days)
 // - Cluster discovery (reconfigure and restart)
 // - Daemon labels
 // - Insecure registries
@@ -44,6 +45,7 @@ func (daemon *Daemon) Reload(conf *config.Config) (err error) {
 	daemon.reloadDebug(conf, attributes)
 	daemon.reloadMaxConcurrentDowloadsAndUploads(conf, attributes)
 	daemon.reloadShutdownTimeout(conf, attributes)
+	daemon.reloadAnonymousVolumeGC(conf, attributes)
 
 	if err := daemon.reloadClusterDiscovery(conf, attributes); err != nil {
 		return err
@@ -123,6 +125,19 @@ func (daemon *Daemon) reloadShutdownTimeout(conf *config.Config, attributes map[
 	attributes["shutdown-timeout"] = fmt.Sprintf("%d", daemon.configStore.ShutdownTimeout)
 }
 
+// reloadAnonymousVolumeGC updates configuration with the anonymous volume
+// garbage collection option and updates the passed attributes
+func (daemon *Daemon) reloadAnonymousVolumeGC(conf *config.Config, attributes map[string]string) {
+	// update corresponding configuration
+	if conf.IsValueSet("anonymous-volume-gc-days") {
+		daemon.configStore.AnonymousVolumeGCDays = conf.AnonymousVolumeGCDays
+		logrus.Debugf("Reset Anonymous Volume GC Days: %d", daemon.configStore.AnonymousVolumeGCDays)
+	}
+
+	// prepare reload event attributes with updatable configurations
+	attributes["anonymous-volume-gc-days"] = fmt.Sprintf("%d", daemon.configStore.AnonymousVolumeGCDays)
+}
+
 // reloadClusterDiscovery updates configuration with cluster discovery options
 // and updates the passed attributes
 func (daemon *Daemon) reloadClusterDiscovery(conf *config.Config, attributes map[string]string) (err error) {
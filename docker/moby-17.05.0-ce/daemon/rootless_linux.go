@@ -0,0 +1,101 @@
+package daemon
+
+import (
+	"path/filepath"
+	"strconv"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/daemon/graphdriver/overlayutils"
+	"github.com/docker/docker/pkg/rootless"
+	"github.com/docker/docker/pkg/sysinfo"
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+)
+
+// rootlessGraphDriver returns the storage driver used in rootless mode when
+// none is configured: overlay2 if the kernel allows overlay mounts in the
+// user namespace, vfs otherwise.
+func rootlessGraphDriver(root string) string {
+	if err := overlayutils.SupportsMount(root); err != nil {
+		logrus.Infof("Using the vfs storage driver, as overlay mounts are not supported in the user namespace: %v", err)
+		return "vfs"
+	}
+	return "overlay2"
+}
+
+// rootlessCgroupParent returns the cgroup below which the cgroups of the
+// containers are created in rootless mode. Only the cgroup of the daemon can
+// have been delegated to the user, so it is below it. It returns false if no
+// cgroup controller was delegated, the runtime can't create cgroups then.
+func rootlessCgroupParent() (string, bool) {
+	info := sysinfo.New(true)
+	if !info.MemoryLimit && !info.CPUShares && !info.PidsLimit {
+		return "", false
+	}
+	controller := "cpu"
+	if info.CgroupUnified {
+		controller = ""
+	}
+	own, err := cgroups.GetThisCgroupDir(controller)
+	if err != nil {
+		logrus.Warnf("Failed to find the cgroup of the daemon: %v", err)
+		return "", false
+	}
+	return filepath.Join(own, "docker"), true
+}
+
+// forwardRootlessPorts publishes the ports of the container on the host in
+// rootless mode. Otherwise they are only published in the network namespace
+// of the daemon.
+func (daemon *Daemon) forwardRootlessPorts(c *container.Container) error {
+	if !daemon.configStore.Rootless {
+		return nil
+	}
+	daemon.releaseRootlessPorts(c)
+
+	var ids []int
+	pf := rootless.NewPortForwarder(daemon.configStore.GetExecRoot())
+	for port, bindings := range c.NetworkSettings.Ports {
+		for _, b := range bindings {
+			hostPort, err := strconv.Atoi(b.HostPort)
+			if err != nil {
+				continue
+			}
+			id, err := pf.Add(port.Proto(), b.HostIP, hostPort)
+			if err != nil {
+				for _, id := range ids {
+					pf.Remove(id)
+				}
+				return err
+			}
+			ids = append(ids, id)
+		}
+	}
+
+	daemon.rootlessPortsMu.Lock()
+	if daemon.rootlessPorts == nil {
+		daemon.rootlessPorts = make(map[string][]int)
+	}
+	daemon.rootlessPorts[c.ID] = ids
+	daemon.rootlessPortsMu.Unlock()
+	return nil
+}
+
+// releaseRootlessPorts removes the forwardings of the ports of the container
+// in rootless mode.
+func (daemon *Daemon) releaseRootlessPorts(c *container.Container) {
+	if !daemon.configStore.Rootless {
+		return
+	}
+	daemon.rootlessPortsMu.Lock()
+	ids := daemon.rootlessPorts[c.ID]
+	delete(daemon.rootlessPorts, c.ID)
+	daemon.rootlessPortsMu.Unlock()
+
+	pf := rootless.NewPortForwarder(daemon.configStore.GetExecRoot())
+	for _, id := range ids {
+		if err := pf.Remove(id); err != nil {
+			logrus.Warnf("Failed to unpublish a port of container %s: %v", c.ID, err)
+		}
+	}
+}
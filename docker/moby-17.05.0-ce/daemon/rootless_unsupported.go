@@ -0,0 +1,18 @@
+// +build !linux
+
+package daemon
+
+import "github.com/docker/docker/container"
+
+// rootlessGraphDriver returns the storage driver used in rootless mode, which
+// isn't supported on this platform.
+func rootlessGraphDriver(root string) string {
+	return ""
+}
+
+func (daemon *Daemon) forwardRootlessPorts(c *container.Container) error {
+	return nil
+}
+
+func (daemon *Daemon) releaseRootlessPorts(c *container.Container) {
+}
@@ -3,7 +3,12 @@ package daemon
 import (
 	"github.com/Sirupsen/logrus"
 	swarmtypes "github.com/docker/docker/api/types/swarm"
+	"github.com/docker/docker/container"
+	"github.com/docker/docker/daemon/cluster/convert"
+	"github.com/docker/docker/pkg/secretprovider"
 	"github.com/docker/swarmkit/agent/exec"
+	swarmapi "github.com/docker/swarmkit/api"
+	"github.com/pkg/errors"
 )
 
 // SetContainerSecretStore sets the secret store backend for the container
@@ -34,3 +39,36 @@ func (daemon *Daemon) SetContainerSecretReferences(name string, refs []*swarmtyp
 
 	return nil
 }
+
+// secretData returns the value of a secret for the container. Secrets that
+// were created with a driver are fetched from the secret provider plugin.
+func (daemon *Daemon) secretData(c *container.Container, secret *swarmapi.Secret) ([]byte, error) {
+	driver, err := convert.SecretDriverFromGRPC(secret)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error decoding driver of secret %s", secret.Spec.Annotations.Name)
+	}
+	if driver == nil {
+		return secret.Spec.Data, nil
+	}
+
+	labels := make(map[string]string)
+	for k, v := range secret.Spec.Annotations.Labels {
+		if k != convert.SecretDriverLabel {
+			labels[k] = v
+		}
+	}
+
+	provider := secretprovider.NewPlugin(driver.Name, daemon.PluginStore)
+	value, err := provider.GetSecret(&secretprovider.Request{
+		SecretName:   secret.Spec.Annotations.Name,
+		SecretID:     secret.ID,
+		SecretLabels: labels,
+		ServiceName:  c.Config.Labels["com.docker.swarm.service.name"],
+		ServiceID:    c.Config.Labels["com.docker.swarm.service.id"],
+		TaskID:       c.Config.Labels["com.docker.swarm.task.id"],
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "error getting secret %s from driver %s", secret.Spec.Annotations.Name, driver.Name)
+	}
+	return value, nil
+}
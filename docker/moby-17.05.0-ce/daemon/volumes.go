@@ -185,16 +185,25 @@ func (daemon *Daemon) registerMountPoints(container *container.Container, hostCo
 		}
 
 		if mp.Type == mounttypes.TypeVolume {
-			var v volume.Volume
+			var (
+				v          volume.Volume
+				driverOpts map[string]string
+				labels     map[string]string
+			)
 			if cfg.VolumeOptions != nil {
-				var driverOpts map[string]string
 				if cfg.VolumeOptions.DriverConfig != nil {
 					driverOpts = cfg.VolumeOptions.DriverConfig.Options
 				}
-				v, err = daemon.volumes.CreateWithRef(mp.Name, mp.Driver, container.ID, driverOpts, cfg.VolumeOptions.Labels)
-			} else {
-				v, err = daemon.volumes.CreateWithRef(mp.Name, mp.Driver, container.ID, nil, nil)
+				labels = cfg.VolumeOptions.Labels
 			}
+			if cfg.Source == "" {
+				anonymousLabels := map[string]string{volume.AnonymousLabel: ""}
+				for k, v := range labels {
+					anonymousLabels[k] = v
+				}
+				labels = anonymousLabels
+			}
+			v, err = daemon.volumes.CreateWithRef(mp.Name, mp.Driver, container.ID, driverOpts, labels)
 			if err != nil {
 				return err
 			}
@@ -0,0 +1,138 @@
+package daemon
+
+import (
+	"io"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/pkg/stringid"
+	"github.com/docker/docker/volume"
+	volumestore "github.com/docker/docker/volume/store"
+)
+
+// snapshotSourceLabel is the label set on the snapshots of volumes to the name
+// of the volume they were taken from.
+const snapshotSourceLabel = "com.docker.volume.snapshot.source"
+
+// referenceVolume gets the volume name with a new reference, so that it
+// isn't removed while its data is used. The returned function releases the
+// reference.
+func (daemon *Daemon) referenceVolume(name string) (volume.Volume, func(), error) {
+	v, err := daemon.volumes.Get(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	ref := stringid.GenerateNonCryptoID()
+	v, err = daemon.volumes.GetWithRef(name, v.DriverName(), ref)
+	if err != nil {
+		return nil, nil, err
+	}
+	return v, func() { daemon.volumes.Dereference(v, ref) }, nil
+}
+
+// VolumeClone creates the volume name with a copy of the data of the volume
+// src, on the same driver.
+func (daemon *Daemon) VolumeClone(src, name string, opts, labels map[string]string) (*types.Volume, error) {
+	if name == "" {
+		name = stringid.GenerateNonCryptoID()
+	}
+
+	sv, release, err := daemon.referenceVolume(src)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	v, err := daemon.volumes.Clone(sv, name, opts, labels)
+	if err != nil {
+		return nil, err
+	}
+
+	daemon.LogVolumeEvent(v.Name(), "create", map[string]string{"driver": v.DriverName(), "source": sv.Name()})
+	apiV := volumeToAPIType(v)
+	apiV.Mountpoint = v.Path()
+	return apiV, nil
+}
+
+// VolumeSnapshot creates the volume name with a point-in-time copy of the
+// data of the volume src. When no name is given, the snapshot is named after
+// src and the time it is taken. It fails if the driver of src can't take
+// snapshots. The local driver copies the data of src, so the containers
+// writing to src should be paused or stopped first.
+func (daemon *Daemon) VolumeSnapshot(src, name string, labels map[string]string) (*types.Volume, error) {
+	if name == "" {
+		name = src + "-" + time.Now().UTC().Format("20060102T150405Z")
+	}
+	l := map[string]string{snapshotSourceLabel: src}
+	for k, v := range labels {
+		l[k] = v
+	}
+
+	sv, release, err := daemon.referenceVolume(src)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	v, err := daemon.volumes.Snapshot(sv, name, l)
+	if err != nil {
+		return nil, err
+	}
+
+	daemon.LogVolumeEvent(v.Name(), "create", map[string]string{"driver": v.DriverName(), "source": sv.Name()})
+	apiV := volumeToAPIType(v)
+	apiV.Mountpoint = v.Path()
+	return apiV, nil
+}
+
+// VolumeExport writes the data of the volume name to out, as a tar archive.
+func (daemon *Daemon) VolumeExport(name string, out io.Writer) error {
+	v, release, err := daemon.referenceVolume(name)
+	if err != nil {
+		return err
+	}
+	defer release()
+	rc, err := daemon.volumes.Export(v)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	_, err = io.Copy(out, rc)
+	return err
+}
+
+// VolumeImport extracts the tar archive in into the volume name. The volume
+// is created on the default driver if it doesn't exist, and removed again if
+// the archive can't be extracted.
+func (daemon *Daemon) VolumeImport(name string, in io.Reader) error {
+	created := false
+	v, release, err := daemon.referenceVolume(name)
+	if err != nil {
+		if !volumestore.IsNotExist(err) {
+			return err
+		}
+		ref := stringid.GenerateNonCryptoID()
+		v, err = daemon.volumes.CreateWithRef(name, "", ref, nil, nil)
+		if err != nil {
+			return err
+		}
+		created = true
+		release = func() { daemon.volumes.Dereference(v, ref) }
+		daemon.LogVolumeEvent(v.Name(), "create", map[string]string{"driver": v.DriverName()})
+	}
+
+	err = daemon.volumes.Import(v, in, &archive.TarOptions{
+		UIDMaps: daemon.uidMaps,
+		GIDMaps: daemon.gidMaps,
+	})
+	release()
+	if err != nil && created {
+		if rmErr := daemon.volumes.Remove(v); rmErr != nil {
+			logrus.Warnf("Failed to remove volume %s after failing to import its data: %v", v.Name(), rmErr)
+		} else {
+			daemon.LogVolumeEvent(v.Name(), "destroy", map[string]string{"driver": v.DriverName()})
+		}
+	}
+	return err
+}
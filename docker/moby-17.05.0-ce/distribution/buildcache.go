@@ -0,0 +1,411 @@
+package distribution
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/distribution/manifest/schema2"
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/distribution/xfer"
+	"github.com/docker/docker/image"
+	"github.com/docker/docker/layer"
+	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/pkg/ioutils"
+	"github.com/docker/docker/pkg/progress"
+	refstore "github.com/docker/docker/reference"
+	"github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+// MediaTypeBuildCacheConfig is the media type of the configuration of a
+// build cache, which is pushed to a registry like the configuration of an
+// image.
+const MediaTypeBuildCacheConfig = "application/vnd.docker.build.cache.config.v1+json"
+
+// BuildCacheConfig is the configuration of a build cache. Images are the
+// configurations of the images that a build produced, including those of
+// stages that are not tagged. Their history maps the instructions of the
+// build to their layers. RootFS lists the layers of all images once, in the
+// order of the layers of the manifest of the cache.
+type BuildCacheConfig struct {
+	RootFS *image.RootFS     `json:"rootfs"`
+	Images []json.RawMessage `json:"images"`
+}
+
+// BuildCacheImageStore stores the images of build caches.
+type BuildCacheImageStore interface {
+	Create(config []byte) (image.ID, error)
+	Get(id image.ID) (*image.Image, error)
+}
+
+// BuildCacheLayerStore stores the layers of build caches.
+type BuildCacheLayerStore interface {
+	Register(io.Reader, layer.ChainID) (layer.Layer, error)
+	Get(layer.ChainID) (layer.Layer, error)
+	Release(layer.Layer) ([]layer.Metadata, error)
+}
+
+// PushBuildCache pushes the images with the IDs, and their layers, to a
+// registry as the build cache ref. The registry and the upload manager are
+// those of imagePushConfig, which doesn't need stores.
+func PushBuildCache(ctx context.Context, ref reference.NamedTagged, ids []image.ID, is BuildCacheImageStore, ls BuildCacheLayerStore, imagePushConfig *ImagePushConfig) error {
+	cache := &BuildCacheConfig{RootFS: image.NewRootFS()}
+	chains := make(map[layer.DiffID]layer.ChainID)
+	for _, id := range ids {
+		img, err := is.Get(id)
+		if err != nil {
+			return err
+		}
+		cache.Images = append(cache.Images, img.RawJSON())
+		if img.RootFS == nil {
+			continue
+		}
+		for i, diffID := range img.RootFS.DiffIDs {
+			if _, exists := chains[diffID]; exists {
+				continue
+			}
+			chains[diffID] = layer.CreateChainID(img.RootFS.DiffIDs[:i+1])
+			cache.RootFS.Append(diffID)
+		}
+	}
+	configJSON, err := json.Marshal(cache)
+	if err != nil {
+		return err
+	}
+	id := digest.FromBytes(configJSON)
+
+	pushConfig := *imagePushConfig
+	pushConfig.ImageStore = &buildCacheConfigStore{id: id, config: configJSON}
+	pushConfig.ReferenceStore = &buildCacheReference{name: ref, id: id}
+	pushConfig.ConfigMediaType = MediaTypeBuildCacheConfig
+	pushConfig.LayerStore = &buildCacheLayerProvider{
+		diffIDs: cache.RootFS.DiffIDs,
+		chains:  chains,
+		ls:      ls,
+	}
+	pushConfig.RequireSchema2 = true
+	return Push(ctx, ref, &pushConfig)
+}
+
+// PullBuildCache pulls the build cache ref from a registry, creates its
+// images and their layers, and returns the IDs of the images. The registry
+// is that of imagePullConfig, which doesn't need stores.
+func PullBuildCache(ctx context.Context, ref reference.Named, is BuildCacheImageStore, ls BuildCacheLayerStore, imagePullConfig *ImagePullConfig) ([]image.ID, error) {
+	tmpDir, err := ioutils.TempDir("", "docker-build-cache")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	s := &buildCacheDownload{
+		tmpDir: tmpDir,
+		blobs:  make(map[layer.DiffID]string),
+		is:     is,
+		ls:     ls,
+	}
+	pullConfig := *imagePullConfig
+	pullConfig.ImageStore = s
+	pullConfig.ReferenceStore = nil
+	pullConfig.DownloadManager = s
+	pullConfig.Schema2Types = BuildCacheTypes
+	if err := Pull(ctx, ref, &pullConfig); err != nil {
+		return nil, err
+	}
+	return s.images, nil
+}
+
+func releaseLayer(ls BuildCacheLayerStore, l layer.Layer) {
+	if _, err := ls.Release(l); err != nil {
+		logrus.Errorf("Error releasing layer %s: %v", l.ChainID(), err)
+	}
+}
+
+func buildCacheRootFS(c []byte) (*image.RootFS, error) {
+	var cache BuildCacheConfig
+	if err := json.Unmarshal(c, &cache); err != nil {
+		return nil, err
+	}
+	return cache.RootFS, nil
+}
+
+// buildCacheConfigStore provides the configuration of a build cache to push.
+type buildCacheConfigStore struct {
+	id     digest.Digest
+	config []byte
+}
+
+func (s *buildCacheConfigStore) Put([]byte) (digest.Digest, error) {
+	return "", errors.New("cannot store build cache config on push")
+}
+
+func (s *buildCacheConfigStore) Get(d digest.Digest) ([]byte, error) {
+	if d != s.id {
+		return nil, errors.New("build cache config not found")
+	}
+	return s.config, nil
+}
+
+func (s *buildCacheConfigStore) RootFSFromConfig(c []byte) (*image.RootFS, error) {
+	return buildCacheRootFS(c)
+}
+
+// buildCacheReference is the reference of a build cache to push.
+type buildCacheReference struct {
+	name reference.Named
+	id   digest.Digest
+}
+
+func (r *buildCacheReference) References(id digest.Digest) []reference.Named {
+	if r.id != id {
+		return nil
+	}
+	return []reference.Named{r.name}
+}
+
+func (r *buildCacheReference) ReferencesByName(ref reference.Named) []refstore.Association {
+	return []refstore.Association{
+		{
+			Ref: r.name,
+			ID:  r.id,
+		},
+	}
+}
+
+func (r *buildCacheReference) Get(ref reference.Named) (digest.Digest, error) {
+	if r.name.String() != ref.String() {
+		return "", refstore.ErrDoesNotExist
+	}
+	return r.id, nil
+}
+
+func (r *buildCacheReference) AddTag(ref reference.Named, id digest.Digest, force bool) error {
+	// Read only, ignore
+	return nil
+}
+
+func (r *buildCacheReference) AddDigest(ref reference.Canonical, id digest.Digest, force bool) error {
+	// Read only, ignore
+	return nil
+}
+
+func (r *buildCacheReference) Delete(ref reference.Named) (bool, error) {
+	// Read only, ignore
+	return false, nil
+}
+
+// buildCacheLayerProvider provides the layers of a build cache to push as a
+// single chain of layers, even though they belong to the chains of different
+// images.
+type buildCacheLayerProvider struct {
+	diffIDs []layer.DiffID
+	chains  map[layer.DiffID]layer.ChainID // a chain that has the layer on top
+	ls      BuildCacheLayerStore
+}
+
+func (p *buildCacheLayerProvider) Get(id layer.ChainID) (PushLayer, error) {
+	for i := 0; i <= len(p.diffIDs); i++ {
+		if layer.CreateChainID(p.diffIDs[:i]) == id {
+			return &buildCacheLayer{p: p, n: i}, nil
+		}
+	}
+	return nil, errors.New("layer not found")
+}
+
+// buildCacheLayer is the layer of a build cache on top of its first n
+// layers.
+type buildCacheLayer struct {
+	p *buildCacheLayerProvider
+	n int
+}
+
+func (l *buildCacheLayer) ChainID() layer.ChainID {
+	return layer.CreateChainID(l.p.diffIDs[:l.n])
+}
+
+func (l *buildCacheLayer) DiffID() layer.DiffID {
+	return l.p.diffIDs[l.n-1]
+}
+
+func (l *buildCacheLayer) Parent() PushLayer {
+	if l.n <= 1 {
+		return nil
+	}
+	return &buildCacheLayer{p: l.p, n: l.n - 1}
+}
+
+func (l *buildCacheLayer) get() (layer.Layer, error) {
+	return l.p.ls.Get(l.p.chains[l.DiffID()])
+}
+
+func (l *buildCacheLayer) Open() (io.ReadCloser, error) {
+	sl, err := l.get()
+	if err != nil {
+		return nil, err
+	}
+	rc, err := sl.TarStream()
+	if err != nil {
+		releaseLayer(l.p.ls, sl)
+		return nil, err
+	}
+	return ioutils.NewReadCloserWrapper(rc, func() error {
+		defer releaseLayer(l.p.ls, sl)
+		return rc.Close()
+	}), nil
+}
+
+func (l *buildCacheLayer) Size() (int64, error) {
+	sl, err := l.get()
+	if err != nil {
+		return 0, err
+	}
+	defer releaseLayer(l.p.ls, sl)
+	return sl.DiffSize()
+}
+
+func (l *buildCacheLayer) MediaType() string {
+	// layer store always returns uncompressed tars
+	return schema2.MediaTypeUncompressedLayer
+}
+
+func (l *buildCacheLayer) Release() {
+	// Nothing needs to be released, layers are only held while they are read
+}
+
+// buildCacheDownload downloads the layers of a build cache to temporary
+// files, and creates the images of the cache once its configuration is
+// pulled. The layers can only be registered then, as the configuration has
+// the chains of the layers.
+type buildCacheDownload struct {
+	tmpDir string
+	blobs  map[layer.DiffID]string // compressed layers by DiffID
+	is     BuildCacheImageStore
+	ls     BuildCacheLayerStore
+	images []image.ID
+}
+
+func (s *buildCacheDownload) Download(ctx context.Context, initialRootFS image.RootFS, layers []xfer.DownloadDescriptor, progressOutput progress.Output) (image.RootFS, func(), error) {
+	for _, l := range layers {
+		diffID, err := s.download(ctx, l, progressOutput)
+		if err != nil {
+			return initialRootFS, nil, err
+		}
+		initialRootFS.Append(diffID)
+	}
+	return initialRootFS, nil, nil
+}
+
+func (s *buildCacheDownload) download(ctx context.Context, l xfer.DownloadDescriptor, progressOutput progress.Output) (layer.DiffID, error) {
+	defer l.Close()
+	rc, _, err := l.Download(ctx, progressOutput)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to download")
+	}
+	defer rc.Close()
+
+	f, err := ioutil.TempFile(s.tmpDir, "layer")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	r := io.TeeReader(rc, f)
+	inflatedLayerData, err := archive.DecompressStream(r)
+	if err != nil {
+		return "", err
+	}
+	defer inflatedLayerData.Close()
+	digester := digest.Canonical.Digester()
+	if _, err := io.Copy(digester.Hash(), inflatedLayerData); err != nil {
+		return "", err
+	}
+	// Write what is left after the end of the compressed data.
+	if _, err := io.Copy(ioutil.Discard, r); err != nil {
+		return "", err
+	}
+
+	diffID := layer.DiffID(digester.Digest())
+	s.blobs[diffID] = f.Name()
+	progress.Update(progressOutput, l.ID(), "Download complete")
+	return diffID, nil
+}
+
+func (s *buildCacheDownload) Put(c []byte) (digest.Digest, error) {
+	var cache BuildCacheConfig
+	if err := json.Unmarshal(c, &cache); err != nil {
+		return "", err
+	}
+	for _, config := range cache.Images {
+		id, err := s.createImage(config)
+		if err != nil {
+			return "", err
+		}
+		s.images = append(s.images, id)
+	}
+	return digest.FromBytes(c), nil
+}
+
+func (s *buildCacheDownload) Get(d digest.Digest) ([]byte, error) {
+	return nil, errors.New("build cache config not found")
+}
+
+func (s *buildCacheDownload) RootFSFromConfig(c []byte) (*image.RootFS, error) {
+	return buildCacheRootFS(c)
+}
+
+// createImage creates an image of the build cache, after registering its
+// layers that don't exist yet.
+func (s *buildCacheDownload) createImage(config []byte) (image.ID, error) {
+	img, err := image.NewFromJSON(config)
+	if err != nil {
+		return "", err
+	}
+
+	if img.RootFS != nil {
+		var parent layer.ChainID
+		for i, diffID := range img.RootFS.DiffIDs {
+			chainID := layer.CreateChainID(img.RootFS.DiffIDs[:i+1])
+			l, err := s.ls.Get(chainID)
+			if err != nil {
+				l, err = s.register(diffID, parent)
+				if err != nil {
+					return "", err
+				}
+			}
+			defer releaseLayer(s.ls, l)
+			parent = chainID
+		}
+	}
+
+	return s.is.Create(config)
+}
+
+func (s *buildCacheDownload) register(diffID layer.DiffID, parent layer.ChainID) (layer.Layer, error) {
+	blob, ok := s.blobs[diffID]
+	if !ok {
+		return nil, errors.Errorf("layer %s is missing from the build cache", diffID)
+	}
+	f, err := os.Open(blob)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	inflatedLayerData, err := archive.DecompressStream(f)
+	if err != nil {
+		return nil, err
+	}
+	defer inflatedLayerData.Close()
+
+	l, err := s.ls.Register(inflatedLayerData, parent)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to register layer %s", diffID)
+	}
+	if l.DiffID() != diffID {
+		releaseLayer(s.ls, l)
+		return nil, errors.Errorf("layer %s of the build cache has the content of %s", diffID, l.DiffID())
+	}
+	return l, nil
+}
@@ -0,0 +1,390 @@
+package distribution
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/distribution/metadata"
+	"github.com/docker/docker/distribution/xfer"
+	"github.com/docker/docker/image"
+	"github.com/docker/docker/layer"
+	"github.com/docker/docker/pkg/progress"
+	"github.com/docker/docker/pkg/testutil/assert"
+	"github.com/docker/docker/registry"
+	"github.com/opencontainers/go-digest"
+	"golang.org/x/net/context"
+)
+
+// testRegistry is a stand-in for a registry, which implements the parts of
+// the registry API that pushes and pulls use.
+type testRegistry struct {
+	mu        sync.Mutex
+	blobs     map[digest.Digest][]byte
+	uploads   map[string]*bytes.Buffer
+	manifests map[string]testManifest // by repository and tag or digest
+}
+
+type testManifest struct {
+	mediaType string
+	content   []byte
+}
+
+var (
+	testBlobUploadPath = regexp.MustCompile(`^/v2/(.+)/blobs/uploads/([^/]*)$`)
+	testBlobPath       = regexp.MustCompile(`^/v2/(.+)/blobs/([^/]+)$`)
+	testManifestPath   = regexp.MustCompile(`^/v2/(.+)/manifests/([^/]+)$`)
+)
+
+func newTestRegistry() *testRegistry {
+	return &testRegistry{
+		blobs:     make(map[digest.Digest][]byte),
+		uploads:   make(map[string]*bytes.Buffer),
+		manifests: make(map[string]testManifest),
+	}
+}
+
+func (r *testRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	w.Header().Set("Docker-Distribution-API-Version", "registry/2.0")
+	if req.URL.Path == "/v2/" {
+		return
+	}
+	if m := testBlobUploadPath.FindStringSubmatch(req.URL.Path); m != nil {
+		r.serveUpload(w, req, m[1], m[2])
+		return
+	}
+	if m := testBlobPath.FindStringSubmatch(req.URL.Path); m != nil {
+		blob, ok := r.blobs[digest.Digest(m[2])]
+		if !ok {
+			testRegistryError(w, "BLOB_UNKNOWN")
+			return
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Length", fmt.Sprint(len(blob)))
+		w.Header().Set("Docker-Content-Digest", m[2])
+		if req.Method == "GET" {
+			w.Write(blob)
+		}
+		return
+	}
+	if m := testManifestPath.FindStringSubmatch(req.URL.Path); m != nil {
+		r.serveManifest(w, req, m[1], m[2])
+		return
+	}
+	w.WriteHeader(http.StatusNotFound)
+}
+
+func (r *testRegistry) serveUpload(w http.ResponseWriter, req *http.Request, name, uuid string) {
+	if req.Method == "POST" {
+		uuid = fmt.Sprintf("upload-%d", len(r.uploads))
+		r.uploads[uuid] = &bytes.Buffer{}
+	}
+	upload, ok := r.uploads[uuid]
+	if !ok {
+		testRegistryError(w, "BLOB_UPLOAD_UNKNOWN")
+		return
+	}
+	io.Copy(upload, req.Body)
+
+	if req.Method == "PUT" {
+		dgst := digest.Digest(req.URL.Query().Get("digest"))
+		if digest.FromBytes(upload.Bytes()) != dgst {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		r.blobs[dgst] = upload.Bytes()
+		delete(r.uploads, uuid)
+		w.Header().Set("Location", fmt.Sprintf("/v2/%s/blobs/%s", name, dgst))
+		w.Header().Set("Docker-Content-Digest", dgst.String())
+		w.WriteHeader(http.StatusCreated)
+		return
+	}
+
+	w.Header().Set("Location", fmt.Sprintf("/v2/%s/blobs/uploads/%s", name, uuid))
+	w.Header().Set("Docker-Upload-UUID", uuid)
+	w.Header().Set("Range", fmt.Sprintf("0-%d", upload.Len()))
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (r *testRegistry) serveManifest(w http.ResponseWriter, req *http.Request, name, tagOrDigest string) {
+	if req.Method == "PUT" {
+		content, _ := ioutil.ReadAll(req.Body)
+		m := testManifest{mediaType: req.Header.Get("Content-Type"), content: content}
+		dgst := digest.FromBytes(content)
+		r.manifests[name+":"+tagOrDigest] = m
+		r.manifests[name+":"+dgst.String()] = m
+		w.Header().Set("Location", fmt.Sprintf("/v2/%s/manifests/%s", name, dgst))
+		w.Header().Set("Docker-Content-Digest", dgst.String())
+		w.WriteHeader(http.StatusCreated)
+		return
+	}
+
+	m, ok := r.manifests[name+":"+tagOrDigest]
+	if !ok {
+		testRegistryError(w, "MANIFEST_UNKNOWN")
+		return
+	}
+	w.Header().Set("Content-Type", m.mediaType)
+	w.Header().Set("Content-Length", fmt.Sprint(len(m.content)))
+	w.Header().Set("Docker-Content-Digest", digest.FromBytes(m.content).String())
+	if req.Method == "GET" {
+		w.Write(m.content)
+	}
+}
+
+func testRegistryError(w http.ResponseWriter, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNotFound)
+	fmt.Fprintf(w, `{"errors":[{"code":%q,"message":"not found"}]}`, code)
+}
+
+// testImageStore is an image store that keeps images in memory.
+type testImageStore struct {
+	images map[image.ID][]byte
+}
+
+func (s *testImageStore) Create(config []byte) (image.ID, error) {
+	id := image.IDFromDigest(digest.FromBytes(config))
+	s.images[id] = config
+	return id, nil
+}
+
+func (s *testImageStore) Get(id image.ID) (*image.Image, error) {
+	config, ok := s.images[id]
+	if !ok {
+		return nil, fmt.Errorf("image %s not found", id)
+	}
+	return image.NewFromJSON(config)
+}
+
+// testLayerStore is a layer store that keeps the content of layers in
+// memory.
+type testLayerStore struct {
+	layers map[layer.ChainID]*testLayer
+}
+
+func (s *testLayerStore) Register(r io.Reader, parent layer.ChainID) (layer.Layer, error) {
+	content, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	l := &testLayer{diffID: layer.DiffID(digest.FromBytes(content)), content: content}
+	if parent != "" {
+		if l.parent = s.layers[parent]; l.parent == nil {
+			return nil, fmt.Errorf("parent %s not found", parent)
+		}
+	}
+	if existing, ok := s.layers[l.ChainID()]; ok {
+		return existing, nil
+	}
+	s.layers[l.ChainID()] = l
+	return l, nil
+}
+
+func (s *testLayerStore) Get(id layer.ChainID) (layer.Layer, error) {
+	l, ok := s.layers[id]
+	if !ok {
+		return nil, layer.ErrLayerDoesNotExist
+	}
+	return l, nil
+}
+
+func (s *testLayerStore) Release(l layer.Layer) ([]layer.Metadata, error) {
+	return nil, nil
+}
+
+type testLayer struct {
+	diffID  layer.DiffID
+	content []byte
+	parent  *testLayer
+}
+
+func (l *testLayer) TarStream() (io.ReadCloser, error) {
+	return ioutil.NopCloser(bytes.NewReader(l.content)), nil
+}
+
+func (l *testLayer) TarStreamFrom(layer.ChainID) (io.ReadCloser, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (l *testLayer) ChainID() layer.ChainID {
+	return layer.CreateChainID(l.diffIDs())
+}
+
+func (l *testLayer) diffIDs() []layer.DiffID {
+	if l.parent == nil {
+		return []layer.DiffID{l.diffID}
+	}
+	return append(l.parent.diffIDs(), l.diffID)
+}
+
+func (l *testLayer) DiffID() layer.DiffID {
+	return l.diffID
+}
+
+func (l *testLayer) Parent() layer.Layer {
+	if l.parent == nil {
+		return nil
+	}
+	return l.parent
+}
+
+func (l *testLayer) Size() (int64, error) {
+	return l.DiffSize()
+}
+
+func (l *testLayer) DiffSize() (int64, error) {
+	return int64(len(l.content)), nil
+}
+
+func (l *testLayer) Metadata() (map[string]string, error) {
+	return nil, nil
+}
+
+// createTestImage registers layers with the contents in ls, and creates an
+// image of them in is, with a history entry for each layer.
+func createTestImage(t *testing.T, is *testImageStore, ls *testLayerStore, contents ...string) image.ID {
+	rootFS := image.NewRootFS()
+	var history []image.History
+	var parent layer.ChainID
+	for _, content := range contents {
+		l, err := ls.Register(bytes.NewReader([]byte(content)), parent)
+		assert.NilError(t, err)
+		parent = l.ChainID()
+		rootFS.Append(l.DiffID())
+		history = append(history, image.History{CreatedBy: "/bin/sh -c echo " + content})
+	}
+	config, err := json.Marshal(&image.Image{
+		V1Image: image.V1Image{Created: time.Unix(0, 0).UTC(), OS: "linux"},
+		RootFS:  rootFS,
+		History: history,
+	})
+	assert.NilError(t, err)
+	id, err := is.Create(config)
+	assert.NilError(t, err)
+	return id
+}
+
+func newTestBuildCacheConfig(t *testing.T, ts *httptest.Server) (Config, func()) {
+	u, err := url.Parse(ts.URL)
+	assert.NilError(t, err)
+	tmpDir, err := ioutil.TempDir("", "build-cache-metadata")
+	assert.NilError(t, err)
+	metadataStore, err := metadata.NewFSMetadataStore(tmpDir)
+	assert.NilError(t, err)
+
+	return Config{
+		MetaHeaders:      http.Header{},
+		AuthConfig:       &types.AuthConfig{},
+		ProgressOutput:   progress.DiscardOutput(),
+		RegistryService:  registry.NewService(registry.ServiceOptions{InsecureRegistries: []string{u.Host}, V2Only: true}),
+		ImageEventLogger: func(string, string, string) {},
+		MetadataStore:    metadataStore,
+	}, func() { os.RemoveAll(tmpDir) }
+}
+
+func TestBuildCachePushAndPull(t *testing.T) {
+	ts := httptest.NewServer(newTestRegistry())
+	defer ts.Close()
+	config, cleanup := newTestBuildCacheConfig(t, ts)
+	defer cleanup()
+
+	u, err := url.Parse(ts.URL)
+	assert.NilError(t, err)
+	ref, err := reference.ParseNormalizedNamed(u.Host + "/app/cache:build")
+	assert.NilError(t, err)
+
+	// Two stages of a build, which share the layer of their base image, and
+	// an untagged stage that has a different base image.
+	is := &testImageStore{images: make(map[image.ID][]byte)}
+	ls := &testLayerStore{layers: make(map[layer.ChainID]*testLayer)}
+	ids := []image.ID{
+		createTestImage(t, is, ls, "base", "builder"),
+		createTestImage(t, is, ls, "base", "app"),
+		createTestImage(t, is, ls, "other"),
+	}
+
+	err = PushBuildCache(context.Background(), ref.(reference.NamedTagged), ids, is, ls, &ImagePushConfig{
+		Config:        config,
+		UploadManager: xfer.NewLayerUploadManager(3),
+	})
+	assert.NilError(t, err)
+
+	// The images and their layers are created from the cache alone, except
+	// for the layers that already exist.
+	pulledImages := &testImageStore{images: make(map[image.ID][]byte)}
+	pulledLayers := &testLayerStore{layers: make(map[layer.ChainID]*testLayer)}
+	_, err = pulledLayers.Register(bytes.NewReader([]byte("base")), "")
+	assert.NilError(t, err)
+
+	pulledIDs, err := PullBuildCache(context.Background(), ref, pulledImages, pulledLayers, &ImagePullConfig{Config: config})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, pulledIDs, ids)
+	for _, id := range ids {
+		assert.DeepEqual(t, pulledImages.images[id], is.images[id])
+	}
+
+	assert.Equal(t, len(pulledLayers.layers), len(ls.layers))
+	for chainID, l := range ls.layers {
+		pulled, ok := pulledLayers.layers[chainID]
+		assert.Equal(t, ok, true)
+		assert.Equal(t, string(pulled.content), string(l.content))
+	}
+}
+
+func TestBuildCachePullImage(t *testing.T) {
+	ts := httptest.NewServer(newTestRegistry())
+	defer ts.Close()
+	config, cleanup := newTestBuildCacheConfig(t, ts)
+	defer cleanup()
+
+	u, err := url.Parse(ts.URL)
+	assert.NilError(t, err)
+	ref, err := reference.ParseNormalizedNamed(u.Host + "/app:latest")
+	assert.NilError(t, err)
+
+	// An image is not a build cache.
+	is := &testImageStore{images: make(map[image.ID][]byte)}
+	ls := &testLayerStore{layers: make(map[layer.ChainID]*testLayer)}
+	id := createTestImage(t, is, ls, "app")
+	diffID := layer.DiffID(digest.FromBytes([]byte("app")))
+	err = Push(context.Background(), ref, &ImagePushConfig{
+		Config: Config{
+			MetaHeaders:      config.MetaHeaders,
+			AuthConfig:       config.AuthConfig,
+			ProgressOutput:   config.ProgressOutput,
+			RegistryService:  config.RegistryService,
+			ImageEventLogger: config.ImageEventLogger,
+			MetadataStore:    config.MetadataStore,
+			ImageStore:       &buildCacheConfigStore{id: digest.Digest(id), config: is.images[id]},
+			ReferenceStore:   &buildCacheReference{name: ref, id: digest.Digest(id)},
+			RequireSchema2:   true,
+		},
+		ConfigMediaType: "application/vnd.docker.container.image.v1+json",
+		LayerStore: &buildCacheLayerProvider{
+			diffIDs: []layer.DiffID{diffID},
+			chains:  map[layer.DiffID]layer.ChainID{diffID: layer.ChainID(diffID)},
+			ls:      ls,
+		},
+		UploadManager: xfer.NewLayerUploadManager(3),
+	})
+	assert.NilError(t, err)
+
+	_, err = PullBuildCache(context.Background(), ref, is, ls, &ImagePullConfig{Config: config})
+	assert.Error(t, err, `Encountered remote "application/vnd.docker.container.image.v1+json"(image) when fetching`)
+}
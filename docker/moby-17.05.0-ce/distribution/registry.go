@@ -39,6 +39,11 @@ var PluginTypes = []string{
 	schema2.MediaTypePluginConfig,
 }
 
+// BuildCacheTypes represents the schema2 config types for build caches
+var BuildCacheTypes = []string{
+	MediaTypeBuildCacheConfig,
+}
+
 var mediaTypeClasses map[string]string
 
 func init() {
@@ -51,6 +56,9 @@ func init() {
 	for _, t := range PluginTypes {
 		mediaTypeClasses[t] = "plugin"
 	}
+	for _, t := range BuildCacheTypes {
+		mediaTypeClasses[t] = "build cache"
+	}
 }
 
 // NewV2Repository returns a repository (v2 only). It creates an HTTP transport
@@ -23,5 +23,7 @@ swagger generate operation -f api/swagger.yaml \
     -n ContainerUpdate \
     -n ContainerWait \
     -n ImageHistory \
+    -n VolumeClone \
+    -n VolumeSnapshot \
     -n VolumesCreate \
     -n VolumesList
@@ -6423,3 +6423,25 @@ CMD echo foo
 	out, _ := dockerCmd(c, "inspect", "--format", "{{ json .Config.Cmd }}", "build2")
 	c.Assert(strings.TrimSpace(out), checker.Equals, `["/bin/sh","-c","echo foo"]`)
 }
+
+func (s *DockerSuite) TestBuildOutputRemovedOnFailure(c *check.C) {
+	testRequires(c, DaemonIsLinux)
+	tmp, err := ioutil.TempDir("", "test-build-output")
+	c.Assert(err, checker.IsNil)
+	defer os.RemoveAll(tmp)
+
+	for _, output := range []string{
+		"type=local,dest=" + filepath.Join(tmp, "out"),
+		"type=tar,dest=" + filepath.Join(tmp, "out.tar"),
+	} {
+		result := cli.Docker(icmd.Command("build", "--output", output), build.WithDockerfile(`FROM busybox
+RUN echo foo > /foo
+RUN exit 1`))
+		c.Assert(result.ExitCode, checker.Not(checker.Equals), 0, check.Commentf("output: %s", output))
+	}
+
+	// Nothing is left of the outputs.
+	entries, err := ioutil.ReadDir(tmp)
+	c.Assert(err, checker.IsNil)
+	c.Assert(entries, checker.HasLen, 0)
+}
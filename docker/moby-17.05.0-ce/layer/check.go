@@ -0,0 +1,268 @@
+package layer
+
+import (
+	"fmt"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/daemon/graphdriver"
+	"github.com/opencontainers/go-digest"
+)
+
+// CheckProblem is a problem found by the integrity check of a layer store.
+type CheckProblem struct {
+	// Type is the type of the object with the problem: "layer" for an
+	// image layer, "mount" for a read-write layer, or "cache" for the data
+	// of a layer in the storage driver.
+	Type string
+	// ID is the chain ID of the layer, the name of the read-write layer or
+	// the id of the data in the storage driver.
+	ID string
+	// Description describes the problem.
+	Description string
+	// Repair describes how the problem was repaired, if it was.
+	Repair string
+}
+
+// CheckOptions are the options of the integrity check of a layer store.
+type CheckOptions struct {
+	// Repair repairs the problems which can be.
+	Repair bool
+	// MountInUse returns whether the read-write layer name is used. The
+	// read-write layers which are not are reported, and removed on repair.
+	MountInUse func(name string) bool
+}
+
+// CheckableStore is a Store whose integrity can be checked.
+type CheckableStore interface {
+	Check(opts CheckOptions) ([]CheckProblem, error)
+}
+
+// quarantineStore is a MetadataStore which can move the metadata of the
+// layers and read-write layers aside.
+type quarantineStore interface {
+	quarantineLayer(ChainID) error
+	quarantineMount(string) error
+	removeTransactions() (int, error)
+}
+
+// Check checks that the metadata of the layers and read-write layers of the
+// store is complete, that their data exists in the storage driver, and that
+// the content of the layers matches their diff IDs. The metadata which is
+// incomplete is quarantined on repair, the data of the storage driver which
+// is not used by any layer is removed, and the missing data of the
+// read-write layers is recreated empty.
+//
+// The layers and read-write layers are not created while the store is
+// repaired. Without repair, the data and the metadata of the layers being
+// created may be reported.
+func (ls *layerStore) Check(opts CheckOptions) ([]CheckProblem, error) {
+	if opts.Repair {
+		ls.checkL.Lock()
+		defer ls.checkL.Unlock()
+	}
+
+	var problems []CheckProblem
+	report := func(p CheckProblem, repair func() (string, error)) {
+		if opts.Repair && repair != nil {
+			r, err := repair()
+			if err != nil {
+				logrus.Errorf("Failed to repair %s %s: %v", p.Type, p.ID, err)
+			} else {
+				p.Repair = r
+			}
+		}
+		problems = append(problems, p)
+	}
+	qs, _ := ls.store.(quarantineStore)
+
+	// The metadata is listed with the layers and read-write layers locked so
+	// that the ones released in the meantime are not reported.
+	ls.mountL.Lock()
+	ls.layerL.Lock()
+	ids, mounts, err := ls.store.List()
+	if err != nil {
+		ls.layerL.Unlock()
+		ls.mountL.Unlock()
+		return nil, err
+	}
+
+	for _, id := range ids {
+		if _, ok := ls.layerMap[id]; ok {
+			continue
+		}
+		id := id
+		var repair func() (string, error)
+		if qs != nil {
+			repair = func() (string, error) {
+				return "quarantined", qs.quarantineLayer(id)
+			}
+		}
+		report(CheckProblem{Type: "layer", ID: id.String(), Description: "the metadata of the layer is incomplete"}, repair)
+	}
+	layers := make([]ChainID, 0, len(ls.layerMap))
+	for id := range ls.layerMap {
+		layers = append(layers, id)
+	}
+	ls.layerL.Unlock()
+
+	for _, name := range mounts {
+		if _, ok := ls.mounts[name]; ok {
+			continue
+		}
+		name := name
+		var repair func() (string, error)
+		if qs != nil {
+			repair = func() (string, error) {
+				return "quarantined", qs.quarantineMount(name)
+			}
+		}
+		report(CheckProblem{Type: "mount", ID: name, Description: "the metadata of the read-write layer is incomplete"}, repair)
+	}
+	ls.mountL.Unlock()
+
+	if qs != nil && opts.Repair {
+		if n, err := qs.removeTransactions(); err != nil {
+			logrus.Errorf("Failed to remove the unfinished layer metadata: %v", err)
+		} else if n > 0 {
+			logrus.Infof("Removed %d unfinished layer metadata", n)
+		}
+	}
+
+	for _, id := range layers {
+		if p := ls.checkLayer(id); p != nil {
+			report(*p, nil)
+		}
+	}
+
+	ls.checkMounts(opts, report)
+
+	return problems, ls.checkCache(report)
+}
+
+// checkLayer checks that the data of the layer id exists and that its content
+// matches its diff ID. No reference is taken on the layer, so that the layers
+// which are not used are not removed by the check.
+func (ls *layerStore) checkLayer(id ChainID) *CheckProblem {
+	rl := ls.lookup(id)
+	if rl == nil {
+		return nil
+	}
+	problem := func(format string, args ...interface{}) *CheckProblem {
+		if ls.lookup(id) == nil {
+			// The layer was removed in the meantime.
+			return nil
+		}
+		return &CheckProblem{Type: "layer", ID: id.String(), Description: fmt.Sprintf(format, args...)}
+	}
+
+	if !ls.driver.Exists(rl.cacheID) {
+		return problem("the data of the layer is missing from the storage driver")
+	}
+
+	ts, err := ls.getTarStream(rl)
+	if err != nil {
+		return problem("the content of the layer can't be read: %v", err)
+	}
+	defer ts.Close()
+	dgst, err := digest.FromReader(ts)
+	if err != nil {
+		return problem("the content of the layer can't be read: %v", err)
+	}
+	if DiffID(dgst) != rl.diffID {
+		return problem("the content of the layer doesn't match its diff ID %s, got %s", rl.diffID, dgst)
+	}
+	return nil
+}
+
+// lookup returns the layer id without taking a reference on it.
+func (ls *layerStore) lookup(id ChainID) *roLayer {
+	ls.layerL.Lock()
+	defer ls.layerL.Unlock()
+	return ls.layerMap[id]
+}
+
+// checkMounts checks that the read-write layers are used, and that their data
+// exists. The read-write layers which are referenced, like the ones of the
+// containers being created or of the images mounted by the builder, are used.
+func (ls *layerStore) checkMounts(opts CheckOptions, report func(CheckProblem, func() (string, error))) {
+	ls.mountL.Lock()
+	mounts := make([]*mountedLayer, 0, len(ls.mounts))
+	referenced := make(map[string]bool)
+	for _, m := range ls.mounts {
+		mounts = append(mounts, m)
+		referenced[m.name] = m.hasReferences()
+	}
+	ls.mountL.Unlock()
+
+	for _, m := range mounts {
+		m := m
+		if opts.MountInUse != nil && !referenced[m.name] && !opts.MountInUse(m.name) {
+			report(CheckProblem{Type: "mount", ID: m.name, Description: "the read-write layer is not used by any container"}, func() (string, error) {
+				rw, err := ls.GetRWLayer(m.name)
+				if err != nil {
+					return "", err
+				}
+				if _, err := ls.ReleaseRWLayer(rw); err != nil {
+					return "", err
+				}
+				return "removed", nil
+			})
+			continue
+		}
+
+		if m.initID != "" && !ls.driver.Exists(m.initID) {
+			report(CheckProblem{Type: "mount", ID: m.name, Description: "the data of the init layer is missing from the storage driver"}, nil)
+			continue
+		}
+		if !ls.driver.Exists(m.mountID) {
+			report(CheckProblem{Type: "mount", ID: m.name, Description: "the data of the read-write layer is missing from the storage driver"}, func() (string, error) {
+				if err := ls.driver.CreateReadWrite(m.mountID, m.cacheParent(), nil); err != nil {
+					return "", err
+				}
+				return "recreated empty, the changes of the container are lost", nil
+			})
+		}
+	}
+}
+
+// checkCache checks that the data held by the storage driver is used by a
+// layer or a read-write layer, if the driver can list it.
+func (ls *layerStore) checkCache(report func(CheckProblem, func() (string, error))) error {
+	ld, ok := ls.driver.(graphdriver.ListDriver)
+	if !ok {
+		return nil
+	}
+	ids, err := ld.List()
+	if err != nil {
+		if err == graphdriver.ErrNotSupported {
+			return nil
+		}
+		return err
+	}
+
+	used := make(map[string]struct{})
+	ls.layerL.Lock()
+	for _, l := range ls.layerMap {
+		used[l.cacheID] = struct{}{}
+	}
+	ls.layerL.Unlock()
+	ls.mountL.Lock()
+	for _, m := range ls.mounts {
+		used[m.mountID] = struct{}{}
+		if m.initID != "" {
+			used[m.initID] = struct{}{}
+		}
+	}
+	ls.mountL.Unlock()
+
+	for _, id := range ids {
+		if _, ok := used[id]; ok {
+			continue
+		}
+		id := id
+		report(CheckProblem{Type: "cache", ID: id, Description: "the data is not used by any layer"}, func() (string, error) {
+			return "removed", ls.driver.Remove(id)
+		})
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package layer
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+)
+
+func checkProblemIDs(problems []CheckProblem) []string {
+	var ids []string
+	for _, p := range problems {
+		ids = append(ids, p.Type+":"+p.ID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestCheck(t *testing.T) {
+	// TODO Windows: Figure out why this is failing
+	if runtime.GOOS == "windows" {
+		t.Skip("Failing on Windows")
+	}
+	ls, td, cleanup := newTestStore(t)
+	defer cleanup()
+
+	good, err := createLayer(ls, "", initWithFiles(newTestFile("good.txt", []byte("good"), 0644)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bad, err := createLayer(ls, good.ChainID(), initWithFiles(newTestFile("bad.txt", []byte("bad"), 0644)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ls.CreateRWLayer("unused", good.ChainID(), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	lsi := ls.(*layerStore)
+	dir, err := lsi.driver.Get(bad.(*referencedCacheLayer).cacheID, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "bad.txt"), []byte("BAD"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := lsi.driver.Create("orphan", "", nil); err != nil {
+		t.Fatal(err)
+	}
+	incomplete := digest.FromString("incomplete")
+	if err := os.MkdirAll(filepath.Join(td, "sha256", incomplete.Hex()), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	// The store is loaded again, like when the daemon restarts.
+	ls, err = NewStoreFromGraphDriver(lsi.store, lsi.driver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lsi = ls.(*layerStore)
+
+	opts := CheckOptions{
+		MountInUse: func(name string) bool { return name != "unused" },
+	}
+	problems, err := lsi.Check(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"cache:orphan",
+		"layer:" + bad.ChainID().String(),
+		"layer:" + string(incomplete),
+		"mount:unused",
+	}
+	sort.Strings(expected)
+	if ids := checkProblemIDs(problems); !stringSlicesEqual(ids, expected) {
+		t.Fatalf("expected the problems %v, got %v", expected, ids)
+	}
+	for _, p := range problems {
+		if p.Repair != "" {
+			t.Fatalf("expected no repair without the repair option, got %+v", p)
+		}
+	}
+
+	opts.Repair = true
+	if _, err := lsi.Check(opts); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(td, "quarantine", "sha256", incomplete.Hex())); err != nil {
+		t.Fatalf("expected the incomplete layer to be quarantined, got %v", err)
+	}
+
+	// The layer whose content doesn't match can't be repaired.
+	problems, err = lsi.Check(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = []string{"layer:" + bad.ChainID().String()}
+	if ids := checkProblemIDs(problems); !stringSlicesEqual(ids, expected) {
+		t.Fatalf("expected the problems %v after the repair, got %v", expected, ids)
+	}
+}
+
+func TestCheckReferencedMount(t *testing.T) {
+	// TODO Windows: Figure out why this is failing
+	if runtime.GOOS == "windows" {
+		t.Skip("Failing on Windows")
+	}
+	ls, _, cleanup := newTestStore(t)
+	defer cleanup()
+
+	base, err := createLayer(ls, "", initWithFiles(newTestFile("base.txt", []byte("base"), 0644)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A read-write layer which is referenced, like the one of a container
+	// being created, is used even if no container uses it yet.
+	rw, err := ls.CreateRWLayer("creating", base.ChainID(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	problems, err := ls.(*layerStore).Check(CheckOptions{
+		Repair:     true,
+		MountInUse: func(name string) bool { return false },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(problems) != 0 {
+		t.Fatalf("expected no problems, got %+v", problems)
+	}
+	if _, err := ls.GetRWLayer(rw.Name()); err != nil {
+		t.Fatalf("expected the read-write layer to be kept, got %v", err)
+	}
+}
+
+func stringSlicesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
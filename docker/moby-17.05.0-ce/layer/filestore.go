@@ -381,3 +381,52 @@ func (fms *fileMetadataStore) Remove(layer ChainID) error {
 func (fms *fileMetadataStore) RemoveMount(mount string) error {
 	return os.RemoveAll(fms.getMountDirectory(mount))
 }
+
+// quarantine moves the directory dir of the store to the quarantine
+// directory, at the same relative path.
+func (fms *fileMetadataStore) quarantine(dir string) error {
+	rel, err := filepath.Rel(fms.root, dir)
+	if err != nil {
+		return err
+	}
+	target := filepath.Join(fms.root, "quarantine", rel)
+	if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(target); err != nil {
+		return err
+	}
+	return os.Rename(dir, target)
+}
+
+func (fms *fileMetadataStore) quarantineLayer(layer ChainID) error {
+	return fms.quarantine(fms.getLayerDirectory(layer))
+}
+
+func (fms *fileMetadataStore) quarantineMount(mount string) error {
+	return fms.quarantine(fms.getMountDirectory(mount))
+}
+
+// removeTransactions removes the metadata of the transactions which were not
+// committed, and returns their number.
+func (fms *fileMetadataStore) removeTransactions() (int, error) {
+	fileInfos, err := ioutil.ReadDir(filepath.Join(fms.root, "tmp"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	var n int
+	for _, fi := range fileInfos {
+		if !fi.IsDir() || !strings.HasPrefix(fi.Name(), "write-set-") {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(fms.root, "tmp", fi.Name())); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
@@ -60,6 +60,11 @@ type layerStore struct {  //NewStoreFromGraphDriver 中初始化会使用该结
 	mounts map[string]*mountedLayer
 	mountL sync.Mutex
 
+	// checkL is held for reading while layers and read-write layers are
+	// created, and for writing while the store is repaired, so that the
+	// data of the layers being created isn't taken for unused data.
+	checkL sync.RWMutex
+
 	useTarSplit bool
 }
 
@@ -300,6 +305,9 @@ func (ls *layerStore) Register(ts io.Reader, parent ChainID) (Layer, error) {
 }
 
 func (ls *layerStore) registerWithDescriptor(ts io.Reader, parent ChainID, descriptor distribution.Descriptor) (Layer, error) {
+	ls.checkL.RLock()
+	defer ls.checkL.RUnlock()
+
 	// err is used to hold the error which will always trigger
 	// cleanup of creates sources but may not be an error returned
 	// to the caller (already exists).
@@ -534,6 +542,8 @@ func (ls *layerStore) CreateRWLayer(name string, parent ChainID, opts *CreateRWL
 		initFunc = opts.InitFunc
 	}
 
+	ls.checkL.RLock()
+	defer ls.checkL.RUnlock()
 	ls.mountL.Lock()
 	defer ls.mountL.Unlock()
 	m, ok := ls.mounts[name] //该容器已经存在了，直接返回报错
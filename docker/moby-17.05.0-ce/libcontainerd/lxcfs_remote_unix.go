@@ -1,5 +1,6 @@
 package libcontainerd
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"net"
@@ -7,7 +8,6 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strconv"
-	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -23,11 +23,13 @@ const (
 	maxLxcfsConnectionRetryCount      = 3
 	lxcfsHealthCheckTimeout     	  = 3 * time.Second
 	lxcfsShutdownTimeout   		  = 3 * time.Second
+	lxcfsKillTimeout                  = 3 * time.Second
 	lxcfsBinary            		  = "docker-lxcfs"
 	lxcfsPidFilename      		  = "docker-lxcfs.pid"
 	lxcfsSockFilename    		  = "docker-lxcfs.sock"
 	lxcfsLogDir                       = "/var/log/lxcfs/lxcfs.log"
 	lxcfsStateDir            	  = "lxcfs"
+	lxcfsDefaultMountPath             = "/usr/local/var/lib/lxcfs/"
 	//eventLxcfsTimestampFilename       = "event-lxcfs.ts"
 
 	lxcfsHealthString                 = "lxcfs docker health protocol"
@@ -36,6 +38,41 @@ const (
 	lxcfsReadWriteTimeout             = 100 * time.Millisecond
 )
 
+const (
+	// LxcfsStateStart is reported when lxcfs has been started.
+	LxcfsStateStart = "start"
+	// LxcfsStateStop is reported when lxcfs has stopped.
+	LxcfsStateStop = "stop"
+	// LxcfsStateHealthy is reported when lxcfs answers the health
+	// protocol again after it failed to.
+	LxcfsStateHealthy = "healthy"
+	// LxcfsStateUnhealthy is reported when lxcfs stops answering the
+	// health protocol.
+	LxcfsStateUnhealthy = "unhealthy"
+)
+
+// LxcfsBackend defines callbacks that the lxcfs remote makes.
+type LxcfsBackend interface {
+	// LxcfsRestarted is called after lxcfs crashed and a new instance
+	// with the given pid has been started.
+	LxcfsRestarted(pid int)
+	// LxcfsStateChanged is called when lxcfs starts or stops, and when
+	// it becomes healthy or unhealthy.
+	LxcfsStateChanged(state string, status LxcfsStatus)
+	// LxcfsHealthChecked is called after every health check.
+	LxcfsHealthChecked(status LxcfsStatus, err error)
+}
+
+// LxcfsStatus describes the lxcfs instance supervised by the remote.
+type LxcfsStatus struct {
+	Pid                int
+	StartedAt          time.Time
+	RestartCount       int
+	MountPath          string
+	Healthy            bool
+	HealthCheckLatency time.Duration
+}
+
 type LxcfsRemote struct {
 	sync.RWMutex
 	apiClient           containerd.APIClient
@@ -57,6 +94,12 @@ type LxcfsRemote struct {
 	daemonWaitCh         chan struct{}
 	liveRestore          bool
 	oomScore             int
+
+	backend              LxcfsBackend
+	restartCount         int
+	startedAt            time.Time
+	healthy              bool
+	healthCheckLatency   time.Duration
 }
 
 // New creates a fresh instance of libcontainerd remote.
@@ -91,6 +134,10 @@ func NewLxcfs(stateDir string, options ...RemoteOption) (_ *LxcfsRemote, err err
 		r.logPath = lxcfsLogDir
 	}
 
+	if r.mountPath == "" {
+		r.mountPath = lxcfsDefaultMountPath
+	}
+
 	fmt.Printf("yang test ... rpcaddr:%s, logpath:%s, debugLog:%d, allowOther:%d, offMultithread:%d\n", r.rpcAddr, r.logPath, r.debugLog, r.allowOther, r.offMultithread)
 	if err := r.runLxcfsDaemon(); err != nil {
 		return nil, fmt.Errorf("runLxcfsDaemon failed: %v", err)
@@ -122,6 +169,31 @@ func GetLxcfsDir() string {
 	return lxcfsStateDir
 }
 
+// MountPath returns the directory lxcfs mounts its FUSE filesystem on.
+func (r *LxcfsRemote) MountPath() string {
+	return r.mountPath
+}
+
+// CheckHealth returns an error if the lxcfs FUSE filesystem is not mounted
+// or lxcfs does not answer the health protocol.
+func (r *LxcfsRemote) CheckHealth() error {
+	mounted, err := mount.Mounted(filepath.Clean(r.mountPath))
+	if err != nil {
+		return fmt.Errorf("lxcfs: error checking mount path %s: %v", r.mountPath, err)
+	}
+	if !mounted {
+		return fmt.Errorf("lxcfs: %s is not mounted", r.mountPath)
+	}
+
+	r.connMutex.Lock()
+	err = r.healthCheck()
+	r.connMutex.Unlock()
+	if err != nil {
+		return fmt.Errorf("lxcfs: health check failed: %v", err)
+	}
+	return nil
+}
+
 func (r *LxcfsRemote) RemoveUmountLxcfs(target string) {
 	err := mount.ForceUnmount(target)
 	if err != nil {
@@ -136,12 +208,11 @@ func (r *LxcfsRemote) RemoveUmountLxcfs(target string) {
 func (r *LxcfsRemote) healthCheck() error {
 	buf, err := r.WriteRead([]byte(lxcfsHealthString))
 	if err != nil {
-		logrus.Errorf("Error to write read message because of %v", err.Error())
-		return nil
+		return err
 	}
 
-	if strings.Compare(string(buf), lxcfsHealthAckString) == 0 {
-		logrus.Warnf("Error to recv message because of %v", buf)
+	if ack := string(bytes.TrimRight(buf, "\x00")); ack != lxcfsHealthAckString {
+		return fmt.Errorf("unexpected health check reply %q", ack)
 	}
 
 	return nil
@@ -164,16 +235,15 @@ func (r *LxcfsRemote) WriteRead(writedata []byte)( []byte,  error) {
 
 	buf := make([]byte, lxcfsMaxBufLen)
 	conn.SetReadDeadline(time.Now().Add(lxcfsReadWriteTimeout))
-	_, err = conn.Read(buf)
+	n, err := conn.Read(buf)
 	if err != nil {
 		logrus.Errorf("Error to recv message because of %v", err.Error())
 		return nil, err
 	}
 
-	return buf, nil
+	return buf[:n], nil
 }
 
-
 //reloadLiveRestore
 func (r *LxcfsRemote) UpdateOptions(options ...RemoteOption) error {
 	for _, option := range options {
@@ -188,6 +258,9 @@ func (r *LxcfsRemote) Client(b Backend) (Client, error) {
 	return nil, nil
 }
 
+// handleLxcfsConnectionChange supervises lxcfs. It restarts lxcfs when the
+// process exits or stops answering the health protocol, and tells the
+// backend so that the fresh FUSE files can be mounted into the containers.
 func (r *LxcfsRemote) handleLxcfsConnectionChange() {
 	var transientFailureCount = 0
 
@@ -196,52 +269,180 @@ func (r *LxcfsRemote) handleLxcfsConnectionChange() {
 	for {
 		<-ticker.C
 
-		r.connMutex.Lock()
-		err := r.healthCheck()
-		r.connMutex.Unlock()
-		if err == nil {
+		if r.closeManually {
+			return
+		}
+
+		if r.lxcfsPid == -1 {
 			continue
 		}
 
-		logrus.Debugf("lxcfs: containerd health check returned error: %v", err)
+		exited := r.lxcfsExited()
+		if !exited {
+			r.connMutex.Lock()
+			start := time.Now()
+			err := r.healthCheck()
+			latency := time.Since(start)
+			r.connMutex.Unlock()
 
-		if r.lxcfsPid != -1 {
-			transientFailureCount++
-			if transientFailureCount >= maxLxcfsConnectionRetryCount {
-				fmt.Printf("yang test ...2222...\n");
+			r.setHealth(err == nil, latency, err)
+			if err == nil {
 				transientFailureCount = 0
-				if system.IsProcessAlive(r.lxcfsPid) {
-					 system.KillProcess(r.lxcfsPid)
-				}
-
-				if r.startByDocker == true {
-				    <-r.daemonWaitCh
-				}
-
-				r.connMutex.Lock()
-				if r.conn != nil {
-					r.conn.Close()
-					r.conn = nil
-				}
-				r.connMutex.Unlock()
-
-				if err = r.runLxcfsDaemon(); err != nil {
-					logrus.Errorf("lxcfs: error restarting containerd: %v", err)
-					continue
-				}
-
-				time.Sleep(500 * time.Millisecond)
-
-				r.connMutex.Lock()
-				err = r.LxcfsRemoteConnect(r.rpcAddr)
-				r.connMutex.Unlock()
-				if err != nil {
-					logrus.Errorf("lxcfs: LxcfsRemoteConnect error: %v", err)
-				}
 				continue
 			}
+
+			logrus.Debugf("lxcfs: health check returned error: %v", err)
+			transientFailureCount++
+			if transientFailureCount < maxLxcfsConnectionRetryCount {
+				continue
+			}
+		}
+		transientFailureCount = 0
+
+		if exited {
+			r.setHealth(false, 0, nil)
+			r.notifyStateChanged(LxcfsStateStop)
+		}
+
+		logrus.Warnf("lxcfs: lxcfs (%d) is not healthy (exited: %v), restarting it", r.lxcfsPid, exited)
+		if err := r.restartLxcfsDaemon(); err != nil {
+			logrus.Errorf("lxcfs: error restarting lxcfs: %v", err)
+		}
+	}
+}
+
+// lxcfsExited reports whether the lxcfs process has gone away.
+func (r *LxcfsRemote) lxcfsExited() bool {
+	if r.startByDocker {
+		select {
+		case <-r.daemonWaitCh:
+			return true
+		default:
+			return false
 		}
 	}
+	return !system.IsProcessAlive(r.lxcfsPid)
+}
+
+// restartLxcfsDaemon kills what is left of lxcfs, starts a new instance and
+// reconnects to it. The killed process must have exited first, otherwise the
+// new instance would not be started and the killed one would be adopted from
+// the pid file.
+func (r *LxcfsRemote) restartLxcfsDaemon() error {
+	if system.IsProcessAlive(r.lxcfsPid) {
+		system.KillProcess(r.lxcfsPid)
+	}
+
+	if r.startByDocker {
+		select {
+		case <-r.daemonWaitCh:
+		case <-time.After(lxcfsKillTimeout):
+			return fmt.Errorf("lxcfs (%d) didn't exit within %v after it was killed", r.lxcfsPid, lxcfsKillTimeout)
+		}
+	} else if err := waitLxcfsExit(r.lxcfsPid, lxcfsKillTimeout); err != nil {
+		return err
+	}
+
+	r.connMutex.Lock()
+	if r.conn != nil {
+		r.conn.Close()
+		r.conn = nil
+	}
+	r.connMutex.Unlock()
+
+	if err := r.runLxcfsDaemon(); err != nil {
+		return err
+	}
+
+	time.Sleep(500 * time.Millisecond)
+
+	r.connMutex.Lock()
+	err := r.LxcfsRemoteConnect(r.rpcAddr)
+	r.connMutex.Unlock()
+	if err != nil {
+		return err
+	}
+
+	r.Lock()
+	r.restartCount++
+	backend := r.backend
+	r.Unlock()
+
+	r.notifyStateChanged(LxcfsStateStart)
+	if backend != nil {
+		backend.LxcfsRestarted(r.lxcfsPid)
+	}
+	return nil
+}
+
+// waitLxcfsExit waits up to timeout for the lxcfs process pid, which the
+// daemon didn't start and can't wait for, to exit.
+func waitLxcfsExit(pid int, timeout time.Duration) error {
+	for start := time.Now(); system.IsProcessAlive(pid); time.Sleep(100 * time.Millisecond) {
+		if time.Since(start) > timeout {
+			return fmt.Errorf("lxcfs (%d) didn't exit within %v after it was killed", pid, timeout)
+		}
+	}
+	return nil
+}
+
+// Status returns the current status of lxcfs.
+func (r *LxcfsRemote) Status() LxcfsStatus {
+	r.RLock()
+	defer r.RUnlock()
+	return LxcfsStatus{
+		Pid:                r.lxcfsPid,
+		StartedAt:          r.startedAt,
+		RestartCount:       r.restartCount,
+		MountPath:          r.mountPath,
+		Healthy:            r.healthy,
+		HealthCheckLatency: r.healthCheckLatency,
+	}
+}
+
+// setHealth records the result of a health check and tells the backend
+// about it. err is nil for healthy results and for checks that could not be
+// made because lxcfs exited.
+func (r *LxcfsRemote) setHealth(healthy bool, latency time.Duration, err error) {
+	r.Lock()
+	changed := r.healthy != healthy
+	r.healthy = healthy
+	if latency != 0 {
+		r.healthCheckLatency = latency
+	}
+	backend := r.backend
+	r.Unlock()
+
+	if backend == nil {
+		return
+	}
+	if latency != 0 {
+		backend.LxcfsHealthChecked(r.Status(), err)
+	}
+	if changed {
+		if healthy {
+			backend.LxcfsStateChanged(LxcfsStateHealthy, r.Status())
+		} else {
+			backend.LxcfsStateChanged(LxcfsStateUnhealthy, r.Status())
+		}
+	}
+}
+
+func (r *LxcfsRemote) notifyStateChanged(state string) {
+	r.RLock()
+	backend := r.backend
+	r.RUnlock()
+
+	if backend != nil {
+		backend.LxcfsStateChanged(state, r.Status())
+	}
+}
+
+// SetBackend sets the backend that is notified when lxcfs is restarted.
+func (r *LxcfsRemote) SetBackend(b LxcfsBackend) {
+	r.Lock()
+	r.backend = b
+	r.Unlock()
 }
 
 func (r *LxcfsRemote) Cleanup() {
@@ -252,6 +453,7 @@ func (r *LxcfsRemote) Cleanup() {
 
 	r.RemoveUmountLxcfs(r.mountPath)
 	r.closeManually = true
+	defer r.notifyStateChanged(LxcfsStateStop)
 
 	r.connMutex.Lock()
 	if r.conn != nil {
@@ -305,8 +507,13 @@ func (r *LxcfsRemote) runLxcfsDaemon() error {
 		}
 		if system.IsProcessAlive(int(pid)) {
 			logrus.Infof("lxcfs: previous instance of lxcfs still alive (%d)", pid)
+			r.Lock()
 			r.lxcfsPid = int(pid)
 			r.startByDocker = false
+			if r.startedAt.IsZero() {
+				r.startedAt = time.Now()
+			}
+			r.Unlock()
 			return nil
 		}
 	}
@@ -346,7 +553,7 @@ func (r *LxcfsRemote) runLxcfsDaemon() error {
 	args = append(args, "-p")
 	args = append(args, filepath.Join(r.stateDir, lxcfsPidFilename))
 
-	if r.logPath {
+	if r.logPath != "" {
 		args = append(args, "-l")
 		args = append(args, r.logPath)
 	}
@@ -357,7 +564,7 @@ func (r *LxcfsRemote) runLxcfsDaemon() error {
 	args = append(args, "-L")
 	args = append(args, filepath.Join(r.stateDir, lxcfsSockFilename))
 
-	args = append(args, "/usr/local/var/lib/lxcfs/")
+	args = append(args, r.mountPath)
 
 	logrus.Debugf("lxcfs: runLxcfsDaemon Args: %s", args)
 
@@ -391,8 +598,11 @@ func (r *LxcfsRemote) runLxcfsDaemon() error {
 		close(r.daemonWaitCh)
 	}() // Reap our child when needed
 
+	r.Lock()
 	r.lxcfsPid = cmd.Process.Pid
 	r.startByDocker = true
+	r.startedAt = time.Now()
+	r.Unlock()
 	return nil
 }
 
@@ -0,0 +1,27 @@
+// +build !windows
+
+package libcontainerd
+
+import (
+	"os/exec"
+	"testing"
+	"time"
+)
+
+func TestWaitLxcfsExit(t *testing.T) {
+	cmd := exec.Command("sleep", "60")
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	pid := cmd.Process.Pid
+
+	if err := waitLxcfsExit(pid, 200*time.Millisecond); err == nil {
+		t.Fatal("expected an error while the process is alive")
+	}
+
+	cmd.Process.Kill()
+	go cmd.Wait()
+	if err := waitLxcfsExit(pid, lxcfsKillTimeout); err != nil {
+		t.Fatal(err)
+	}
+}
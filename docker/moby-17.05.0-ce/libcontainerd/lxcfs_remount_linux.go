@@ -0,0 +1,230 @@
+package libcontainerd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"strings"
+	"syscall"
+	"unsafe"
+
+	"github.com/docker/docker/pkg/reexec"
+	"golang.org/x/sys/unix"
+)
+
+// The new mount API lets a bind mount cloned in the host mount namespace be
+// attached inside the mount namespace of a container, which a plain bind
+// mount(2) refuses to do. It is available since Linux 5.2.
+const (
+	sysOpenTree          = 428
+	sysMoveMount         = 429
+	openTreeClone        = 0x1
+	moveMountFEmptyPath  = 0x4
+	lxcfsRemountCommand  = "docker-lxcfs-remount"
+	lxcfsFuseTypePrefix  = "fuse"
+	mountinfoSeparator   = " - "
+	mountinfoMountPoint  = 4
+	mountinfoMinFieldLen = 5
+)
+
+func init() {
+	reexec.Register(lxcfsRemountCommand, lxcfsRemountMain)
+}
+
+type lxcfsRemountOptions struct {
+	Pid       int
+	MountPath string
+	Files     []string
+}
+
+// LxcfsRemount replaces the lxcfs files of a previous lxcfs instance that
+// are mounted in the mount namespace of pid with the files of the current
+// one. Files that are not mounted from lxcfs in the container are left
+// alone. It returns the files that were remounted.
+func LxcfsRemount(pid int, mountPath string, files []string) ([]string, error) {
+	options := &lxcfsRemountOptions{
+		Pid:       pid,
+		MountPath: mountPath,
+		Files:     files,
+	}
+
+	cmd := reexec.Command(lxcfsRemountCommand)
+	w, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("lxcfs remount error on pipe creation: %v", err)
+	}
+
+	stdout := bytes.NewBuffer(nil)
+	stderr := bytes.NewBuffer(nil)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("lxcfs remount error on re-exec cmd: %v", err)
+	}
+	if err := json.NewEncoder(w).Encode(options); err != nil {
+		return nil, fmt.Errorf("lxcfs remount json encode to pipe failed: %v", err)
+	}
+	w.Close()
+
+	if err := cmd.Wait(); err != nil {
+		return nil, fmt.Errorf("lxcfs remount re-exec error: %v: output: %s", err, stderr)
+	}
+
+	var remounted []string
+	if err := json.NewDecoder(stdout).Decode(&remounted); err != nil {
+		return nil, fmt.Errorf("lxcfs remount json decode failed: %v", err)
+	}
+	return remounted, nil
+}
+
+// lxcfsRemountMain is the entry-point for docker-lxcfs-remount on re-exec.
+func lxcfsRemountMain() {
+	goruntime.LockOSThread()
+
+	var options *lxcfsRemountOptions
+	if err := json.NewDecoder(os.Stdin).Decode(&options); err != nil {
+		lxcfsRemountFatal(err)
+	}
+
+	remounted, err := lxcfsRemount(options)
+	if err != nil {
+		lxcfsRemountFatal(err)
+	}
+
+	if err := json.NewEncoder(os.Stdout).Encode(remounted); err != nil {
+		lxcfsRemountFatal(err)
+	}
+	os.Exit(0)
+}
+
+func lxcfsRemountFatal(err error) {
+	fmt.Fprint(os.Stderr, err)
+	os.Exit(1)
+}
+
+func lxcfsRemount(options *lxcfsRemountOptions) ([]string, error) {
+	f, err := os.Open(fmt.Sprintf("/proc/%d/mountinfo", options.Pid))
+	if err != nil {
+		return nil, err
+	}
+	stale, err := lxcfsMountPoints(f, options.Files)
+	f.Close()
+	if err != nil {
+		return nil, err
+	}
+	if len(stale) == 0 {
+		return []string{}, nil
+	}
+
+	// Clone the fresh lxcfs files while still in the host mount namespace.
+	trees := make(map[string]int)
+	for _, target := range stale {
+		source := filepath.Join(options.MountPath, target)
+		fd, err := openTree(source)
+		if err != nil {
+			return nil, fmt.Errorf("error cloning %s: %v", source, err)
+		}
+		trees[target] = fd
+	}
+
+	ns, err := os.Open(fmt.Sprintf("/proc/%d/ns/mnt", options.Pid))
+	if err != nil {
+		return nil, err
+	}
+	defer ns.Close()
+
+	// setns(2) refuses to change the mount namespace of a thread that
+	// shares its filesystem attributes with the other threads.
+	if err := unix.Unshare(unix.CLONE_FS); err != nil {
+		return nil, fmt.Errorf("error unsharing filesystem attributes: %v", err)
+	}
+	if err := unix.Setns(int(ns.Fd()), unix.CLONE_NEWNS); err != nil {
+		return nil, fmt.Errorf("error joining mount namespace of %d: %v", options.Pid, err)
+	}
+
+	remounted := []string{}
+	for _, target := range stale {
+		if err := unix.Unmount(target, unix.MNT_DETACH); err != nil && err != unix.EINVAL {
+			return remounted, fmt.Errorf("error unmounting %s: %v", target, err)
+		}
+		if err := moveMount(trees[target], target); err != nil {
+			return remounted, fmt.Errorf("error mounting %s: %v", target, err)
+		}
+		remounted = append(remounted, target)
+	}
+	return remounted, nil
+}
+
+// lxcfsMountPoints returns the files that are mounted from a FUSE
+// filesystem according to the mountinfo read from r.
+func lxcfsMountPoints(r io.Reader, files []string) ([]string, error) {
+	wanted := make(map[string]bool)
+	for _, f := range files {
+		wanted[f] = true
+	}
+
+	var mountPoints []string
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		// 36 35 0:42 /proc/meminfo /proc/meminfo rw,relatime - fuse.lxcfs lxcfs rw
+		text := s.Text()
+		sep := strings.Index(text, mountinfoSeparator)
+		if sep < 0 {
+			return nil, fmt.Errorf("error parsing mountinfo line %q", text)
+		}
+		fields := strings.Fields(text[:sep])
+		post := strings.Fields(text[sep+len(mountinfoSeparator):])
+		if len(fields) < mountinfoMinFieldLen || len(post) < 1 {
+			return nil, fmt.Errorf("error parsing mountinfo line %q", text)
+		}
+
+		mountPoint := fields[mountinfoMountPoint]
+		if wanted[mountPoint] && strings.HasPrefix(post[0], lxcfsFuseTypePrefix) {
+			mountPoints = append(mountPoints, mountPoint)
+			// Only remount a file once, even if it is stacked.
+			wanted[mountPoint] = false
+		}
+	}
+	return mountPoints, s.Err()
+}
+
+// fdCwd is AT_FDCWD, which as a negative constant cannot be converted to
+// uintptr directly.
+var fdCwd = unix.AT_FDCWD
+
+func openTree(path string) (int, error) {
+	p, err := syscall.BytePtrFromString(path)
+	if err != nil {
+		return -1, err
+	}
+	fd, _, errno := syscall.Syscall(sysOpenTree, uintptr(fdCwd), uintptr(unsafe.Pointer(p)), uintptr(openTreeClone|unix.O_CLOEXEC))
+	if errno != 0 {
+		if errno == syscall.ENOSYS {
+			return -1, fmt.Errorf("remounting lxcfs requires Linux 5.2 or later: %v", errno)
+		}
+		return -1, errno
+	}
+	return int(fd), nil
+}
+
+func moveMount(fd int, target string) error {
+	empty, err := syscall.BytePtrFromString("")
+	if err != nil {
+		return err
+	}
+	t, err := syscall.BytePtrFromString(target)
+	if err != nil {
+		return err
+	}
+	_, _, errno := syscall.Syscall6(sysMoveMount, uintptr(fd), uintptr(unsafe.Pointer(empty)), uintptr(fdCwd), uintptr(unsafe.Pointer(t)), moveMountFEmptyPath, 0)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package libcontainerd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const lxcfsMountinfoFixture = `127 103 0:44 / / rw,relatime - overlay overlay rw,lowerdir=/var/lib/docker/overlay2/l/ABC
+128 127 0:47 / /proc rw,nosuid,nodev,noexec,relatime - proc proc rw
+129 128 0:42 /proc/meminfo /proc/meminfo ro,nosuid,nodev,relatime - fuse.lxcfs lxcfs rw,user_id=0,group_id=0,allow_other
+130 128 0:42 /proc/stat /proc/stat ro,nosuid,nodev,relatime - fuse.lxcfs lxcfs rw,user_id=0,group_id=0,allow_other
+131 128 8:1 /custom/uptime /proc/uptime ro,relatime - ext4 /dev/sda1 rw,errors=remount-ro
+132 128 0:42 /proc/meminfo /proc/meminfo ro,nosuid,nodev,relatime - fuse.lxcfs lxcfs rw,user_id=0,group_id=0,allow_other
+`
+
+func TestLxcfsMountPoints(t *testing.T) {
+	files := []string{"/proc/meminfo", "/proc/stat", "/proc/uptime", "/proc/cpuinfo"}
+	mountPoints, err := lxcfsMountPoints(strings.NewReader(lxcfsMountinfoFixture), files)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"/proc/meminfo", "/proc/stat"}
+	if !reflect.DeepEqual(mountPoints, expected) {
+		t.Fatalf("expected %v, got %v", expected, mountPoints)
+	}
+}
+
+func TestLxcfsMountPointsInvalid(t *testing.T) {
+	if _, err := lxcfsMountPoints(strings.NewReader("129 128 0:42 /proc/meminfo\n"), []string{"/proc/meminfo"}); err == nil {
+		t.Fatal("expected an error for a mountinfo line without separator")
+	}
+}
@@ -0,0 +1,212 @@
+package libcontainerd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"strings"
+
+	"github.com/docker/docker/pkg/reexec"
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+	"golang.org/x/sys/unix"
+)
+
+// lxcfs renders its /proc files for the cgroups and the pid namespace of the
+// process reading them. The view of a container is read by a process which
+// joins the cgroups of the container and is started in its pid namespace.
+const (
+	lxcfsViewCommand     = "docker-lxcfs-view"
+	lxcfsViewReadCommand = "docker-lxcfs-view-read"
+)
+
+// lxcfsViewFiles are the /proc files of the view of a container.
+var lxcfsViewFiles = []string{
+	"/proc/meminfo",
+	"/proc/cpuinfo",
+	"/proc/stat",
+	"/proc/uptime",
+	"/proc/diskstats",
+}
+
+func init() {
+	reexec.Register(lxcfsViewCommand, lxcfsViewMain)
+	reexec.Register(lxcfsViewReadCommand, lxcfsViewReadMain)
+}
+
+type lxcfsViewOptions struct {
+	Pid       int
+	MountPath string
+	Files     []string
+}
+
+// LxcfsProcFiles returns the lxcfs virtualized /proc files (keyed by their
+// path, e.g. "/proc/meminfo") as they are rendered for the cgroups of pid.
+func (r *LxcfsRemote) LxcfsProcFiles(pid int) (map[string]string, error) {
+	return lxcfsView(lxcfsViewCommand, &lxcfsViewOptions{
+		Pid:       pid,
+		MountPath: r.mountPath,
+		Files:     lxcfsViewFiles,
+	})
+}
+
+// lxcfsView runs the re-exec command with options and returns the files it
+// read. The files are the whole output of the command.
+func lxcfsView(command string, options *lxcfsViewOptions) (map[string]string, error) {
+	cmd := reexec.Command(command)
+	w, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("lxcfs view error on pipe creation: %v", err)
+	}
+
+	stdout := bytes.NewBuffer(nil)
+	stderr := bytes.NewBuffer(nil)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("lxcfs view error on re-exec cmd: %v", err)
+	}
+	if err := json.NewEncoder(w).Encode(options); err != nil {
+		return nil, fmt.Errorf("lxcfs view json encode to pipe failed: %v", err)
+	}
+	w.Close()
+
+	if err := cmd.Wait(); err != nil {
+		return nil, fmt.Errorf("lxcfs view re-exec error: %v: output: %s", err, stderr)
+	}
+
+	files := make(map[string]string)
+	if err := json.Unmarshal(stdout.Bytes(), &files); err != nil {
+		return nil, fmt.Errorf("lxcfs view json decode failed: %v", err)
+	}
+	return files, nil
+}
+
+// lxcfsViewMain is the entry-point for docker-lxcfs-view on re-exec. It joins
+// the cgroups and the pid namespace of the container, and starts
+// docker-lxcfs-view-read there.
+func lxcfsViewMain() {
+	// The pid namespace of the children is the one of the thread starting
+	// them.
+	goruntime.LockOSThread()
+
+	var options *lxcfsViewOptions
+	if err := json.NewDecoder(os.Stdin).Decode(&options); err != nil {
+		lxcfsViewFatal(err)
+	}
+
+	if err := joinCgroups(options.Pid); err != nil {
+		lxcfsViewFatal(err)
+	}
+	ns, err := os.Open(fmt.Sprintf("/proc/%d/ns/pid", options.Pid))
+	if err != nil {
+		lxcfsViewFatal(err)
+	}
+	if err := unix.Setns(int(ns.Fd()), unix.CLONE_NEWPID); err != nil {
+		lxcfsViewFatal(fmt.Errorf("error joining pid namespace of %d: %v", options.Pid, err))
+	}
+	ns.Close()
+
+	files, err := lxcfsView(lxcfsViewReadCommand, options)
+	if err != nil {
+		lxcfsViewFatal(err)
+	}
+	if err := json.NewEncoder(os.Stdout).Encode(files); err != nil {
+		lxcfsViewFatal(err)
+	}
+	os.Exit(0)
+}
+
+// lxcfsViewReadMain is the entry-point for docker-lxcfs-view-read on
+// re-exec. It reads the lxcfs files.
+func lxcfsViewReadMain() {
+	var options *lxcfsViewOptions
+	if err := json.NewDecoder(os.Stdin).Decode(&options); err != nil {
+		lxcfsViewFatal(err)
+	}
+
+	files, err := readLxcfsFiles(options.MountPath, options.Files)
+	if err != nil {
+		lxcfsViewFatal(err)
+	}
+	if err := json.NewEncoder(os.Stdout).Encode(files); err != nil {
+		lxcfsViewFatal(err)
+	}
+	os.Exit(0)
+}
+
+// readLxcfsFiles reads the files from the lxcfs filesystem mounted at
+// mountPath.
+func readLxcfsFiles(mountPath string, files []string) (map[string]string, error) {
+	content := make(map[string]string)
+	for _, f := range files {
+		b, err := ioutil.ReadFile(filepath.Join(mountPath, f))
+		if err != nil {
+			return nil, err
+		}
+		content[f] = string(b)
+	}
+	return content, nil
+}
+
+// joinCgroups moves the current process to the cgroups of pid.
+func joinCgroups(pid int) error {
+	paths, err := cgroups.ParseCgroupFile(fmt.Sprintf("/proc/%d/cgroup", pid))
+	if err != nil {
+		return err
+	}
+	dirs, err := cgroupDirs(paths)
+	if err != nil {
+		return err
+	}
+	for _, dir := range dirs {
+		if err := cgroups.WriteCgroupProc(dir, os.Getpid()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cgroupDirs returns the directories of the cgroups paths, keyed by their
+// subsystem, once per hierarchy.
+func cgroupDirs(paths map[string]string) ([]string, error) {
+	seen := make(map[string]bool)
+	var dirs []string
+	for subsystem, path := range paths {
+		var dir string
+		if subsystem == "" {
+			// The unified hierarchy is only joined when it has all the
+			// controllers.
+			if !cgroups.IsCgroup2UnifiedMode() {
+				continue
+			}
+			dir = filepath.Join(cgroups.UnifiedMountpoint, path)
+		} else {
+			mnt, root, err := cgroups.FindCgroupMountpointAndRoot(subsystem)
+			if err != nil {
+				if cgroups.IsNotFound(err) {
+					continue
+				}
+				return nil, err
+			}
+			if root != "/" {
+				path = strings.TrimPrefix(path, root)
+			}
+			dir = filepath.Join(mnt, path)
+		}
+		if !seen[dir] {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs, nil
+}
+
+func lxcfsViewFatal(err error) {
+	fmt.Fprint(os.Stderr, err)
+	os.Exit(1)
+}
@@ -0,0 +1,62 @@
+package libcontainerd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/pkg/reexec"
+)
+
+func init() {
+	reexec.Init()
+}
+
+func TestLxcfsView(t *testing.T) {
+	if os.Getuid() != 0 {
+		t.Skip("joining cgroups and namespaces requires root")
+	}
+	tmp, err := ioutil.TempDir("", "lxcfs-view-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	expected := map[string]string{
+		"/proc/meminfo": "MemTotal:       1048576 kB\n",
+		"/proc/uptime":  "42.00 42.00\n",
+	}
+	for f, content := range expected {
+		p := filepath.Join(tmp, f)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The view of the test process itself is read, from its own cgroups and
+	// pid namespace.
+	files, err := lxcfsView(lxcfsViewCommand, &lxcfsViewOptions{
+		Pid:       os.Getpid(),
+		MountPath: tmp,
+		Files:     []string{"/proc/meminfo", "/proc/uptime"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(files, expected) {
+		t.Fatalf("expected %v, got %v", expected, files)
+	}
+
+	if _, err := lxcfsView(lxcfsViewCommand, &lxcfsViewOptions{
+		Pid:       os.Getpid(),
+		MountPath: tmp,
+		Files:     []string{"/proc/stat"},
+	}); err == nil {
+		t.Fatal("expected an error for a missing file")
+	}
+}
@@ -0,0 +1,159 @@
+package archive
+
+import (
+	"archive/tar"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/docker/docker/pkg/ioutils"
+)
+
+// reproducibleEntry is an entry of a tar archive, whose content is stored at
+// offset in a temporary file.
+type reproducibleEntry struct {
+	hdr    *tar.Header
+	offset int64
+}
+
+type reproducibleEntries []*reproducibleEntry
+
+func (e reproducibleEntries) Len() int           { return len(e) }
+func (e reproducibleEntries) Swap(i, j int)      { e[i], e[j] = e[j], e[i] }
+func (e reproducibleEntries) Less(i, j int) bool { return e[i].hdr.Name < e[j].hdr.Name }
+
+// ReproducibleTar reads the tar archive r, and returns a tar archive with the
+// same files that doesn't depend on when, by whom, or in which order the files
+// were written. The modification times of the files are clamped to epoch,
+// access and change times are dropped, and so are user and group names, only
+// their IDs are kept. The entries are sorted by name, so that the parent
+// directories of files still come first. The content of a group of hard links
+// is in the entry that comes first, and the other entries link to it.
+//
+// r is read completely before ReproducibleTar returns, with the content of the
+// files buffered in a temporary file until the returned archive is closed.
+func ReproducibleTar(r io.Reader, epoch time.Time) (io.ReadCloser, error) {
+	f, err := ioutil.TempFile("", "docker-reproducible-tar")
+	if err != nil {
+		return nil, err
+	}
+	cleanup := func() {
+		f.Close()
+		os.Remove(f.Name())
+	}
+
+	entries, err := readReproducibleEntries(r, f)
+	if err != nil {
+		cleanup()
+		return nil, err
+	}
+	for _, e := range entries {
+		normalizeHeader(e.hdr, epoch)
+	}
+	sort.Stable(entries)
+	relinkHardlinks(entries)
+
+	pr, pw := io.Pipe()
+	go func() {
+		defer cleanup()
+		tw := tar.NewWriter(pw)
+		for _, e := range entries {
+			if err := tw.WriteHeader(e.hdr); err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+			if e.hdr.Typeflag == tar.TypeReg || e.hdr.Typeflag == tar.TypeRegA {
+				if _, err := io.Copy(tw, io.NewSectionReader(f, e.offset, e.hdr.Size)); err != nil {
+					pw.CloseWithError(err)
+					return
+				}
+			}
+		}
+		pw.CloseWithError(tw.Close())
+	}()
+	return ioutils.NewReadCloserWrapper(pr, pr.Close), nil
+}
+
+// readReproducibleEntries reads the entries of the tar archive r, and writes
+// the content of its files to f.
+func readReproducibleEntries(r io.Reader, f *os.File) (reproducibleEntries, error) {
+	var (
+		entries reproducibleEntries
+		offset  int64
+	)
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		n, err := io.Copy(f, tr)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, &reproducibleEntry{hdr: hdr, offset: offset})
+		offset += n
+	}
+}
+
+func normalizeHeader(hdr *tar.Header, epoch time.Time) {
+	hdr.ModTime = hdr.ModTime.Truncate(time.Second)
+	if hdr.ModTime.After(epoch) {
+		hdr.ModTime = epoch
+	}
+	hdr.AccessTime = time.Time{}
+	hdr.ChangeTime = time.Time{}
+	hdr.Uname = ""
+	hdr.Gname = ""
+}
+
+// relinkHardlinks moves the content of each group of hard links in the sorted
+// entries to the entry of the group that comes first, as an entry can only
+// link to one that comes before it. Links to files that are not in the
+// archive, such as those of lower layers, are kept.
+func relinkHardlinks(entries reproducibleEntries) {
+	files := make(map[string]*reproducibleEntry)
+	for _, e := range entries {
+		if e.hdr.Typeflag == tar.TypeReg || e.hdr.Typeflag == tar.TypeRegA {
+			files[e.hdr.Name] = e
+		}
+	}
+
+	// The name of the entry with the content of each group of links, by the
+	// name of the file that the group links to.
+	first := make(map[string]string)
+	for _, e := range entries {
+		if e.hdr.Typeflag != tar.TypeLink {
+			continue
+		}
+		target, ok := files[e.hdr.Linkname]
+		if !ok {
+			continue
+		}
+		name, ok := first[target.hdr.Name]
+		if !ok {
+			name = e.hdr.Name
+			if target.hdr.Name < name {
+				name = target.hdr.Name
+			}
+			first[target.hdr.Name] = name
+		}
+		if name == e.hdr.Name {
+			// The link comes before the file, so it takes the content.
+			hdr := *target.hdr
+			hdr.Name = e.hdr.Name
+			e.hdr, e.offset = &hdr, target.offset
+
+			target.hdr.Typeflag = tar.TypeLink
+			target.hdr.Linkname = name
+			target.hdr.Size = 0
+			continue
+		}
+		e.hdr.Linkname = name
+	}
+}
@@ -0,0 +1,116 @@
+package archive
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+)
+
+type testTarEntry struct {
+	hdr     tar.Header
+	content string
+}
+
+func writeTestTar(t *testing.T, entries []testTarEntry) []byte {
+	buf := new(bytes.Buffer)
+	tw := tar.NewWriter(buf)
+	for _, e := range entries {
+		hdr := e.hdr
+		hdr.Size = int64(len(e.content))
+		if err := tw.WriteHeader(&hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(e.content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func reproducibleTar(t *testing.T, archive []byte, epoch time.Time) []byte {
+	rc, err := ReproducibleTar(bytes.NewReader(archive), epoch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+	out, err := ioutil.ReadAll(rc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func TestReproducibleTar(t *testing.T) {
+	epoch := time.Unix(1500000000, 0)
+	before := epoch.Add(-time.Hour)
+	after := epoch.Add(time.Hour + time.Millisecond)
+
+	archive := writeTestTar(t, []testTarEntry{
+		{hdr: tar.Header{Name: "b/", Typeflag: tar.TypeDir, Mode: 0755, ModTime: after}},
+		{hdr: tar.Header{Name: "b/file", Typeflag: tar.TypeReg, Mode: 0644, ModTime: before, Uname: "root"}, content: "hello"},
+		{hdr: tar.Header{Name: "a", Typeflag: tar.TypeLink, Linkname: "b/file", ModTime: before}},
+		{hdr: tar.Header{Name: "c", Typeflag: tar.TypeSymlink, Linkname: "b/file", ModTime: after}},
+		{hdr: tar.Header{Name: "d", Typeflag: tar.TypeLink, Linkname: "lower/file", ModTime: after}},
+	})
+
+	tr := tar.NewReader(bytes.NewReader(reproducibleTar(t, archive, epoch)))
+	expected := []struct {
+		name     string
+		typeflag byte
+		linkname string
+		content  string
+		modTime  time.Time
+	}{
+		{"a", tar.TypeReg, "", "hello", before},
+		{"b/", tar.TypeDir, "", "", epoch},
+		{"b/file", tar.TypeLink, "a", "", before},
+		{"c", tar.TypeSymlink, "b/file", "", epoch},
+		{"d", tar.TypeLink, "lower/file", "", epoch},
+	}
+	for _, e := range expected {
+		hdr, err := tr.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := ioutil.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hdr.Name != e.name || hdr.Typeflag != e.typeflag || hdr.Linkname != e.linkname || string(content) != e.content {
+			t.Fatalf("Expected %s (%c) -> %q with %q, got %s (%c) -> %q with %q", e.name, e.typeflag, e.linkname, e.content, hdr.Name, hdr.Typeflag, hdr.Linkname, content)
+		}
+		if !hdr.ModTime.Equal(e.modTime) {
+			t.Fatalf("Expected %s to be modified at %s, got %s", e.name, e.modTime, hdr.ModTime)
+		}
+		if hdr.Uname != "" || hdr.Gname != "" {
+			t.Fatalf("Expected no user and group names for %s, got %q and %q", e.name, hdr.Uname, hdr.Gname)
+		}
+	}
+	if _, err := tr.Next(); err != io.EOF {
+		t.Fatalf("Expected end of archive, got %v", err)
+	}
+}
+
+func TestReproducibleTarOrder(t *testing.T) {
+	epoch := time.Unix(1500000000, 0)
+	first := writeTestTar(t, []testTarEntry{
+		{hdr: tar.Header{Name: "x", Typeflag: tar.TypeReg, ModTime: time.Now()}, content: "x"},
+		{hdr: tar.Header{Name: "y", Typeflag: tar.TypeReg, ModTime: time.Now()}, content: "y"},
+	})
+	second := writeTestTar(t, []testTarEntry{
+		{hdr: tar.Header{Name: "y", Typeflag: tar.TypeReg, ModTime: time.Now().Add(time.Minute)}, content: "y"},
+		{hdr: tar.Header{Name: "x", Typeflag: tar.TypeReg, ModTime: time.Now().Add(time.Minute)}, content: "x"},
+	})
+	if bytes.Equal(first, second) {
+		t.Fatal("Expected the archives to differ")
+	}
+	if !bytes.Equal(reproducibleTar(t, first, epoch), reproducibleTar(t, second, epoch)) {
+		t.Fatal("Expected the reproducible archives to be the same")
+	}
+}
@@ -0,0 +1,58 @@
+// Package rootless runs the daemon as an unprivileged user. The daemon is
+// started again in new user, mount and network namespaces, where the user is
+// root, and its network namespace is connected to the host by slirp4netns.
+package rootless
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/docker/docker/pkg/homedir"
+)
+
+// childEnv is set in the environment of the daemon started by Run.
+const childEnv = "_DOCKERD_ROOTLESS_CHILD"
+
+// RunningInChild returns whether the process is the daemon started by Run in
+// the user namespace.
+func RunningInChild() bool {
+	return os.Getenv(childEnv) != ""
+}
+
+// DataHome returns the directory for the data of the user, which is
+// $XDG_DATA_HOME or ~/.local/share.
+func DataHome() (string, error) {
+	return xdgDir("XDG_DATA_HOME", ".local", "share")
+}
+
+// ConfigHome returns the directory for the configuration of the user, which
+// is $XDG_CONFIG_HOME or ~/.config.
+func ConfigHome() (string, error) {
+	return xdgDir("XDG_CONFIG_HOME", ".config")
+}
+
+// RuntimeDir returns the directory for the runtime files of the user, which
+// is $XDG_RUNTIME_DIR.
+func RuntimeDir() (string, error) {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return dir, nil
+	}
+	return "", errors.New("XDG_RUNTIME_DIR needs to be set in rootless mode")
+}
+
+func xdgDir(env string, elem ...string) (string, error) {
+	if dir := os.Getenv(env); dir != "" {
+		return dir, nil
+	}
+	home := homedir.Get()
+	if home == "" {
+		return "", errors.New("could not determine the home directory of the user")
+	}
+	return filepath.Join(append([]string{home}, elem...)...), nil
+}
+
+// APISocket returns the path of the API socket of slirp4netns in stateDir.
+func APISocket(stateDir string) string {
+	return filepath.Join(stateDir, "slirp4netns.sock")
+}
@@ -0,0 +1,155 @@
+package rootless
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strconv"
+	"syscall"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/pkg/idtools"
+	"github.com/docker/docker/pkg/mount"
+)
+
+// syncFd is the file descriptor of the pipe on which the daemon started by
+// Run waits until its namespaces are set up.
+const syncFd = 3
+
+// Run starts the current executable again, with the same arguments, in new
+// user, mount and network namespaces. The user is root in the user namespace,
+// and the IDs from 1 up are mapped to its ranges in /etc/subuid and
+// /etc/subgid. The network namespace is connected to the host by slirp4netns,
+// whose API socket is created in stateDir. Run returns when the daemon exits.
+func Run(stateDir string) error {
+	usr, err := idtools.LookupUID(os.Getuid())
+	if err != nil {
+		return fmt.Errorf("failed to look up the current user: %v", err)
+	}
+	uidMaps, gidMaps, err := idtools.CreateIDMappings(usr.Name, usr.Name)
+	if err != nil {
+		return fmt.Errorf("rootless mode requires subordinate ID ranges for user %s: %v", usr.Name, err)
+	}
+	if err := os.MkdirAll(stateDir, 0700); err != nil {
+		return err
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+
+	cmd := exec.Command("/proc/self/exe", os.Args[1:]...)
+	cmd.Args[0] = os.Args[0]
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), childEnv+"=1")
+	cmd.ExtraFiles = []*os.File{r}
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Cloneflags: syscall.CLONE_NEWUSER | syscall.CLONE_NEWNS | syscall.CLONE_NEWNET,
+		Pdeathsig:  syscall.SIGKILL,
+	}
+	if err := cmd.Start(); err != nil {
+		r.Close()
+		return fmt.Errorf("failed to start the daemon in a user namespace: %v", err)
+	}
+	r.Close()
+
+	slirp, err := setupChild(cmd.Process.Pid, os.Getuid(), os.Getgid(), uidMaps, gidMaps, stateDir)
+	if err != nil {
+		cmd.Process.Kill()
+		cmd.Wait()
+		return err
+	}
+	defer func() {
+		slirp.Process.Kill()
+		slirp.Wait()
+		os.Remove(APISocket(stateDir))
+	}()
+
+	// Let the daemon go on.
+	if _, err := w.Write([]byte{0}); err != nil {
+		cmd.Process.Kill()
+		cmd.Wait()
+		return err
+	}
+
+	sigc := make(chan os.Signal, 1)
+	signal.Notify(sigc, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT, syscall.SIGHUP, syscall.SIGUSR1, syscall.SIGUSR2)
+	defer signal.Stop(sigc)
+	go func() {
+		for sig := range sigc {
+			cmd.Process.Signal(sig)
+		}
+	}()
+	return cmd.Wait()
+}
+
+// setupChild maps the IDs of the user namespace of the process pid, and
+// starts slirp4netns for its network namespace.
+func setupChild(pid, uid, gid int, uidMaps, gidMaps []idtools.IDMap, stateDir string) (*exec.Cmd, error) {
+	if out, err := exec.Command("newuidmap", mapArgs(pid, uid, uidMaps)...).CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("failed to map the user IDs: %v: %s", err, out)
+	}
+	if out, err := exec.Command("newgidmap", mapArgs(pid, gid, gidMaps)...).CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("failed to map the group IDs: %v: %s", err, out)
+	}
+
+	socket := APISocket(stateDir)
+	if err := os.Remove(socket); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	ready, readyw, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	defer ready.Close()
+
+	slirp := exec.Command("slirp4netns",
+		"--configure", "--mtu=65520", "--disable-host-loopback",
+		"--api-socket", socket, "--ready-fd", "3",
+		strconv.Itoa(pid), "tap0")
+	slirp.ExtraFiles = []*os.File{readyw}
+	slirp.SysProcAttr = &syscall.SysProcAttr{Pdeathsig: syscall.SIGKILL}
+	if err := slirp.Start(); err != nil {
+		readyw.Close()
+		return nil, fmt.Errorf("failed to start slirp4netns: %v", err)
+	}
+	readyw.Close()
+	logrus.Debugf("Started slirp4netns for the network namespace of process %d", pid)
+
+	// slirp4netns writes "1" once the tap device is configured, and closes
+	// the pipe if it exits.
+	if _, err := ready.Read(make([]byte, 1)); err != nil {
+		slirp.Process.Kill()
+		slirp.Wait()
+		return nil, fmt.Errorf("slirp4netns failed to set up the network namespace: %v", err)
+	}
+	return slirp, nil
+}
+
+// mapArgs returns the arguments of newuidmap or newgidmap mapping id to root
+// in the user namespace of the process pid, and the subordinate IDs in maps
+// to the IDs from 1 up.
+func mapArgs(pid, id int, maps []idtools.IDMap) []string {
+	args := []string{strconv.Itoa(pid), "0", strconv.Itoa(id), "1"}
+	for _, m := range maps {
+		args = append(args, strconv.Itoa(m.ContainerID+1), strconv.Itoa(m.HostID), strconv.Itoa(m.Size))
+	}
+	return args
+}
+
+// InitChild waits until Run has set up the namespaces of the daemon, and
+// keeps the mounts of the daemon from propagating to the host. The daemon
+// must call it before it creates any file, as its IDs aren't mapped before.
+func InitChild() error {
+	f := os.NewFile(syncFd, "rootless-sync")
+	defer f.Close()
+	if _, err := f.Read(make([]byte, 1)); err != nil {
+		return fmt.Errorf("failed to wait for the user namespace to be set up: %v", err)
+	}
+	return mount.MakeRSlave("/")
+}
@@ -0,0 +1,19 @@
+package rootless
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/pkg/idtools"
+)
+
+func TestMapArgs(t *testing.T) {
+	maps := []idtools.IDMap{
+		{ContainerID: 0, HostID: 100000, Size: 65536},
+		{ContainerID: 65536, HostID: 300000, Size: 1000},
+	}
+	expected := []string{"42", "0", "1000", "1", "1", "100000", "65536", "65537", "300000", "1000"}
+	if args := mapArgs(42, 1000, maps); !reflect.DeepEqual(args, expected) {
+		t.Fatalf("Expected %v, got %v", expected, args)
+	}
+}
@@ -0,0 +1,100 @@
+package rootless
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestXDGDirs(t *testing.T) {
+	defer os.Setenv("HOME", os.Getenv("HOME"))
+	defer os.Setenv("XDG_DATA_HOME", os.Getenv("XDG_DATA_HOME"))
+	defer os.Setenv("XDG_RUNTIME_DIR", os.Getenv("XDG_RUNTIME_DIR"))
+
+	os.Setenv("HOME", "/home/user")
+	os.Setenv("XDG_DATA_HOME", "")
+	if dir, err := DataHome(); err != nil || dir != "/home/user/.local/share" {
+		t.Fatalf("Expected /home/user/.local/share, got %q (%v)", dir, err)
+	}
+	os.Setenv("XDG_DATA_HOME", "/data")
+	if dir, err := DataHome(); err != nil || dir != "/data" {
+		t.Fatalf("Expected /data, got %q (%v)", dir, err)
+	}
+
+	os.Setenv("XDG_RUNTIME_DIR", "")
+	if _, err := RuntimeDir(); err == nil {
+		t.Fatal("Expected an error without XDG_RUNTIME_DIR")
+	}
+	os.Setenv("XDG_RUNTIME_DIR", "/run/user/1000")
+	if dir, err := RuntimeDir(); err != nil || dir != "/run/user/1000" {
+		t.Fatalf("Expected /run/user/1000, got %q (%v)", dir, err)
+	}
+}
+
+// serveSlirpAPI answers the requests on the API socket in dir with reply, and
+// sends the requests on the returned channel.
+func serveSlirpAPI(t *testing.T, dir string, reply string) <-chan map[string]interface{} {
+	l, err := net.Listen("unix", APISocket(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	reqs := make(chan map[string]interface{}, 1)
+	go func() {
+		defer l.Close()
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		b, _ := ioutil.ReadAll(conn)
+		var req map[string]interface{}
+		json.Unmarshal(b, &req)
+		conn.Write([]byte(reply))
+		reqs <- req
+	}()
+	return reqs
+}
+
+func TestPortForwarderAdd(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rootless-slirp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	reqs := serveSlirpAPI(t, dir, `{"return": {"id": 7}}`)
+	id, err := NewPortForwarder(dir).Add("tcp", "", 8080)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != 7 {
+		t.Fatalf("Expected forwarding 7, got %d", id)
+	}
+
+	req := <-reqs
+	args, _ := req["arguments"].(map[string]interface{})
+	if req["execute"] != "add_hostfwd" || args["host_addr"] != "0.0.0.0" || args["host_port"] != 8080.0 ||
+		args["guest_addr"] != guestAddr || args["guest_port"] != 8080.0 || args["proto"] != "tcp" {
+		t.Fatalf("Unexpected request %v", req)
+	}
+}
+
+func TestPortForwarderError(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rootless-slirp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	serveSlirpAPI(t, dir, `{"error": {"desc": "bad request"}}`)
+	if err := NewPortForwarder(dir).Remove(7); err == nil || err.Error() != "failed to remove port forwarding 7: bad request" {
+		t.Fatalf("Expected the error of slirp4netns, got %v", err)
+	}
+
+	if _, err := NewPortForwarder(filepath.Join(dir, "missing")).Add("udp", "127.0.0.1", 53); err == nil {
+		t.Fatal("Expected an error without slirp4netns")
+	}
+}
@@ -0,0 +1,15 @@
+// +build !linux
+
+package rootless
+
+import "errors"
+
+// Run is not supported on this platform.
+func Run(stateDir string) error {
+	return errors.New("rootless mode is only supported on Linux")
+}
+
+// InitChild is not supported on this platform.
+func InitChild() error {
+	return errors.New("rootless mode is only supported on Linux")
+}
@@ -0,0 +1,108 @@
+package rootless
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+)
+
+// guestAddr is the address of the network namespace of the daemon on the tap
+// device configured by slirp4netns.
+const guestAddr = "10.0.2.100"
+
+// PortForwarder publishes ports of the network namespace of the daemon on the
+// host, through the API socket of slirp4netns.
+type PortForwarder struct {
+	socket string
+}
+
+// NewPortForwarder returns a PortForwarder using the API socket of
+// slirp4netns in stateDir.
+func NewPortForwarder(stateDir string) *PortForwarder {
+	return &PortForwarder{socket: APISocket(stateDir)}
+}
+
+type slirpRequest struct {
+	Execute   string      `json:"execute"`
+	Arguments interface{} `json:"arguments,omitempty"`
+}
+
+type slirpHostFwd struct {
+	Proto     string `json:"proto,omitempty"`
+	HostAddr  string `json:"host_addr,omitempty"`
+	HostPort  int    `json:"host_port,omitempty"`
+	GuestAddr string `json:"guest_addr,omitempty"`
+	GuestPort int    `json:"guest_port,omitempty"`
+	ID        int    `json:"id,omitempty"`
+}
+
+type slirpResponse struct {
+	Return *slirpHostFwd `json:"return,omitempty"`
+	Error  *struct {
+		Desc string `json:"desc"`
+	} `json:"error,omitempty"`
+}
+
+// Add forwards the port of the host on hostIP to the same port in the
+// network namespace of the daemon, and returns the ID of the forwarding.
+func (p *PortForwarder) Add(proto, hostIP string, port int) (int, error) {
+	if hostIP == "" {
+		hostIP = "0.0.0.0"
+	}
+	resp, err := p.call(slirpRequest{
+		Execute: "add_hostfwd",
+		Arguments: slirpHostFwd{
+			Proto:     proto,
+			HostAddr:  hostIP,
+			HostPort:  port,
+			GuestAddr: guestAddr,
+			GuestPort: port,
+		},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to forward %s port %s:%d: %v", proto, hostIP, port, err)
+	}
+	if resp.Return == nil {
+		return 0, fmt.Errorf("failed to forward %s port %s:%d: no forwarding ID returned", proto, hostIP, port)
+	}
+	return resp.Return.ID, nil
+}
+
+// Remove removes the forwarding with the given ID.
+func (p *PortForwarder) Remove(id int) error {
+	if _, err := p.call(slirpRequest{Execute: "remove_hostfwd", Arguments: slirpHostFwd{ID: id}}); err != nil {
+		return fmt.Errorf("failed to remove port forwarding %d: %v", id, err)
+	}
+	return nil
+}
+
+func (p *PortForwarder) call(req slirpRequest) (*slirpResponse, error) {
+	conn, err := net.DialUnix("unix", nil, &net.UnixAddr{Name: p.socket, Net: "unix"})
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return nil, err
+	}
+	// slirp4netns handles a single request per connection, which ends when
+	// the write side is closed.
+	if err := conn.CloseWrite(); err != nil {
+		return nil, err
+	}
+	b, err := ioutil.ReadAll(conn)
+	if err != nil {
+		return nil, err
+	}
+	var resp slirpResponse
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, fmt.Errorf("invalid response %q: %v", b, err)
+	}
+	if resp.Error != nil {
+		return nil, errors.New(resp.Error.Desc)
+	}
+	return &resp, nil
+}
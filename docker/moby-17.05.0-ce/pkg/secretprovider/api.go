@@ -0,0 +1,41 @@
+package secretprovider
+
+const (
+	// SecretProviderAPIImplements is the name of the interface all secret
+	// provider plugins implement
+	SecretProviderAPIImplements = "secretprovider"
+
+	// SecretProviderAPIGetSecret is the url for fetching the value of a secret
+	SecretProviderAPIGetSecret = "SecretProvider.GetSecret"
+)
+
+// Request holds the information about the secret and the task that needs
+// it, which is passed to the secret provider plugin.
+type Request struct {
+	// SecretName is the name of the secret in the swarm
+	SecretName string `json:",omitempty"`
+
+	// SecretID is the ID of the secret in the swarm
+	SecretID string `json:",omitempty"`
+
+	// SecretLabels are the labels of the secret
+	SecretLabels map[string]string `json:",omitempty"`
+
+	// ServiceName is the name of the service the task belongs to
+	ServiceName string `json:",omitempty"`
+
+	// ServiceID is the ID of the service the task belongs to
+	ServiceID string `json:",omitempty"`
+
+	// TaskID is the ID of the task that needs the secret
+	TaskID string `json:",omitempty"`
+}
+
+// Response represents the response of the secret provider plugin.
+type Response struct {
+	// Value is the value of the secret
+	Value []byte `json:",omitempty"`
+
+	// Err stores a message in case there's an error
+	Err string `json:",omitempty"`
+}
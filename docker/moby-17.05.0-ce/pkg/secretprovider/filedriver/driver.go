@@ -0,0 +1,63 @@
+// Package filedriver is a reference secret provider plugin that serves the
+// value of each secret from a file named after the secret in a directory.
+// It is meant for tests, the files are read every time a task starts so
+// that rotating a secret only requires the file to be replaced.
+package filedriver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+
+	"github.com/docker/docker/pkg/plugins"
+	"github.com/docker/docker/pkg/plugins/transport"
+	"github.com/docker/docker/pkg/secretprovider"
+)
+
+// Driver is a file backed secret provider.
+type Driver struct {
+	root string
+}
+
+// New returns a secret provider that serves secrets from the files in root.
+func New(root string) *Driver {
+	return &Driver{root: root}
+}
+
+// GetSecret returns the content of the file named after the requested secret.
+func (d *Driver) GetSecret(req *secretprovider.Request) ([]byte, error) {
+	name := filepath.Clean(req.SecretName)
+	if name == "" || name != filepath.Base(name) || name == "." || name == ".." {
+		return nil, fmt.Errorf("invalid secret name %q", req.SecretName)
+	}
+	return ioutil.ReadFile(filepath.Join(d.root, name))
+}
+
+// ServeHTTP implements the secret provider plugin protocol.
+func (d *Driver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/Plugin.Activate":
+		writeJSON(w, plugins.Manifest{Implements: []string{secretprovider.SecretProviderAPIImplements}})
+	case "/" + secretprovider.SecretProviderAPIGetSecret:
+		var req secretprovider.Request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeJSON(w, secretprovider.Response{Err: err.Error()})
+			return
+		}
+		value, err := d.GetSecret(&req)
+		if err != nil {
+			writeJSON(w, secretprovider.Response{Err: err.Error()})
+			return
+		}
+		writeJSON(w, secretprovider.Response{Value: value})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", transport.VersionMimetype)
+	json.NewEncoder(w).Encode(v)
+}
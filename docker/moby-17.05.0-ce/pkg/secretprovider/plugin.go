@@ -0,0 +1,79 @@
+package secretprovider
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/docker/docker/pkg/plugingetter"
+	"github.com/docker/docker/pkg/plugins"
+)
+
+// Plugin allows third party plugins to provide the value of swarm secrets
+// when a task starts, instead of the value being stored in the swarm.
+type Plugin interface {
+	// Name returns the registered plugin name
+	Name() string
+
+	// GetSecret returns the value of the secret described by the request
+	GetSecret(*Request) ([]byte, error)
+}
+
+// NewPlugin returns the secret provider plugin with the given name. The
+// plugin is looked up with pg, or with the legacy plugin discovery when pg
+// is nil, the first time it is used.
+func NewPlugin(name string, pg plugingetter.PluginGetter) Plugin {
+	return &secretProviderPlugin{name: name, getter: pg}
+}
+
+// secretProviderPlugin is an internal adapter to docker plugin system
+type secretProviderPlugin struct {
+	plugin *plugins.Client
+	getter plugingetter.PluginGetter
+	name   string
+	once   sync.Once
+}
+
+func (p *secretProviderPlugin) Name() string {
+	return p.name
+}
+
+func (p *secretProviderPlugin) GetSecret(req *Request) ([]byte, error) {
+	if err := p.initPlugin(); err != nil {
+		return nil, err
+	}
+
+	res := &Response{}
+	if err := p.plugin.Call(SecretProviderAPIGetSecret, req, res); err != nil {
+		return nil, err
+	}
+
+	if res.Err != "" {
+		return nil, errors.New(res.Err)
+	}
+
+	return res.Value, nil
+}
+
+// initPlugin initializes the secret provider plugin if needed
+func (p *secretProviderPlugin) initPlugin() error {
+	// Lazy loading of plugins
+	var err error
+	p.once.Do(func() {
+		if p.plugin == nil {
+			var plugin plugingetter.CompatPlugin
+			var e error
+
+			if p.getter != nil {
+				plugin, e = p.getter.Get(p.name, SecretProviderAPIImplements, plugingetter.Lookup)
+			} else {
+				plugin, e = plugins.Get(p.name, SecretProviderAPIImplements)
+			}
+			if e != nil {
+				err = e
+				return
+			}
+			p.plugin = plugin.Client()
+		}
+	})
+	return err
+}
@@ -0,0 +1,131 @@
+// +build !windows
+
+package secretprovider_test
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/docker/pkg/plugingetter"
+	"github.com/docker/docker/pkg/plugins"
+	"github.com/docker/docker/pkg/secretprovider"
+	"github.com/docker/docker/pkg/secretprovider/filedriver"
+	"github.com/docker/go-connections/tlsconfig"
+)
+
+// testPluginGetter returns the file driver served on a unix socket for any
+// secret provider lookup.
+type testPluginGetter struct {
+	client *plugins.Client
+}
+
+type testPlugin struct {
+	client *plugins.Client
+}
+
+func (p testPlugin) Client() *plugins.Client { return p.client }
+func (p testPlugin) Name() string            { return "file" }
+func (p testPlugin) BasePath() string        { return "" }
+func (p testPlugin) IsV1() bool              { return true }
+
+func (g testPluginGetter) Get(name, capability string, mode int) (plugingetter.CompatPlugin, error) {
+	if capability != secretprovider.SecretProviderAPIImplements {
+		return nil, plugins.ErrNotImplements
+	}
+	return testPlugin{client: g.client}, nil
+}
+
+func (g testPluginGetter) GetAllByCap(capability string) ([]plugingetter.CompatPlugin, error) {
+	return nil, nil
+}
+
+func (g testPluginGetter) GetAllManagedPluginsByCap(capability string) []plugingetter.CompatPlugin {
+	return nil
+}
+
+func (g testPluginGetter) Handle(capability string, callback func(string, *plugins.Client)) {}
+
+func startFileDriver(t *testing.T, root string) (*plugins.Client, func()) {
+	tmp, err := ioutil.TempDir("", "secretprovider-sock")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sock := filepath.Join(tmp, "file.sock")
+	l, err := net.Listen("unix", sock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := &httptest.Server{
+		Listener: l,
+		Config:   &http.Server{Handler: filedriver.New(root)},
+	}
+	server.Start()
+
+	client, err := plugins.NewClient("unix://"+sock, &tlsconfig.Options{InsecureSkipVerify: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, func() {
+		server.Close()
+		os.RemoveAll(tmp)
+	}
+}
+
+func TestGetSecretFromFileDriver(t *testing.T) {
+	root, err := ioutil.TempDir("", "secretprovider-root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	client, stop := startFileDriver(t, root)
+	defer stop()
+
+	if err := ioutil.WriteFile(filepath.Join(root, "npmrc"), []byte("token=1"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	p := secretprovider.NewPlugin("file", testPluginGetter{client: client})
+	value, err := p.GetSecret(&secretprovider.Request{SecretName: "npmrc", SecretID: "abc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(value) != "token=1" {
+		t.Fatalf("expected token=1, got %q", value)
+	}
+
+	// Rotating the secret only requires the file to change.
+	if err := ioutil.WriteFile(filepath.Join(root, "npmrc"), []byte("token=2"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	value, err = p.GetSecret(&secretprovider.Request{SecretName: "npmrc", SecretID: "abc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(value) != "token=2" {
+		t.Fatalf("expected token=2, got %q", value)
+	}
+}
+
+func TestGetSecretFromFileDriverErrors(t *testing.T) {
+	root, err := ioutil.TempDir("", "secretprovider-root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	client, stop := startFileDriver(t, root)
+	defer stop()
+
+	p := secretprovider.NewPlugin("file", testPluginGetter{client: client})
+	for _, name := range []string{"missing", "../etc/passwd", ""} {
+		if _, err := p.GetSecret(&secretprovider.Request{SecretName: name}); err == nil {
+			t.Fatalf("expected an error for secret %q", name)
+		}
+	}
+}
@@ -170,3 +170,29 @@ func (sf *StderrFormatter) Write(buf []byte) (int, error) {
 	}
 	return len(buf), err
 }
+
+// AuxFormatter is a streamFormatter that writes auxiliary information, which
+// is not presented to the user.
+type AuxFormatter struct {
+	io.Writer
+	*StreamFormatter
+}
+
+// Emit writes aux as the auxiliary information of a message. Nothing is
+// written if the stream is not formatted as JSON.
+func (sf *AuxFormatter) Emit(aux interface{}) error {
+	if !sf.json {
+		return nil
+	}
+	auxJSON, err := json.Marshal(aux)
+	if err != nil {
+		return err
+	}
+	raw := json.RawMessage(auxJSON)
+	b, err := json.Marshal(&jsonmessage.JSONMessage{Aux: &raw})
+	if err != nil {
+		return err
+	}
+	_, err = sf.Writer.Write(append(b, streamNewlineBytes...))
+	return err
+}
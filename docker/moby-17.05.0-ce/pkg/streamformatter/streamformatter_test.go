@@ -1,6 +1,7 @@
 package streamformatter
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"reflect"
@@ -106,3 +107,25 @@ func TestJSONFormatProgress(t *testing.T) {
 		t.Fatal("Original progress not equals progress from FormatProgress")
 	}
 }
+
+func TestJSONAuxFormatterEmit(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	aux := &AuxFormatter{Writer: buf, StreamFormatter: NewJSONStreamFormatter()}
+	if err := aux.Emit(map[string]string{"ID": "id"}); err != nil {
+		t.Fatal(err)
+	}
+	if expected := `{"aux":{"ID":"id"}}` + streamNewline; buf.String() != expected {
+		t.Fatalf("Aux message must be %q, got: %q", expected, buf.String())
+	}
+}
+
+func TestAuxFormatterEmit(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	aux := &AuxFormatter{Writer: buf, StreamFormatter: NewStreamFormatter()}
+	if err := aux.Emit(map[string]string{"ID": "id"}); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("Aux message must not be written without JSON, got: %q", buf.String())
+	}
+}
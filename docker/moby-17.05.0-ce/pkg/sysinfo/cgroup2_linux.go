@@ -0,0 +1,147 @@
+package sysinfo
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+	rsystem "github.com/opencontainers/runc/libcontainer/system"
+)
+
+// newV2 fills sysInfo from the controllers available in the unified (v2)
+// cgroup hierarchy. There is no devices controller in it, the device rules are
+// enforced with eBPF programs instead.
+func newV2(sysInfo *SysInfo, quiet bool) {
+	dir, err := cgroupDirV2()
+	if err != nil {
+		logrus.Warnf("Failed to parse cgroup information: %v", err)
+		return
+	}
+	controllers, err := controllersV2(dir)
+	if err != nil {
+		logrus.Warnf("Failed to parse cgroup information: %v", err)
+		return
+	}
+
+	if controllers["memory"] {
+		sysInfo.cgroupMemInfo = cgroupMemInfo{
+			MemoryLimit:       true,
+			SwapLimit:         swapLimitV2(),
+			MemoryReservation: true,
+			MemoryPressure:    memoryPressureV2(),
+		}
+		if !quiet && !sysInfo.SwapLimit {
+			logrus.Warn("Your kernel does not support swap memory limit")
+		}
+		if !quiet && !sysInfo.MemoryPressure {
+			logrus.Warn("Your kernel does not support memory pressure stall information")
+		}
+	} else if !quiet {
+		logrus.Warn("Your kernel does not support cgroup memory limit")
+	}
+
+	if controllers["cpu"] {
+		sysInfo.cgroupCPUInfo = cgroupCPUInfo{
+			CPUShares:    true,
+			CPUCfsPeriod: true,
+			CPUCfsQuota:  true,
+		}
+	} else if !quiet {
+		logrus.Warn("Unable to find cpu controller in the unified cgroup hierarchy")
+	}
+
+	if controllers["io"] {
+		sysInfo.cgroupBlkioInfo = cgroupBlkioInfo{
+			BlkioWeight:          true,
+			BlkioWeightDevice:    true,
+			BlkioReadBpsDevice:   true,
+			BlkioWriteBpsDevice:  true,
+			BlkioReadIOpsDevice:  true,
+			BlkioWriteIOpsDevice: true,
+		}
+	} else if !quiet {
+		logrus.Warn("Unable to find io controller in the unified cgroup hierarchy")
+	}
+
+	if controllers["cpuset"] {
+		sysInfo.cgroupCpusetInfo = checkCgroupCpusetInfoV2(dir)
+	} else if !quiet {
+		logrus.Warn("Unable to find cpuset controller in the unified cgroup hierarchy")
+	}
+
+	if controllers["pids"] {
+		sysInfo.cgroupPids = cgroupPids{PidsLimit: true}
+	} else if !quiet {
+		logrus.Warn("Your kernel does not support cgroup pids limit")
+	}
+}
+
+// cgroupDirV2 returns the cgroup whose controllers can be used by the
+// containers: the root one, or the cgroup of the daemon in a user namespace,
+// as only it can have been delegated to the user then.
+func cgroupDirV2() (string, error) {
+	if !rsystem.RunningInUserNS() {
+		return cgroups.UnifiedMountpoint, nil
+	}
+	own, err := cgroups.GetThisCgroupDir("")
+	if err != nil {
+		return "", err
+	}
+	return path.Join(cgroups.UnifiedMountpoint, own), nil
+}
+
+// swapLimitV2 returns whether swap accounting is enabled. The root cgroup has
+// no memory.* files, the cgroup of the daemon is checked instead.
+func swapLimitV2() bool {
+	own, err := cgroups.GetThisCgroupDir("")
+	if err != nil || own == "/" {
+		return false
+	}
+	return cgroupEnabled(path.Join(cgroups.UnifiedMountpoint, own), "memory.swap.max")
+}
+
+// controllersV2 returns the controllers available in the cgroup dir.
+func controllersV2(dir string) (map[string]bool, error) {
+	content, err := ioutil.ReadFile(path.Join(dir, "cgroup.controllers"))
+	if err != nil {
+		return nil, err
+	}
+	controllers := make(map[string]bool)
+	for _, c := range strings.Fields(string(content)) {
+		controllers[c] = true
+	}
+	return controllers, nil
+}
+
+// checkCgroupCpusetInfoV2 reads the cpus and memory nodes available in the
+// cgroup dir. The root cgroup has no cpuset.*.effective files, all of them are
+// available there.
+func checkCgroupCpusetInfoV2(dir string) cgroupCpusetInfo {
+	cpus, err := ioutil.ReadFile(path.Join(dir, "cpuset.cpus.effective"))
+	if err != nil {
+		if cpus, err = ioutil.ReadFile("/sys/devices/system/cpu/online"); err != nil {
+			return cgroupCpusetInfo{}
+		}
+	}
+	mems, err := ioutil.ReadFile(path.Join(dir, "cpuset.mems.effective"))
+	if err != nil {
+		if mems, err = ioutil.ReadFile("/sys/devices/system/node/online"); err != nil {
+			return cgroupCpusetInfo{}
+		}
+	}
+	return cgroupCpusetInfo{
+		Cpuset: true,
+		Cpus:   strings.TrimSpace(string(cpus)),
+		Mems:   strings.TrimSpace(string(mems)),
+	}
+}
+
+// memoryPressureV2 returns whether the kernel provides the pressure stall
+// information, which is only read from the unified hierarchy.
+func memoryPressureV2() bool {
+	_, err := os.Stat("/proc/pressure/memory")
+	return err == nil
+}
@@ -27,6 +27,9 @@ type SysInfo struct {
 
 	// Whether the cgroup has the mountpoint of "devices" or not
 	CgroupDevicesEnabled bool
+
+	// Whether the host uses the unified (v2) cgroup hierarchy or not
+	CgroupUnified bool
 }
 
 type cgroupMemInfo struct {
@@ -47,6 +50,9 @@ type cgroupMemInfo struct {
 
 	// Whether kernel memory limit is supported or not
 	KernelMemory bool
+
+	// Whether the memory pressure stall information is available or not
+	MemoryPressure bool
 }
 
 type cgroupCPUInfo struct {
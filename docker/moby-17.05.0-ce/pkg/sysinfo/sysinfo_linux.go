@@ -5,11 +5,13 @@ import (
 	"io/ioutil"
 	"os"
 	"path"
+	"path/filepath"
 	"strings"
 	"syscall"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/opencontainers/runc/libcontainer/cgroups"
+	rsystem "github.com/opencontainers/runc/libcontainer/system"
 )
 
 const (
@@ -22,8 +24,19 @@ func findCgroupMountpoints() (map[string]string, error) {
 	if err != nil {
 		return nil, fmt.Errorf("Failed to parse cgroup information: %v", err)
 	}
+	var own map[string]string
+	if rsystem.RunningInUserNS() {
+		// Only the controllers whose subtree was delegated to us can be
+		// used in a user namespace, as in rootless mode.
+		if own, err = cgroups.ParseCgroupFile("/proc/self/cgroup"); err != nil {
+			return nil, fmt.Errorf("Failed to parse cgroup information: %v", err)
+		}
+	}
 	mps := make(map[string]string)
 	for _, m := range cgMounts {
+		if own != nil && !cgroupDelegated(m, own) {
+			continue
+		}
 		for _, ss := range m.Subsystems {
 			mps[ss] = m.Mountpoint
 		}
@@ -31,24 +44,44 @@ func findCgroupMountpoints() (map[string]string, error) {
 	return mps, nil
 }
 
+// cgroupDelegated returns whether the cgroup of the daemon in the hierarchy
+// mounted by m is writable, so that it can create the cgroups of the
+// containers below it.
+func cgroupDelegated(m cgroups.Mount, own map[string]string) bool {
+	dir, err := m.GetThisCgroupDir(own)
+	if err != nil {
+		return false
+	}
+	rel, err := filepath.Rel(m.Root, dir)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return false
+	}
+	return syscall.Access(filepath.Join(m.Mountpoint, rel), 2 /* W_OK */) == nil
+}
+
 // New returns a new SysInfo, using the filesystem to detect which features
 // the kernel supports. If `quiet` is `false` warnings are printed in logs
 // whenever an error occurs or misconfigurations are present.
 func New(quiet bool) *SysInfo {
 	sysInfo := &SysInfo{}
-	cgMounts, err := findCgroupMountpoints()
-	if err != nil {
-		logrus.Warnf("Failed to parse cgroup information: %v", err)
+	if cgroups.IsCgroup2UnifiedMode() {
+		sysInfo.CgroupUnified = true
+		newV2(sysInfo, quiet)
 	} else {
-		sysInfo.cgroupMemInfo = checkCgroupMem(cgMounts, quiet)
-		sysInfo.cgroupCPUInfo = checkCgroupCPU(cgMounts, quiet)
-		sysInfo.cgroupBlkioInfo = checkCgroupBlkioInfo(cgMounts, quiet)
-		sysInfo.cgroupCpusetInfo = checkCgroupCpusetInfo(cgMounts, quiet)
-		sysInfo.cgroupPids = checkCgroupPids(quiet)
-	}
+		cgMounts, err := findCgroupMountpoints()
+		if err != nil {
+			logrus.Warnf("Failed to parse cgroup information: %v", err)
+		} else {
+			sysInfo.cgroupMemInfo = checkCgroupMem(cgMounts, quiet)
+			sysInfo.cgroupCPUInfo = checkCgroupCPU(cgMounts, quiet)
+			sysInfo.cgroupBlkioInfo = checkCgroupBlkioInfo(cgMounts, quiet)
+			sysInfo.cgroupCpusetInfo = checkCgroupCpusetInfo(cgMounts, quiet)
+			sysInfo.cgroupPids = checkCgroupPids(cgMounts, quiet)
+		}
 
-	_, ok := cgMounts["devices"]
-	sysInfo.CgroupDevicesEnabled = ok
+		_, ok := cgMounts["devices"]
+		sysInfo.CgroupDevicesEnabled = ok
+	}
 
 	sysInfo.IPv4ForwardingDisabled = !readProcBool("/proc/sys/net/ipv4/ip_forward")
 	sysInfo.BridgeNFCallIPTablesDisabled = !readProcBool("/proc/sys/net/bridge/bridge-nf-call-iptables")
@@ -231,11 +264,10 @@ func checkCgroupCpusetInfo(cgMounts map[string]string, quiet bool) cgroupCpusetI
 }
 
 // checkCgroupPids reads the pids information from the pids cgroup mount point.
-func checkCgroupPids(quiet bool) cgroupPids {
-	_, err := cgroups.FindCgroupMountpoint("pids")
-	if err != nil {
+func checkCgroupPids(cgMounts map[string]string, quiet bool) cgroupPids {
+	if _, ok := cgMounts["pids"]; !ok {
 		if !quiet {
-			logrus.Warn(err)
+			logrus.Warn("Your kernel does not support cgroup pids limit")
 		}
 		return cgroupPids{}
 	}
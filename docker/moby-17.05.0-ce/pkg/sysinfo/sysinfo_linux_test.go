@@ -56,3 +56,39 @@ func TestCgroupEnabled(t *testing.T) {
 		t.Fatal("cgroupEnabled should be true")
 	}
 }
+
+func TestControllersV2(t *testing.T) {
+	cgroupDir, err := ioutil.TempDir("", "cgroup2-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(cgroupDir)
+
+	if err := ioutil.WriteFile(path.Join(cgroupDir, "cgroup.controllers"), []byte("cpuset cpu io memory pids\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path.Join(cgroupDir, "cpuset.cpus.effective"), []byte("0-3\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path.Join(cgroupDir, "cpuset.mems.effective"), []byte("0\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	controllers, err := controllersV2(cgroupDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []string{"cpuset", "cpu", "io", "memory", "pids"} {
+		if !controllers[c] {
+			t.Fatalf("expected controller %s to be available", c)
+		}
+	}
+	if controllers["hugetlb"] {
+		t.Fatal("expected controller hugetlb not to be available")
+	}
+
+	info := checkCgroupCpusetInfoV2(cgroupDir)
+	if !info.Cpuset || info.Cpus != "0-3" || info.Mems != "0" {
+		t.Fatalf("unexpected cpuset info %+v", info)
+	}
+}
@@ -35,6 +35,12 @@ type Store interface {
 	Delete(ref reference.Named) (bool, error)
 	Get(ref reference.Named) (digest.Digest, error)
 }
+
+// ListableStore is a Store which can list all of its references.
+type ListableStore interface {
+	Store
+	Associations() []Association
+}
 /*
 referfenceStore的类型为reference.store，这个应该是docker用户最熟悉的部分了。以一个ubunu镜像为例，ubuntu镜像的名字就叫ubuntu，一个完成的镜像还包括tag，
 于是就有了ubuntu:latest、ubuntu:14.04等。这部分信息其实就是存储才referenceStore中。
@@ -346,6 +352,28 @@ func (store *store) ReferencesByName(ref reference.Named) []Association {
 	return associations
 }
 
+// Associations returns all the references of the store.
+func (store *store) Associations() []Association {
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+
+	var associations []Association
+	for _, repository := range store.Repositories {
+		for refStr, refID := range repository {
+			ref, err := reference.ParseNormalizedNamed(refStr)
+			if err != nil {
+				// Should never happen
+				continue
+			}
+			associations = append(associations, Association{Ref: ref, ID: refID})
+		}
+	}
+
+	sort.Sort(lexicalAssociations(associations))
+
+	return associations
+}
+
 func (store *store) save() error {
 	// Store the json
 	jsonData, err := json.Marshal(store)
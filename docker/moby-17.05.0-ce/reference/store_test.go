@@ -60,6 +60,38 @@ func TestLoad(t *testing.T) {
 	}
 }
 
+func TestAssociations(t *testing.T) {
+	jsonFile, err := ioutil.TempFile("", "tag-store-test")
+	if err != nil {
+		t.Fatalf("error creating temp file: %v", err)
+	}
+	defer os.RemoveAll(jsonFile.Name())
+
+	_, err = jsonFile.Write(marshalledSaveLoadTestCases)
+	if err != nil {
+		t.Fatalf("error writing to temp file: %v", err)
+	}
+	jsonFile.Close()
+
+	store, err := NewReferenceStore(jsonFile.Name())
+	if err != nil {
+		t.Fatalf("error creating tag store: %v", err)
+	}
+
+	associations := store.(ListableStore).Associations()
+	if len(associations) != len(saveLoadTestCases) {
+		t.Fatalf("expected %d associations, got %d", len(saveLoadTestCases), len(associations))
+	}
+	for i, a := range associations {
+		if i > 0 && associations[i-1].Ref.String() > a.Ref.String() {
+			t.Fatalf("associations are not sorted: %s before %s", associations[i-1].Ref.String(), a.Ref.String())
+		}
+		if expectedID := saveLoadTestCases[reference.FamiliarString(a.Ref)]; a.ID != expectedID {
+			t.Fatalf("expected %s for %s - got %s", expectedID, reference.FamiliarString(a.Ref), a.ID)
+		}
+	}
+}
+
 func TestSave(t *testing.T) {
 	jsonFile, err := ioutil.TempFile("", "tag-store-test")
 	if err != nil {
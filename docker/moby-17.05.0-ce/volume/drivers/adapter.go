@@ -2,6 +2,9 @@ package volumedrivers
 
 import (
 	"errors"
+	"fmt"
+	"io"
+	"net/url"
 	"path/filepath"
 	"strings"
 
@@ -87,6 +90,79 @@ func (a *volumeDriverAdapter) Get(name string) (volume.Volume, error) {
 	}, nil
 }
 
+// Snapshot asks the plugin to snapshot src, if it has the capability.
+func (a *volumeDriverAdapter) Snapshot(src volume.Volume, name string) (volume.Volume, error) {
+	if !a.getCapabilities().Snapshot {
+		return nil, volume.ErrNotSupported
+	}
+	if err := a.proxy.Snapshot(src.Name(), name); err != nil {
+		return nil, err
+	}
+	return a.newVolume(name), nil
+}
+
+// Clone asks the plugin to clone src, if it has the capability.
+func (a *volumeDriverAdapter) Clone(src volume.Volume, name string, opts map[string]string) (volume.Volume, error) {
+	if !a.getCapabilities().Clone {
+		return nil, volume.ErrNotSupported
+	}
+	if err := a.proxy.Clone(src.Name(), name, opts); err != nil {
+		return nil, err
+	}
+	return a.newVolume(name), nil
+}
+
+// streamClient is implemented by the plugin clients able to stream data,
+// which the export and import of volumes need.
+type streamClient interface {
+	Stream(serviceMethod string, args interface{}) (io.ReadCloser, error)
+	SendFile(serviceMethod string, data io.Reader, ret interface{}) error
+}
+
+type volumeDriverExportRequest struct {
+	Name string
+}
+
+type volumeDriverImportResponse struct {
+	Err string
+}
+
+// Export streams the tar archive of v from the plugin, if it has the
+// capability.
+func (a *volumeDriverAdapter) Export(v volume.Volume) (io.ReadCloser, error) {
+	c, ok := a.proxy.client.(streamClient)
+	if !ok || !a.getCapabilities().Export {
+		return nil, volume.ErrNotSupported
+	}
+	return c.Stream("VolumeDriver.Export", volumeDriverExportRequest{Name: v.Name()})
+}
+
+// Import streams the tar archive data to the plugin, if it has the
+// capability.
+func (a *volumeDriverAdapter) Import(v volume.Volume, data io.Reader) error {
+	c, ok := a.proxy.client.(streamClient)
+	if !ok || !a.getCapabilities().Export {
+		return volume.ErrNotSupported
+	}
+	var ret volumeDriverImportResponse
+	if err := c.SendFile(fmt.Sprintf("VolumeDriver.Import?name=%s", url.QueryEscape(v.Name())), data, &ret); err != nil {
+		return err
+	}
+	if ret.Err != "" {
+		return errors.New(ret.Err)
+	}
+	return nil
+}
+
+func (a *volumeDriverAdapter) newVolume(name string) volume.Volume {
+	return &volumeAdapter{
+		proxy:        a.proxy,
+		name:         name,
+		driverName:   a.name,
+		baseHostPath: a.baseHostPath,
+	}
+}
+
 func (a *volumeDriverAdapter) Scope() string {
 	cap := a.getCapabilities()
 	return cap.Scope
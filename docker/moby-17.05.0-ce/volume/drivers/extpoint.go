@@ -48,6 +48,10 @@ type volumeDriver interface {
 	Get(name string) (volume *proxyVolume, err error)
 	// Capabilities gets the list of capabilities of the driver
 	Capabilities() (capabilities volume.Capability, err error)
+	// Snapshot creates the volume target with a point-in-time copy of the given volume
+	Snapshot(name, target string) (err error)
+	// Clone creates the volume target with the given options and a copy of the given volume
+	Clone(name, target string, opts map[string]string) (err error)
 }
 
 type driverExtpoint struct {
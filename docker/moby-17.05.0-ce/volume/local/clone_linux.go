@@ -0,0 +1,43 @@
+package local
+
+import (
+	"fmt"
+
+	"github.com/docker/docker/daemon/graphdriver/copy"
+	"github.com/docker/docker/volume"
+)
+
+// Clone creates the volume name with the options opts and a copy of the data
+// of src. The files are cloned with reflinks on the filesystems supporting
+// them, so that the copy is fast and shares the blocks of src until they are
+// written. Volumes mounted from a device aren't supported, as their data isn't
+// in the volume directory.
+func (r *Root) Clone(src volume.Volume, name string, opts map[string]string) (volume.Volume, error) {
+	lv, ok := src.(*localVolume)
+	if !ok {
+		return nil, fmt.Errorf("unknown volume type %T", src)
+	}
+	if lv.needsMount() || opts["type"] != "" || opts["device"] != "" || opts["o"] != "" {
+		return nil, volume.ErrNotSupported
+	}
+
+	v, err := r.Create(name, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := copy.DirCopy(lv.path, v.Path(), copy.Content); err != nil {
+		if rmErr := r.Remove(v); rmErr != nil {
+			return nil, fmt.Errorf("%v, and the volume could not be removed: %v", err, rmErr)
+		}
+		return nil, fmt.Errorf("error while copying the data of volume %s: %v", lv.name, err)
+	}
+	return v, nil
+}
+
+// Snapshot creates the volume name with a copy of the data of src, made the
+// same way as the copies of Clone. The copy is only a point-in-time one if
+// src isn't written during it, so the containers writing to src should be
+// paused or stopped first.
+func (r *Root) Snapshot(src volume.Volume, name string) (volume.Volume, error) {
+	return r.Clone(src, name, nil)
+}
@@ -0,0 +1,131 @@
+package local
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/docker/volume"
+)
+
+func TestClone(t *testing.T) {
+	rootDir, err := ioutil.TempDir("", "local-volume-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(rootDir)
+
+	r, err := New(rootDir, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src, err := r.Create("src", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(src.Path(), "dir"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(src.Path(), "dir", "file"), []byte("data"), 0640); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Link(filepath.Join(src.Path(), "dir", "file"), filepath.Join(src.Path(), "link")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("dir/file", filepath.Join(src.Path(), "symlink")); err != nil {
+		t.Fatal(err)
+	}
+
+	dst, err := r.Clone(src, "dst", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Get("dst"); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(dst.Path(), "dir", "file"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "data" {
+		t.Fatalf("expected the cloned file to contain %q, got %q", "data", b)
+	}
+	fi, err := os.Stat(filepath.Join(dst.Path(), "dir"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.Mode().Perm() != 0700 {
+		t.Fatalf("expected the cloned directory mode to be 0700, got %v", fi.Mode().Perm())
+	}
+	fi1, err := os.Stat(filepath.Join(dst.Path(), "dir", "file"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fi2, err := os.Stat(filepath.Join(dst.Path(), "link"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !os.SameFile(fi1, fi2) {
+		t.Fatal("expected the hard link to be kept")
+	}
+	if link, err := os.Readlink(filepath.Join(dst.Path(), "symlink")); err != nil || link != "dir/file" {
+		t.Fatalf("expected the symlink to be kept, got %q, %v", link, err)
+	}
+
+	// The source is left untouched.
+	if err := ioutil.WriteFile(filepath.Join(dst.Path(), "dir", "file"), []byte("changed"), 0640); err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := ioutil.ReadFile(filepath.Join(src.Path(), "dir", "file")); string(b) != "data" {
+		t.Fatalf("expected the source file to be unchanged, got %q", b)
+	}
+
+	if _, err := r.Clone(src, "tmpfs", map[string]string{"type": "tmpfs", "device": "tmpfs"}); err != volume.ErrNotSupported {
+		t.Fatalf("expected cloning to a mounted volume not to be supported, got %v", err)
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	rootDir, err := ioutil.TempDir("", "local-volume-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(rootDir)
+
+	r, err := New(rootDir, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src, err := r.Create("src", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(src.Path(), "file"), []byte("data"), 0640); err != nil {
+		t.Fatal(err)
+	}
+
+	snap, err := r.Snapshot(src, "snap")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Later writes to the source don't change the snapshot.
+	if err := ioutil.WriteFile(filepath.Join(src.Path(), "file"), []byte("changed"), 0640); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := ioutil.ReadFile(filepath.Join(snap.Path(), "file")); err != nil || string(b) != "data" {
+		t.Fatalf("expected the snapshot file to contain %q, got %q, %v", "data", b, err)
+	}
+
+	tmpfs, err := r.Create("tmpfs", map[string]string{"type": "tmpfs", "device": "tmpfs"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Snapshot(tmpfs, "tmpfs-snap"); err != volume.ErrNotSupported {
+		t.Fatalf("expected snapshots of a mounted volume not to be supported, got %v", err)
+	}
+}
@@ -17,6 +17,8 @@ import (
 
 	"github.com/Sirupsen/logrus"
 	"github.com/docker/docker/api"
+	"github.com/docker/docker/daemon/graphdriver/quota"
+	"github.com/docker/docker/pkg/directory"
 	"github.com/docker/docker/pkg/idtools"
 	"github.com/docker/docker/pkg/mount"
 	"github.com/docker/docker/volume"
@@ -56,6 +58,7 @@ type activeMount struct {
 // is the base path that the Root instance uses to store its
 // volumes. The base path is created here if it does not exist.
 func New(scope string, rootUID, rootGID int) (*Root, error) {
+	var err error
 	rootDirectory := filepath.Join(scope, volumesPathName)
 
 	if err := idtools.MkdirAllAs(rootDirectory, 0700, rootUID, rootGID); err != nil {
@@ -70,6 +73,12 @@ func New(scope string, rootUID, rootGID int) (*Root, error) {
 		rootGID: rootGID,
 	}
 
+	// Project quotas are used to limit the size of the volumes, on the
+	// filesystems supporting them.
+	if r.quotaCtl, err = quota.NewControl(rootDirectory); err != nil {
+		logrus.Debugf("project quotas are not supported for local volumes: %v", err)
+	}
+
 	dirs, err := ioutil.ReadDir(rootDirectory)
 	if err != nil {
 		return nil, err
@@ -121,12 +130,13 @@ func New(scope string, rootUID, rootGID int) (*Root, error) {
 // manages the creation/removal of volumes. It uses only standard vfs
 // commands to create/remove dirs within its provided scope.
 type Root struct {
-	m       sync.Mutex
-	scope   string
-	path    string
-	volumes map[string]*localVolume
-	rootUID int
-	rootGID int
+	m        sync.Mutex
+	scope    string
+	path     string
+	volumes  map[string]*localVolume
+	rootUID  int
+	rootGID  int
+	quotaCtl *quota.Control
 }
 
 // List lists all the volumes
@@ -167,7 +177,24 @@ func (r *Root) Create(name string, opts map[string]string) (volume.Volume, error
 	}
 
 	path := r.DataPath(name)
-	if err := idtools.MkdirAllAs(path, 0755, r.rootUID, r.rootGID); err != nil {
+	v = &localVolume{
+		driverName: r.Name(),
+		name:       name,
+		path:       path,
+	}
+
+	if len(opts) != 0 {
+		if err := setOpts(v, opts); err != nil {
+			return nil, err
+		}
+		if err := r.validateQuota(v); err != nil {
+			return nil, err
+		}
+	}
+
+	// The data directory is created once the quota of the volume directory
+	// is set, so that it inherits its project id.
+	if err := idtools.MkdirAllAs(filepath.Dir(path), 0755, r.rootUID, r.rootGID); err != nil {
 		if os.IsExist(err) {
 			return nil, fmt.Errorf("volume already exists under %s", filepath.Dir(path))
 		}
@@ -181,16 +208,14 @@ func (r *Root) Create(name string, opts map[string]string) (volume.Volume, error
 		}
 	}()
 
-	v = &localVolume{
-		driverName: r.Name(),
-		name:       name,
-		path:       path,
+	if err = r.setQuota(v); err != nil {
+		return nil, err
+	}
+	if err = idtools.MkdirAs(path, 0755, r.rootUID, r.rootGID); err != nil {
+		return nil, errors.Wrapf(err, "error while creating volume path '%s'", path)
 	}
 
 	if len(opts) != 0 {
-		if err = setOpts(v, opts); err != nil {
-			return nil, err
-		}
 		var b []byte
 		b, err = json.Marshal(v.opts)
 		if err != nil {
@@ -272,6 +297,22 @@ func (r *Root) Scope() string {
 	return volume.LocalScope
 }
 
+// DiskUsage returns the number of bytes used by the volume with the given
+// name. It is read from the project quota of the volume if it has one, and
+// computed from its files otherwise.
+func (r *Root) DiskUsage(name string) (int64, error) {
+	r.m.Lock()
+	v, exists := r.volumes[name]
+	r.m.Unlock()
+	if !exists {
+		return 0, ErrNotFound
+	}
+	if size, ok, err := r.quotaUsage(v); ok {
+		return size, err
+	}
+	return directory.Size(v.path)
+}
+
 func (r *Root) validateName(name string) error {
 	if len(name) == 1 {
 		return validationError{fmt.Errorf("volume name is too short, names should be at least two alphanumeric characters")}
@@ -318,7 +359,7 @@ func (v *localVolume) Path() string {
 func (v *localVolume) Mount(id string) (string, error) {
 	v.m.Lock()
 	defer v.m.Unlock()
-	if v.opts != nil {
+	if v.needsMount() {
 		if !v.active.mounted {
 			if err := v.mount(); err != nil {
 				return "", err
@@ -340,7 +381,7 @@ func (v *localVolume) Unmount(id string) error {
 	// Essentially docker doesn't care if this fails, it will send an error, but
 	// ultimately there's nothing that can be done. If we don't decrement the count
 	// this volume can never be removed until a daemon restart occurs.
-	if v.opts != nil {
+	if v.needsMount() {
 		v.active.count--
 	}
 
@@ -352,7 +393,7 @@ func (v *localVolume) Unmount(id string) error {
 }
 
 func (v *localVolume) unmount() error {
-	if v.opts != nil {
+	if v.needsMount() {
 		if err := mount.Unmount(v.path); err != nil {
 			if mounted, mErr := mount.Mounted(v.path); mounted || mErr != nil {
 				return errors.Wrapf(err, "error while unmounting volume path '%s'", v.path)
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/docker/docker/daemon/graphdriver/quota"
 	"github.com/docker/docker/pkg/mount"
 )
 
@@ -285,6 +286,61 @@ func TestCreateWithOpts(t *testing.T) {
 	}
 }
 
+func TestCreateWithSize(t *testing.T) {
+	if runtime.GOOS == "windows" || runtime.GOOS == "solaris" {
+		t.Skip()
+	}
+	rootDir, err := ioutil.TempDir("", "local-volume-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(rootDir)
+
+	r, err := New(rootDir, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, opts := range []map[string]string{
+		{"size": "notasize"},
+		{"size": "0"},
+		{"size": "10m", "type": "tmpfs", "device": "tmpfs"},
+	} {
+		if _, err := r.Create("test", opts); err == nil {
+			t.Fatalf("expected %v to cause error", opts)
+		}
+	}
+
+	vol, err := r.Create("test", map[string]string{"size": "10m"})
+	if r.quotaCtl == nil {
+		// The temporary directory isn't on xfs with project quotas.
+		if err == nil || !strings.Contains(err.Error(), "project quota") {
+			t.Fatalf("expected the lack of quota support to cause error, got %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(r.path, "test")); !os.IsNotExist(err) {
+			t.Fatalf("expected the volume directory to be removed, got %v", err)
+		}
+		return
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := vol.(*localVolume)
+	if v.needsMount() {
+		t.Fatal("expected a volume with a size only not to be mounted")
+	}
+	var q quota.Quota
+	if err := r.quotaCtl.GetQuota(filepath.Dir(v.path), &q); err != nil {
+		t.Fatal(err)
+	}
+	if q.Size != 10*1024*1024 {
+		t.Fatalf("expected a quota of 10m, got %d", q.Size)
+	}
+	if _, err := r.DiskUsage("test"); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestRealodNoOpts(t *testing.T) {
 	rootDir, err := ioutil.TempDir("", "volume-test-reload-no-opts")
 	if err != nil {
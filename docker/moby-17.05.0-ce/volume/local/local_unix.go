@@ -13,7 +13,9 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/docker/docker/daemon/graphdriver/quota"
 	"github.com/docker/docker/pkg/mount"
+	units "github.com/docker/go-units"
 )
 
 var (
@@ -23,6 +25,7 @@ var (
 		"type":   true, // specify the filesystem type for mount, e.g. nfs
 		"o":      true, // generic mount options
 		"device": true, // device to mount from
+		"size":   true, // quota size limit
 	}
 )
 
@@ -30,10 +33,11 @@ type optsConfig struct {
 	MountType   string
 	MountOpts   string
 	MountDevice string
+	Quota       quota.Quota
 }
 
 func (o *optsConfig) String() string {
-	return fmt.Sprintf("type='%s' device='%s' o='%s'", o.MountType, o.MountDevice, o.MountOpts)
+	return fmt.Sprintf("type='%s' device='%s' o='%s' size='%d'", o.MountType, o.MountDevice, o.MountOpts, o.Quota.Size)
 }
 
 // scopedPath verifies that the path where the volume is located
@@ -65,9 +69,64 @@ func setOpts(v *localVolume, opts map[string]string) error {
 		MountOpts:   opts["o"],
 		MountDevice: opts["device"],
 	}
+	if val, ok := opts["size"]; ok {
+		size, err := units.RAMInBytes(strings.TrimSpace(val))
+		if err != nil {
+			return validationError{fmt.Errorf("invalid size %q: %v", val, err)}
+		}
+		if size <= 0 {
+			return validationError{fmt.Errorf("invalid size %q: the size must be positive", val)}
+		}
+		if v.needsMount() {
+			// The quota would apply to the directory the filesystem is
+			// mounted on, not to the filesystem.
+			return validationError{fmt.Errorf("the size option can't be used with the type, device and o options")}
+		}
+		v.opts.Quota.Size = uint64(size)
+	}
+	return nil
+}
+
+// needsMount returns whether a filesystem is mounted on the data directory of
+// the volume. Volumes only created with a size aren't mounted.
+func (v *localVolume) needsMount() bool {
+	if v.opts == nil {
+		return false
+	}
+	return v.opts.MountType != "" || v.opts.MountOpts != "" || v.opts.MountDevice != ""
+}
+
+// validateQuota checks that the backing filesystem of the volumes supports
+// the quota requested for v.
+func (r *Root) validateQuota(v *localVolume) error {
+	if v.opts == nil || v.opts.Quota.Size == 0 || r.quotaCtl != nil {
+		return nil
+	}
+	return validationError{fmt.Errorf("the size option requires project quota support, which the backing filesystem of %s lacks: it must be xfs mounted with the pquota option", r.path)}
+}
+
+// setQuota sets the quota of v on the directory of the volume, which must be
+// set before the data directory is created in it.
+func (r *Root) setQuota(v *localVolume) error {
+	if v.opts == nil || v.opts.Quota.Size == 0 {
+		return nil
+	}
+	if err := r.quotaCtl.SetQuota(filepath.Dir(v.path), v.opts.Quota); err != nil {
+		return errors.Wrapf(err, "error while setting the size of volume %s", v.name)
+	}
 	return nil
 }
 
+// quotaUsage returns the number of bytes used by v counted by its quota, and
+// false if it has no quota.
+func (r *Root) quotaUsage(v *localVolume) (int64, bool, error) {
+	if v.opts == nil || v.opts.Quota.Size == 0 || r.quotaCtl == nil {
+		return 0, false, nil
+	}
+	size, err := r.quotaCtl.GetUsage(filepath.Dir(v.path))
+	return int64(size), true, err
+}
+
 func (v *localVolume) mount() error {
 	if v.opts.MountDevice == "" {
 		return fmt.Errorf("missing device in volume options")
@@ -29,6 +29,22 @@ func setOpts(v *localVolume, opts map[string]string) error {
 	return nil
 }
 
+func (v *localVolume) needsMount() bool {
+	return false
+}
+
 func (v *localVolume) mount() error {
 	return nil
 }
+
+func (r *Root) validateQuota(v *localVolume) error {
+	return nil
+}
+
+func (r *Root) setQuota(v *localVolume) error {
+	return nil
+}
+
+func (r *Root) quotaUsage(v *localVolume) (int64, bool, error) {
+	return 0, false, nil
+}
@@ -0,0 +1,180 @@
+package store
+
+import (
+	"io"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/pkg/archive"
+	"github.com/docker/docker/pkg/chrootarchive"
+	"github.com/docker/docker/pkg/ioutils"
+	"github.com/docker/docker/pkg/stringid"
+	"github.com/docker/docker/volume"
+	volumedrivers "github.com/docker/docker/volume/drivers"
+)
+
+// Clone creates the volume name with the options opts and a copy of the data
+// of the volume src, on the driver of src. The driver copies the data if it
+// supports it, otherwise the data is copied through the mount points of the
+// volumes.
+func (s *VolumeStore) Clone(src volume.Volume, name string, opts, labels map[string]string) (volume.Volume, error) {
+	return s.copyVolume("clone", src, name, opts, labels, func(vd volume.Driver) (volume.Volume, error) {
+		if cd, ok := vd.(volume.CloneDriver); ok {
+			return cd.Clone(unwrapVolume(src), name, opts)
+		}
+		return nil, volume.ErrNotSupported
+	})
+}
+
+// Snapshot creates the volume name with a point-in-time copy of the data of
+// the volume src, on the driver of src. A copy of the data that src may be
+// written during isn't a snapshot, so it fails when the driver can't
+// snapshot src.
+func (s *VolumeStore) Snapshot(src volume.Volume, name string, labels map[string]string) (volume.Volume, error) {
+	return s.copyVolume("snapshot", src, name, nil, labels, func(vd volume.Driver) (volume.Volume, error) {
+		if sd, ok := vd.(volume.SnapshotDriver); ok {
+			v, err := sd.Snapshot(unwrapVolume(src), name)
+			if err != volume.ErrNotSupported {
+				return v, err
+			}
+		}
+		return nil, errSnapshotNotSupported
+	})
+}
+
+func (s *VolumeStore) copyVolume(op string, src volume.Volume, name string, opts, labels map[string]string, copyFn func(volume.Driver) (volume.Volume, error)) (volume.Volume, error) {
+	name = normaliseVolumeName(name)
+	valid, err := volume.IsVolumeNameValid(name)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return nil, &OpErr{Err: errInvalidName, Name: name, Op: op}
+	}
+
+	s.locks.Lock(name)
+	defer s.locks.Unlock(name)
+
+	if _, exists := s.getNamed(name); exists {
+		return nil, &OpErr{Err: errNameConflict, Name: name, Op: op}
+	}
+	vd, err := volumedrivers.CreateDriver(src.DriverName())
+	if err != nil {
+		return nil, &OpErr{Err: err, Name: name, Op: op}
+	}
+	if v, _ := vd.Get(name); v != nil {
+		return nil, &OpErr{Err: errNameConflict, Name: name, Op: op}
+	}
+
+	v, err := copyFn(vd)
+	if err == volume.ErrNotSupported {
+		logrus.Debugf("Copying the data of volume %s to %s, driver %s doesn't support %s", src.Name(), name, vd.Name(), op)
+		v, err = copyVolumeData(vd, src, name, opts)
+	}
+	if err != nil {
+		return nil, &OpErr{Err: err, Name: name, Op: op}
+	}
+
+	v, err = s.register(v, vd, opts, labels)
+	if err != nil {
+		return nil, err
+	}
+	s.setNamed(v, "")
+	return v, nil
+}
+
+// copyVolumeData creates the volume name on the driver vd, and copies the data
+// of src to it through their mount points.
+func copyVolumeData(vd volume.Driver, src volume.Volume, name string, opts map[string]string) (volume.Volume, error) {
+	v, err := vd.Create(name, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := mountAndCopy(src, v); err != nil {
+		if rmErr := vd.Remove(v); rmErr != nil {
+			logrus.Warnf("Failed to remove volume %s after failing to copy its data: %v", name, rmErr)
+		}
+		return nil, err
+	}
+	return v, nil
+}
+
+func mountAndCopy(src, dst volume.Volume) error {
+	id := stringid.GenerateNonCryptoID()
+	srcPath, err := src.Mount(id)
+	if err != nil {
+		return err
+	}
+	defer src.Unmount(id)
+	dstPath, err := dst.Mount(id)
+	if err != nil {
+		return err
+	}
+	defer dst.Unmount(id)
+	return chrootarchive.CopyWithTar(srcPath, dstPath)
+}
+
+// Export returns a tar archive of the data of the volume v. The driver of v
+// creates the archive if it supports it, otherwise the volume is mounted
+// until the archive is closed.
+func (s *VolumeStore) Export(v volume.Volume) (io.ReadCloser, error) {
+	vd, err := volumedrivers.GetDriver(v.DriverName())
+	if err != nil {
+		return nil, &OpErr{Err: err, Name: v.Name(), Op: "export"}
+	}
+	if ed, ok := vd.(volume.ExportDriver); ok {
+		rc, err := ed.Export(unwrapVolume(v))
+		if err != volume.ErrNotSupported {
+			if err != nil {
+				return nil, &OpErr{Err: err, Name: v.Name(), Op: "export"}
+			}
+			return rc, nil
+		}
+	}
+
+	id := stringid.GenerateNonCryptoID()
+	path, err := v.Mount(id)
+	if err != nil {
+		return nil, &OpErr{Err: err, Name: v.Name(), Op: "export"}
+	}
+	rc, err := archive.Tar(path, archive.Uncompressed)
+	if err != nil {
+		v.Unmount(id)
+		return nil, &OpErr{Err: err, Name: v.Name(), Op: "export"}
+	}
+	return ioutils.NewReadCloserWrapper(rc, func() error {
+		err := rc.Close()
+		v.Unmount(id)
+		return err
+	}), nil
+}
+
+// Import extracts the tar archive data in the volume v. The driver of v
+// extracts the archive if it supports it, otherwise it is extracted through
+// the mount point of the volume. The files of v are kept, unless the archive
+// overwrites them.
+func (s *VolumeStore) Import(v volume.Volume, data io.Reader, options *archive.TarOptions) error {
+	vd, err := volumedrivers.GetDriver(v.DriverName())
+	if err != nil {
+		return &OpErr{Err: err, Name: v.Name(), Op: "import"}
+	}
+	if ed, ok := vd.(volume.ExportDriver); ok {
+		err := ed.Import(unwrapVolume(v), data)
+		if err != volume.ErrNotSupported {
+			if err != nil {
+				return &OpErr{Err: err, Name: v.Name(), Op: "import"}
+			}
+			return nil
+		}
+	}
+
+	id := stringid.GenerateNonCryptoID()
+	path, err := v.Mount(id)
+	if err != nil {
+		return &OpErr{Err: err, Name: v.Name(), Op: "import"}
+	}
+	defer v.Unmount(id)
+	if err := chrootarchive.Untar(data, path, options); err != nil {
+		return &OpErr{Err: err, Name: v.Name(), Op: "import"}
+	}
+	return nil
+}
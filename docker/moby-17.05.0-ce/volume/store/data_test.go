@@ -0,0 +1,193 @@
+// +build linux
+
+package store
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/docker/pkg/reexec"
+	"github.com/docker/docker/volume"
+	"github.com/docker/docker/volume/drivers"
+	"github.com/docker/docker/volume/local"
+	volumetestutils "github.com/docker/docker/volume/testutils"
+)
+
+func init() {
+	reexec.Init()
+}
+
+func TestCloneAndSnapshot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test-clone")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	drv, err := local.New(dir, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	volumedrivers.Register(drv, "local")
+	defer volumedrivers.Unregister("local")
+
+	s, err := New(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := s.Create("src", "local", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(src.Path(), "file"), []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	clone, err := s.Clone(src, "clone", nil, map[string]string{"a": "b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadFile(filepath.Join(clone.Path(), "file"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "data" {
+		t.Fatalf("expected the data of the clone to be copied, got %q", b)
+	}
+	if v, err := s.Get("clone"); err != nil {
+		t.Fatal(err)
+	} else if labels := v.(volume.DetailedVolume).Labels(); labels["a"] != "b" {
+		t.Fatalf("expected the clone to have its labels, got %v", labels)
+	}
+
+	if _, err := s.Clone(src, "clone", nil, nil); !IsNameConflict(err) {
+		t.Fatalf("expected a name conflict, got %v", err)
+	}
+
+	snapshot, err := s.Snapshot(src, "snapshot", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(src.Path(), "file"), []byte("changed"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	b, err = ioutil.ReadFile(filepath.Join(snapshot.Path(), "file"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "data" {
+		t.Fatalf("expected the snapshot to keep the data it was taken with, got %q", b)
+	}
+	if _, err := s.Get("snapshot"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCloneCopyError(t *testing.T) {
+	volumedrivers.Register(volumetestutils.NewFakeDriver("fake"), "fake")
+	defer volumedrivers.Unregister("fake")
+	dir, err := ioutil.TempDir("", "test-clone")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	s, err := New(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := s.Create("src", "fake", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The fake driver can't clone, and the data of its volumes can't be
+	// copied.
+	if _, err := s.Clone(src, "clone", nil, nil); err == nil {
+		t.Fatal("expected the copy of the data to fail")
+	}
+	if _, err := s.Get("clone"); !IsNotExist(err) {
+		t.Fatalf("expected the clone to be removed, got %v", err)
+	}
+
+	// The fake driver can't take point-in-time copies, and a copy of the
+	// data isn't taken instead.
+	if _, err := s.Snapshot(src, "snapshot", nil); err == nil || err.(*OpErr).Err != errSnapshotNotSupported {
+		t.Fatalf("expected the snapshot not to be supported, got %v", err)
+	}
+	if _, err := s.Get("snapshot"); !IsNotExist(err) {
+		t.Fatalf("expected no snapshot to be created, got %v", err)
+	}
+}
+
+func TestExportImport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test-export")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	drv, err := local.New(dir, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	volumedrivers.Register(drv, "local")
+	defer volumedrivers.Unregister("local")
+
+	s, err := New(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := s.Create("src", "local", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(src.Path(), "file"), []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	rc, err := s.Export(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadAll(rc)
+	rc.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	tr := tar.NewReader(bytes.NewReader(b))
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, hdr.Name)
+	}
+	if len(names) == 0 || names[len(names)-1] != "file" {
+		t.Fatalf("expected the archive to contain file, got %v", names)
+	}
+
+	dst, err := s.Create("dst", "local", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Import(dst, bytes.NewReader(b), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err = ioutil.ReadFile(filepath.Join(dst.Path(), "file"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "data" {
+		t.Fatalf("expected the data to be imported, got %q", b)
+	}
+}
@@ -2,6 +2,7 @@ package store
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/boltdb/bolt"
@@ -15,6 +16,9 @@ type volumeMetadata struct {
 	Driver  string
 	Labels  map[string]string
 	Options map[string]string
+	// LastUsed is the last time a reference to the volume was added or
+	// removed.
+	LastUsed time.Time `json:",omitempty"`
 }
 
 func (s *VolumeStore) setMeta(name string, meta volumeMetadata) error {
@@ -52,6 +56,19 @@ func getMeta(tx *bolt.Tx, name string, meta *volumeMetadata) error {
 	return nil
 }
 
+// touchMeta sets the last use time of the volume name to now.
+func (s *VolumeStore) touchMeta(name string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		var meta volumeMetadata
+		if err := getMeta(tx, name, &meta); err != nil {
+			return err
+		}
+		meta.Name = name
+		meta.LastUsed = time.Now().UTC()
+		return setMeta(tx, name, meta)
+	})
+}
+
 func (s *VolumeStore) removeMeta(name string) error {
 	return s.db.Update(func(tx *bolt.Tx) error {
 		return removeMeta(tx, name)
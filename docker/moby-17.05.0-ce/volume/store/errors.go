@@ -15,6 +15,8 @@ var (
 	errInvalidName = errors.New("volume name is not valid on this platform")
 	// errNameConflict is a typed error returned on create when a volume exists with the given name, but for a different driver
 	errNameConflict = errors.New("volume name must be unique")
+	// errSnapshotNotSupported is a typed error returned when taking a snapshot of a volume whose driver can't take point-in-time copies
+	errSnapshotNotSupported = errors.New("volume driver can't take point-in-time snapshots")
 )
 
 // OpErr is the error type returned by functions in the store package. It describes
@@ -2,6 +2,7 @@ package store
 
 import (
 	"sync"
+	"time"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/boltdb/bolt"
@@ -58,6 +59,15 @@ func (s *VolumeStore) restore() {
 				}
 			}
 
+			if meta.LastUsed.IsZero() {
+				// The last use of the volume wasn't recorded when it was
+				// created, so it starts from now.
+				meta.LastUsed = time.Now().UTC()
+				if err := s.setMeta(v.Name(), meta); err != nil {
+					logrus.WithError(err).WithField("driver", meta.Driver).WithField("volume", v.Name()).Warn("Error updating volume metadata on restore")
+				}
+			}
+
 			// increment driver refcount
 			volumedrivers.CreateDriver(meta.Driver)
 
@@ -282,6 +282,9 @@ func (s *VolumeStore) CreateWithRef(name, driverName, ref string, opts, labels m
 	}
 
 	s.setNamed(v, ref)
+	if ref != "" {
+		s.touch(name)
+	}
 	return v, nil
 }
 
@@ -409,6 +412,13 @@ func (s *VolumeStore) create(name, driverName string, opts, labels map[string]st
 	if err != nil {
 		return nil, err
 	}
+	return s.register(v, vd, opts, labels)
+}
+
+// register stores the labels and options of the volume v, newly created by
+// the driver vd.
+func (s *VolumeStore) register(v volume.Volume, vd volume.Driver, opts, labels map[string]string) (volume.Volume, error) {
+	name := v.Name()
 	s.globalLock.Lock()
 	s.labels[name] = labels
 	s.options[name] = opts
@@ -416,10 +426,11 @@ func (s *VolumeStore) create(name, driverName string, opts, labels map[string]st
 	s.globalLock.Unlock()
 
 	metadata := volumeMetadata{
-		Name:    name,
-		Driver:  vd.Name(),
-		Labels:  labels,
-		Options: opts,
+		Name:     name,
+		Driver:   vd.Name(),
+		Labels:   labels,
+		Options:  opts,
+		LastUsed: time.Now().UTC(),
 	}
 
 	if err := s.setMeta(name, metadata); err != nil {
@@ -447,6 +458,9 @@ func (s *VolumeStore) GetWithRef(name, driverName, ref string) (volume.Volume, e
 	}
 
 	s.setNamed(v, ref)
+	if ref != "" {
+		s.touch(name)
+	}
 
 	s.globalLock.RLock()
 	defer s.globalLock.RUnlock()
@@ -589,11 +603,34 @@ func (s *VolumeStore) Dereference(v volume.Volume, ref string) {
 	defer s.locks.Unlock(name)
 
 	s.globalLock.Lock()
-	defer s.globalLock.Unlock()
-
 	if s.refs[name] != nil {
 		delete(s.refs[name], ref)
 	}
+	_, exists := s.names[name]
+	s.globalLock.Unlock()
+
+	if exists {
+		s.touch(name)
+	}
+}
+
+// touch records that a reference to the volume name was just added or
+// removed. Callers of this function are expected to hold the name lock.
+func (s *VolumeStore) touch(name string) {
+	if err := s.touchMeta(name); err != nil {
+		logrus.Errorf("Error updating the last use of volume %q: %v", name, err)
+	}
+}
+
+// LastUsed returns the last time a reference to the given volume was added
+// or removed, or the time the volume was created if it was never referenced.
+// The time is zero if the volume is unknown to the store.
+func (s *VolumeStore) LastUsed(v volume.Volume) (time.Time, error) {
+	meta, err := s.getMeta(v.Name())
+	if err != nil {
+		return time.Time{}, &OpErr{Err: err, Name: v.Name(), Op: "get"}
+	}
+	return meta.LastUsed, nil
 }
 
 // Refs gets the current list of refs for the given volume
@@ -6,6 +6,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/docker/docker/volume/drivers"
 	volumetestutils "github.com/docker/docker/volume/testutils"
@@ -232,3 +233,65 @@ func TestDerefMultipleOfSameRef(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestLastUsed(t *testing.T) {
+	volumedrivers.Register(volumetestutils.NewFakeDriver("fake"), "fake")
+	defer volumedrivers.Unregister("fake")
+	dir, err := ioutil.TempDir("", "test-last-used")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	s, err := New(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := s.Create("fake1", "fake", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	created, err := s.LastUsed(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.IsZero() {
+		t.Fatal("expected the creation time to be recorded")
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	if _, err := s.GetWithRef("fake1", "fake", "ref"); err != nil {
+		t.Fatal(err)
+	}
+	referenced, err := s.LastUsed(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !referenced.After(created) {
+		t.Fatalf("expected the last use to be updated by a reference, got %v, created at %v", referenced, created)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	s.Dereference(v, "ref")
+	dereferenced, err := s.LastUsed(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !dereferenced.After(referenced) {
+		t.Fatalf("expected the last use to be updated by a dereference, got %v, referenced at %v", dereferenced, referenced)
+	}
+
+	// The last use is kept across restarts.
+	if err := s.Shutdown(); err != nil {
+		t.Fatal(err)
+	}
+	s, err = New(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Shutdown()
+	if restored, err := s.LastUsed(v); err != nil || !restored.Equal(dereferenced) {
+		t.Fatalf("expected the last use to be %v after a restart, got %v, %v", dereferenced, restored, err)
+	}
+}
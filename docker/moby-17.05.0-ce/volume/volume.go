@@ -2,6 +2,7 @@ package volume
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -18,6 +19,10 @@ import (
 // implemented in the local package.
 const DefaultDriverName = "local"
 
+// AnonymousLabel is the label of the volumes created for the anonymous
+// volumes of containers, which aren't named by the user.
+const AnonymousLabel = "com.docker.volume.anonymous"
+
 // Scopes define if a volume has is cluster-wide (global) or local only.
 // Scopes are returned by the volume driver when it is queried for capabilities and then set on a volume
 const (
@@ -49,6 +54,43 @@ type Capability struct {
 	// A `local` scope indicates that the driver only manages volumes resources local to the host
 	// Scope is declared by the driver
 	Scope string
+	// Snapshot, Clone and Export tell whether the driver implements the
+	// corresponding operations on the data of its volumes. When it doesn't,
+	// the daemon copies the data through the mount points of the volumes,
+	// except for snapshots, which the daemon can't take.
+	Snapshot bool
+	Clone    bool
+	Export   bool
+}
+
+// ErrNotSupported is returned by the data operations of a driver which can't
+// carry them out for a volume. The daemon then falls back to copying the
+// data itself.
+var ErrNotSupported = errors.New("operation not supported by the volume driver")
+
+// SnapshotDriver is a Driver able to create point-in-time copies of its
+// volumes.
+type SnapshotDriver interface {
+	// Snapshot creates the volume name with a copy of the data of src, as it
+	// is when Snapshot is called.
+	Snapshot(src Volume, name string) (Volume, error)
+}
+
+// CloneDriver is a Driver able to copy the data of its volumes to new
+// volumes.
+type CloneDriver interface {
+	// Clone creates the volume name with the options opts and a copy of the
+	// data of src.
+	Clone(src Volume, name string, opts map[string]string) (Volume, error)
+}
+
+// ExportDriver is a Driver able to export and import the data of its volumes
+// as tar archives.
+type ExportDriver interface {
+	// Export returns a tar archive of the data of v.
+	Export(v Volume) (io.ReadCloser, error)
+	// Import extracts the tar archive data in v.
+	Import(v Volume, data io.Reader) error
 }
 
 // Volume is a place to store data. It is backed by a specific driver, and can be mounted.
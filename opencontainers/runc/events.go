@@ -53,6 +53,7 @@ type blkio struct {
 	IoMergedRecursive       []blkioEntry `json:"ioMergedRecursive,omitempty"`
 	IoTimeRecursive         []blkioEntry `json:"ioTimeRecursive,omitempty"`
 	SectorsRecursive        []blkioEntry `json:"sectorsRecursive,omitempty"`
+	PSI                     *psiStats    `json:"psi,omitempty"`
 }
 
 type pids struct {
@@ -77,6 +78,20 @@ type cpuUsage struct {
 type cpu struct {
 	Usage      cpuUsage   `json:"usage,omitempty"`
 	Throttling throttling `json:"throttling,omitempty"`
+	PSI        *psiStats  `json:"psi,omitempty"`
+}
+
+type psiData struct {
+	Avg10  float64 `json:"avg10"`
+	Avg60  float64 `json:"avg60"`
+	Avg300 float64 `json:"avg300"`
+	// Units: microseconds.
+	Total uint64 `json:"total"`
+}
+
+type psiStats struct {
+	Some psiData `json:"some,omitempty"`
+	Full psiData `json:"full,omitempty"`
 }
 
 type memoryEntry struct {
@@ -92,9 +107,19 @@ type memory struct {
 	Swap      memoryEntry       `json:"swap,omitempty"`
 	Kernel    memoryEntry       `json:"kernel,omitempty"`
 	KernelTCP memoryEntry       `json:"kernelTCP,omitempty"`
+	Events    *memoryEvents     `json:"events,omitempty"`
+	PSI       *psiStats         `json:"psi,omitempty"`
 	Raw       map[string]uint64 `json:"raw,omitempty"`
 }
 
+type memoryEvents struct {
+	Low     uint64 `json:"low"`
+	High    uint64 `json:"high"`
+	Max     uint64 `json:"max"`
+	Oom     uint64 `json:"oom"`
+	OomKill uint64 `json:"oomKill"`
+}
+
 var eventsCommand = cli.Command{
 	Name:  "events",
 	Usage: "display container events such as OOM notifications, cpu, memory, and IO usage statistics",
@@ -202,6 +227,7 @@ func convertLibcontainerStats(ls *libcontainer.Stats) *stats {
 	s.Cpu.Throttling.Periods = cg.CpuStats.ThrottlingData.Periods
 	s.Cpu.Throttling.ThrottledPeriods = cg.CpuStats.ThrottlingData.ThrottledPeriods
 	s.Cpu.Throttling.ThrottledTime = cg.CpuStats.ThrottlingData.ThrottledTime
+	s.Cpu.PSI = convertPSI(cg.CpuStats.PSI)
 
 	s.Memory.Cache = cg.MemoryStats.Cache
 	s.Memory.Kernel = convertMemoryEntry(cg.MemoryStats.KernelUsage)
@@ -209,6 +235,16 @@ func convertLibcontainerStats(ls *libcontainer.Stats) *stats {
 	s.Memory.Swap = convertMemoryEntry(cg.MemoryStats.SwapUsage)
 	s.Memory.Usage = convertMemoryEntry(cg.MemoryStats.Usage)
 	s.Memory.Raw = cg.MemoryStats.Stats
+	if e := cg.MemoryStats.Events; e != nil {
+		s.Memory.Events = &memoryEvents{
+			Low:     e.Low,
+			High:    e.High,
+			Max:     e.Max,
+			Oom:     e.Oom,
+			OomKill: e.OomKill,
+		}
+	}
+	s.Memory.PSI = convertPSI(cg.MemoryStats.PSI)
 
 	s.Blkio.IoServiceBytesRecursive = convertBlkioEntry(cg.BlkioStats.IoServiceBytesRecursive)
 	s.Blkio.IoServicedRecursive = convertBlkioEntry(cg.BlkioStats.IoServicedRecursive)
@@ -218,6 +254,7 @@ func convertLibcontainerStats(ls *libcontainer.Stats) *stats {
 	s.Blkio.IoMergedRecursive = convertBlkioEntry(cg.BlkioStats.IoMergedRecursive)
 	s.Blkio.IoTimeRecursive = convertBlkioEntry(cg.BlkioStats.IoTimeRecursive)
 	s.Blkio.SectorsRecursive = convertBlkioEntry(cg.BlkioStats.SectorsRecursive)
+	s.Blkio.PSI = convertPSI(cg.BlkioStats.PSI)
 
 	s.Hugetlb = make(map[string]hugetlb)
 	for k, v := range cg.HugetlbStats {
@@ -226,6 +263,16 @@ func convertLibcontainerStats(ls *libcontainer.Stats) *stats {
 	return &s
 }
 
+func convertPSI(c *cgroups.PSIStats) *psiStats {
+	if c == nil {
+		return nil
+	}
+	return &psiStats{
+		Some: psiData(c.Some),
+		Full: psiData(c.Full),
+	}
+}
+
 func convertHugtlb(c cgroups.HugetlbStats) hugetlb {
 	return hugetlb{
 		Usage:   c.Usage,
@@ -0,0 +1,81 @@
+// +build linux
+
+package fs2
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+	"github.com/opencontainers/runc/libcontainer/configs"
+)
+
+// defaultCpuPeriod is the default period of cpu.max, in usecs.
+const defaultCpuPeriod = 100000
+
+func setCpu(path string, r *configs.Resources) error {
+	if r.CpuShares != 0 {
+		if err := writeFile(path, "cpu.weight", strconv.FormatUint(cpuWeight(r.CpuShares), 10)); err != nil {
+			return err
+		}
+	}
+	if r.CpuQuota != 0 || r.CpuPeriod != 0 {
+		quota := "max"
+		if r.CpuQuota > 0 {
+			quota = strconv.FormatInt(r.CpuQuota, 10)
+		}
+		period := r.CpuPeriod
+		if period == 0 {
+			period = defaultCpuPeriod
+		}
+		if err := writeFile(path, "cpu.max", quota+" "+strconv.FormatInt(period, 10)); err != nil {
+			return err
+		}
+	}
+	if r.CpuRtPeriod != 0 || r.CpuRtRuntime != 0 {
+		return fmt.Errorf("real-time CPU scheduling is not supported by the unified hierarchy")
+	}
+	return nil
+}
+
+// cpuWeight converts CPU shares, from 2 to 262144, to a cpu.weight, from 1 to
+// 10000.
+func cpuWeight(shares int64) uint64 {
+	if shares < 2 {
+		shares = 2
+	}
+	if shares > 262144 {
+		shares = 262144
+	}
+	return uint64(1 + ((shares-2)*9999)/262142)
+}
+
+func setCpuset(path string, r *configs.Resources) error {
+	if r.CpusetCpus != "" {
+		if err := writeFile(path, "cpuset.cpus", r.CpusetCpus); err != nil {
+			return err
+		}
+	}
+	if r.CpusetMems != "" {
+		if err := writeFile(path, "cpuset.mems", r.CpusetMems); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func statCpu(path string, stats *cgroups.Stats) error {
+	values, err := getCgroupParamKeyValues(path, "cpu.stat")
+	if err != nil {
+		return err
+	}
+	// The times are in usecs in the unified hierarchy, and in nsecs in
+	// the stats.
+	stats.CpuStats.CpuUsage.TotalUsage = values["usage_usec"] * 1000
+	stats.CpuStats.CpuUsage.UsageInUsermode = values["user_usec"] * 1000
+	stats.CpuStats.CpuUsage.UsageInKernelmode = values["system_usec"] * 1000
+	stats.CpuStats.ThrottlingData.Periods = values["nr_periods"]
+	stats.CpuStats.ThrottlingData.ThrottledPeriods = values["nr_throttled"]
+	stats.CpuStats.ThrottlingData.ThrottledTime = values["throttled_usec"] * 1000
+	return nil
+}
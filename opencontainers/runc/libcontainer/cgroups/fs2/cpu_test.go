@@ -0,0 +1,69 @@
+// +build linux
+
+package fs2
+
+import (
+	"testing"
+
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+	"github.com/opencontainers/runc/libcontainer/configs"
+)
+
+func TestCpuWeight(t *testing.T) {
+	for shares, weight := range map[int64]uint64{
+		0:      1,
+		2:      1,
+		1024:   39,
+		262144: 10000,
+		300000: 10000,
+	} {
+		if w := cpuWeight(shares); w != weight {
+			t.Fatalf("Expected cpu.weight %d for %d shares, got %d", weight, shares, w)
+		}
+	}
+}
+
+func TestSetCpu(t *testing.T) {
+	helper := NewCgroupTestUtil(t)
+	defer helper.cleanup()
+
+	r := &configs.Resources{CpuShares: 1024, CpuQuota: 50000}
+	if err := setCpu(helper.CgroupPath, r); err != nil {
+		t.Fatal(err)
+	}
+	if value := helper.readFile("cpu.weight"); value != "39" {
+		t.Fatalf("Got the wrong value, set cpu.weight failed: %q", value)
+	}
+	if value := helper.readFile("cpu.max"); value != "50000 100000" {
+		t.Fatalf("Got the wrong value, set cpu.max failed: %q", value)
+	}
+
+	r = &configs.Resources{CpuPeriod: 20000}
+	if err := setCpu(helper.CgroupPath, r); err != nil {
+		t.Fatal(err)
+	}
+	if value := helper.readFile("cpu.max"); value != "max 20000" {
+		t.Fatalf("Got the wrong value, set cpu.max failed: %q", value)
+	}
+}
+
+func TestStatCpu(t *testing.T) {
+	helper := NewCgroupTestUtil(t)
+	defer helper.cleanup()
+	helper.writeFileContents(map[string]string{
+		"cpu.stat": "usage_usec 3000\nuser_usec 2000\nsystem_usec 1000\nnr_periods 10\nnr_throttled 2\nthrottled_usec 500\n",
+	})
+
+	stats := cgroups.NewStats()
+	if err := statCpu(helper.CgroupPath, stats); err != nil {
+		t.Fatal(err)
+	}
+	usage := stats.CpuStats.CpuUsage
+	if usage.TotalUsage != 3000000 || usage.UsageInUsermode != 2000000 || usage.UsageInKernelmode != 1000000 {
+		t.Fatalf("Unexpected CPU usage: %+v", usage)
+	}
+	expected := cgroups.ThrottlingData{Periods: 10, ThrottledPeriods: 2, ThrottledTime: 500000}
+	if stats.CpuStats.ThrottlingData != expected {
+		t.Fatalf("Expected throttling data %+v, got %+v", expected, stats.CpuStats.ThrottlingData)
+	}
+}
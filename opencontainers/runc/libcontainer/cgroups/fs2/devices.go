@@ -0,0 +1,253 @@
+// +build linux
+
+package fs2
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"syscall"
+	"unsafe"
+
+	"github.com/opencontainers/runc/libcontainer/configs"
+	"github.com/opencontainers/runc/libcontainer/system"
+)
+
+// The unified hierarchy has no devices controller, the access to the devices
+// is checked by an eBPF program of type BPF_PROG_TYPE_CGROUP_DEVICE attached
+// to the cgroup. The program is generated from the device rules, in the same
+// order as they would be written to devices.allow and devices.deny.
+
+// bpfInsn is an eBPF instruction, struct bpf_insn.
+type bpfInsn struct {
+	code uint8
+	regs uint8 // dst_reg:4, src_reg:4
+	off  int16
+	imm  int32
+}
+
+const (
+	// eBPF opcodes.
+	bpfLdxMemW  = 0x61 // BPF_LDX | BPF_MEM | BPF_W
+	bpfAnd32Imm = 0x54 // BPF_ALU | BPF_AND | BPF_K
+	bpfRsh32Imm = 0x74 // BPF_ALU | BPF_RSH | BPF_K
+	bpfMov32Imm = 0xb4 // BPF_ALU | BPF_MOV | BPF_K
+	bpfMov32Reg = 0xbc // BPF_ALU | BPF_MOV | BPF_X
+	bpfJeqImm   = 0x15 // BPF_JMP | BPF_JEQ | BPF_K
+	bpfJneImm   = 0x55 // BPF_JMP | BPF_JNE | BPF_K
+	bpfJneReg   = 0x5d // BPF_JMP | BPF_JNE | BPF_X
+	bpfExit     = 0x95 // BPF_JMP | BPF_EXIT
+
+	// Registers. The context, struct bpf_cgroup_dev_ctx, is in r1 and the
+	// result in r0.
+	bpfR0 = 0
+	bpfR1 = 1
+	bpfR2 = 2 // device type
+	bpfR3 = 3 // access
+	bpfR4 = 4 // major
+	bpfR5 = 5 // minor
+
+	// Values of the device type and access in struct bpf_cgroup_dev_ctx.
+	bpfDevcgDevBlock  = 1
+	bpfDevcgDevChar   = 2
+	bpfDevcgAccMknod  = 1
+	bpfDevcgAccRead   = 2
+	bpfDevcgAccWrite  = 4
+	bpfDevcgAccAll    = bpfDevcgAccMknod | bpfDevcgAccRead | bpfDevcgAccWrite
+	bpfProgTypeDevice = 15 // BPF_PROG_TYPE_CGROUP_DEVICE
+	bpfAttachDevice   = 6  // BPF_CGROUP_DEVICE
+	bpfProgLoad       = 5  // BPF_PROG_LOAD
+	bpfProgAttach     = 8  // BPF_PROG_ATTACH
+)
+
+// The bpf syscall doesn't exist in the stdlib for all the platforms.
+var bpfMap = map[string]uintptr{
+	"linux/386":     357,
+	"linux/arm64":   280,
+	"linux/amd64":   321,
+	"linux/arm":     386,
+	"linux/ppc":     361,
+	"linux/ppc64":   361,
+	"linux/ppc64le": 361,
+	"linux/s390x":   351,
+}
+
+func insn(code uint8, dst, src uint8, off int16, imm int32) bpfInsn {
+	return bpfInsn{code: code, regs: dst | src<<4, off: off, imm: imm}
+}
+
+// deviceRules returns the device rules of r, in the order they apply. The
+// rules without a catch-all rule deny the access to the other devices.
+func deviceRules(r *configs.Resources) []*configs.Device {
+	if len(r.Devices) > 0 {
+		return r.Devices
+	}
+	all := &configs.Device{Type: 'a', Major: configs.Wildcard, Minor: configs.Wildcard, Permissions: "rwm"}
+	if r.AllowAllDevices != nil && !*r.AllowAllDevices {
+		return append([]*configs.Device{all}, r.AllowedDevices...)
+	}
+	// Like in the v1 hierarchy, where the cgroup inherits the rules of its
+	// parent, the devices are allowed by default.
+	all.Allow = true
+	return append([]*configs.Device{all}, r.DeniedDevices...)
+}
+
+// deviceFilter returns the eBPF program which allows or denies the access to
+// the devices according to rules. The last rule matching the device applies.
+func deviceFilter(rules []*configs.Device) ([]bpfInsn, error) {
+	// Load the type, access, major and minor of the device.
+	prog := []bpfInsn{
+		insn(bpfLdxMemW, bpfR2, bpfR1, 0, 0),
+		insn(bpfAnd32Imm, bpfR2, 0, 0, 0xffff),
+		insn(bpfLdxMemW, bpfR3, bpfR1, 0, 0),
+		insn(bpfRsh32Imm, bpfR3, 0, 0, 16),
+		insn(bpfLdxMemW, bpfR4, bpfR1, 4, 0),
+		insn(bpfLdxMemW, bpfR5, bpfR1, 8, 0),
+	}
+	for i := len(rules) - 1; i >= 0; i-- {
+		block, catchAll, err := deviceRuleBlock(rules[i])
+		if err != nil {
+			return nil, err
+		}
+		prog = append(prog, block...)
+		if catchAll {
+			// The rules before a catch-all rule never apply.
+			return prog, nil
+		}
+	}
+	return append(prog, insn(bpfMov32Imm, bpfR0, 0, 0, 0), insn(bpfExit, 0, 0, 0, 0)), nil
+}
+
+// deviceRuleBlock returns the instructions which return the decision of the
+// rule dev if it matches the device, and go to the next rule otherwise.
+func deviceRuleBlock(dev *configs.Device) (block []bpfInsn, catchAll bool, err error) {
+	var access int32
+	for _, p := range dev.Permissions {
+		switch p {
+		case 'r':
+			access |= bpfDevcgAccRead
+		case 'w':
+			access |= bpfDevcgAccWrite
+		case 'm':
+			access |= bpfDevcgAccMknod
+		default:
+			return nil, false, fmt.Errorf("invalid device permissions %q", dev.Permissions)
+		}
+	}
+
+	switch dev.Type {
+	case 'a':
+	case 'b':
+		block = append(block, insn(bpfJneImm, bpfR2, 0, 0, bpfDevcgDevBlock))
+	case 'c':
+		block = append(block, insn(bpfJneImm, bpfR2, 0, 0, bpfDevcgDevChar))
+	default:
+		return nil, false, fmt.Errorf("invalid device type %q", dev.Type)
+	}
+	if access != bpfDevcgAccAll {
+		block = append(block, insn(bpfMov32Reg, bpfR1, bpfR3, 0, 0), insn(bpfAnd32Imm, bpfR1, 0, 0, access))
+		if dev.Allow {
+			// All the access requested must be allowed.
+			block = append(block, insn(bpfJneReg, bpfR1, bpfR3, 0, 0))
+		} else {
+			// Any access requested which is denied denies the access.
+			block = append(block, insn(bpfJeqImm, bpfR1, 0, 0, 0))
+		}
+	}
+	if dev.Major != configs.Wildcard {
+		block = append(block, insn(bpfJneImm, bpfR4, 0, 0, int32(dev.Major)))
+	}
+	if dev.Minor != configs.Wildcard {
+		block = append(block, insn(bpfJneImm, bpfR5, 0, 0, int32(dev.Minor)))
+	}
+	catchAll = len(block) == 0
+
+	var result int32
+	if dev.Allow {
+		result = 1
+	}
+	block = append(block, insn(bpfMov32Imm, bpfR0, 0, 0, result), insn(bpfExit, 0, 0, 0, 0))
+	// The conditions jump to the end of the block when they don't match.
+	for i := range block {
+		if block[i].code == bpfJneImm || block[i].code == bpfJneReg || block[i].code == bpfJeqImm {
+			block[i].off = int16(len(block) - i - 1)
+		}
+	}
+	return block, catchAll, nil
+}
+
+func bpf(cmd int, attr unsafe.Pointer, size uintptr) (uintptr, error) {
+	nr, ok := bpfMap[fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH)]
+	if !ok {
+		return 0, fmt.Errorf("unsupported platform %s/%s", runtime.GOOS, runtime.GOARCH)
+	}
+	r, _, errno := syscall.Syscall(nr, uintptr(cmd), uintptr(attr), size)
+	if errno != 0 {
+		return 0, errno
+	}
+	return r, nil
+}
+
+// loadDeviceFilter loads the program prog and attaches it to the cgroup at
+// path, replacing the program attached before, if any.
+func loadDeviceFilter(path string, prog []bpfInsn) error {
+	license := []byte("Apache\x00")
+	load := struct {
+		progType    uint32
+		insnCnt     uint32
+		insns       uint64
+		license     uint64
+		logLevel    uint32
+		logSize     uint32
+		logBuf      uint64
+		kernVersion uint32
+		progFlags   uint32
+	}{
+		progType: bpfProgTypeDevice,
+		insnCnt:  uint32(len(prog)),
+		insns:    uint64(uintptr(unsafe.Pointer(&prog[0]))),
+		license:  uint64(uintptr(unsafe.Pointer(&license[0]))),
+	}
+	fd, err := bpf(bpfProgLoad, unsafe.Pointer(&load), unsafe.Sizeof(load))
+	runtime.KeepAlive(prog)
+	runtime.KeepAlive(license)
+	if err != nil {
+		return fmt.Errorf("failed to load the device filter: %v", err)
+	}
+	defer syscall.Close(int(fd))
+
+	dir, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+
+	// Without flags, the program replaces the one attached before.
+	attach := struct {
+		targetFd    uint32
+		attachBpfFd uint32
+		attachType  uint32
+		attachFlags uint32
+	}{
+		targetFd:    uint32(dir.Fd()),
+		attachBpfFd: uint32(fd),
+		attachType:  bpfAttachDevice,
+	}
+	if _, err := bpf(bpfProgAttach, unsafe.Pointer(&attach), unsafe.Sizeof(attach)); err != nil {
+		return fmt.Errorf("failed to attach the device filter to %s: %v", path, err)
+	}
+	return nil
+}
+
+func setDevices(path string, r *configs.Resources) error {
+	// Like for the devices controller of the v1 hierarchy, the rules are
+	// left to the parent cgroup in a user namespace.
+	if system.RunningInUserNS() {
+		return nil
+	}
+	prog, err := deviceFilter(deviceRules(r))
+	if err != nil {
+		return err
+	}
+	return loadDeviceFilter(path, prog)
+}
@@ -0,0 +1,133 @@
+// +build linux
+
+package fs2
+
+import (
+	"testing"
+
+	"github.com/opencontainers/runc/libcontainer/configs"
+)
+
+// runDeviceFilter interprets the instructions of prog, which are the ones
+// generated by deviceFilter, for the access to a device.
+func runDeviceFilter(t *testing.T, prog []bpfInsn, devType, access, major, minor uint32) bool {
+	ctx := []uint32{access<<16 | devType, major, minor}
+	var regs [11]uint32
+	for pc := 0; pc < len(prog); pc++ {
+		i := prog[pc]
+		dst, src := i.regs&0xf, i.regs>>4
+		switch i.code {
+		case bpfLdxMemW:
+			regs[dst] = ctx[(int(i.off))/4]
+		case bpfAnd32Imm:
+			regs[dst] &= uint32(i.imm)
+		case bpfRsh32Imm:
+			regs[dst] >>= uint32(i.imm)
+		case bpfMov32Imm:
+			regs[dst] = uint32(i.imm)
+		case bpfMov32Reg:
+			regs[dst] = regs[src]
+		case bpfJeqImm:
+			if regs[dst] == uint32(i.imm) {
+				pc += int(i.off)
+			}
+		case bpfJneImm:
+			if regs[dst] != uint32(i.imm) {
+				pc += int(i.off)
+			}
+		case bpfJneReg:
+			if regs[dst] != regs[src] {
+				pc += int(i.off)
+			}
+		case bpfExit:
+			return regs[0] == 1
+		default:
+			t.Fatalf("unexpected instruction %#x", i.code)
+		}
+	}
+	t.Fatal("the program doesn't exit")
+	return false
+}
+
+func TestDeviceFilter(t *testing.T) {
+	rules := []*configs.Device{
+		{Type: 'a', Major: configs.Wildcard, Minor: configs.Wildcard, Permissions: "rwm"},
+		{Type: 'c', Major: configs.Wildcard, Minor: configs.Wildcard, Permissions: "m", Allow: true},
+		{Type: 'c', Major: 1, Minor: 3, Permissions: "rwm", Allow: true},
+		{Type: 'c', Major: 1, Minor: 5, Permissions: "r", Allow: true},
+		{Type: 'c', Major: 10, Minor: configs.Wildcard, Permissions: "rw", Allow: true},
+		{Type: 'c', Major: 10, Minor: 200, Permissions: "w"},
+	}
+	prog, err := deviceFilter(rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		devType, access, major, minor uint32
+		allowed                       bool
+	}{
+		{bpfDevcgDevChar, bpfDevcgAccRead, 1, 3, true},
+		{bpfDevcgDevChar, bpfDevcgAccRead | bpfDevcgAccWrite, 1, 3, true},
+		{bpfDevcgDevChar, bpfDevcgAccRead, 1, 5, true},
+		{bpfDevcgDevChar, bpfDevcgAccWrite, 1, 5, false},
+		{bpfDevcgDevChar, bpfDevcgAccMknod, 4, 1, true},
+		{bpfDevcgDevChar, bpfDevcgAccRead, 4, 1, false},
+		{bpfDevcgDevBlock, bpfDevcgAccMknod, 8, 0, false},
+		{bpfDevcgDevBlock, bpfDevcgAccRead | bpfDevcgAccWrite, 8, 0, false},
+		{bpfDevcgDevBlock, bpfDevcgAccRead, 1, 3, false},
+		{bpfDevcgDevChar, bpfDevcgAccRead, 10, 229, true},
+		{bpfDevcgDevChar, bpfDevcgAccRead, 10, 200, true},
+		{bpfDevcgDevChar, bpfDevcgAccRead | bpfDevcgAccWrite, 10, 200, false},
+	} {
+		if allowed := runDeviceFilter(t, prog, tc.devType, tc.access, tc.major, tc.minor); allowed != tc.allowed {
+			t.Errorf("device type %d access %d %d:%d: expected allowed %v, got %v", tc.devType, tc.access, tc.major, tc.minor, tc.allowed, allowed)
+		}
+	}
+}
+
+func TestDeviceFilterLegacyRules(t *testing.T) {
+	allowAll := false
+	r := &configs.Resources{
+		AllowAllDevices: &allowAll,
+		AllowedDevices: []*configs.Device{
+			{Type: 'c', Major: 1, Minor: 3, Permissions: "rwm", Allow: true},
+		},
+	}
+	prog, err := deviceFilter(deviceRules(r))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !runDeviceFilter(t, prog, bpfDevcgDevChar, bpfDevcgAccRead, 1, 3) {
+		t.Fatal("expected the access to an allowed device to be allowed")
+	}
+	if runDeviceFilter(t, prog, bpfDevcgDevBlock, bpfDevcgAccRead, 8, 0) {
+		t.Fatal("expected the access to another device to be denied")
+	}
+
+	// Without rules, the devices are allowed like in the v1 hierarchy.
+	r = &configs.Resources{
+		DeniedDevices: []*configs.Device{
+			{Type: 'b', Major: 8, Minor: configs.Wildcard, Permissions: "rwm"},
+		},
+	}
+	prog, err = deviceFilter(deviceRules(r))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !runDeviceFilter(t, prog, bpfDevcgDevChar, bpfDevcgAccRead, 1, 3) {
+		t.Fatal("expected the access to a device to be allowed by default")
+	}
+	if runDeviceFilter(t, prog, bpfDevcgDevBlock, bpfDevcgAccWrite, 8, 1) {
+		t.Fatal("expected the access to a denied device to be denied")
+	}
+}
+
+func TestDeviceFilterInvalidRule(t *testing.T) {
+	if _, err := deviceFilter([]*configs.Device{{Type: 'c', Major: 1, Minor: 3, Permissions: "rx", Allow: true}}); err == nil {
+		t.Fatal("expected an error for invalid permissions")
+	}
+	if _, err := deviceFilter([]*configs.Device{{Type: 'p', Major: 1, Minor: 3, Permissions: "r", Allow: true}}); err == nil {
+		t.Fatal("expected an error for an invalid type")
+	}
+}
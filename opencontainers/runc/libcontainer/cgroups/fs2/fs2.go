@@ -0,0 +1,274 @@
+// +build linux
+
+// Package fs2 manages the cgroups of containers on hosts using the unified
+// (v2) hierarchy, where all the controllers share a single hierarchy.
+package fs2
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+	"github.com/opencontainers/runc/libcontainer/configs"
+	libcontainerUtils "github.com/opencontainers/runc/libcontainer/utils"
+)
+
+// Manager manages the cgroup of a container in the unified hierarchy. Path is
+// the absolute path of the cgroup, it is set by Apply if it is empty.
+type Manager struct {
+	mu      sync.Mutex
+	Cgroups *configs.Cgroup
+	Path    string
+}
+
+func (m *Manager) Apply(pid int) error {
+	if m.Cgroups == nil {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if p, ok := m.Cgroups.Paths[""]; ok {
+		// Just join an existing cgroup.
+		m.Path = p
+		return cgroups.WriteCgroupProc(m.Path, pid)
+	}
+	if m.Path == "" {
+		path, err := cgroupPath(m.Cgroups)
+		if err != nil {
+			return err
+		}
+		m.Path = path
+	}
+	if err := createCgroup(m.Path); err != nil {
+		return err
+	}
+	return cgroups.WriteCgroupProc(m.Path, pid)
+}
+
+// cgroupPath returns the absolute path of the cgroup c. A relative path is
+// relative to the cgroup of the current process.
+func cgroupPath(c *configs.Cgroup) (string, error) {
+	if (c.Name != "" || c.Parent != "") && c.Path != "" {
+		return "", fmt.Errorf("cgroup: either Path or Name and Parent should be used")
+	}
+	// Path safety is important, see fs.getCgroupData.
+	innerPath := libcontainerUtils.CleanPath(c.Path)
+	if innerPath == "" {
+		innerPath = filepath.Join(libcontainerUtils.CleanPath(c.Parent), libcontainerUtils.CleanPath(c.Name))
+	}
+	if filepath.IsAbs(innerPath) {
+		return filepath.Join(cgroups.UnifiedMountpoint, innerPath), nil
+	}
+	// The unified hierarchy has an empty list of controllers in
+	// /proc/self/cgroup.
+	own, err := cgroups.GetThisCgroupDir("")
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cgroups.UnifiedMountpoint, own, innerPath), nil
+}
+
+// createCgroup creates the cgroup at path, with the controllers available
+// in its parent enabled. In the unified hierarchy, a controller can only be
+// used in a cgroup if it is enabled in the cgroup.subtree_control file of all
+// its ancestors.
+func createCgroup(path string) error {
+	rel, err := filepath.Rel(cgroups.UnifiedMountpoint, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return fmt.Errorf("cgroup %s is not in the unified hierarchy", path)
+	}
+	current := cgroups.UnifiedMountpoint
+	for _, elem := range strings.Split(rel, string(filepath.Separator)) {
+		if err := enableControllers(current); err != nil {
+			return err
+		}
+		current = filepath.Join(current, elem)
+		if err := os.Mkdir(current, 0755); err != nil && !os.IsExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// enableControllers enables the controllers available in the cgroup dir for
+// its children.
+func enableControllers(dir string) error {
+	content, err := ioutil.ReadFile(filepath.Join(dir, "cgroup.controllers"))
+	if err != nil {
+		return err
+	}
+	for _, c := range strings.Fields(string(content)) {
+		// A controller can't be enabled below a cgroup with processes,
+		// other than the root one, it is then not available to the
+		// container.
+		writeFile(dir, "cgroup.subtree_control", "+"+c)
+	}
+	return nil
+}
+
+func (m *Manager) Destroy() error {
+	if m.Cgroups != nil && m.Cgroups.Paths != nil {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.Path == "" {
+		return nil
+	}
+	if err := cgroups.RemovePaths(map[string]string{"": m.Path}); err != nil {
+		return err
+	}
+	m.Path = ""
+	return nil
+}
+
+// GetPaths returns the path of the cgroup with an empty controller name, as
+// all the controllers share it.
+func (m *Manager) GetPaths() map[string]string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.Path == "" {
+		return map[string]string{}
+	}
+	return map[string]string{"": m.Path}
+}
+
+func (m *Manager) GetPids() ([]int, error) {
+	return cgroups.GetPids(m.getPath())
+}
+
+func (m *Manager) GetAllPids() ([]int, error) {
+	return cgroups.GetAllPids(m.getPath())
+}
+
+func (m *Manager) getPath() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.Path
+}
+
+func (m *Manager) GetStats() (*cgroups.Stats, error) {
+	path := m.getPath()
+	stats := cgroups.NewStats()
+	controllers, err := availableControllers(path)
+	if err != nil {
+		return nil, err
+	}
+	// cpu.stat is there even without the cpu controller.
+	if err := statCpu(path, stats); err != nil {
+		return nil, err
+	}
+	if controllers["memory"] {
+		if err := statMemory(path, stats); err != nil {
+			return nil, err
+		}
+	}
+	if controllers["io"] {
+		if err := statIo(path, stats); err != nil {
+			return nil, err
+		}
+	}
+	if controllers["pids"] {
+		if err := statPids(path, stats); err != nil {
+			return nil, err
+		}
+	}
+	// The pressure files are there without the controllers too, if the
+	// kernel was built with PSI.
+	if stats.CpuStats.PSI, err = statPSI(path, "cpu.pressure"); err != nil {
+		return nil, err
+	}
+	if stats.MemoryStats.PSI, err = statPSI(path, "memory.pressure"); err != nil {
+		return nil, err
+	}
+	if stats.BlkioStats.PSI, err = statPSI(path, "io.pressure"); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// availableControllers returns the controllers enabled in the cgroup at
+// path.
+func availableControllers(path string) (map[string]bool, error) {
+	content, err := ioutil.ReadFile(filepath.Join(path, "cgroup.controllers"))
+	if err != nil {
+		return nil, err
+	}
+	controllers := make(map[string]bool)
+	for _, c := range strings.Fields(string(content)) {
+		controllers[c] = true
+	}
+	return controllers, nil
+}
+
+func (m *Manager) Set(container *configs.Config) error {
+	if container.Cgroups == nil || container.Cgroups.Paths != nil {
+		return nil
+	}
+	path := m.getPath()
+	r := container.Cgroups.Resources
+	if r == nil {
+		return nil
+	}
+	if err := setCpu(path, r); err != nil {
+		return err
+	}
+	if err := setCpuset(path, r); err != nil {
+		return err
+	}
+	if err := setMemory(path, r); err != nil {
+		return err
+	}
+	if err := setIo(path, r); err != nil {
+		return err
+	}
+	if err := setPids(path, r); err != nil {
+		return err
+	}
+	if err := setHugetlb(path, r); err != nil {
+		return err
+	}
+	if err := setDevices(path, r); err != nil {
+		return err
+	}
+	if r.Freezer != configs.Undefined {
+		if err := setFreezer(path, r.Freezer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Freeze freezes or thaws all the processes of the cgroup.
+func (m *Manager) Freeze(state configs.FreezerState) error {
+	if err := setFreezer(m.getPath(), state); err != nil {
+		return err
+	}
+	m.Cgroups.Resources.Freezer = state
+	return nil
+}
+
+func setFreezer(path string, state configs.FreezerState) error {
+	switch state {
+	case configs.Frozen:
+		return writeFile(path, "cgroup.freeze", "1")
+	case configs.Thawed:
+		return writeFile(path, "cgroup.freeze", "0")
+	}
+	return fmt.Errorf("invalid freezer state %q", state)
+}
+
+func writeFile(dir, file, data string) error {
+	if dir == "" {
+		return fmt.Errorf("no such directory for %s", file)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, file), []byte(data), 0700); err != nil {
+		return fmt.Errorf("failed to write %v to %v: %v", data, file, err)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+// +build linux
+
+package fs2
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+	"github.com/opencontainers/runc/libcontainer/configs"
+)
+
+// ioWeight converts a blkio weight, from 10 to 1000, to an io.weight, from 1
+// to 10000.
+func ioWeight(weight uint16) uint64 {
+	if weight < 10 {
+		weight = 10
+	}
+	if weight > 1000 {
+		weight = 1000
+	}
+	return 1 + (uint64(weight)-10)*9999/990
+}
+
+func setIo(path string, r *configs.Resources) error {
+	if r.BlkioLeafWeight != 0 {
+		return fmt.Errorf("blkio leaf weights are not supported by the unified hierarchy")
+	}
+	// The bfq scheduler has its own weights, in the same range as blkio.
+	weightFile, bfq := "io.weight", false
+	if _, err := os.Stat(filepath.Join(path, "io.bfq.weight")); err == nil {
+		weightFile, bfq = "io.bfq.weight", true
+	}
+	weight := func(w uint16) string {
+		if bfq {
+			return strconv.FormatUint(uint64(w), 10)
+		}
+		return strconv.FormatUint(ioWeight(w), 10)
+	}
+	if r.BlkioWeight != 0 {
+		if err := writeFile(path, weightFile, weight(r.BlkioWeight)); err != nil {
+			return err
+		}
+	}
+	for _, wd := range r.BlkioWeightDevice {
+		if err := writeFile(path, weightFile, fmt.Sprintf("%d:%d %s", wd.Major, wd.Minor, weight(wd.Weight))); err != nil {
+			return err
+		}
+	}
+	for key, devices := range map[string][]*configs.ThrottleDevice{
+		"rbps":  r.BlkioThrottleReadBpsDevice,
+		"wbps":  r.BlkioThrottleWriteBpsDevice,
+		"riops": r.BlkioThrottleReadIOPSDevice,
+		"wiops": r.BlkioThrottleWriteIOPSDevice,
+	} {
+		for _, td := range devices {
+			if err := writeFile(path, "io.max", fmt.Sprintf("%d:%d %s=%d", td.Major, td.Minor, key, td.Rate)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// statIo parses io.stat, which has a line per device like
+//  8:0 rbytes=1459200 wbytes=314773504 rios=192 wios=353 dbytes=0 dios=0
+func statIo(path string, stats *cgroups.Stats) error {
+	contents, err := ioutil.ReadFile(filepath.Join(path, "io.stat"))
+	if err != nil {
+		return err
+	}
+	for _, line := range strings.Split(string(contents), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		var major, minor uint64
+		if _, err := fmt.Sscanf(fields[0], "%d:%d", &major, &minor); err != nil {
+			return fmt.Errorf("failed to parse io.stat device %q - %v", fields[0], err)
+		}
+		for _, field := range fields[1:] {
+			kv := strings.SplitN(field, "=", 2)
+			if len(kv) != 2 {
+				continue
+			}
+			v, err := strconv.ParseUint(kv[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("failed to parse io.stat value %q - %v", field, err)
+			}
+			entry := cgroups.BlkioStatEntry{Major: major, Minor: minor, Value: v}
+			switch kv[0] {
+			case "rbytes":
+				entry.Op = "Read"
+				stats.BlkioStats.IoServiceBytesRecursive = append(stats.BlkioStats.IoServiceBytesRecursive, entry)
+			case "wbytes":
+				entry.Op = "Write"
+				stats.BlkioStats.IoServiceBytesRecursive = append(stats.BlkioStats.IoServiceBytesRecursive, entry)
+			case "rios":
+				entry.Op = "Read"
+				stats.BlkioStats.IoServicedRecursive = append(stats.BlkioStats.IoServicedRecursive, entry)
+			case "wios":
+				entry.Op = "Write"
+				stats.BlkioStats.IoServicedRecursive = append(stats.BlkioStats.IoServicedRecursive, entry)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+// +build linux
+
+package fs2
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+	"github.com/opencontainers/runc/libcontainer/configs"
+)
+
+func TestSetIoWeight(t *testing.T) {
+	helper := NewCgroupTestUtil(t)
+	defer helper.cleanup()
+
+	r := &configs.Resources{BlkioWeight: 500}
+	if err := setIo(helper.CgroupPath, r); err != nil {
+		t.Fatal(err)
+	}
+	if value := helper.readFile("io.weight"); value != "4950" {
+		t.Fatalf("Got the wrong value, set io.weight failed: %q", value)
+	}
+
+	// The bfq weights are in the same range as the blkio ones.
+	helper.writeFileContents(map[string]string{
+		"io.bfq.weight": "100",
+	})
+	if err := setIo(helper.CgroupPath, r); err != nil {
+		t.Fatal(err)
+	}
+	if value := helper.readFile("io.bfq.weight"); value != "500" {
+		t.Fatalf("Got the wrong value, set io.bfq.weight failed: %q", value)
+	}
+}
+
+func TestSetIoMax(t *testing.T) {
+	helper := NewCgroupTestUtil(t)
+	defer helper.cleanup()
+
+	r := &configs.Resources{
+		BlkioThrottleReadBpsDevice: []*configs.ThrottleDevice{configs.NewThrottleDevice(8, 0, 1024)},
+	}
+	if err := setIo(helper.CgroupPath, r); err != nil {
+		t.Fatal(err)
+	}
+	if value := helper.readFile("io.max"); value != "8:0 rbps=1024" {
+		t.Fatalf("Got the wrong value, set io.max failed: %q", value)
+	}
+}
+
+func TestStatIo(t *testing.T) {
+	helper := NewCgroupTestUtil(t)
+	defer helper.cleanup()
+	helper.writeFileContents(map[string]string{
+		"io.stat": "8:0 rbytes=4096 wbytes=8192 rios=1 wios=2 dbytes=0 dios=0\n",
+	})
+
+	stats := cgroups.NewStats()
+	if err := statIo(helper.CgroupPath, stats); err != nil {
+		t.Fatal(err)
+	}
+	expected := []cgroups.BlkioStatEntry{
+		{Major: 8, Minor: 0, Op: "Read", Value: 4096},
+		{Major: 8, Minor: 0, Op: "Write", Value: 8192},
+	}
+	if !reflect.DeepEqual(stats.BlkioStats.IoServiceBytesRecursive, expected) {
+		t.Fatalf("Expected %+v, got %+v", expected, stats.BlkioStats.IoServiceBytesRecursive)
+	}
+	expected = []cgroups.BlkioStatEntry{
+		{Major: 8, Minor: 0, Op: "Read", Value: 1},
+		{Major: 8, Minor: 0, Op: "Write", Value: 2},
+	}
+	if !reflect.DeepEqual(stats.BlkioStats.IoServicedRecursive, expected) {
+		t.Fatalf("Expected %+v, got %+v", expected, stats.BlkioStats.IoServicedRecursive)
+	}
+}
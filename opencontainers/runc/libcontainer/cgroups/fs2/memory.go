@@ -0,0 +1,126 @@
+// +build linux
+
+package fs2
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+	"github.com/opencontainers/runc/libcontainer/configs"
+)
+
+// memoryLimit returns the value of a memory.* limit file for limit, where -1
+// stands for no limit.
+func memoryLimit(limit int64) string {
+	if limit == -1 {
+		return "max"
+	}
+	return strconv.FormatInt(limit, 10)
+}
+
+func setMemory(path string, r *configs.Resources) error {
+	if r.KernelMemory != 0 {
+		return fmt.Errorf("kernel memory limits are not supported by the unified hierarchy")
+	}
+	if r.OomKillDisable {
+		return fmt.Errorf("disabling the OOM killer is not supported by the unified hierarchy")
+	}
+	if r.MemorySwappiness != nil && *r.MemorySwappiness != -1 {
+		return fmt.Errorf("memory swappiness is not supported by the unified hierarchy")
+	}
+	if r.Memory != 0 {
+		if err := writeFile(path, "memory.max", memoryLimit(r.Memory)); err != nil {
+			return err
+		}
+	}
+	// MemorySwap is the limit of memory and swap, memory.swap.max is the
+	// limit of swap only.
+	if r.MemorySwap != 0 {
+		swap := int64(-1)
+		if r.MemorySwap != -1 {
+			if r.Memory <= 0 {
+				return fmt.Errorf("a memory limit is required to limit the swap")
+			}
+			if r.MemorySwap < r.Memory {
+				return fmt.Errorf("memory+swap limit should be larger than memory limit, update memory limit first")
+			}
+			swap = r.MemorySwap - r.Memory
+		}
+		// memory.swap.max is missing when swap accounting is disabled.
+		if _, err := os.Stat(filepath.Join(path, "memory.swap.max")); err == nil {
+			if err := writeFile(path, "memory.swap.max", memoryLimit(swap)); err != nil {
+				return err
+			}
+		} else if swap != -1 {
+			return fmt.Errorf("swap limits are not supported: %v", err)
+		}
+	}
+	if r.MemoryReservation != 0 {
+		if err := writeFile(path, "memory.low", memoryLimit(r.MemoryReservation)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func statMemory(path string, stats *cgroups.Stats) error {
+	values, err := getCgroupParamKeyValues(path, "memory.stat")
+	if err != nil {
+		return err
+	}
+	for k, v := range values {
+		stats.MemoryStats.Stats[k] = v
+	}
+	stats.MemoryStats.Cache = values["file"]
+
+	usage, err := getMemoryData(path, "memory")
+	if err != nil {
+		return err
+	}
+	events, err := getCgroupParamKeyValues(path, "memory.events")
+	if err != nil {
+		return err
+	}
+	usage.Failcnt = events["max"]
+	stats.MemoryStats.Usage = usage
+	stats.MemoryStats.Events = &cgroups.MemoryEvents{
+		Low:     events["low"],
+		High:    events["high"],
+		Max:     events["max"],
+		Oom:     events["oom"],
+		OomKill: events["oom_kill"],
+	}
+
+	// The swap files are missing when swap accounting is disabled.
+	if _, err := os.Stat(filepath.Join(path, "memory.swap.current")); err == nil {
+		swapUsage, err := getMemoryData(path, "memory.swap")
+		if err != nil {
+			return err
+		}
+		stats.MemoryStats.SwapUsage = swapUsage
+	}
+	return nil
+}
+
+// getMemoryData returns the usage and limit in the files <name>.current and
+// <name>.max.
+func getMemoryData(path, name string) (cgroups.MemoryData, error) {
+	memoryData := cgroups.MemoryData{}
+
+	usage, err := getCgroupParamUint(path, name+".current")
+	if err != nil {
+		return cgroups.MemoryData{}, fmt.Errorf("failed to parse %s.current - %v", name, err)
+	}
+	memoryData.Usage = usage
+	limit, err := getCgroupParamMax(path, name+".max", math.MaxUint64)
+	if err != nil {
+		return cgroups.MemoryData{}, fmt.Errorf("failed to parse %s.max - %v", name, err)
+	}
+	memoryData.Limit = limit
+
+	return memoryData, nil
+}
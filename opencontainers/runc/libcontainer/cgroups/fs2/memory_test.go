@@ -0,0 +1,83 @@
+// +build linux
+
+package fs2
+
+import (
+	"math"
+	"testing"
+
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+	"github.com/opencontainers/runc/libcontainer/configs"
+)
+
+func TestSetMemoryAndSwap(t *testing.T) {
+	helper := NewCgroupTestUtil(t)
+	defer helper.cleanup()
+	helper.writeFileContents(map[string]string{
+		"memory.max":      "max",
+		"memory.swap.max": "max",
+	})
+
+	r := &configs.Resources{Memory: 1 << 20, MemorySwap: 3 << 20, MemoryReservation: 1 << 19}
+	if err := setMemory(helper.CgroupPath, r); err != nil {
+		t.Fatal(err)
+	}
+	if value := helper.readFile("memory.max"); value != "1048576" {
+		t.Fatalf("Got the wrong value, set memory.max failed: %q", value)
+	}
+	// The swap limit doesn't include the memory.
+	if value := helper.readFile("memory.swap.max"); value != "2097152" {
+		t.Fatalf("Got the wrong value, set memory.swap.max failed: %q", value)
+	}
+	if value := helper.readFile("memory.low"); value != "524288" {
+		t.Fatalf("Got the wrong value, set memory.low failed: %q", value)
+	}
+
+	r = &configs.Resources{Memory: 1 << 20, MemorySwap: -1}
+	if err := setMemory(helper.CgroupPath, r); err != nil {
+		t.Fatal(err)
+	}
+	if value := helper.readFile("memory.swap.max"); value != "max" {
+		t.Fatalf("Got the wrong value, set memory.swap.max failed: %q", value)
+	}
+}
+
+func TestSetMemorySwapWithoutMemory(t *testing.T) {
+	helper := NewCgroupTestUtil(t)
+	defer helper.cleanup()
+
+	r := &configs.Resources{MemorySwap: 3 << 20}
+	if err := setMemory(helper.CgroupPath, r); err == nil {
+		t.Fatal("Expected an error limiting the swap without a memory limit")
+	}
+}
+
+func TestStatMemory(t *testing.T) {
+	helper := NewCgroupTestUtil(t)
+	defer helper.cleanup()
+	helper.writeFileContents(map[string]string{
+		"memory.stat":    "anon 1024\nfile 2048\n",
+		"memory.current": "4096",
+		"memory.max":     "max",
+		"memory.events":  "low 0\nhigh 0\nmax 3\noom 1\noom_kill 1\n",
+	})
+
+	stats := cgroups.NewStats()
+	if err := statMemory(helper.CgroupPath, stats); err != nil {
+		t.Fatal(err)
+	}
+	expected := cgroups.MemoryData{Usage: 4096, Failcnt: 3, Limit: math.MaxUint64}
+	if stats.MemoryStats.Usage != expected {
+		t.Fatalf("Expected memory usage %+v, got %+v", expected, stats.MemoryStats.Usage)
+	}
+	if stats.MemoryStats.Cache != 2048 {
+		t.Fatalf("Expected cache 2048, got %d", stats.MemoryStats.Cache)
+	}
+	expectedEvents := cgroups.MemoryEvents{Max: 3, Oom: 1, OomKill: 1}
+	if stats.MemoryStats.Events == nil || *stats.MemoryStats.Events != expectedEvents {
+		t.Fatalf("Expected memory events %+v, got %+v", expectedEvents, stats.MemoryStats.Events)
+	}
+	if stats.MemoryStats.Stats["anon"] != 1024 {
+		t.Fatalf("Expected anon 1024, got %d", stats.MemoryStats.Stats["anon"])
+	}
+}
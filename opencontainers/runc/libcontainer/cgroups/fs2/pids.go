@@ -0,0 +1,38 @@
+// +build linux
+
+package fs2
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+	"github.com/opencontainers/runc/libcontainer/configs"
+)
+
+func setPids(path string, r *configs.Resources) error {
+	if r.PidsLimit == 0 {
+		return nil
+	}
+	// "max" is the fallback value.
+	limit := "max"
+	if r.PidsLimit > 0 {
+		limit = strconv.FormatInt(r.PidsLimit, 10)
+	}
+	return writeFile(path, "pids.max", limit)
+}
+
+func statPids(path string, stats *cgroups.Stats) error {
+	current, err := getCgroupParamUint(path, "pids.current")
+	if err != nil {
+		return fmt.Errorf("failed to parse pids.current - %s", err)
+	}
+	// 0 represents "no limit".
+	max, err := getCgroupParamMax(path, "pids.max", 0)
+	if err != nil {
+		return fmt.Errorf("failed to parse pids.max - %s", err)
+	}
+	stats.PidsStats.Current = current
+	stats.PidsStats.Limit = max
+	return nil
+}
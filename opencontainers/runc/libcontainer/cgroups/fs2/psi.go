@@ -0,0 +1,85 @@
+// +build linux
+
+package fs2
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+)
+
+// statPSI parses the pressure stall information file of the cgroup at path,
+// like cpu.pressure, which has the lines
+//  some avg10=0.00 avg60=0.00 avg300=0.00 total=0
+//  full avg10=0.00 avg60=0.00 avg300=0.00 total=0
+// It returns nil if the kernel doesn't provide the information.
+func statPSI(path, file string) (*cgroups.PSIStats, error) {
+	f, err := os.Open(filepath.Join(path, file))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var psi cgroups.PSIStats
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		var data *cgroups.PSIData
+		switch fields[0] {
+		case "some":
+			data = &psi.Some
+		case "full":
+			data = &psi.Full
+		default:
+			continue
+		}
+		if err := parsePSIData(fields[1:], data); err != nil {
+			return nil, fmt.Errorf("failed to parse %s - %v", file, err)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		// The file is there but can't be read when PSI is disabled with
+		// psi=0 on the kernel command line.
+		if pathErr, ok := err.(*os.PathError); ok && pathErr.Err == syscall.EOPNOTSUPP {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &psi, nil
+}
+
+func parsePSIData(fields []string, data *cgroups.PSIData) error {
+	for _, field := range fields {
+		kv := strings.SplitN(field, "=", 2)
+		if len(kv) != 2 {
+			return fmt.Errorf("invalid field %q", field)
+		}
+		var err error
+		switch kv[0] {
+		case "avg10":
+			data.Avg10, err = strconv.ParseFloat(kv[1], 64)
+		case "avg60":
+			data.Avg60, err = strconv.ParseFloat(kv[1], 64)
+		case "avg300":
+			data.Avg300, err = strconv.ParseFloat(kv[1], 64)
+		case "total":
+			data.Total, err = strconv.ParseUint(kv[1], 10, 64)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+// +build linux
+
+package fs2
+
+import (
+	"testing"
+
+	"github.com/opencontainers/runc/libcontainer/cgroups"
+)
+
+func TestStatPSI(t *testing.T) {
+	helper := NewCgroupTestUtil(t)
+	defer helper.cleanup()
+	helper.writeFileContents(map[string]string{
+		"memory.pressure": "some avg10=12.50 avg60=3.00 avg300=0.75 total=1234\nfull avg10=1.00 avg60=0.00 avg300=0.00 total=56\n",
+	})
+
+	psi, err := statPSI(helper.CgroupPath, "memory.pressure")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := cgroups.PSIStats{
+		Some: cgroups.PSIData{Avg10: 12.5, Avg60: 3, Avg300: 0.75, Total: 1234},
+		Full: cgroups.PSIData{Avg10: 1, Total: 56},
+	}
+	if psi == nil || *psi != expected {
+		t.Fatalf("Expected %+v, got %+v", expected, psi)
+	}
+}
+
+func TestStatPSIMissing(t *testing.T) {
+	helper := NewCgroupTestUtil(t)
+	defer helper.cleanup()
+
+	psi, err := statPSI(helper.CgroupPath, "cpu.pressure")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if psi != nil {
+		t.Fatalf("Expected no pressure stall information, got %+v", psi)
+	}
+}
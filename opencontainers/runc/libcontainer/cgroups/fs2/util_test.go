@@ -0,0 +1,53 @@
+// +build linux
+
+/*
+Utility for testing cgroup operations.
+
+Creates a mock of a cgroup of the unified hierarchy for the duration of the
+test.
+*/
+package fs2
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+type cgroupTestUtil struct {
+	// Path to the mock cgroup directory.
+	CgroupPath string
+
+	t *testing.T
+}
+
+func NewCgroupTestUtil(t *testing.T) *cgroupTestUtil {
+	tempDir, err := ioutil.TempDir("", "cgroup2_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &cgroupTestUtil{CgroupPath: tempDir, t: t}
+}
+
+func (c *cgroupTestUtil) cleanup() {
+	os.RemoveAll(c.CgroupPath)
+}
+
+// Write the specified contents on the mock of the specified cgroup files.
+func (c *cgroupTestUtil) writeFileContents(fileContents map[string]string) {
+	for file, contents := range fileContents {
+		if err := writeFile(c.CgroupPath, file, contents); err != nil {
+			c.t.Fatal(err)
+		}
+	}
+}
+
+// Read the contents of the mock of the specified cgroup file.
+func (c *cgroupTestUtil) readFile(file string) string {
+	contents, err := ioutil.ReadFile(c.CgroupPath + "/" + file)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	return strings.TrimSpace(string(contents))
+}
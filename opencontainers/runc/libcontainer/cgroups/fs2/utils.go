@@ -0,0 +1,81 @@
+// +build linux
+
+package fs2
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrNotValidFormat = errors.New("line is not a valid key value format")
+)
+
+// Parses a cgroup param and returns as name, value
+//  i.e. "usage_usec 1234" will return as usage_usec, 1234
+func getCgroupParamKeyValue(t string) (string, uint64, error) {
+	parts := strings.Fields(t)
+	switch len(parts) {
+	case 2:
+		value, err := strconv.ParseUint(parts[1], 10, 64)
+		if err != nil {
+			return "", 0, fmt.Errorf("unable to convert param value (%q) to uint64: %v", parts[1], err)
+		}
+
+		return parts[0], value, nil
+	default:
+		return "", 0, ErrNotValidFormat
+	}
+}
+
+// Gets a single uint64 value from the specified cgroup file.
+func getCgroupParamUint(cgroupPath, cgroupFile string) (uint64, error) {
+	fileName := filepath.Join(cgroupPath, cgroupFile)
+	contents, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := strconv.ParseUint(strings.TrimSpace(string(contents)), 10, 64)
+	if err != nil {
+		return res, fmt.Errorf("unable to parse %q as a uint from Cgroup file %q", string(contents), fileName)
+	}
+	return res, nil
+}
+
+// Gets a single uint64 value from the specified cgroup file, where "max"
+// stands for no limit and is returned as max.
+func getCgroupParamMax(cgroupPath, cgroupFile string, max uint64) (uint64, error) {
+	contents, err := ioutil.ReadFile(filepath.Join(cgroupPath, cgroupFile))
+	if err != nil {
+		return 0, err
+	}
+	if strings.TrimSpace(string(contents)) == "max" {
+		return max, nil
+	}
+	return getCgroupParamUint(cgroupPath, cgroupFile)
+}
+
+// Gets the key value pairs of a flat keyed cgroup file, such as memory.stat.
+func getCgroupParamKeyValues(cgroupPath, cgroupFile string) (map[string]uint64, error) {
+	contents, err := ioutil.ReadFile(filepath.Join(cgroupPath, cgroupFile))
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]uint64)
+	for _, line := range strings.Split(strings.TrimSpace(string(contents)), "\n") {
+		if line == "" {
+			continue
+		}
+		k, v, err := getCgroupParamKeyValue(line)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s - %v", cgroupFile, err)
+		}
+		values[k] = v
+	}
+	return values, nil
+}
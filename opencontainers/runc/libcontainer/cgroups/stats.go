@@ -28,9 +28,32 @@ type CpuUsage struct {
 	UsageInUsermode uint64 `json:"usage_in_usermode"`
 }
 
+// PSIData holds the pressure stall information of a resource for some or all
+// the tasks of a cgroup.
+type PSIData struct {
+	// Share of the time the tasks were stalled, in percent, over the last
+	// 10, 60 and 300 seconds.
+	Avg10  float64 `json:"avg10"`
+	Avg60  float64 `json:"avg60"`
+	Avg300 float64 `json:"avg300"`
+	// Total time the tasks were stalled.
+	// Units: microseconds.
+	Total uint64 `json:"total"`
+}
+
+// PSIStats holds the pressure stall information of a resource, which is
+// only available in the unified hierarchy.
+type PSIStats struct {
+	// time some tasks were stalled on the resource
+	Some PSIData `json:"some,omitempty"`
+	// time all the tasks were stalled on the resource at the same time
+	Full PSIData `json:"full,omitempty"`
+}
+
 type CpuStats struct {
 	CpuUsage       CpuUsage       `json:"cpu_usage,omitempty"`
 	ThrottlingData ThrottlingData `json:"throttling_data,omitempty"`
+	PSI            *PSIStats      `json:"psi,omitempty"`
 }
 
 type MemoryData struct {
@@ -51,10 +74,28 @@ type MemoryStats struct {
 	KernelUsage MemoryData `json:"kernel_usage,omitempty"`
 	// usage of kernel TCP memory
 	KernelTCPUsage MemoryData `json:"kernel_tcp_usage,omitempty"`
+	// counts of the memory events, only in the unified hierarchy
+	Events *MemoryEvents `json:"events,omitempty"`
+	PSI    *PSIStats     `json:"psi,omitempty"`
 
 	Stats map[string]uint64 `json:"stats,omitempty"`
 }
 
+// MemoryEvents holds the number of times the memory events of a cgroup
+// happened, as counted in memory.events.
+type MemoryEvents struct {
+	// usage was reclaimed below the low boundary
+	Low uint64 `json:"low"`
+	// usage went over the high boundary and was throttled
+	High uint64 `json:"high"`
+	// usage was about to go over the max boundary
+	Max uint64 `json:"max"`
+	// the OOM killer was invoked
+	Oom uint64 `json:"oom"`
+	// a process was killed by the OOM killer
+	OomKill uint64 `json:"oom_kill"`
+}
+
 type PidsStats struct {
 	// number of pids in the cgroup
 	Current uint64 `json:"current,omitempty"`
@@ -79,6 +120,7 @@ type BlkioStats struct {
 	IoMergedRecursive       []BlkioStatEntry `json:"io_merged_recursive,omitempty"`
 	IoTimeRecursive         []BlkioStatEntry `json:"io_time_recursive,omitempty"`
 	SectorsRecursive        []BlkioStatEntry `json:"sectors_recursive,omitempty"`
+	PSI                     *PSIStats        `json:"psi,omitempty"`
 }
 
 type HugetlbStats struct {
@@ -11,6 +11,8 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/docker/go-units"
@@ -19,8 +21,30 @@ import (
 const (
 	cgroupNamePrefix = "name="
 	CgroupProcesses  = "cgroup.procs"
+
+	// UnifiedMountpoint is the mountpoint of the unified (v2) hierarchy.
+	UnifiedMountpoint = "/sys/fs/cgroup"
+	cgroup2SuperMagic = 0x63677270
+)
+
+var (
+	isUnifiedOnce sync.Once
+	isUnified     bool
 )
 
+// IsCgroup2UnifiedMode returns whether the host only uses the unified (v2)
+// hierarchy, instead of the per-controller v1 hierarchies.
+func IsCgroup2UnifiedMode() bool {
+	isUnifiedOnce.Do(func() {
+		var st syscall.Statfs_t
+		if err := syscall.Statfs(UnifiedMountpoint, &st); err != nil {
+			return
+		}
+		isUnified = int64(st.Type) == cgroup2SuperMagic
+	})
+	return isUnified
+}
+
 // https://www.kernel.org/doc/Documentation/cgroup-v1/cgroups.txt
 func FindCgroupMountpoint(subsystem string) (string, error) {
 	// We are not using mount.GetMounts() because it's super-inefficient,
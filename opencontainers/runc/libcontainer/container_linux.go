@@ -1206,6 +1206,18 @@ func (c *linuxContainer) runType() (Status, error) {
 }
 
 func (c *linuxContainer) isPaused() (bool, error) {
+	if path, ok := c.cgroupManager.GetPaths()[""]; ok {
+		// The unified hierarchy has no freezer controller, cgroup.freeze
+		// is in all the cgroups but the root one.
+		data, err := ioutil.ReadFile(filepath.Join(path, "cgroup.freeze"))
+		if err != nil {
+			if os.IsNotExist(err) {
+				return false, nil
+			}
+			return false, newSystemErrorWithCause(err, "checking if container is paused")
+		}
+		return bytes.Equal(bytes.TrimSpace(data), []byte("1")), nil
+	}
 	data, err := ioutil.ReadFile(filepath.Join(c.cgroupManager.GetPaths()["freezer"], "freezer.state"))
 	if err != nil {
 		// If freezer cgroup is not mounted, the container would just be not paused.
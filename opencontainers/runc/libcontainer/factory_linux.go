@@ -15,6 +15,7 @@ import (
 	"github.com/docker/docker/pkg/mount"
 	"github.com/opencontainers/runc/libcontainer/cgroups"
 	"github.com/opencontainers/runc/libcontainer/cgroups/fs"
+	"github.com/opencontainers/runc/libcontainer/cgroups/fs2"
 	"github.com/opencontainers/runc/libcontainer/cgroups/systemd"
 	"github.com/opencontainers/runc/libcontainer/configs"
 	"github.com/opencontainers/runc/libcontainer/configs/validate"
@@ -54,9 +55,16 @@ func SystemdCgroups(l *LinuxFactory) error {
 
 // Cgroupfs is an options func to configure a LinuxFactory to return
 // containers that use the native cgroups filesystem implementation to
-// create and manage cgroups.
+// create and manage cgroups. The fs2 implementation is used on hosts with
+// the unified hierarchy.
 func Cgroupfs(l *LinuxFactory) error {
 	l.NewCgroupsManager = func(config *configs.Cgroup, paths map[string]string) cgroups.Manager {
+		if cgroups.IsCgroup2UnifiedMode() {
+			return &fs2.Manager{
+				Cgroups: config,
+				Path:    paths[""],
+			}
+		}
 		return &fs.Manager{
 			Cgroups: config,
 			Paths:   paths,
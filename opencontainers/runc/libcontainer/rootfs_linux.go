@@ -238,6 +238,9 @@ func mountToRootfs(m *configs.Mount, rootfs, mountLabel string) error {
 			}
 		}
 	case "cgroup":
+		if cgroups.IsCgroup2UnifiedMode() {
+			return mountCgroupV2(m, rootfs, mountLabel)
+		}
 		binds, err := getCgroupMounts(m)
 		if err != nil {
 			return err
@@ -296,6 +299,22 @@ func mountToRootfs(m *configs.Mount, rootfs, mountLabel string) error {
 	return nil
 }
 
+// mountCgroupV2 bind mounts the cgroup of the container in the unified
+// hierarchy, which the process already joined, on the destination of m.
+func mountCgroupV2(m *configs.Mount, rootfs, mountLabel string) error {
+	dir, err := cgroups.GetThisCgroupDir("")
+	if err != nil {
+		return err
+	}
+	return mountToRootfs(&configs.Mount{
+		Device:           "bind",
+		Source:           filepath.Join(cgroups.UnifiedMountpoint, dir),
+		Destination:      m.Destination,
+		Flags:            syscall.MS_BIND | syscall.MS_REC | m.Flags,
+		PropagationFlags: m.PropagationFlags,
+	}, rootfs, mountLabel)
+}
+
 func getCgroupMounts(m *configs.Mount) ([]*configs.Mount, error) {
 	mounts, err := cgroups.GetCgroupMounts(false)
 	if err != nil {
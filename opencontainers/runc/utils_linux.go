@@ -13,6 +13,7 @@ import (
 	"github.com/Sirupsen/logrus"
 	"github.com/coreos/go-systemd/activation"
 	"github.com/opencontainers/runc/libcontainer"
+	"github.com/opencontainers/runc/libcontainer/cgroups"
 	"github.com/opencontainers/runc/libcontainer/cgroups/systemd"
 	"github.com/opencontainers/runc/libcontainer/specconv"
 	"github.com/opencontainers/runtime-spec/specs-go"
@@ -34,6 +35,9 @@ func loadFactory(context *cli.Context) (libcontainer.Factory, error) {
 	}
 	cgroupManager := libcontainer.Cgroupfs
 	if context.GlobalBool("systemd-cgroup") {
+		if cgroups.IsCgroup2UnifiedMode() {
+			return nil, fmt.Errorf("systemd cgroup flag passed, but the systemd cgroup driver does not support the unified cgroup hierarchy")
+		}
 		if systemd.UseSystemd() {
 			cgroupManager = libcontainer.SystemdCgroups
 		} else {